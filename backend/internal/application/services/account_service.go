@@ -0,0 +1,613 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReconcileDateTolerance is how many days a statement line's date may be
+// from the recorded entry it matches; banks often post a few days late
+const ReconcileDateTolerance = 3
+
+// AccountService defines the interface for account operations
+type AccountService interface {
+	CreateAccount(ctx context.Context, account *entities.Account) error
+	GetAccounts(ctx context.Context, userID string) ([]*entities.AccountSummary, error)
+	GetAccount(ctx context.Context, userID, id string) (*entities.AccountSummary, error)
+	UpdateAccount(ctx context.Context, id string, account *entities.Account) error
+	DeleteAccount(ctx context.Context, userID, id string) error
+	GetLedger(ctx context.Context, userID, id string, from, to time.Time) (*entities.AccountLedger, error)
+	CreateTransfer(ctx context.Context, transfer *entities.Transfer) error
+	DeleteTransfer(ctx context.Context, userID, id string) error
+	Reconcile(ctx context.Context, userID, id string, statement *entities.Statement) (*entities.Reconciliation, error)
+}
+
+// accountService implements the AccountService interface
+type accountService struct {
+	accountRepo     repositories.AccountRepository
+	expenseRepo     repositories.ExpenseRepository
+	incomeService   IncomeService
+	currencyService CurrencyService
+}
+
+// NewAccountService creates a new account service
+func NewAccountService(accountRepo repositories.AccountRepository, expenseRepo repositories.ExpenseRepository, incomeService IncomeService, currencyService CurrencyService) AccountService {
+	return &accountService{
+		accountRepo:     accountRepo,
+		expenseRepo:     expenseRepo,
+		incomeService:   incomeService,
+		currencyService: currencyService,
+	}
+}
+
+// CreateAccount creates an account. The currency defaults to the user's base
+// currency and the opening date to today.
+func (s *accountService) CreateAccount(ctx context.Context, account *entities.Account) error {
+	if account.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	if err := s.validateAccount(ctx, account, ""); err != nil {
+		return err
+	}
+	currency, err := resolveCurrency(ctx, s.currencyService, account.UserID, account.Currency)
+	if err != nil {
+		return err
+	}
+	account.Currency = currency
+
+	now := time.Now()
+	if account.OpeningDate.IsZero() {
+		account.OpeningDate = startOfDay(now)
+	}
+	account.ID = uuid.NewString()
+	account.CreatedAt = now
+	account.UpdatedAt = now
+
+	if err := s.accountRepo.Create(ctx, toRepositoryAccount(account)); err != nil {
+		return fmt.Errorf("failed to save account: %w", err)
+	}
+	return nil
+}
+
+// GetAccounts gets the user's accounts with their current balances
+func (s *accountService) GetAccounts(ctx context.Context, userID string) ([]*entities.AccountSummary, error) {
+	stored, err := s.accountRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	summaries := make([]*entities.AccountSummary, 0, len(stored))
+	for _, account := range stored {
+		summary, err := s.summarize(ctx, toEntityAccount(account))
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// GetAccount gets an account owned by the user with its current balance
+func (s *accountService) GetAccount(ctx context.Context, userID, id string) (*entities.AccountSummary, error) {
+	stored, err := getOwnedAccount(ctx, s.accountRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.summarize(ctx, toEntityAccount(stored))
+}
+
+// UpdateAccount updates an account. The currency is fixed once anything has
+// been recorded against the account.
+func (s *accountService) UpdateAccount(ctx context.Context, id string, account *entities.Account) error {
+	existing, err := getOwnedAccount(ctx, s.accountRepo, account.UserID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.validateAccount(ctx, account, id); err != nil {
+		return err
+	}
+	if account.Currency == "" {
+		account.Currency = existing.Currency
+	}
+	if account.Currency, err = normalizeCurrency(account.Currency); err != nil {
+		return err
+	}
+	if account.Currency != existing.Currency {
+		count, err := s.accountRepo.CountEntries(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to count account entries: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: the currency can't change once the account has been used", ErrInvalidInput)
+		}
+	}
+	if account.OpeningDate.IsZero() {
+		account.OpeningDate = existing.OpeningDate
+	}
+
+	account.ID = id
+	account.CreatedAt = existing.CreatedAt
+	account.UpdatedAt = time.Now()
+
+	if err := s.accountRepo.Update(ctx, toRepositoryAccount(account)); err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+	return nil
+}
+
+// DeleteAccount deletes an account nothing has been recorded or imported
+// against yet and no categorization rule is scoped to. Used accounts should
+// be archived instead so their history is kept.
+func (s *accountService) DeleteAccount(ctx context.Context, userID, id string) error {
+	if _, err := getOwnedAccount(ctx, s.accountRepo, userID, id); err != nil {
+		return err
+	}
+	count, err := s.accountRepo.CountEntries(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to count account entries: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: the account has %d entries, rules or imports, archive it instead", ErrInvalidInput, count)
+	}
+
+	if err := s.accountRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+	return nil
+}
+
+// GetLedger lists an account's entries dated in [from, to) with a running
+// balance. A zero from starts at the opening date and a zero to runs to the
+// latest entry.
+func (s *accountService) GetLedger(ctx context.Context, userID, id string, from, to time.Time) (*entities.AccountLedger, error) {
+	stored, err := getOwnedAccount(ctx, s.accountRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, fmt.Errorf("%w: the range must end after it starts", ErrInvalidInput)
+	}
+
+	account := toEntityAccount(stored)
+	entries, err := s.entries(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	return buildLedger(account, entries, from, to), nil
+}
+
+// CreateTransfer moves money between two of the user's accounts. ToAmount
+// defaults to Amount converted into the destination account's currency.
+func (s *accountService) CreateTransfer(ctx context.Context, transfer *entities.Transfer) error {
+	if transfer.FromAccountID == transfer.ToAccountID {
+		return fmt.Errorf("%w: a transfer needs two different accounts", ErrInvalidInput)
+	}
+	if !transfer.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidInput)
+	}
+	if transfer.ToAmount.IsNegative() {
+		return fmt.Errorf("%w: to amount must not be negative", ErrInvalidInput)
+	}
+	from, err := getOwnedAccount(ctx, s.accountRepo, transfer.UserID, transfer.FromAccountID)
+	if err != nil {
+		return err
+	}
+	to, err := getOwnedAccount(ctx, s.accountRepo, transfer.UserID, transfer.ToAccountID)
+	if err != nil {
+		return err
+	}
+	for _, account := range []*repositories.Account{from, to} {
+		if account.Archived {
+			return fmt.Errorf("%w: account %q is archived", ErrInvalidInput, account.Name)
+		}
+	}
+
+	transfer.Note = strings.TrimSpace(transfer.Note)
+	if transfer.Date.IsZero() {
+		transfer.Date = time.Now()
+	}
+	switch {
+	case from.Currency == to.Currency && transfer.ToAmount.IsZero():
+		transfer.ToAmount = transfer.Amount
+	case from.Currency == to.Currency && !transfer.ToAmount.Equal(transfer.Amount):
+		return fmt.Errorf("%w: both accounts are in %s, so the amounts must match", ErrInvalidInput, from.Currency)
+	case transfer.ToAmount.IsZero():
+		if transfer.ToAmount, err = s.currencyService.Convert(ctx, transfer.UserID, transfer.Amount, from.Currency, to.Currency, transfer.Date); err != nil {
+			return err
+		}
+	}
+
+	transfer.ID = uuid.NewString()
+	transfer.CreatedAt = time.Now()
+	if err := s.accountRepo.CreateTransfer(ctx, toRepositoryTransfer(transfer)); err != nil {
+		return fmt.Errorf("failed to save transfer: %w", err)
+	}
+	return nil
+}
+
+// DeleteTransfer deletes a transfer owned by the user
+func (s *accountService) DeleteTransfer(ctx context.Context, userID, id string) error {
+	transfer, err := s.accountRepo.GetTransferByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) || (err == nil && transfer.UserID != userID) {
+		return fmt.Errorf("transfer %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get transfer: %w", err)
+	}
+	if err := s.accountRepo.DeleteTransfer(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete transfer: %w", err)
+	}
+	return nil
+}
+
+// Reconcile matches a statement's lines against what was recorded in the
+// account and reports what is missing on either side
+func (s *accountService) Reconcile(ctx context.Context, userID, id string, statement *entities.Statement) (*entities.Reconciliation, error) {
+	stored, err := getOwnedAccount(ctx, s.accountRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateStatement(statement); err != nil {
+		return nil, err
+	}
+	if statement.AccountLast4 != "" && stored.Last4 != "" && statement.AccountLast4 != stored.Last4 {
+		return nil, fmt.Errorf("%w: the statement is for an account ending %s, not %s", ErrInvalidInput, statement.AccountLast4, stored.Last4)
+	}
+
+	account := toEntityAccount(stored)
+	entries, err := s.entries(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	return reconcile(account, entries, statement), nil
+}
+
+// summarize works out an account's current balance
+func (s *accountService) summarize(ctx context.Context, account *entities.Account) (*entities.AccountSummary, error) {
+	entries, err := s.entries(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	ledger := buildLedger(account, entries, time.Time{}, time.Time{})
+	return &entities.AccountSummary{Account: account, Balance: ledger.ClosingBalance}, nil
+}
+
+// entries collects every expense, income and transfer recorded against an
+// account as signed amounts in its currency, oldest first. Incomes are dated
+// the first of their month.
+func (s *accountService) entries(ctx context.Context, account *entities.Account) ([]*entities.AccountEntry, error) {
+	expenses, err := s.expenseRepo.GetByUserID(ctx, account.UserID, &repositories.ExpenseFilters{AccountID: account.ID, OldestFirst: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+	incomes, err := s.incomeService.GetIncomes(ctx, account.UserID)
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := s.accountRepo.GetTransfersByAccount(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfers: %w", err)
+	}
+
+	var entries []*entities.AccountEntry
+	for _, expense := range expenses {
+		amount, err := s.currencyService.Convert(ctx, account.UserID, expense.Amount, expense.Currency, account.Currency, expense.Date)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entities.AccountEntry{
+			Kind:        entities.EntryExpense,
+			ID:          expense.ID,
+			Date:        expense.Date,
+			Description: expense.Description,
+			Amount:      amount.Neg(),
+		})
+	}
+	for _, income := range incomes {
+		if income.AccountID != account.ID {
+			continue
+		}
+		amount, err := s.currencyService.Convert(ctx, account.UserID, income.Amount, income.Currency, account.Currency, income.Month)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entities.AccountEntry{
+			Kind:        entities.EntryIncome,
+			ID:          income.ID,
+			Date:        income.Month,
+			Description: income.Source,
+			Amount:      amount,
+		})
+	}
+	for _, transfer := range transfers {
+		entry := &entities.AccountEntry{ID: transfer.ID, Date: transfer.Date, Description: transfer.Note}
+		if transfer.FromAccountID == account.ID {
+			entry.Kind = entities.EntryTransferOut
+			entry.Amount = transfer.Amount.Neg()
+		} else {
+			entry.Kind = entities.EntryTransferIn
+			entry.Amount = transfer.ToAmount
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Date.Before(entries[j].Date) })
+	return entries, nil
+}
+
+// buildLedger runs the balance through entries, sorted oldest first, keeping
+// those in [from, to). Entries before the opening date are already part of
+// the opening balance and are left out.
+func buildLedger(account *entities.Account, entries []*entities.AccountEntry, from, to time.Time) *entities.AccountLedger {
+	if from.Before(account.OpeningDate) {
+		from = account.OpeningDate
+	}
+	ledger := &entities.AccountLedger{
+		Account: account,
+		From:    from,
+		Entries: []*entities.AccountEntry{},
+	}
+	if !to.IsZero() {
+		ledger.To = &to
+	}
+
+	balance := account.OpeningBalance
+	ledger.OpeningBalance = balance
+	for _, entry := range entries {
+		if entry.Date.Before(account.OpeningDate) || (!to.IsZero() && !entry.Date.Before(to)) {
+			continue
+		}
+		balance = balance.Add(entry.Amount)
+		if entry.Date.Before(from) {
+			ledger.OpeningBalance = balance
+			continue
+		}
+		listed := *entry
+		listed.Balance = balance
+		ledger.Entries = append(ledger.Entries, &listed)
+	}
+	ledger.ClosingBalance = balance
+	return ledger
+}
+
+// reconcile pairs each statement line with the closest recorded entry of the
+// same amount and direction within ReconcileDateTolerance days. Lines are
+// taken in date order and each entry matches at most one line.
+func reconcile(account *entities.Account, entries []*entities.AccountEntry, statement *entities.Statement) *entities.Reconciliation {
+	end := statement.EndDate.AddDate(0, 0, 1)
+	result := &entities.Reconciliation{
+		AccountID:  account.ID,
+		StartDate:  statement.StartDate,
+		EndDate:    statement.EndDate,
+		Matched:    []*entities.ReconciledLine{},
+		Unrecorded: []*entities.StatementLine{},
+		Unmatched:  []*entities.AccountEntry{},
+	}
+
+	ledger := buildLedger(account, entries, statement.StartDate.AddDate(0, 0, -ReconcileDateTolerance), end.AddDate(0, 0, ReconcileDateTolerance))
+	candidates := ledger.Entries
+	matched := make([]bool, len(candidates))
+
+	lines := make([]*entities.StatementLine, len(statement.Lines))
+	copy(lines, statement.Lines)
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].Date.Before(lines[j].Date) })
+
+	for _, line := range lines {
+		amount := line.Amount
+		if line.Type == entities.StatementDebit {
+			amount = amount.Neg()
+		}
+		best, bestDays := -1, ReconcileDateTolerance+1
+		for i, entry := range candidates {
+			if matched[i] || !entry.Amount.Equal(amount) {
+				continue
+			}
+			if days := daysApart(line.Date, entry.Date); days < bestDays {
+				best, bestDays = i, days
+			}
+		}
+		if best < 0 {
+			result.Unrecorded = append(result.Unrecorded, line)
+			continue
+		}
+		matched[best] = true
+		result.Matched = append(result.Matched, &entities.ReconciledLine{Line: line, Entry: candidates[best]})
+	}
+
+	for i, entry := range candidates {
+		if !matched[i] && !entry.Date.Before(statement.StartDate) && entry.Date.Before(end) {
+			result.Unmatched = append(result.Unmatched, entry)
+		}
+	}
+
+	result.RecordedBalance = buildLedger(account, entries, time.Time{}, end).ClosingBalance
+	if statement.ClosingBalance != nil {
+		difference := statement.ClosingBalance.Sub(result.RecordedBalance)
+		result.StatementBalance = statement.ClosingBalance
+		result.Difference = &difference
+	}
+	return result
+}
+
+// daysApart counts the whole days between the calendar dates of a and b
+func daysApart(a, b time.Time) int {
+	days := int(startOfDay(a).Sub(startOfDay(b)).Hours() / 24)
+	if days < 0 {
+		return -days
+	}
+	return days
+}
+
+// validateStatement checks a statement's lines and fills in its period
+func validateStatement(statement *entities.Statement) error {
+	if len(statement.Lines) == 0 {
+		return fmt.Errorf("%w: the statement has no lines", ErrInvalidInput)
+	}
+	var first, last time.Time
+	for i, line := range statement.Lines {
+		if line.Date.IsZero() {
+			return fmt.Errorf("%w: line %d has no date", ErrInvalidInput, i+1)
+		}
+		if !line.Amount.IsPositive() {
+			return fmt.Errorf("%w: line %d amount must be greater than zero", ErrInvalidInput, i+1)
+		}
+		if line.Type != entities.StatementDebit && line.Type != entities.StatementCredit {
+			return fmt.Errorf("%w: line %d type must be %q or %q", ErrInvalidInput, i+1, entities.StatementDebit, entities.StatementCredit)
+		}
+		line.Date = startOfDay(line.Date)
+		if first.IsZero() || line.Date.Before(first) {
+			first = line.Date
+		}
+		if line.Date.After(last) {
+			last = line.Date
+		}
+	}
+
+	if statement.StartDate.IsZero() {
+		statement.StartDate = first
+	}
+	if statement.EndDate.IsZero() {
+		statement.EndDate = last
+	}
+	statement.StartDate = startOfDay(statement.StartDate)
+	statement.EndDate = startOfDay(statement.EndDate)
+	if statement.EndDate.Before(statement.StartDate) {
+		return fmt.Errorf("%w: the statement ends before it starts", ErrInvalidInput)
+	}
+	if first.Before(statement.StartDate) || last.After(statement.EndDate) {
+		return fmt.Errorf("%w: every line must fall within the statement period", ErrInvalidInput)
+	}
+	return nil
+}
+
+// validateAccount checks an account's fields and that its name is free.
+// id is the account being updated, or empty for a new one.
+func (s *accountService) validateAccount(ctx context.Context, account *entities.Account, id string) error {
+	account.Name = strings.TrimSpace(account.Name)
+	account.Last4 = strings.TrimSpace(account.Last4)
+	if account.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if !account.Type.IsValid() {
+		return fmt.Errorf("%w: type must be %q, %q, %q or %q", ErrInvalidInput, entities.BankAccount, entities.CreditCard, entities.UPIAccount, entities.CashWallet)
+	}
+	if account.Last4 != "" && !isDigits(account.Last4, 4) {
+		return fmt.Errorf("%w: last4 must be the last 4 digits of the account or card number", ErrInvalidInput)
+	}
+	if !account.OpeningDate.IsZero() {
+		account.OpeningDate = startOfDay(account.OpeningDate)
+	}
+
+	accounts, err := s.accountRepo.GetByUserID(ctx, account.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+	for _, other := range accounts {
+		if other.ID != id && strings.EqualFold(other.Name, account.Name) {
+			return fmt.Errorf("%w: an account named %q already exists", ErrInvalidInput, other.Name)
+		}
+	}
+	return nil
+}
+
+// isDigits reports whether s is exactly n ASCII digits
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAccount checks that an expense, income or transfer may be recorded
+// against accountID. Archived accounts are rejected unless the record was
+// already against them (existing). An empty accountID is always fine.
+func checkAccount(ctx context.Context, accountRepo repositories.AccountRepository, userID, accountID, existing string) error {
+	if accountID == "" {
+		return nil
+	}
+	account, err := getOwnedAccount(ctx, accountRepo, userID, accountID)
+	if err != nil {
+		return err
+	}
+	if account.Archived && accountID != existing {
+		return fmt.Errorf("%w: account %q is archived", ErrInvalidInput, account.Name)
+	}
+	return nil
+}
+
+// getOwnedAccount loads an account and hides it unless it belongs to userID
+func getOwnedAccount(ctx context.Context, accountRepo repositories.AccountRepository, userID, id string) (*repositories.Account, error) {
+	stored, err := accountRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("account %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if stored.UserID != userID {
+		return nil, fmt.Errorf("account %s: %w", id, ErrNotFound)
+	}
+	return stored, nil
+}
+
+// toRepositoryAccount converts an account entity to its repository model
+func toRepositoryAccount(account *entities.Account) *repositories.Account {
+	return &repositories.Account{
+		ID:             account.ID,
+		UserID:         account.UserID,
+		Name:           account.Name,
+		Type:           string(account.Type),
+		Currency:       account.Currency,
+		Last4:          account.Last4,
+		OpeningBalance: account.OpeningBalance,
+		OpeningDate:    account.OpeningDate,
+		Archived:       account.Archived,
+		CreatedAt:      account.CreatedAt,
+		UpdatedAt:      account.UpdatedAt,
+	}
+}
+
+// toEntityAccount converts a repository account model to an entity
+func toEntityAccount(account *repositories.Account) *entities.Account {
+	return &entities.Account{
+		ID:             account.ID,
+		UserID:         account.UserID,
+		Name:           account.Name,
+		Type:           entities.AccountType(account.Type),
+		Currency:       account.Currency,
+		Last4:          account.Last4,
+		OpeningBalance: account.OpeningBalance,
+		OpeningDate:    account.OpeningDate,
+		Archived:       account.Archived,
+		CreatedAt:      account.CreatedAt,
+		UpdatedAt:      account.UpdatedAt,
+	}
+}
+
+// toRepositoryTransfer converts a transfer entity to its repository model
+func toRepositoryTransfer(transfer *entities.Transfer) *repositories.Transfer {
+	return &repositories.Transfer{
+		ID:            transfer.ID,
+		UserID:        transfer.UserID,
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		ToAmount:      transfer.ToAmount,
+		Date:          transfer.Date,
+		Note:          transfer.Note,
+		CreatedAt:     transfer.CreatedAt,
+	}
+}
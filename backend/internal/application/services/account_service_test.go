@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func newTestAccount(t *testing.T, svc *testServices, userID, name string, kind entities.AccountType, opening string) *entities.Account {
+	t.Helper()
+	account := &entities.Account{
+		UserID:         userID,
+		Name:           name,
+		Type:           kind,
+		Last4:          "4321",
+		OpeningBalance: decimal.RequireFromString(opening),
+		OpeningDate:    date(2024, 3, 1),
+	}
+	if err := svc.accounts.CreateAccount(context.Background(), account); err != nil {
+		t.Fatalf("CreateAccount() error = %v", err)
+	}
+	return account
+}
+
+func addAccountExpense(t *testing.T, svc *testServices, account *entities.Account, amount string, on time.Time) *entities.Expense {
+	t.Helper()
+	expense := newTestExpense(account.UserID, amount, on, entities.Self, entities.Food)
+	expense.AccountID = account.ID
+	if _, err := svc.expenses.AddExpense(context.Background(), expense); err != nil {
+		t.Fatalf("AddExpense() error = %v", err)
+	}
+	return expense
+}
+
+func TestAccountBalancesAndTransfers(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	bank := newTestAccount(t, svc, "u1", "Savings account", entities.BankAccount, "10000")
+	card := newTestAccount(t, svc, "u1", "Credit card", entities.CreditCard, "0")
+
+	addAccountExpense(t, svc, card, "500", date(2024, 3, 5))
+	addAccountExpense(t, svc, bank, "200", date(2024, 3, 10))
+	salary := &entities.Income{UserID: "u1", Amount: decimal.NewFromInt(50000), Month: month(2024, 3), Source: "Salary", AccountID: bank.ID}
+	if err := svc.incomes.AddIncome(ctx, salary); err != nil {
+		t.Fatalf("AddIncome() error = %v", err)
+	}
+	payment := &entities.Transfer{UserID: "u1", FromAccountID: bank.ID, ToAccountID: card.ID, Amount: decimal.NewFromInt(500), Date: date(2024, 3, 20)}
+	if err := svc.accounts.CreateTransfer(ctx, payment); err != nil {
+		t.Fatalf("CreateTransfer() error = %v", err)
+	}
+	if !payment.ToAmount.Equal(payment.Amount) {
+		t.Errorf("to amount = %s, want %s for accounts in the same currency", payment.ToAmount, payment.Amount)
+	}
+
+	accounts, err := svc.accounts.GetAccounts(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetAccounts() error = %v", err)
+	}
+	balances := map[string]string{}
+	for _, summary := range accounts {
+		balances[summary.Account.Name] = summary.Balance.String()
+	}
+	if balances["Savings account"] != "59300" || balances["Credit card"] != "0" {
+		t.Errorf("balances = %v, want 59300 in the bank and 0 on the card", balances)
+	}
+
+	ledger, err := svc.accounts.GetLedger(ctx, "u1", bank.ID, date(2024, 3, 15), time.Time{})
+	if err != nil {
+		t.Fatalf("GetLedger() error = %v", err)
+	}
+	if ledger.OpeningBalance.String() != "59800" || len(ledger.Entries) != 1 || ledger.ClosingBalance.String() != "59300" {
+		t.Errorf("ledger = %s, %d entries, %s; want 59800, 1 transfer, 59300", ledger.OpeningBalance, len(ledger.Entries), ledger.ClosingBalance)
+	}
+	if entry := ledger.Entries[0]; entry.Kind != entities.EntryTransferOut || entry.Amount.String() != "-500" {
+		t.Errorf("entry = %s %s, want a transfer out of -500", entry.Kind, entry.Amount)
+	}
+
+	// Paying off the card moves money without counting as spending
+	breakdown, err := svc.expenses.GetMonthlyBreakdown(ctx, "u1", month(2024, 3), entities.CashBasis)
+	if err != nil {
+		t.Fatalf("GetMonthlyBreakdown() error = %v", err)
+	}
+	if breakdown.TotalExpenses.String() != "700" {
+		t.Errorf("total expenses = %s, want 700", breakdown.TotalExpenses)
+	}
+
+	if err := svc.accounts.DeleteAccount(ctx, "u1", card.ID); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("DeleteAccount() of a used account error = %v, want ErrInvalidInput", err)
+	}
+	// Accounts only a rule is scoped to can't be deleted either
+	wallet := newTestAccount(t, svc, "u1", "Wallet", entities.CashWallet, "0")
+	newTestRule(t, svc, &entities.CategorizationRule{Pattern: "metro", AccountID: wallet.ID, MainCategory: entities.Self, SubCategory: entities.Travel})
+	if err := svc.accounts.DeleteAccount(ctx, "u1", wallet.ID); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("DeleteAccount() of an account with a rule error = %v, want ErrInvalidInput", err)
+	}
+	if err := svc.accounts.DeleteTransfer(ctx, "u2", payment.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DeleteTransfer() by another user error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestAccountValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	bank := newTestAccount(t, svc, "u1", "Savings account", entities.BankAccount, "0")
+
+	invalid := map[string]*entities.Account{
+		"duplicate name": {UserID: "u1", Name: "savings ACCOUNT", Type: entities.BankAccount},
+		"unknown type":   {UserID: "u1", Name: "Piggy bank", Type: "jar"},
+		"bad last4":      {UserID: "u1", Name: "Card", Type: entities.CreditCard, Last4: "12a4"},
+	}
+	for name, account := range invalid {
+		if err := svc.accounts.CreateAccount(ctx, account); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: CreateAccount() error = %v, want ErrInvalidInput", name, err)
+		}
+	}
+
+	other := newTestExpense("u2", "10", date(2024, 3, 5), entities.Self, entities.Food)
+	other.AccountID = bank.ID
+	if _, err := svc.expenses.AddExpense(ctx, other); !errors.Is(err, ErrNotFound) {
+		t.Errorf("AddExpense() against another user's account error = %v, want ErrNotFound", err)
+	}
+
+	expense := addAccountExpense(t, svc, bank, "10", date(2024, 3, 5))
+	bank.Archived = true
+	if err := svc.accounts.UpdateAccount(ctx, bank.ID, bank); err != nil {
+		t.Fatalf("UpdateAccount() error = %v", err)
+	}
+	archived := newTestExpense("u1", "10", date(2024, 3, 6), entities.Self, entities.Food)
+	archived.AccountID = bank.ID
+	if _, err := svc.expenses.AddExpense(ctx, archived); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("AddExpense() against an archived account error = %v, want ErrInvalidInput", err)
+	}
+	expense.Description = "still on the old account"
+	if err := svc.expenses.UpdateExpense(ctx, expense.ID, expense); err != nil {
+		t.Errorf("UpdateExpense() already on the archived account error = %v", err)
+	}
+}
+
+func TestReconcileStatement(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	bank := newTestAccount(t, svc, "u1", "Savings account", entities.BankAccount, "1000")
+	rent := addAccountExpense(t, svc, bank, "200", date(2024, 3, 10))
+	addAccountExpense(t, svc, bank, "75", date(2024, 3, 12))
+	forgotten := addAccountExpense(t, svc, bank, "40", date(2024, 3, 28))
+
+	closing := decimal.NewFromInt(-274)
+	line := func(amount string, day int) *entities.StatementLine {
+		return &entities.StatementLine{Date: date(2024, 3, day), Amount: decimal.RequireFromString(amount), Type: entities.StatementDebit}
+	}
+	statement := &entities.Statement{
+		AccountLast4:   "4321",
+		StartDate:      date(2024, 3, 1),
+		EndDate:        date(2024, 3, 31),
+		ClosingBalance: &closing,
+		Lines:          []*entities.StatementLine{line("75", 12), line("200", 12), line("999", 15)},
+	}
+	result, err := svc.accounts.Reconcile(ctx, "u1", bank.ID, statement)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if len(result.Matched) != 2 || result.Matched[1].Entry.ID != rent.ID {
+		t.Errorf("matched = %+v, want both the 75 and the 200 posted two days late", result.Matched)
+	}
+	if len(result.Unrecorded) != 1 || result.Unrecorded[0].Amount.String() != "999" {
+		t.Errorf("unrecorded = %+v, want the 999 line", result.Unrecorded)
+	}
+	if len(result.Unmatched) != 1 || result.Unmatched[0].ID != forgotten.ID {
+		t.Errorf("unmatched = %+v, want the 40 expense", result.Unmatched)
+	}
+	if result.RecordedBalance.String() != "685" || result.Difference == nil || result.Difference.String() != "-959" {
+		t.Errorf("recorded balance = %s, difference = %v; want 685 and -959", result.RecordedBalance, result.Difference)
+	}
+
+	statement.AccountLast4 = "9999"
+	if _, err := svc.accounts.Reconcile(ctx, "u1", bank.ID, statement); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Reconcile() of another account's statement error = %v, want ErrInvalidInput", err)
+	}
+}
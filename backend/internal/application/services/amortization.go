@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// MaxAmortizeMonths caps how many months an expense can be spread over
+	MaxAmortizeMonths = 60
+
+	// amortizationPrecision is the number of decimal places kept for monthly shares
+	amortizationPrecision = 2
+)
+
+// normalizeBasis defaults an empty basis to cash and rejects unknown ones
+func normalizeBasis(basis entities.Basis) (entities.Basis, error) {
+	switch basis {
+	case "":
+		return entities.CashBasis, nil
+	case entities.CashBasis, entities.AmortizedBasis:
+		return basis, nil
+	}
+	return "", fmt.Errorf("%w: basis must be %q or %q", ErrInvalidInput, entities.CashBasis, entities.AmortizedBasis)
+}
+
+// validateAmortization checks the number of months an expense is spread over.
+// A single month is the same as no amortization.
+func validateAmortization(expense *entities.Expense) error {
+	if expense.AmortizeMonths < 0 || expense.AmortizeMonths > MaxAmortizeMonths {
+		return fmt.Errorf("%w: amortize_months must be between 0 and %d", ErrInvalidInput, MaxAmortizeMonths)
+	}
+	if expense.AmortizeMonths == 1 {
+		expense.AmortizeMonths = 0
+	}
+	return nil
+}
+
+// amortizedExpenses replaces a month's cash-basis expenses with their amortized
+// equivalent: amortized expenses paid that month are swapped for their share,
+// and shares of those paid in earlier months are added
+func (s *expenseService) amortizedExpenses(ctx context.Context, userID string, month time.Time, paid []*entities.Expense) ([]*entities.Expense, error) {
+	expenses := make([]*entities.Expense, 0, len(paid))
+	for _, expense := range paid {
+		if expense.AmortizeMonths <= 1 {
+			expenses = append(expenses, expense)
+		}
+	}
+
+	start := month.AddDate(0, 1-MaxAmortizeMonths, 0)
+	end := month.AddDate(0, 1, 0)
+	stored, err := s.expenseRepo.GetByUserID(ctx, userID, &repositories.ExpenseFilters{
+		StartDate:   &start,
+		EndDate:     &end,
+		Amortized:   true,
+		OldestFirst: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get amortized expenses: %w", err)
+	}
+	for _, expense := range toEntityExpenses(stored) {
+		if share, ok := amortizedShare(expense, month); ok {
+			expenses = append(expenses, share)
+		}
+	}
+	return expenses, nil
+}
+
+// amortizedShare returns the part of an amortized expense that falls in month,
+// split evenly from the month it was paid in. The last month absorbs rounding.
+func amortizedShare(expense *entities.Expense, month time.Time) (*entities.Expense, bool) {
+	first := startOfMonth(expense.Date)
+	index := (month.Year()-first.Year())*12 + int(month.Month()-first.Month())
+	if index < 0 || index >= expense.AmortizeMonths {
+		return nil, false
+	}
+
+	months := decimal.NewFromInt(int64(expense.AmortizeMonths))
+	share := expense.Amount.DivRound(months, amortizationPrecision)
+	if index == expense.AmortizeMonths-1 {
+		share = expense.Amount.Sub(share.Mul(months.Sub(decimal.NewFromInt(1))))
+	}
+	return scaleExpense(expense, share), true
+}
+
+// scaleExpense copies an expense with its amount changed to amount and its line
+// items scaled in proportion. The last item absorbs rounding so the items still
+// add up to amount.
+func scaleExpense(expense *entities.Expense, amount decimal.Decimal) *entities.Expense {
+	scaled := *expense
+	scaled.Amount = amount
+	if len(expense.Items) == 0 || expense.Amount.IsZero() {
+		return &scaled
+	}
+
+	scaled.Items = make([]*entities.ExpenseItem, len(expense.Items))
+	remaining := amount
+	for i, item := range expense.Items {
+		scaledItem := *item
+		if i == len(expense.Items)-1 {
+			scaledItem.Amount = remaining
+		} else {
+			scaledItem.Amount = item.Amount.Mul(amount).DivRound(expense.Amount, amortizationPrecision)
+			remaining = remaining.Sub(scaledItem.Amount)
+		}
+		scaled.Items[i] = &scaledItem
+	}
+	return &scaled
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestAmortizedShare(t *testing.T) {
+	expense := newTestExpense("u1", "1000", date(2024, 11, 20), entities.Self, entities.Education)
+	expense.AmortizeMonths = 3
+	expense.Items = []*entities.ExpenseItem{
+		{Amount: decimal.NewFromInt(700), SubCategory: entities.Education},
+		{Amount: decimal.NewFromInt(300), SubCategory: entities.Misc},
+	}
+
+	tests := []struct {
+		month time.Time
+		want  string
+		ok    bool
+	}{
+		{month(2024, 10), "", false},
+		{month(2024, 11), "333.33", true},
+		{month(2024, 12), "333.33", true},
+		{month(2025, 1), "333.34", true},
+		{month(2025, 2), "", false},
+	}
+	for _, tt := range tests {
+		share, ok := amortizedShare(expense, tt.month)
+		if ok != tt.ok {
+			t.Errorf("%s: covered = %v, want %v", tt.month.Format("2006-01"), ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if share.Amount.String() != tt.want {
+			t.Errorf("%s: share = %s, want %s", tt.month.Format("2006-01"), share.Amount, tt.want)
+		}
+		if total := share.Items[0].Amount.Add(share.Items[1].Amount); !total.Equal(share.Amount) {
+			t.Errorf("%s: items add up to %s, want %s", tt.month.Format("2006-01"), total, share.Amount)
+		}
+	}
+	if !expense.Amount.Equal(decimal.NewFromInt(1000)) || !expense.Items[0].Amount.Equal(decimal.NewFromInt(700)) {
+		t.Error("amortizedShare must not modify the expense")
+	}
+}
+
+func TestAmortizedBreakdown(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	for _, m := range []time.Time{month(2024, 1), month(2024, 2)} {
+		income := &entities.Income{UserID: "u1", Amount: decimal.NewFromInt(5000), Month: m, Source: "Salary"}
+		if err := svc.incomes.AddIncome(ctx, income); err != nil {
+			t.Fatalf("AddIncome failed: %v", err)
+		}
+	}
+	premium := newTestExpense("u1", "12000", date(2024, 1, 5), entities.Self, entities.Misc)
+	premium.AmortizeMonths = 12
+	if _, err := svc.expenses.AddExpense(ctx, premium); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	// Paid before the window and too long ago to still be spread
+	old := newTestExpense("u1", "600", date(2023, 1, 5), entities.Self, entities.Misc)
+	old.AmortizeMonths = 6
+	if _, err := svc.expenses.AddExpense(ctx, old); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	if _, err := svc.expenses.AddExpense(ctx, newTestExpense("u1", "500", date(2024, 2, 10), entities.Self, entities.Food)); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+
+	cash, err := svc.expenses.GetMonthlyBreakdown(ctx, "u1", month(2024, 2), "")
+	if err != nil {
+		t.Fatalf("GetMonthlyBreakdown failed: %v", err)
+	}
+	if cash.Basis != entities.CashBasis || !cash.TotalExpenses.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("cash basis: %s %s, want cash 500", cash.Basis, cash.TotalExpenses)
+	}
+
+	amortized, err := svc.expenses.GetMonthlyBreakdown(ctx, "u1", month(2024, 2), entities.AmortizedBasis)
+	if err != nil {
+		t.Fatalf("GetMonthlyBreakdown failed: %v", err)
+	}
+	if !amortized.TotalExpenses.Equal(decimal.NewFromInt(1500)) || !amortized.SubCategoryBreakdown[entities.Misc].Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("amortized basis: total %s, Misc %s, want 1500 and 1000", amortized.TotalExpenses, amortized.SubCategoryBreakdown[entities.Misc])
+	}
+	// January is compared on the same basis: 1000 instead of the 12000 paid
+	if !amortized.Comparison.TotalExpenses.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("expected a +500 change from January, got %s", amortized.Comparison.TotalExpenses)
+	}
+
+	savings, err := svc.expenses.CalculateSavings(ctx, "u1", month(2024, 1), entities.AmortizedBasis)
+	if err != nil {
+		t.Fatalf("CalculateSavings failed: %v", err)
+	}
+	if !savings.Equal(decimal.NewFromInt(4000)) {
+		t.Errorf("amortized January savings = %s, want 4000", savings)
+	}
+	if _, err := svc.expenses.CalculateSavings(ctx, "u1", month(2024, 1), "accrual"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown basis, got %v", err)
+	}
+
+	tooLong := newTestExpense("u1", "10", date(2024, 1, 5), entities.Self, entities.Misc)
+	tooLong.AmortizeMonths = MaxAmortizeMonths + 1
+	if _, err := svc.expenses.AddExpense(ctx, tooLong); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for too many months, got %v", err)
+	}
+}
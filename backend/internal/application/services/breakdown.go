@@ -0,0 +1,101 @@
+package services
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// percentPrecision is the number of decimal places kept for percentages
+const percentPrecision = 2
+
+var hundred = decimal.NewFromInt(100)
+
+// buildBreakdown totals a month's expenses per category against its income.
+// All sums are exact; only percentages are rounded.
+func buildBreakdown(month time.Time, income decimal.Decimal, expenses []*entities.Expense) *entities.MonthlyBreakdown {
+	breakdown := &entities.MonthlyBreakdown{
+		Month:                month,
+		TotalIncome:          income,
+		TotalExpenses:        decimal.Zero,
+		CategoryBreakdown:    make(map[entities.MainCategory]decimal.Decimal),
+		SubCategoryBreakdown: make(map[entities.SubCategory]decimal.Decimal),
+		CategoryCrossTab:     make(map[entities.MainCategory]map[entities.SubCategory]decimal.Decimal),
+	}
+
+	for _, expense := range expenses {
+		breakdown.TotalExpenses = breakdown.TotalExpenses.Add(expense.Amount)
+		breakdown.CategoryBreakdown[expense.MainCategory] = breakdown.CategoryBreakdown[expense.MainCategory].Add(expense.Amount)
+
+		row, ok := breakdown.CategoryCrossTab[expense.MainCategory]
+		if !ok {
+			row = make(map[entities.SubCategory]decimal.Decimal)
+			breakdown.CategoryCrossTab[expense.MainCategory] = row
+		}
+		// Itemized expenses count each line item under its own sub-category
+		for sub, amount := range subCategoryAmounts(expense) {
+			breakdown.SubCategoryBreakdown[sub] = breakdown.SubCategoryBreakdown[sub].Add(amount)
+			row[sub] = row[sub].Add(amount)
+		}
+	}
+	breakdown.Savings = income.Sub(breakdown.TotalExpenses)
+
+	if income.IsPositive() {
+		percentages := &entities.IncomePercentages{
+			Expenses:      percentOf(breakdown.TotalExpenses, income),
+			Savings:       percentOf(breakdown.Savings, income),
+			Categories:    make(map[entities.MainCategory]decimal.Decimal, len(breakdown.CategoryBreakdown)),
+			SubCategories: make(map[entities.SubCategory]decimal.Decimal, len(breakdown.SubCategoryBreakdown)),
+		}
+		for category, amount := range breakdown.CategoryBreakdown {
+			percentages.Categories[category] = percentOf(amount, income)
+		}
+		for category, amount := range breakdown.SubCategoryBreakdown {
+			percentages.SubCategories[category] = percentOf(amount, income)
+		}
+		breakdown.IncomePercentages = percentages
+	}
+
+	return breakdown
+}
+
+// compareBreakdowns computes the change from previous to current.
+// Categories present in only one of the months are compared against zero.
+func compareBreakdowns(current, previous *entities.MonthlyBreakdown) *entities.BreakdownComparison {
+	comparison := &entities.BreakdownComparison{
+		PreviousMonth: previous.Month,
+		TotalIncome:   current.TotalIncome.Sub(previous.TotalIncome),
+		TotalExpenses: current.TotalExpenses.Sub(previous.TotalExpenses),
+		Savings:       current.Savings.Sub(previous.Savings),
+		Categories:    make(map[entities.MainCategory]decimal.Decimal),
+		SubCategories: make(map[entities.SubCategory]decimal.Decimal),
+	}
+
+	for category, amount := range current.CategoryBreakdown {
+		comparison.Categories[category] = amount.Sub(previous.CategoryBreakdown[category])
+	}
+	for category, amount := range previous.CategoryBreakdown {
+		if _, ok := current.CategoryBreakdown[category]; !ok {
+			comparison.Categories[category] = amount.Neg()
+		}
+	}
+	for category, amount := range current.SubCategoryBreakdown {
+		comparison.SubCategories[category] = amount.Sub(previous.SubCategoryBreakdown[category])
+	}
+	for category, amount := range previous.SubCategoryBreakdown {
+		if _, ok := current.SubCategoryBreakdown[category]; !ok {
+			comparison.SubCategories[category] = amount.Neg()
+		}
+	}
+
+	return comparison
+}
+
+// percentOf returns part as a percentage of whole, rounded to percentPrecision places
+func percentOf(part, whole decimal.Decimal) decimal.Decimal {
+	if whole.IsZero() {
+		return decimal.Zero
+	}
+	return part.Mul(hundred).Div(whole).Round(percentPrecision)
+}
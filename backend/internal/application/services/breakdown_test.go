@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestBuildBreakdown(t *testing.T) {
+	date := month(2024, 3)
+	expenses := []*entities.Expense{
+		newTestExpense("u1", "0.10", date, entities.ChennaiHouse, entities.Food),
+		newTestExpense("u1", "0.20", date, entities.ChennaiHouse, entities.Food),
+		newTestExpense("u1", "300", date, entities.ChennaiHouse, entities.Travel),
+		newTestExpense("u1", "1000", date, entities.Self, entities.Education),
+	}
+
+	breakdown := buildBreakdown(date, decimal.NewFromInt(3000), expenses)
+
+	if breakdown.TotalExpenses.String() != "1300.3" {
+		t.Errorf("expected exact total 1300.3, got %s", breakdown.TotalExpenses)
+	}
+	if breakdown.Savings.String() != "1699.7" {
+		t.Errorf("expected savings 1699.7, got %s", breakdown.Savings)
+	}
+	if got := breakdown.CategoryCrossTab[entities.ChennaiHouse][entities.Food]; got.String() != "0.3" {
+		t.Errorf("expected Chennai House/Food 0.3, got %s", got)
+	}
+	if _, ok := breakdown.CategoryCrossTab[entities.Self][entities.Food]; ok {
+		t.Error("cross-tab should only contain combinations that were spent on")
+	}
+	if got := breakdown.IncomePercentages.Categories[entities.Self]; got.String() != "33.33" {
+		t.Errorf("expected Self to be 33.33%% of income, got %s", got)
+	}
+	if got := breakdown.IncomePercentages.Savings; got.String() != "56.66" {
+		t.Errorf("expected savings rate 56.66%%, got %s", got)
+	}
+
+	noIncome := buildBreakdown(date, decimal.Zero, expenses)
+	if noIncome.IncomePercentages != nil {
+		t.Error("percentages should be omitted when there is no income")
+	}
+	if !noIncome.Savings.Equal(noIncome.TotalExpenses.Neg()) {
+		t.Errorf("expected negative savings without income, got %s", noIncome.Savings)
+	}
+}
+
+func TestCompareBreakdowns(t *testing.T) {
+	previous := buildBreakdown(month(2024, 2), decimal.NewFromInt(1000), []*entities.Expense{
+		newTestExpense("u1", "200", month(2024, 2), entities.Self, entities.Food),
+		newTestExpense("u1", "50", month(2024, 2), entities.Savings, entities.Misc),
+	})
+	current := buildBreakdown(month(2024, 3), decimal.NewFromInt(1200), []*entities.Expense{
+		newTestExpense("u1", "150", month(2024, 3), entities.Self, entities.Food),
+		newTestExpense("u1", "75", month(2024, 3), entities.ChennaiHouse, entities.Travel),
+	})
+
+	comparison := compareBreakdowns(current, previous)
+
+	checks := map[string]decimal.Decimal{
+		"income":        comparison.TotalIncome,
+		"expenses":      comparison.TotalExpenses,
+		"savings":       comparison.Savings,
+		"self":          comparison.Categories[entities.Self],
+		"savings cat":   comparison.Categories[entities.Savings],
+		"chennai house": comparison.Categories[entities.ChennaiHouse],
+	}
+	expected := map[string]string{
+		"income":        "200",
+		"expenses":      "-25",
+		"savings":       "225",
+		"self":          "-50",
+		"savings cat":   "-50",
+		"chennai house": "75",
+	}
+	for name, got := range checks {
+		if got.String() != expected[name] {
+			t.Errorf("%s delta: expected %s, got %s", name, expected[name], got)
+		}
+	}
+}
+
+func TestCalculateSavingsUsesAllIncomeSources(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	incomeService, expenseService := svc.incomes, svc.expenses
+
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for source, amount := range map[string]int64{"Salary": 80000, "Freelance": 20000} {
+		income := &entities.Income{UserID: "u1", Amount: decimal.NewFromInt(amount), Month: march, Source: source}
+		if err := incomeService.AddIncome(ctx, income); err != nil {
+			t.Fatalf("AddIncome failed: %v", err)
+		}
+	}
+	expense := newTestExpense("u1", "35000.75", march.AddDate(0, 0, 4), entities.BangaloreHouse, entities.Misc)
+	if _, err := expenseService.AddExpense(ctx, expense); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+
+	savings, err := expenseService.CalculateSavings(ctx, "u1", march.AddDate(0, 0, 10), entities.CashBasis)
+	if err != nil {
+		t.Fatalf("CalculateSavings failed: %v", err)
+	}
+	if savings.String() != "64999.25" {
+		t.Errorf("expected savings 64999.25, got %s", savings)
+	}
+
+	breakdown, err := expenseService.GetMonthlyBreakdown(ctx, "u1", march, entities.CashBasis)
+	if err != nil {
+		t.Fatalf("GetMonthlyBreakdown failed: %v", err)
+	}
+	if breakdown.Comparison == nil || breakdown.Comparison.TotalExpenses.String() != "35000.75" {
+		t.Errorf("expected comparison against an empty February, got %+v", breakdown.Comparison)
+	}
+}
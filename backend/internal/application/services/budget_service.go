@@ -0,0 +1,448 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxRolloverMonths bounds how far back unspent amounts are carried into a budget
+const maxRolloverMonths = 12
+
+// BudgetService defines the interface for budget operations
+type BudgetService interface {
+	CreateBudget(ctx context.Context, budget *entities.Budget) error
+	GetBudget(ctx context.Context, userID, id string) (*entities.Budget, error)
+	GetBudgets(ctx context.Context, userID string, month time.Time) ([]*entities.Budget, error)
+	UpdateBudget(ctx context.Context, id string, budget *entities.Budget) error
+	DeleteBudget(ctx context.Context, userID, id string) error
+	GetBudgetReport(ctx context.Context, userID string, month time.Time) (*entities.BudgetReport, error)
+	CheckExpense(ctx context.Context, expense *entities.Expense) ([]*entities.BudgetWarning, error)
+}
+
+// budgetService implements the BudgetService interface
+type budgetService struct {
+	budgetRepo      repositories.BudgetRepository
+	expenseRepo     repositories.ExpenseRepository
+	categoryService CategoryService
+	currencyService CurrencyService
+}
+
+// NewBudgetService creates a new budget service
+func NewBudgetService(budgetRepo repositories.BudgetRepository, expenseRepo repositories.ExpenseRepository, categoryService CategoryService, currencyService CurrencyService) BudgetService {
+	return &budgetService{
+		budgetRepo:      budgetRepo,
+		expenseRepo:     expenseRepo,
+		categoryService: categoryService,
+		currencyService: currencyService,
+	}
+}
+
+// CreateBudget creates a budget for a category and month
+func (s *budgetService) CreateBudget(ctx context.Context, budget *entities.Budget) error {
+	if err := s.validateBudget(ctx, budget); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	budget.ID = ""
+	budget.CreatedAt = now
+	budget.UpdatedAt = now
+
+	stored := toRepositoryBudget(budget)
+	if err := s.budgetRepo.Create(ctx, stored); err != nil {
+		return fmt.Errorf("failed to save budget: %w", err)
+	}
+	budget.ID = stored.ID
+	return nil
+}
+
+// GetBudget gets a single budget owned by the user
+func (s *budgetService) GetBudget(ctx context.Context, userID, id string) (*entities.Budget, error) {
+	stored, err := s.getOwnedBudget(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toEntityBudget(stored), nil
+}
+
+// GetBudgets gets every budget the user set for a month
+func (s *budgetService) GetBudgets(ctx context.Context, userID string, month time.Time) ([]*entities.Budget, error) {
+	stored, err := s.budgetRepo.GetByMonth(ctx, userID, startOfMonth(month))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	budgets := make([]*entities.Budget, 0, len(stored))
+	for _, budget := range stored {
+		budgets = append(budgets, toEntityBudget(budget))
+	}
+	return budgets, nil
+}
+
+// UpdateBudget updates an existing budget
+func (s *budgetService) UpdateBudget(ctx context.Context, id string, budget *entities.Budget) error {
+	existing, err := s.getOwnedBudget(ctx, budget.UserID, id)
+	if err != nil {
+		return err
+	}
+
+	budget.ID = id
+	if err := s.validateBudget(ctx, budget); err != nil {
+		return err
+	}
+
+	budget.CreatedAt = existing.CreatedAt
+	budget.UpdatedAt = time.Now()
+
+	if err := s.budgetRepo.Update(ctx, toRepositoryBudget(budget)); err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	}
+	return nil
+}
+
+// DeleteBudget deletes a budget
+func (s *budgetService) DeleteBudget(ctx context.Context, userID, id string) error {
+	if _, err := s.getOwnedBudget(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.budgetRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+	return nil
+}
+
+// GetBudgetReport compares every budget in a month with the actual spending
+func (s *budgetService) GetBudgetReport(ctx context.Context, userID string, month time.Time) (*entities.BudgetReport, error) {
+	month = startOfMonth(month)
+	budgets, err := s.budgetRepo.GetByMonth(ctx, userID, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	evaluation, err := s.newEvaluation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.BudgetReport{
+		Month:    month,
+		Currency: evaluation.base,
+		Budgets:  make([]*entities.BudgetStatus, 0, len(budgets)),
+	}
+	for _, budget := range budgets {
+		status, err := evaluation.status(ctx, budget, 0)
+		if err != nil {
+			return nil, err
+		}
+		report.Budgets = append(report.Budgets, status)
+	}
+	return report, nil
+}
+
+// CheckExpense re-evaluates the budgets covering a newly saved expense and returns
+// a warning for each one it pushed past 80% or 100%. Only the highest threshold
+// crossed is reported per budget.
+func (s *budgetService) CheckExpense(ctx context.Context, expense *entities.Expense) ([]*entities.BudgetWarning, error) {
+	budgets, err := s.budgetRepo.GetByMonth(ctx, expense.UserID, startOfMonth(expense.Date))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+	if len(budgets) == 0 {
+		return nil, nil
+	}
+
+	evaluation, err := s.newEvaluation(ctx, expense.UserID)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := convertExpense(ctx, s.currencyService, expense.UserID, expense, evaluation.base)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []*entities.BudgetWarning
+	for _, budget := range budgets {
+		amount := coveredAmount(converted, entities.CategoryKind(budget.Kind), evaluation.covered(budget))
+		if amount.IsZero() {
+			continue
+		}
+
+		status, err := evaluation.status(ctx, budget, 0)
+		if err != nil {
+			return nil, err
+		}
+		before := percentOf(status.Spent.Sub(amount), status.Available)
+
+		var threshold decimal.Decimal
+		switch {
+		case before.LessThan(entities.BudgetExceededPercent) && !status.PercentUsed.LessThan(entities.BudgetExceededPercent):
+			threshold = entities.BudgetExceededPercent
+		case before.LessThan(entities.BudgetWarningPercent) && !status.PercentUsed.LessThan(entities.BudgetWarningPercent):
+			threshold = entities.BudgetWarningPercent
+		default:
+			continue
+		}
+
+		warnings = append(warnings, &entities.BudgetWarning{
+			BudgetID:    budget.ID,
+			Kind:        entities.CategoryKind(budget.Kind),
+			Category:    budget.Category,
+			Threshold:   threshold,
+			PercentUsed: status.PercentUsed,
+			Spent:       status.Spent,
+			Available:   status.Available,
+			Currency:    status.Currency,
+			Message: fmt.Sprintf("%s budget for %s is %s%% used (%s of %s %s)",
+				budget.Category, budget.Month.Format("January 2006"), status.PercentUsed,
+				status.Spent, status.Available, status.Currency),
+		})
+	}
+	return warnings, nil
+}
+
+// getOwnedBudget loads a budget and hides it unless it belongs to userID
+func (s *budgetService) getOwnedBudget(ctx context.Context, userID, id string) (*repositories.Budget, error) {
+	stored, err := s.budgetRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("budget %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+	if stored.UserID != userID {
+		return nil, fmt.Errorf("budget %s: %w", id, ErrNotFound)
+	}
+	return stored, nil
+}
+
+// validateBudget checks a budget and normalizes its category, month and currency
+func (s *budgetService) validateBudget(ctx context.Context, budget *entities.Budget) error {
+	if budget.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	if !budget.Kind.IsValid() {
+		return fmt.Errorf("%w: kind must be %q or %q", ErrInvalidInput, entities.MainCategoryKind, entities.SubCategoryKind)
+	}
+	if !budget.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidInput)
+	}
+	if budget.Month.IsZero() {
+		return fmt.Errorf("%w: month is required", ErrInvalidInput)
+	}
+	budget.Month = startOfMonth(budget.Month)
+
+	category, err := lookupCategory(ctx, s.categoryService, budget.UserID, budget.Kind, budget.Category)
+	if err != nil {
+		return err
+	}
+	budget.Category = category.Name
+
+	currency, err := resolveCurrency(ctx, s.currencyService, budget.UserID, budget.Currency)
+	if err != nil {
+		return err
+	}
+	budget.Currency = currency
+
+	existing, err := s.budgetRepo.GetByCategory(ctx, budget.UserID, string(budget.Kind), budget.Category, budget.Month)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return fmt.Errorf("failed to check budget: %w", err)
+	}
+	if existing != nil && existing.ID != budget.ID {
+		return fmt.Errorf("%w: a budget for %s in %s already exists", ErrInvalidInput, budget.Category, budget.Month.Format("2006-01"))
+	}
+	return nil
+}
+
+// budgetEvaluation computes budget statuses for one user, reading each month's
+// spending at most once so rollover chains and sibling budgets share it
+type budgetEvaluation struct {
+	service    *budgetService
+	userID     string
+	base       string
+	categories []*entities.Category
+	spending   map[time.Time][]*entities.Expense // amounts converted to base
+}
+
+// newEvaluation loads what every budget evaluation for a user needs
+func (s *budgetService) newEvaluation(ctx context.Context, userID string) (*budgetEvaluation, error) {
+	base, err := s.currencyService.BaseCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.categoryService.GetCategories(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	return &budgetEvaluation{
+		service:    s,
+		userID:     userID,
+		base:       base,
+		categories: categories,
+		spending:   make(map[time.Time][]*entities.Expense),
+	}, nil
+}
+
+// status computes budget-vs-actual, following rollovers up to maxRolloverMonths back
+func (e *budgetEvaluation) status(ctx context.Context, budget *repositories.Budget, depth int) (*entities.BudgetStatus, error) {
+	budgeted, err := e.service.currencyService.Convert(ctx, e.userID, budget.Amount, budget.Currency, e.base, budget.Month)
+	if err != nil {
+		return nil, err
+	}
+
+	rolledOver := decimal.Zero
+	if budget.Rollover && depth < maxRolloverMonths {
+		previous, err := e.service.budgetRepo.GetByCategory(ctx, e.userID, budget.Kind, budget.Category, budget.Month.AddDate(0, -1, 0))
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get previous budget: %w", err)
+		}
+		if previous != nil {
+			previousStatus, err := e.status(ctx, previous, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if previousStatus.Remaining.IsPositive() {
+				rolledOver = previousStatus.Remaining
+			}
+		}
+	}
+
+	expenses, err := e.monthSpending(ctx, budget.Month)
+	if err != nil {
+		return nil, err
+	}
+	covered := e.covered(budget)
+	spent := decimal.Zero
+	for _, expense := range expenses {
+		spent = spent.Add(coveredAmount(expense, entities.CategoryKind(budget.Kind), covered))
+	}
+
+	available := budgeted.Add(rolledOver)
+	percentUsed := percentOf(spent, available)
+	status := &entities.BudgetStatus{
+		Budget:      toEntityBudget(budget),
+		Currency:    e.base,
+		Budgeted:    budgeted,
+		RolledOver:  rolledOver,
+		Available:   available,
+		Spent:       spent,
+		Remaining:   available.Sub(spent),
+		PercentUsed: percentUsed,
+		State:       budgetState(percentUsed),
+	}
+	return status, nil
+}
+
+// budgetState classifies how much of a budget has been used
+func budgetState(percentUsed decimal.Decimal) entities.BudgetState {
+	switch {
+	case !percentUsed.LessThan(entities.BudgetExceededPercent):
+		return entities.BudgetStateExceeded
+	case !percentUsed.LessThan(entities.BudgetWarningPercent):
+		return entities.BudgetStateWarning
+	}
+	return entities.BudgetStateOnTrack
+}
+
+// monthSpending gets a month's expenses with amounts converted to the base currency
+func (e *budgetEvaluation) monthSpending(ctx context.Context, month time.Time) ([]*entities.Expense, error) {
+	if expenses, ok := e.spending[month]; ok {
+		return expenses, nil
+	}
+
+	stored, err := e.service.expenseRepo.GetByUserIDAndDateRange(ctx, e.userID, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+
+	expenses := make([]*entities.Expense, 0, len(stored))
+	for _, expense := range stored {
+		converted, err := convertExpense(ctx, e.service.currencyService, e.userID, toEntityExpense(expense), e.base)
+		if err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, converted)
+	}
+	e.spending[month] = expenses
+	return expenses, nil
+}
+
+// covered returns the names of the budget's category and every category nested under it
+func (e *budgetEvaluation) covered(budget *repositories.Budget) map[string]bool {
+	names := map[string]bool{budget.Category: true}
+	for {
+		added := false
+		for _, category := range e.categories {
+			if string(category.Kind) != budget.Kind || names[category.Name] {
+				continue
+			}
+			for _, parent := range e.categories {
+				if parent.ID == category.ParentID && names[parent.Name] {
+					names[category.Name] = true
+					added = true
+				}
+			}
+		}
+		if !added {
+			return names
+		}
+	}
+}
+
+// coveredAmount returns how much of the expense falls under the covered
+// categories of the given kind. Itemized expenses count only their line items
+// filed under a covered sub-category.
+func coveredAmount(expense *entities.Expense, kind entities.CategoryKind, covered map[string]bool) decimal.Decimal {
+	if kind == entities.MainCategoryKind {
+		if covered[string(expense.MainCategory)] {
+			return expense.Amount
+		}
+		return decimal.Zero
+	}
+
+	amount := decimal.Zero
+	for sub, part := range subCategoryAmounts(expense) {
+		if covered[string(sub)] {
+			amount = amount.Add(part)
+		}
+	}
+	return amount
+}
+
+// toRepositoryBudget converts a budget entity to its repository model
+func toRepositoryBudget(budget *entities.Budget) *repositories.Budget {
+	return &repositories.Budget{
+		ID:        budget.ID,
+		UserID:    budget.UserID,
+		Kind:      string(budget.Kind),
+		Category:  budget.Category,
+		Month:     budget.Month,
+		Amount:    budget.Amount,
+		Currency:  budget.Currency,
+		Rollover:  budget.Rollover,
+		CreatedAt: budget.CreatedAt,
+		UpdatedAt: budget.UpdatedAt,
+	}
+}
+
+// toEntityBudget converts a repository budget model to an entity
+func toEntityBudget(budget *repositories.Budget) *entities.Budget {
+	return &entities.Budget{
+		ID:        budget.ID,
+		UserID:    budget.UserID,
+		Kind:      entities.CategoryKind(budget.Kind),
+		Category:  budget.Category,
+		Month:     budget.Month,
+		Amount:    budget.Amount,
+		Currency:  budget.Currency,
+		Rollover:  budget.Rollover,
+		CreatedAt: budget.CreatedAt,
+		UpdatedAt: budget.UpdatedAt,
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestAddExpenseWarnsWhenBudgetThresholdsAreCrossed(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	march := month(2024, 3)
+
+	budget := &entities.Budget{UserID: "u1", Kind: entities.SubCategoryKind, Category: "food", Month: march.AddDate(0, 0, 12), Amount: decimal.NewFromInt(1000)}
+	if err := svc.budgets.CreateBudget(ctx, budget); err != nil {
+		t.Fatalf("CreateBudget failed: %v", err)
+	}
+	if budget.Category != string(entities.Food) || !budget.Month.Equal(march) || budget.Currency != entities.DefaultCurrency {
+		t.Errorf("expected budget to be normalized, got %+v", budget)
+	}
+	duplicate := &entities.Budget{UserID: "u1", Kind: entities.SubCategoryKind, Category: "Food", Month: march, Amount: decimal.NewFromInt(5)}
+	if err := svc.budgets.CreateBudget(ctx, duplicate); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a second Food budget for March to be rejected, got %v", err)
+	}
+
+	steps := []struct {
+		amount    string
+		threshold string // empty when no warning is expected
+	}{
+		{"700", ""},
+		{"150", "80"},
+		{"100", ""},
+		{"100", "100"},
+		{"10", ""},
+	}
+	for i, step := range steps {
+		warnings, err := svc.expenses.AddExpense(ctx, newTestExpense("u1", step.amount, march.AddDate(0, 0, i), entities.Self, entities.Food))
+		if err != nil {
+			t.Fatalf("AddExpense failed: %v", err)
+		}
+		if step.threshold == "" {
+			if len(warnings) != 0 {
+				t.Errorf("step %d: expected no warning, got %+v", i, warnings[0])
+			}
+			continue
+		}
+		if len(warnings) != 1 || warnings[0].Threshold.String() != step.threshold || warnings[0].BudgetID != budget.ID {
+			t.Errorf("step %d: expected a %s%% warning, got %+v", i, step.threshold, warnings)
+		}
+	}
+
+	// Expenses in other categories don't touch the budget
+	warnings, err := svc.expenses.AddExpense(ctx, newTestExpense("u1", "5000", march, entities.Self, entities.Travel))
+	if err != nil || len(warnings) != 0 {
+		t.Errorf("expected no warnings for an unbudgeted category, got %v, %v", warnings, err)
+	}
+}
+
+func TestBudgetReportRollsOverUnspentAmounts(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	travel, _ := svc.categories.LookupCategory(ctx, "u1", entities.SubCategoryKind, string(entities.Travel))
+	flights := &entities.Category{UserID: "u1", Name: "Flights", Kind: entities.SubCategoryKind, ParentID: travel.ID}
+	if err := svc.categories.CreateCategory(ctx, flights); err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+
+	for _, budget := range []*entities.Budget{
+		{UserID: "u1", Kind: entities.SubCategoryKind, Category: "Travel", Month: month(2024, 2), Amount: decimal.NewFromInt(1000)},
+		{UserID: "u1", Kind: entities.SubCategoryKind, Category: "Travel", Month: month(2024, 3), Amount: decimal.NewFromInt(1000), Rollover: true},
+	} {
+		if err := svc.budgets.CreateBudget(ctx, budget); err != nil {
+			t.Fatalf("CreateBudget failed: %v", err)
+		}
+	}
+	for _, expense := range []*entities.Expense{
+		newTestExpense("u1", "400", month(2024, 2), entities.Self, "Flights"),
+		newTestExpense("u1", "1300", month(2024, 3), entities.Self, entities.Travel),
+	} {
+		if _, err := svc.expenses.AddExpense(ctx, expense); err != nil {
+			t.Fatalf("AddExpense failed: %v", err)
+		}
+	}
+
+	report, err := svc.budgets.GetBudgetReport(ctx, "u1", month(2024, 3))
+	if err != nil {
+		t.Fatalf("GetBudgetReport failed: %v", err)
+	}
+	if len(report.Budgets) != 1 {
+		t.Fatalf("expected 1 budget in March, got %d", len(report.Budgets))
+	}
+	status := report.Budgets[0]
+	if status.RolledOver.String() != "600" || status.Available.String() != "1600" || status.Remaining.String() != "300" {
+		t.Errorf("expected 600 rolled over into 1600 with 300 left, got %s/%s/%s", status.RolledOver, status.Available, status.Remaining)
+	}
+	if status.State != entities.BudgetStateWarning {
+		t.Errorf("expected 81.25%% used to be a warning, got %s at %s%%", status.State, status.PercentUsed)
+	}
+
+	// Renaming the category keeps its budgets attached
+	travel.Name = "Trips"
+	if err := svc.categories.UpdateCategory(ctx, travel.ID, travel); err != nil {
+		t.Fatalf("UpdateCategory failed: %v", err)
+	}
+	budgets, _ := svc.budgets.GetBudgets(ctx, "u1", month(2024, 3))
+	if len(budgets) != 1 || budgets[0].Category != "Trips" {
+		t.Errorf("expected the budget to follow the rename, got %+v", budgets)
+	}
+}
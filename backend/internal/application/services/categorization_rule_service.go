@@ -0,0 +1,415 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// rulePatternConfidence is the confidence of a match by a rule with a
+	// description pattern, which names the merchant or payee outright
+	rulePatternConfidence = 0.95
+
+	// ruleConditionConfidence is the confidence of a match by a rule without a
+	// pattern, which only narrows transactions down by amount, type or account
+	ruleConditionConfidence = 0.75
+
+	// rulePreviewLimit caps how many matching expenses a rule preview lists
+	rulePreviewLimit = 50
+
+	maxRuleNameLength    = 64
+	maxRulePatternLength = 256
+)
+
+// CategorizationRuleService defines the interface for categorization rule operations
+type CategorizationRuleService interface {
+	CreateRule(ctx context.Context, rule *entities.CategorizationRule) error
+	GetRules(ctx context.Context, userID string) ([]*entities.CategorizationRule, error)
+	GetRule(ctx context.Context, userID, id string) (*entities.CategorizationRule, error)
+	UpdateRule(ctx context.Context, id string, rule *entities.CategorizationRule) error
+	DeleteRule(ctx context.Context, userID, id string) error
+	PreviewRule(ctx context.Context, rule *entities.CategorizationRule) (*entities.RulePreview, error)
+}
+
+// categorizationRuleService implements the CategorizationRuleService interface
+type categorizationRuleService struct {
+	ruleRepo        repositories.CategorizationRuleRepository
+	expenseRepo     repositories.ExpenseRepository
+	accountRepo     repositories.AccountRepository
+	categoryService CategoryService
+}
+
+// NewCategorizationRuleService creates a new categorization rule service
+func NewCategorizationRuleService(ruleRepo repositories.CategorizationRuleRepository, expenseRepo repositories.ExpenseRepository, accountRepo repositories.AccountRepository, categoryService CategoryService) CategorizationRuleService {
+	return &categorizationRuleService{
+		ruleRepo:        ruleRepo,
+		expenseRepo:     expenseRepo,
+		accountRepo:     accountRepo,
+		categoryService: categoryService,
+	}
+}
+
+// CreateRule saves a new rule for the user
+func (s *categorizationRuleService) CreateRule(ctx context.Context, rule *entities.CategorizationRule) error {
+	if err := s.validateRule(ctx, rule, nil); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	rule.ID = uuid.NewString()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	if err := s.ruleRepo.Create(ctx, toRepositoryCategorizationRule(rule)); err != nil {
+		return fmt.Errorf("failed to save categorization rule: %w", err)
+	}
+	return nil
+}
+
+// GetRules gets the user's rules in the order they're evaluated
+func (s *categorizationRuleService) GetRules(ctx context.Context, userID string) ([]*entities.CategorizationRule, error) {
+	stored, err := s.ruleRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categorization rules: %w", err)
+	}
+
+	rules := make([]*entities.CategorizationRule, 0, len(stored))
+	for _, rule := range stored {
+		rules = append(rules, toEntityCategorizationRule(rule))
+	}
+	return rules, nil
+}
+
+// GetRule gets a rule owned by the user
+func (s *categorizationRuleService) GetRule(ctx context.Context, userID, id string) (*entities.CategorizationRule, error) {
+	stored, err := s.getOwnedRule(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toEntityCategorizationRule(stored), nil
+}
+
+// UpdateRule replaces a rule owned by the user
+func (s *categorizationRuleService) UpdateRule(ctx context.Context, id string, rule *entities.CategorizationRule) error {
+	existing, err := s.getOwnedRule(ctx, rule.UserID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.validateRule(ctx, rule, existing); err != nil {
+		return err
+	}
+
+	rule.ID = id
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now()
+
+	if err := s.ruleRepo.Update(ctx, toRepositoryCategorizationRule(rule)); err != nil {
+		return fmt.Errorf("failed to update categorization rule: %w", err)
+	}
+	return nil
+}
+
+// DeleteRule deletes a rule owned by the user
+func (s *categorizationRuleService) DeleteRule(ctx context.Context, userID, id string) error {
+	if _, err := s.getOwnedRule(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete categorization rule: %w", err)
+	}
+	return nil
+}
+
+// PreviewRule tests a rule, saved or not, against every expense the user has
+// recorded, reporting how many it matches and how many it would file under
+// different categories
+func (s *categorizationRuleService) PreviewRule(ctx context.Context, rule *entities.CategorizationRule) (*entities.RulePreview, error) {
+	if err := s.validateRule(ctx, rule, nil); err != nil {
+		return nil, err
+	}
+	compiled, err := compileRule(toRepositoryCategorizationRule(rule))
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.expenseRepo.GetByUserID(ctx, rule.UserID, &repositories.ExpenseFilters{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+
+	preview := &entities.RulePreview{Expenses: []*entities.Expense{}}
+	for _, expense := range stored {
+		if !compiled.matches(expenseSubject(expense)) {
+			continue
+		}
+		preview.Matched++
+		if expense.MainCategory == string(rule.MainCategory) && expense.SubCategory == string(rule.SubCategory) {
+			preview.Unchanged++
+		} else {
+			preview.Recategorized++
+		}
+		if len(preview.Expenses) < rulePreviewLimit {
+			preview.Expenses = append(preview.Expenses, toEntityExpense(expense))
+		}
+	}
+	return preview, nil
+}
+
+// validateRule checks a rule's conditions and normalizes its categories.
+// Archived categories are rejected unless the rule being updated (existing)
+// already used them.
+func (s *categorizationRuleService) validateRule(ctx context.Context, rule *entities.CategorizationRule, existing *repositories.CategorizationRule) error {
+	if rule.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+
+	rule.Pattern = strings.TrimSpace(rule.Pattern)
+	if len(rule.Pattern) > maxRulePatternLength {
+		return fmt.Errorf("%w: pattern must be at most %d characters", ErrInvalidInput, maxRulePatternLength)
+	}
+	if rule.Pattern == "" {
+		rule.MatchType = ""
+	} else {
+		if rule.MatchType == "" {
+			rule.MatchType = entities.KeywordMatch
+		}
+		if rule.MatchType != entities.KeywordMatch && rule.MatchType != entities.RegexMatch {
+			return fmt.Errorf("%w: match type must be %q or %q", ErrInvalidInput, entities.KeywordMatch, entities.RegexMatch)
+		}
+	}
+
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		rule.Name = rule.Pattern
+	}
+	if rule.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if len(rule.Name) > maxRuleNameLength {
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidInput, maxRuleNameLength)
+	}
+
+	if (rule.MinAmount != nil && rule.MinAmount.IsNegative()) || (rule.MaxAmount != nil && rule.MaxAmount.IsNegative()) {
+		return fmt.Errorf("%w: amounts can't be negative", ErrInvalidInput)
+	}
+	if rule.MinAmount != nil && rule.MaxAmount != nil && rule.MinAmount.GreaterThan(*rule.MaxAmount) {
+		return fmt.Errorf("%w: min amount can't be more than max amount", ErrInvalidInput)
+	}
+
+	rule.TransactionType = entities.TransactionType(strings.ToLower(strings.TrimSpace(string(rule.TransactionType))))
+	if rule.TransactionType != "" && rule.TransactionType != entities.DebitTransaction && rule.TransactionType != entities.CreditTransaction {
+		return fmt.Errorf("%w: transaction type must be %q or %q", ErrInvalidInput, entities.DebitTransaction, entities.CreditTransaction)
+	}
+	if rule.AccountID != "" {
+		if _, err := getOwnedAccount(ctx, s.accountRepo, rule.UserID, rule.AccountID); err != nil {
+			return err
+		}
+	}
+	if rule.Pattern == "" && rule.MinAmount == nil && rule.MaxAmount == nil && rule.TransactionType == "" && rule.AccountID == "" {
+		return fmt.Errorf("%w: a rule needs at least one condition", ErrInvalidInput)
+	}
+	if _, err := compileRule(toRepositoryCategorizationRule(rule)); err != nil {
+		return err
+	}
+
+	// Reuse the expense checks so a rule always files transactions under valid categories
+	expense := &entities.Expense{
+		UserID:       rule.UserID,
+		MainCategory: rule.MainCategory,
+		SubCategory:  rule.SubCategory,
+	}
+	if expense.MainCategory == "" {
+		return fmt.Errorf("%w: main category is required", ErrInvalidInput)
+	}
+	if expense.SubCategory == "" {
+		return fmt.Errorf("%w: sub-category is required", ErrInvalidInput)
+	}
+	var current *repositories.Expense
+	if existing != nil {
+		current = &repositories.Expense{MainCategory: existing.MainCategory, SubCategory: existing.SubCategory}
+	}
+	if err := resolveCategories(ctx, s.categoryService, expense, current); err != nil {
+		return err
+	}
+	rule.MainCategory = expense.MainCategory
+	rule.SubCategory = expense.SubCategory
+	return nil
+}
+
+// getOwnedRule loads a rule and hides it unless it belongs to userID
+func (s *categorizationRuleService) getOwnedRule(ctx context.Context, userID, id string) (*repositories.CategorizationRule, error) {
+	stored, err := s.ruleRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("categorization rule %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categorization rule: %w", err)
+	}
+	if stored.UserID != userID {
+		return nil, fmt.Errorf("categorization rule %s: %w", id, ErrNotFound)
+	}
+	return stored, nil
+}
+
+// ruleSubject is what a rule's conditions are checked against, taken from a
+// statement transaction or a recorded expense
+type ruleSubject struct {
+	description string
+	amount      *decimal.Decimal
+	kind        entities.TransactionType
+	accountID   string
+}
+
+// transactionSubject describes a statement transaction. An unreadable amount
+// fails every amount condition.
+func transactionSubject(transaction *Transaction) ruleSubject {
+	subject := ruleSubject{
+		description: transaction.Description,
+		kind:        entities.TransactionType(strings.ToLower(transaction.Type)),
+		accountID:   transaction.AccountID,
+	}
+	if amount, err := parseImportAmount(transaction.Amount); err == nil {
+		amount = amount.Abs()
+		subject.amount = &amount
+	}
+	return subject
+}
+
+// expenseSubject describes a recorded expense, which is always a debit
+func expenseSubject(expense *repositories.Expense) ruleSubject {
+	return ruleSubject{
+		description: expense.Description,
+		amount:      &expense.Amount,
+		kind:        entities.DebitTransaction,
+		accountID:   expense.AccountID,
+	}
+}
+
+// compiledRule is a stored rule with its pattern ready to match
+type compiledRule struct {
+	rule    *repositories.CategorizationRule
+	keyword string
+	regex   *regexp.Regexp
+}
+
+// compileRule prepares a rule for matching. Patterns are case-insensitive.
+func compileRule(rule *repositories.CategorizationRule) (*compiledRule, error) {
+	compiled := &compiledRule{rule: rule}
+	switch entities.RuleMatchType(rule.MatchType) {
+	case entities.KeywordMatch:
+		compiled.keyword = strings.ToLower(rule.Pattern)
+	case entities.RegexMatch:
+		regex, err := regexp.Compile("(?i)" + rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid pattern: %v", ErrInvalidInput, err)
+		}
+		compiled.regex = regex
+	}
+	return compiled, nil
+}
+
+// matches reports whether subject meets every condition of the rule
+func (r *compiledRule) matches(subject ruleSubject) bool {
+	if r.keyword != "" && !strings.Contains(strings.ToLower(subject.description), r.keyword) {
+		return false
+	}
+	if r.regex != nil && !r.regex.MatchString(subject.description) {
+		return false
+	}
+	if r.rule.MinAmount != nil && (subject.amount == nil || subject.amount.LessThan(*r.rule.MinAmount)) {
+		return false
+	}
+	if r.rule.MaxAmount != nil && (subject.amount == nil || subject.amount.GreaterThan(*r.rule.MaxAmount)) {
+		return false
+	}
+	if r.rule.TransactionType != "" && r.rule.TransactionType != string(subject.kind) {
+		return false
+	}
+	if r.rule.AccountID != "" && r.rule.AccountID != subject.accountID {
+		return false
+	}
+	return true
+}
+
+// confidence is how sure a match by the rule is
+func (r *compiledRule) confidence() float64 {
+	if r.rule.Pattern != "" {
+		return rulePatternConfidence
+	}
+	return ruleConditionConfidence
+}
+
+// loadRules compiles the user's rules in evaluation order
+func loadRules(ctx context.Context, ruleRepo repositories.CategorizationRuleRepository, userID string) ([]*compiledRule, error) {
+	stored, err := ruleRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categorization rules: %w", err)
+	}
+	rules := make([]*compiledRule, 0, len(stored))
+	for _, rule := range stored {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("categorization rule %s: %w", rule.ID, err)
+		}
+		rules = append(rules, compiled)
+	}
+	return rules, nil
+}
+
+// matchRule returns the first rule matching subject, or nil
+func matchRule(rules []*compiledRule, subject ruleSubject) *compiledRule {
+	for _, rule := range rules {
+		if rule.matches(subject) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// toRepositoryCategorizationRule converts a categorization rule entity to its repository model
+func toRepositoryCategorizationRule(rule *entities.CategorizationRule) *repositories.CategorizationRule {
+	return &repositories.CategorizationRule{
+		ID:              rule.ID,
+		UserID:          rule.UserID,
+		Name:            rule.Name,
+		MatchType:       string(rule.MatchType),
+		Pattern:         rule.Pattern,
+		MinAmount:       rule.MinAmount,
+		MaxAmount:       rule.MaxAmount,
+		TransactionType: string(rule.TransactionType),
+		AccountID:       rule.AccountID,
+		MainCategory:    string(rule.MainCategory),
+		SubCategory:     string(rule.SubCategory),
+		Priority:        rule.Priority,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
+
+// toEntityCategorizationRule converts a repository categorization rule model to an entity
+func toEntityCategorizationRule(rule *repositories.CategorizationRule) *entities.CategorizationRule {
+	return &entities.CategorizationRule{
+		ID:              rule.ID,
+		UserID:          rule.UserID,
+		Name:            rule.Name,
+		MatchType:       entities.RuleMatchType(rule.MatchType),
+		Pattern:         rule.Pattern,
+		MinAmount:       rule.MinAmount,
+		MaxAmount:       rule.MaxAmount,
+		TransactionType: entities.TransactionType(rule.TransactionType),
+		AccountID:       rule.AccountID,
+		MainCategory:    entities.MainCategory(rule.MainCategory),
+		SubCategory:     entities.SubCategory(rule.SubCategory),
+		Priority:        rule.Priority,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
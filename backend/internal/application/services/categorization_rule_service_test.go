@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func newTestRule(t *testing.T, svc *testServices, rule *entities.CategorizationRule) *entities.CategorizationRule {
+	t.Helper()
+	if rule.UserID == "" {
+		rule.UserID = "u1"
+	}
+	if err := svc.rules.CreateRule(context.Background(), rule); err != nil {
+		t.Fatalf("CreateRule() error = %v", err)
+	}
+	return rule
+}
+
+func TestCreateRuleValidation(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+	low, high := decimal.NewFromInt(100), decimal.NewFromInt(10)
+
+	cases := map[string]*entities.CategorizationRule{
+		"no conditions":    {UserID: "u1", Name: "Anything", MainCategory: entities.Self, SubCategory: entities.Food},
+		"invalid regex":    {UserID: "u1", MatchType: entities.RegexMatch, Pattern: "swiggy(", MainCategory: entities.Self, SubCategory: entities.Food},
+		"unknown type":     {UserID: "u1", Pattern: "rent", TransactionType: "transfer", MainCategory: entities.Self, SubCategory: entities.Misc},
+		"min above max":    {UserID: "u1", Pattern: "rent", MinAmount: &low, MaxAmount: &high, MainCategory: entities.Self, SubCategory: entities.Misc},
+		"unknown category": {UserID: "u1", Pattern: "rent", MainCategory: "Mumbai House", SubCategory: entities.Misc},
+		"unknown account":  {UserID: "u1", AccountID: "missing", MainCategory: entities.Self, SubCategory: entities.Misc},
+		"missing user":     {Pattern: "rent", MainCategory: entities.Self, SubCategory: entities.Misc},
+	}
+	for name, rule := range cases {
+		if err := svc.rules.CreateRule(ctx, rule); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	rule := newTestRule(t, svc, &entities.CategorizationRule{Pattern: "  Swiggy ", MainCategory: "self", SubCategory: "food"})
+	if rule.Name != "Swiggy" || rule.MatchType != entities.KeywordMatch || rule.MainCategory != entities.Self || rule.SubCategory != entities.Food {
+		t.Errorf("expected defaults and normalized categories, got %+v", rule)
+	}
+	if _, err := svc.rules.GetRule(ctx, "u2", rule.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected another user's rule to be hidden, got %v", err)
+	}
+}
+
+func TestCategorizeTransactionsWithRules(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+	card := newTestAccount(t, svc, "u1", "Travel card", entities.CreditCard, "0")
+	salary := decimal.NewFromInt(50000)
+
+	keyword := newTestRule(t, svc, &entities.CategorizationRule{Pattern: "swiggy", MainCategory: entities.Self, SubCategory: entities.Food})
+	regex := newTestRule(t, svc, &entities.CategorizationRule{
+		MatchType: entities.RegexMatch, Pattern: `^(swiggy|zomato)\b.*chennai`, Priority: 10,
+		MainCategory: entities.ChennaiHouse, SubCategory: entities.Food,
+	})
+	credit := newTestRule(t, svc, &entities.CategorizationRule{
+		Name: "Salary", MinAmount: &salary, TransactionType: entities.CreditTransaction,
+		MainCategory: entities.Savings, SubCategory: entities.Misc,
+	})
+	travel := newTestRule(t, svc, &entities.CategorizationRule{Name: "Card", AccountID: card.ID, MainCategory: entities.Self, SubCategory: entities.Travel})
+
+	transactions := []*Transaction{
+		{Description: "SWIGGY ORDER BANGALORE", Amount: "450.00", Type: "Debit"},
+		{Description: "Swiggy order Chennai", Amount: "620.00", Type: "Debit"},
+		{Description: "NEFT CR ACME CORP", Amount: "85000.00", Type: "Credit"},
+		{Description: "NEFT CR FRIEND", Amount: "2000.00", Type: "Credit"},
+		{Description: "IRCTC", Amount: "1500.00", Type: "Debit", AccountID: card.ID},
+	}
+	categorized, err := svc.statements.CategorizeTransactions(ctx, "u1", transactions)
+	if err != nil {
+		t.Fatalf("CategorizeTransactions() error = %v", err)
+	}
+
+	want := []struct {
+		rule       string
+		main       entities.MainCategory
+		confidence float64
+	}{
+		{keyword.ID, entities.Self, rulePatternConfidence},
+		{regex.ID, entities.ChennaiHouse, rulePatternConfidence},
+		{credit.ID, entities.Savings, ruleConditionConfidence},
+		{"", "", 0},
+		{travel.ID, entities.Self, ruleConditionConfidence},
+	}
+	for i, result := range categorized {
+		if result.RuleID != want[i].rule || result.MainCategory != want[i].main || result.Confidence != want[i].confidence {
+			t.Errorf("transaction %d: expected rule %q, %q at %v, got %q, %q at %v",
+				i, want[i].rule, want[i].main, want[i].confidence, result.RuleID, result.MainCategory, result.Confidence)
+		}
+	}
+
+	// Other users' rules don't apply
+	categorized, err = svc.statements.CategorizeTransactions(ctx, "u2", transactions[:1])
+	if err != nil {
+		t.Fatalf("CategorizeTransactions() error = %v", err)
+	}
+	if categorized[0].RuleID != "" {
+		t.Errorf("expected no rule for another user, got %q", categorized[0].RuleID)
+	}
+}
+
+func TestPreviewRuleAgainstHistory(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+
+	for _, history := range []struct {
+		user, description string
+		sub               entities.SubCategory
+	}{
+		{"u1", "Swiggy order", entities.Food},
+		{"u1", "SWIGGY Instamart", entities.Misc},
+		{"u1", "Uber", entities.Travel},
+		{"u2", "Swiggy", entities.Misc},
+	} {
+		expense := newTestExpense(history.user, "450", date(2024, 3, 2), entities.Self, history.sub)
+		expense.Description = history.description
+		if _, err := svc.expenses.AddExpense(ctx, expense); err != nil {
+			t.Fatalf("AddExpense() error = %v", err)
+		}
+	}
+
+	preview, err := svc.rules.PreviewRule(ctx, &entities.CategorizationRule{
+		UserID: "u1", Pattern: "swiggy", MainCategory: entities.Self, SubCategory: entities.Food,
+	})
+	if err != nil {
+		t.Fatalf("PreviewRule() error = %v", err)
+	}
+	if preview.Matched != 2 || preview.Unchanged != 1 || preview.Recategorized != 1 || len(preview.Expenses) != 2 {
+		t.Errorf("unexpected preview %+v", preview)
+	}
+
+	rules, err := svc.rules.GetRules(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetRules() error = %v", err)
+	}
+	if len(rules) != 0 {
+		t.Errorf("expected previewing not to save the rule, got %d rules", len(rules))
+	}
+}
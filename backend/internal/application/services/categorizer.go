@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// reviewConfidence is the confidence below which a categorized
+	// transaction is flagged for the user to review
+	reviewConfidence = 0.6
+
+	// minTrainingExamples is how much history a user needs before the
+	// classifier makes suggestions; with less, its guesses are noise
+	minTrainingExamples = 10
+
+	// maxTrainingExpenses caps how many of the most recent expenses the
+	// classifier learns from, so old spending habits fade out
+	maxTrainingExpenses = 2000
+
+	// correctionWeight is how many expenses a review correction counts as.
+	// A correction is the user overriding a suggestion, which says more
+	// about the classifier's mistakes than an ordinary expense does.
+	correctionWeight = 3
+)
+
+// categoryPair is a main and sub-category, the class the classifier predicts
+type categoryPair struct {
+	main entities.MainCategory
+	sub  entities.SubCategory
+}
+
+// less orders pairs by name so ties are broken the same way every time
+func (p categoryPair) less(other categoryPair) bool {
+	if p.main != other.main {
+		return p.main < other.main
+	}
+	return p.sub < other.sub
+}
+
+// classCounts holds what the classifier has seen for one category pair
+type classCounts struct {
+	examples float64            // weighted number of training examples
+	features map[string]float64 // weighted occurrences of each feature
+	total    float64            // sum of features
+}
+
+// naiveBayes is a multinomial naive Bayes classifier over the features of a
+// transaction: the words of its description, the order of magnitude of its
+// amount and its direction. It's trained from scratch for each request,
+// which is cheap at the size of one household's history.
+type naiveBayes struct {
+	classes    map[categoryPair]*classCounts
+	vocabulary map[string]bool
+	examples   float64
+}
+
+// newNaiveBayes creates an untrained classifier
+func newNaiveBayes() *naiveBayes {
+	return &naiveBayes{
+		classes:    make(map[categoryPair]*classCounts),
+		vocabulary: make(map[string]bool),
+	}
+}
+
+// train adds one example of class with the given weight
+func (m *naiveBayes) train(features []string, class categoryPair, weight float64) {
+	counts, ok := m.classes[class]
+	if !ok {
+		counts = &classCounts{features: make(map[string]float64)}
+		m.classes[class] = counts
+	}
+	counts.examples += weight
+	m.examples += weight
+	for _, feature := range features {
+		counts.features[feature] += weight
+		counts.total += weight
+		m.vocabulary[feature] = true
+	}
+}
+
+// predict returns the most probable class for features along with its
+// posterior probability. It makes no prediction until it has seen enough
+// examples of at least two classes, or when none of the features were
+// seen in training.
+func (m *naiveBayes) predict(features []string) (categoryPair, float64, bool) {
+	if m.examples < minTrainingExamples || len(m.classes) < 2 {
+		return categoryPair{}, 0, false
+	}
+	known := features[:0:0]
+	for _, feature := range features {
+		if m.vocabulary[feature] {
+			known = append(known, feature)
+		}
+	}
+	if len(known) == 0 {
+		return categoryPair{}, 0, false
+	}
+
+	// Log scores with add-one smoothing, normalized with log-sum-exp
+	vocabulary := float64(len(m.vocabulary))
+	scores := make(map[categoryPair]float64, len(m.classes))
+	var best categoryPair
+	bestScore := math.Inf(-1)
+	for class, counts := range m.classes {
+		score := math.Log(counts.examples / m.examples)
+		for _, feature := range known {
+			score += math.Log((counts.features[feature] + 1) / (counts.total + vocabulary))
+		}
+		scores[class] = score
+		if score > bestScore || (score == bestScore && class.less(best)) {
+			best, bestScore = class, score
+		}
+	}
+	var sum float64
+	for _, score := range scores {
+		sum += math.Exp(score - bestScore)
+	}
+	return best, 1 / sum, true
+}
+
+// subjectFeatures extracts the classifier's features from a transaction or
+// expense. Description words are lower-cased and counted once; numbers are
+// left out since they're mostly reference numbers that never repeat.
+func subjectFeatures(subject ruleSubject) []string {
+	var features []string
+	seen := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(subject.description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if len(word) < 2 || seen[word] || strings.IndexFunc(word, unicode.IsLetter) < 0 {
+			continue
+		}
+		seen[word] = true
+		features = append(features, word)
+	}
+
+	// Amounts fall into half-decade buckets: up to 3.16, up to 10, up to 31.6 and so on
+	if subject.amount != nil && subject.amount.IsPositive() {
+		bucket := int(math.Floor(2 * math.Log10(subject.amount.InexactFloat64())))
+		features = append(features, "amount:"+strconv.Itoa(bucket))
+	}
+	if subject.kind != "" {
+		features = append(features, "type:"+string(subject.kind))
+	}
+	return features
+}
+
+// trainCategorizer trains a classifier on the user's most recent expenses
+// and their review corrections
+func trainCategorizer(ctx context.Context, expenseRepo repositories.ExpenseRepository, correctionRepo repositories.CategoryCorrectionRepository, userID string) (*naiveBayes, error) {
+	model := newNaiveBayes()
+
+	expenses, err := expenseRepo.GetByUserID(ctx, userID, &repositories.ExpenseFilters{Limit: maxTrainingExpenses})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+	for _, expense := range expenses {
+		class := categoryPair{entities.MainCategory(expense.MainCategory), entities.SubCategory(expense.SubCategory)}
+		model.train(subjectFeatures(expenseSubject(expense)), class, 1)
+	}
+
+	corrections, err := correctionRepo.GetByUserID(ctx, userID, maxTrainingExpenses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category corrections: %w", err)
+	}
+	for _, correction := range corrections {
+		class := categoryPair{entities.MainCategory(correction.MainCategory), entities.SubCategory(correction.SubCategory)}
+		model.train(subjectFeatures(correctionSubject(correction)), class, correctionWeight)
+	}
+	return model, nil
+}
+
+// correctionSubject describes a review correction
+func correctionSubject(correction *repositories.CategoryCorrection) ruleSubject {
+	return ruleSubject{
+		description: correction.Description,
+		amount:      &correction.Amount,
+		kind:        entities.TransactionType(correction.TransactionType),
+	}
+}
+
+// toRepositoryCategoryCorrection converts a category correction entity to its repository model
+func toRepositoryCategoryCorrection(correction *entities.CategoryCorrection) *repositories.CategoryCorrection {
+	return &repositories.CategoryCorrection{
+		ID:              correction.ID,
+		UserID:          correction.UserID,
+		Description:     correction.Description,
+		Amount:          correction.Amount,
+		TransactionType: string(correction.TransactionType),
+		MainCategory:    string(correction.MainCategory),
+		SubCategory:     string(correction.SubCategory),
+		CreatedAt:       correction.CreatedAt,
+	}
+}
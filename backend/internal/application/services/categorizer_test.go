@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// addHistory records count expenses with the given description and categories
+func addHistory(t *testing.T, svc *testServices, count int, description, amount string, main entities.MainCategory, sub entities.SubCategory) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		expense := newTestExpense("u1", amount, date(2024, 3, 1+i), main, sub)
+		expense.Description = description
+		if _, err := svc.expenses.AddExpense(context.Background(), expense); err != nil {
+			t.Fatalf("AddExpense() error = %v", err)
+		}
+	}
+}
+
+func TestCategorizeTransactionsFallsBackToClassifier(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+	transactions := []*Transaction{
+		{Description: "UPI/401234567/SWIGGY ORDER/BLR", Amount: "380.00", Type: "Debit"},
+		{Description: "UBER INDIA TRIP", Amount: "240.00", Type: "Debit"},
+		{Description: "NEW STORE", Amount: "9.00", Type: "Debit"},
+	}
+
+	// Without enough history nothing is guessed
+	addHistory(t, svc, 2, "Swiggy order", "420", entities.Self, entities.Food)
+	categorized, err := svc.statements.CategorizeTransactions(ctx, "u1", transactions)
+	if err != nil {
+		t.Fatalf("CategorizeTransactions() error = %v", err)
+	}
+	if categorized[0].MainCategory != "" || categorized[0].Confidence != 0 || !categorized[0].NeedsReview {
+		t.Errorf("expected no suggestion from a short history, got %+v", categorized[0])
+	}
+
+	addHistory(t, svc, 5, "Swiggy order", "420", entities.Self, entities.Food)
+	addHistory(t, svc, 6, "Uber trip", "250", entities.Self, entities.Travel)
+	rule := newTestRule(t, svc, &entities.CategorizationRule{Pattern: "uber", MainCategory: entities.ChennaiHouse, SubCategory: entities.Travel})
+
+	categorized, err = svc.statements.CategorizeTransactions(ctx, "u1", transactions)
+	if err != nil {
+		t.Fatalf("CategorizeTransactions() error = %v", err)
+	}
+	if got := categorized[0]; got.SubCategory != entities.Food || got.RuleID != "" || got.Confidence < reviewConfidence || got.NeedsReview {
+		t.Errorf("expected a confident food suggestion, got %+v", got)
+	}
+	if got := categorized[1]; got.RuleID != rule.ID || got.MainCategory != entities.ChennaiHouse || got.NeedsReview {
+		t.Errorf("expected the rule to take precedence, got %+v", got)
+	}
+	if got := categorized[2]; !got.NeedsReview || got.Confidence >= reviewConfidence {
+		t.Errorf("expected an unfamiliar merchant to be flagged for review, got %+v", got)
+	}
+}
+
+func TestCategorizeTransactionsLearnsFromCorrections(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+	addHistory(t, svc, 6, "Swiggy order", "420", entities.Self, entities.Food)
+	addHistory(t, svc, 6, "Uber trip", "250", entities.Self, entities.Travel)
+
+	transactions := []*Transaction{{Description: "AMAZON PAY INDIA", Amount: "1299.00", Type: "Debit"}}
+	for i := 0; i < 2; i++ {
+		err := svc.statements.RecordCorrection(ctx, &entities.CategoryCorrection{
+			UserID: "u1", Description: "Amazon Pay India", Amount: decimal.NewFromInt(899),
+			MainCategory: "self", SubCategory: "misc",
+		})
+		if err != nil {
+			t.Fatalf("RecordCorrection() error = %v", err)
+		}
+	}
+
+	categorized, err := svc.statements.CategorizeTransactions(ctx, "u1", transactions)
+	if err != nil {
+		t.Fatalf("CategorizeTransactions() error = %v", err)
+	}
+	if got := categorized[0]; got.MainCategory != entities.Self || got.SubCategory != entities.Misc || got.NeedsReview {
+		t.Errorf("expected the correction to be learned, got %+v", got)
+	}
+
+	// Corrections are per user
+	categorized, err = svc.statements.CategorizeTransactions(ctx, "u2", transactions)
+	if err != nil {
+		t.Fatalf("CategorizeTransactions() error = %v", err)
+	}
+	if categorized[0].SubCategory != "" {
+		t.Errorf("expected no suggestion for another user, got %+v", categorized[0])
+	}
+}
+
+func TestRecordCorrectionValidation(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+
+	cases := map[string]*entities.CategoryCorrection{
+		"missing description": {UserID: "u1", MainCategory: entities.Self, SubCategory: entities.Food},
+		"unknown type":        {UserID: "u1", Description: "Rent", TransactionType: "refund", MainCategory: entities.Self, SubCategory: entities.Misc},
+		"unknown category":    {UserID: "u1", Description: "Rent", MainCategory: "Mumbai House", SubCategory: entities.Misc},
+	}
+	for name, correction := range cases {
+		if err := svc.statements.RecordCorrection(ctx, correction); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	correction := &entities.CategoryCorrection{UserID: "u1", Description: " Rent ", Amount: decimal.NewFromInt(-25000), MainCategory: entities.ChennaiHouse, SubCategory: entities.Misc}
+	if err := svc.statements.RecordCorrection(ctx, correction); err != nil {
+		t.Fatalf("RecordCorrection() error = %v", err)
+	}
+	if correction.Description != "Rent" || !correction.Amount.Equal(decimal.NewFromInt(25000)) || correction.TransactionType != entities.DebitTransaction {
+		t.Errorf("expected a normalized correction, got %+v", correction)
+	}
+}
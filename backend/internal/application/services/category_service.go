@@ -0,0 +1,396 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	maxCategoryNameLength = 64
+	maxCategoryIconLength = 64
+)
+
+// colorPattern matches #RGB and #RRGGBB hex colours
+var colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// CategoryService defines the interface for user-defined category operations
+type CategoryService interface {
+	GetCategories(ctx context.Context, userID string, includeArchived bool) ([]*entities.Category, error)
+	GetCategoryTree(ctx context.Context, userID string, includeArchived bool) ([]*entities.Category, error)
+	GetCategory(ctx context.Context, userID, id string) (*entities.Category, error)
+	LookupCategory(ctx context.Context, userID string, kind entities.CategoryKind, name string) (*entities.Category, error)
+	GetCategoryByRole(ctx context.Context, userID string, role entities.CategoryRole) (*entities.Category, error)
+	CreateCategory(ctx context.Context, category *entities.Category) error
+	UpdateCategory(ctx context.Context, id string, category *entities.Category) error
+	ArchiveCategory(ctx context.Context, userID, id string, archived bool) error
+	MergeCategories(ctx context.Context, userID, sourceID, targetID string) error
+}
+
+// categoryService implements the CategoryService interface
+type categoryService struct {
+	categoryRepo repositories.CategoryRepository
+}
+
+// NewCategoryService creates a new category service
+func NewCategoryService(categoryRepo repositories.CategoryRepository) CategoryService {
+	return &categoryService{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// GetCategories gets the user's categories as a flat list, seeding the defaults on first use
+func (s *categoryService) GetCategories(ctx context.Context, userID string, includeArchived bool) ([]*entities.Category, error) {
+	stored, err := s.loadCategories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]*entities.Category, 0, len(stored))
+	for _, category := range stored {
+		if category.Archived && !includeArchived {
+			continue
+		}
+		categories = append(categories, toEntityCategory(category))
+	}
+	return categories, nil
+}
+
+// GetCategoryTree gets the user's categories nested under their parents
+func (s *categoryService) GetCategoryTree(ctx context.Context, userID string, includeArchived bool) ([]*entities.Category, error) {
+	categories, err := s.GetCategories(ctx, userID, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*entities.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	var roots []*entities.Category
+	for _, category := range categories {
+		if parent, ok := byID[category.ParentID]; ok {
+			parent.Children = append(parent.Children, category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+	return roots, nil
+}
+
+// GetCategory gets a single category owned by the user
+func (s *categoryService) GetCategory(ctx context.Context, userID, id string) (*entities.Category, error) {
+	stored, err := s.getOwnedCategory(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toEntityCategory(stored), nil
+}
+
+// LookupCategory finds a category by kind and case-insensitive name
+func (s *categoryService) LookupCategory(ctx context.Context, userID string, kind entities.CategoryKind, name string) (*entities.Category, error) {
+	if err := s.ensureDefaults(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.categoryRepo.GetByName(ctx, userID, string(kind), strings.TrimSpace(name))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("%s category %q: %w", kind, name, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return toEntityCategory(stored), nil
+}
+
+// GetCategoryByRole finds the user's category with role, whatever it's named now
+func (s *categoryService) GetCategoryByRole(ctx context.Context, userID string, role entities.CategoryRole) (*entities.Category, error) {
+	stored, err := s.loadCategories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range stored {
+		if category.Role == string(role) {
+			return toEntityCategory(category), nil
+		}
+	}
+	return nil, fmt.Errorf("%s category: %w", role, ErrNotFound)
+}
+
+// CreateCategory creates a new category
+func (s *categoryService) CreateCategory(ctx context.Context, category *entities.Category) error {
+	if err := s.ensureDefaults(ctx, category.UserID); err != nil {
+		return err
+	}
+	if !category.Kind.IsValid() {
+		return fmt.Errorf("%w: kind must be %q or %q", ErrInvalidInput, entities.MainCategoryKind, entities.SubCategoryKind)
+	}
+	if err := s.validateCategory(ctx, category); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	category.ID = ""
+	category.Archived = false
+	category.Role = ""
+	category.CreatedAt = now
+	category.UpdatedAt = now
+
+	stored := toRepositoryCategory(category)
+	if err := s.categoryRepo.CreateAll(ctx, []*repositories.Category{stored}); err != nil {
+		return fmt.Errorf("failed to save category: %w", err)
+	}
+	category.ID = stored.ID
+	return nil
+}
+
+// UpdateCategory renames, re-parents or restyles a category.
+// Renaming also renames the category on every expense filed under it.
+func (s *categoryService) UpdateCategory(ctx context.Context, id string, category *entities.Category) error {
+	existing, err := s.getOwnedCategory(ctx, category.UserID, id)
+	if err != nil {
+		return err
+	}
+
+	category.ID = id
+	category.Kind = entities.CategoryKind(existing.Kind)
+	category.Archived = existing.Archived
+	if err := s.validateCategory(ctx, category); err != nil {
+		return err
+	}
+
+	category.CreatedAt = existing.CreatedAt
+	category.UpdatedAt = time.Now()
+
+	if err := s.categoryRepo.Update(ctx, toRepositoryCategory(category)); err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+	return nil
+}
+
+// ArchiveCategory hides a category and its descendants from new expenses, or restores them.
+// Archived categories keep their history and still appear in breakdowns.
+func (s *categoryService) ArchiveCategory(ctx context.Context, userID, id string, archived bool) error {
+	if _, err := s.getOwnedCategory(ctx, userID, id); err != nil {
+		return err
+	}
+
+	all, err := s.categoryRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	now := time.Now()
+	for _, category := range all {
+		if category.ID != id && !isDescendant(all, category.ID, id) {
+			continue
+		}
+		if category.Archived == archived {
+			continue
+		}
+		category.Archived = archived
+		category.UpdatedAt = now
+		if err := s.categoryRepo.Update(ctx, category); err != nil {
+			return fmt.Errorf("failed to archive category: %w", err)
+		}
+	}
+	return nil
+}
+
+// MergeCategories re-files every expense under source as target and removes source
+func (s *categoryService) MergeCategories(ctx context.Context, userID, sourceID, targetID string) error {
+	if sourceID == targetID {
+		return fmt.Errorf("%w: cannot merge a category into itself", ErrInvalidInput)
+	}
+
+	source, err := s.getOwnedCategory(ctx, userID, sourceID)
+	if err != nil {
+		return err
+	}
+	target, err := s.getOwnedCategory(ctx, userID, targetID)
+	if err != nil {
+		return err
+	}
+	if source.Kind != target.Kind {
+		return fmt.Errorf("%w: cannot merge a %s category into a %s category", ErrInvalidInput, source.Kind, target.Kind)
+	}
+
+	all, err := s.categoryRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get categories: %w", err)
+	}
+	if isDescendant(all, targetID, sourceID) {
+		return fmt.Errorf("%w: cannot merge a category into one of its own sub-categories", ErrInvalidInput)
+	}
+
+	err = s.categoryRepo.Merge(ctx, sourceID, targetID)
+	if errors.Is(err, repositories.ErrConflict) {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to merge categories: %w", err)
+	}
+	return nil
+}
+
+// loadCategories gets the user's stored categories, seeding the defaults on first use
+func (s *categoryService) loadCategories(ctx context.Context, userID string) ([]*repositories.Category, error) {
+	stored, err := s.categoryRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	if len(stored) > 0 {
+		return stored, nil
+	}
+
+	defaults := entities.DefaultCategories()
+	seeded := make([]*repositories.Category, 0, len(defaults))
+	for _, category := range defaults {
+		category.UserID = userID
+		seeded = append(seeded, toRepositoryCategory(category))
+	}
+	if err := s.categoryRepo.CreateAll(ctx, seeded); err != nil {
+		return nil, fmt.Errorf("failed to seed default categories: %w", err)
+	}
+	return seeded, nil
+}
+
+// ensureDefaults seeds the default taxonomy for users who don't have one yet
+func (s *categoryService) ensureDefaults(ctx context.Context, userID string) error {
+	_, err := s.loadCategories(ctx, userID)
+	return err
+}
+
+// getOwnedCategory loads a category and hides it unless it belongs to userID
+func (s *categoryService) getOwnedCategory(ctx context.Context, userID, id string) (*repositories.Category, error) {
+	stored, err := s.categoryRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("category %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if stored.UserID != userID {
+		return nil, fmt.Errorf("category %s: %w", id, ErrNotFound)
+	}
+	return stored, nil
+}
+
+// validateCategory checks the name, style and parent of a category being saved
+func (s *categoryService) validateCategory(ctx context.Context, category *entities.Category) error {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if len(category.Name) > maxCategoryNameLength {
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidInput, maxCategoryNameLength)
+	}
+	if category.Color != "" && !colorPattern.MatchString(category.Color) {
+		return fmt.Errorf("%w: color must be a hex value such as #4DB6AC", ErrInvalidInput)
+	}
+	if len(category.Icon) > maxCategoryIconLength {
+		return fmt.Errorf("%w: icon must be at most %d characters", ErrInvalidInput, maxCategoryIconLength)
+	}
+
+	existing, err := s.categoryRepo.GetByName(ctx, category.UserID, string(category.Kind), category.Name)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return fmt.Errorf("failed to check category name: %w", err)
+	}
+	if existing != nil && existing.ID != category.ID {
+		return fmt.Errorf("%w: a %s category named %q already exists", ErrInvalidInput, category.Kind, category.Name)
+	}
+
+	if category.ParentID == "" {
+		return nil
+	}
+	parent, err := s.getOwnedCategory(ctx, category.UserID, category.ParentID)
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%w: parent category not found", ErrInvalidInput)
+	}
+	if err != nil {
+		return err
+	}
+	if parent.Kind != string(category.Kind) {
+		return fmt.Errorf("%w: parent must be a %s category", ErrInvalidInput, category.Kind)
+	}
+	if category.ID != "" {
+		all, err := s.categoryRepo.GetByUserID(ctx, category.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get categories: %w", err)
+		}
+		if parent.ID == category.ID || isDescendant(all, parent.ID, category.ID) {
+			return fmt.Errorf("%w: a category cannot be nested under itself", ErrInvalidInput)
+		}
+	}
+	return nil
+}
+
+// lookupCategory finds one of the user's categories, reporting unknown names as invalid input
+func lookupCategory(ctx context.Context, categoryService CategoryService, userID string, kind entities.CategoryKind, name string) (*entities.Category, error) {
+	category, err := categoryService.LookupCategory(ctx, userID, kind, name)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: unknown %s category %q", ErrInvalidInput, kind, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// isDescendant reports whether id sits somewhere below ancestorID in the tree
+func isDescendant(categories []*repositories.Category, id, ancestorID string) bool {
+	parents := make(map[string]string, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+
+	// Bounded by the number of categories so a corrupt cycle can't loop forever
+	current := parents[id]
+	for i := 0; current != "" && i < len(categories); i++ {
+		if current == ancestorID {
+			return true
+		}
+		current = parents[current]
+	}
+	return false
+}
+
+// toRepositoryCategory converts a category entity to its repository model
+func toRepositoryCategory(category *entities.Category) *repositories.Category {
+	return &repositories.Category{
+		ID:        category.ID,
+		UserID:    category.UserID,
+		Name:      category.Name,
+		Kind:      string(category.Kind),
+		ParentID:  category.ParentID,
+		Color:     category.Color,
+		Icon:      category.Icon,
+		Archived:  category.Archived,
+		Role:      string(category.Role),
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+// toEntityCategory converts a repository category model to an entity
+func toEntityCategory(category *repositories.Category) *entities.Category {
+	return &entities.Category{
+		ID:        category.ID,
+		UserID:    category.UserID,
+		Name:      category.Name,
+		Kind:      entities.CategoryKind(category.Kind),
+		ParentID:  category.ParentID,
+		Color:     category.Color,
+		Icon:      category.Icon,
+		Archived:  category.Archived,
+		Role:      entities.CategoryRole(category.Role),
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func newTestCategoryServices(t *testing.T) (CategoryService, ExpenseService) {
+	t.Helper()
+	svc := newTestServices(t)
+	return svc.categories, svc.expenses
+}
+
+func TestCategoriesAreSeededWithDefaults(t *testing.T) {
+	ctx := context.Background()
+	categories, _ := newTestCategoryServices(t)
+
+	all, err := categories.GetCategories(ctx, "u1", false)
+	if err != nil {
+		t.Fatalf("GetCategories failed: %v", err)
+	}
+	if len(all) != len(entities.DefaultCategories()) {
+		t.Fatalf("expected %d default categories, got %d", len(entities.DefaultCategories()), len(all))
+	}
+
+	// Reading again must not seed a second copy
+	again, _ := categories.GetCategories(ctx, "u1", false)
+	if len(again) != len(all) {
+		t.Errorf("expected seeding to be idempotent, got %d categories", len(again))
+	}
+
+	found, err := categories.LookupCategory(ctx, "u1", entities.SubCategoryKind, "food")
+	if err != nil || found.Name != string(entities.Food) {
+		t.Errorf("expected case-insensitive lookup of Food, got %+v, %v", found, err)
+	}
+}
+
+func TestRenameAndMergeRepointExpenses(t *testing.T) {
+	ctx := context.Background()
+	categories, expenses := newTestCategoryServices(t)
+	date := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	city := &entities.Category{UserID: "u1", Name: "Mumbai Flat", Kind: entities.MainCategoryKind, Color: "#123456"}
+	if err := categories.CreateCategory(ctx, city); err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	expense := newTestExpense("u1", "500", date, "mumbai flat", entities.Food)
+	if _, err := expenses.AddExpense(ctx, expense); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	if expense.MainCategory != "Mumbai Flat" {
+		t.Errorf("expected category name to be normalized, got %q", expense.MainCategory)
+	}
+
+	city.Name = "Mumbai Home"
+	if err := categories.UpdateCategory(ctx, city.ID, city); err != nil {
+		t.Fatalf("UpdateCategory failed: %v", err)
+	}
+	renamed, _ := expenses.GetExpense(ctx, "u1", expense.ID)
+	if renamed.MainCategory != "Mumbai Home" {
+		t.Errorf("expected rename to re-point the expense, got %q", renamed.MainCategory)
+	}
+
+	self, _ := categories.LookupCategory(ctx, "u1", entities.MainCategoryKind, string(entities.Self))
+	food, _ := categories.LookupCategory(ctx, "u1", entities.SubCategoryKind, string(entities.Food))
+	if err := categories.MergeCategories(ctx, "u1", city.ID, food.ID); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected merging across kinds to fail, got %v", err)
+	}
+	if err := categories.MergeCategories(ctx, "u1", city.ID, self.ID); err != nil {
+		t.Fatalf("MergeCategories failed: %v", err)
+	}
+	merged, _ := expenses.GetExpense(ctx, "u1", expense.ID)
+	if merged.MainCategory != entities.Self {
+		t.Errorf("expected merge to re-point the expense to Self, got %q", merged.MainCategory)
+	}
+	if _, err := categories.GetCategory(ctx, "u1", city.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected merged category to be removed, got %v", err)
+	}
+}
+
+func TestRenameRepointsSavedCategoryReferences(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	mapping := &entities.ImportMapping{UserID: "u1", Name: "Bank", DateColumn: "Date", AmountColumn: "Amount", DefaultMainCategory: "Self", DefaultSubCategory: "Food"}
+	if err := svc.imports.CreateMapping(ctx, mapping); err != nil {
+		t.Fatalf("CreateMapping failed: %v", err)
+	}
+	rule := newTestRule(t, svc, &entities.CategorizationRule{Pattern: "swiggy", MainCategory: entities.Self, SubCategory: entities.Food})
+	addHistory(t, svc, 6, "Uber trip", "250", entities.Self, entities.Travel)
+	for i := 0; i < 2; i++ {
+		correction := &entities.CategoryCorrection{UserID: "u1", Description: "Amazon Pay India", Amount: decimal.NewFromInt(899), MainCategory: "self", SubCategory: "misc"}
+		if err := svc.statements.RecordCorrection(ctx, correction); err != nil {
+			t.Fatalf("RecordCorrection failed: %v", err)
+		}
+	}
+
+	self, _ := svc.categories.LookupCategory(ctx, "u1", entities.MainCategoryKind, string(entities.Self))
+	food, _ := svc.categories.LookupCategory(ctx, "u1", entities.SubCategoryKind, string(entities.Food))
+	self.Name, food.Name = "Me", "Dining"
+	for _, category := range []*entities.Category{self, food} {
+		if err := svc.categories.UpdateCategory(ctx, category.ID, category); err != nil {
+			t.Fatalf("UpdateCategory failed: %v", err)
+		}
+	}
+
+	if got, _ := svc.imports.GetMapping(ctx, "u1", mapping.ID); got.DefaultMainCategory != "Me" || got.DefaultSubCategory != "Dining" {
+		t.Errorf("expected the mapping's defaults to follow the rename, got %q / %q", got.DefaultMainCategory, got.DefaultSubCategory)
+	}
+	if got, _ := svc.rules.GetRule(ctx, "u1", rule.ID); got.MainCategory != "Me" || got.SubCategory != "Dining" {
+		t.Errorf("expected the rule's categories to follow the rename, got %q / %q", got.MainCategory, got.SubCategory)
+	}
+	categorized, err := svc.statements.CategorizeTransactions(ctx, "u1", []*Transaction{{Description: "AMAZON PAY INDIA", Amount: "1299.00", Type: "Debit"}})
+	if err != nil {
+		t.Fatalf("CategorizeTransactions failed: %v", err)
+	}
+	if got := categorized[0]; got.MainCategory != "Me" || got.SubCategory != entities.Misc {
+		t.Errorf("expected the correction to follow the rename, got %q / %q", got.MainCategory, got.SubCategory)
+	}
+}
+
+func TestMergeCombinesCollidingBudgets(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	budgets := []*entities.Budget{
+		{UserID: "u1", Kind: entities.SubCategoryKind, Category: "Food", Month: month(2024, 3), Amount: decimal.NewFromInt(1000)},
+		{UserID: "u1", Kind: entities.SubCategoryKind, Category: "Travel", Month: month(2024, 3), Amount: decimal.NewFromInt(500)},
+		{UserID: "u1", Kind: entities.SubCategoryKind, Category: "Travel", Month: month(2024, 4), Amount: decimal.NewFromInt(200)},
+		{UserID: "u1", Kind: entities.SubCategoryKind, Category: "Misc", Month: month(2024, 3), Amount: decimal.NewFromInt(50), Currency: "USD"},
+	}
+	for _, budget := range budgets {
+		if err := svc.budgets.CreateBudget(ctx, budget); err != nil {
+			t.Fatalf("CreateBudget failed: %v", err)
+		}
+	}
+
+	food, _ := svc.categories.LookupCategory(ctx, "u1", entities.SubCategoryKind, string(entities.Food))
+	travel, _ := svc.categories.LookupCategory(ctx, "u1", entities.SubCategoryKind, string(entities.Travel))
+	misc, _ := svc.categories.LookupCategory(ctx, "u1", entities.SubCategoryKind, string(entities.Misc))
+	if err := svc.categories.MergeCategories(ctx, "u1", travel.ID, food.ID); err != nil {
+		t.Fatalf("MergeCategories failed: %v", err)
+	}
+	for _, want := range []struct {
+		month  time.Time
+		amount string
+	}{{month(2024, 3), "1500"}, {month(2024, 4), "200"}} {
+		stored, err := svc.budgets.GetBudgets(ctx, "u1", want.month)
+		if err != nil {
+			t.Fatalf("GetBudgets failed: %v", err)
+		}
+		var got []string
+		for _, budget := range stored {
+			if budget.Category == string(entities.Food) {
+				got = append(got, budget.Amount.String())
+			}
+		}
+		if len(got) != 1 || got[0] != want.amount {
+			t.Errorf("%s: Food budgets = %v, want %s", want.month.Format("2006-01"), got, want.amount)
+		}
+	}
+
+	// Budgets in different currencies can't be added together
+	err := svc.categories.MergeCategories(ctx, "u1", misc.ID, food.ID)
+	if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), "2024-03") {
+		t.Errorf("expected ErrInvalidInput naming March, got %v", err)
+	}
+	if _, err := svc.categories.LookupCategory(ctx, "u1", entities.SubCategoryKind, string(entities.Misc)); err != nil {
+		t.Errorf("expected the rejected merge to keep Misc, got %v", err)
+	}
+}
+
+func TestArchivedCategoriesRejectNewExpenses(t *testing.T) {
+	ctx := context.Background()
+	categories, expenses := newTestCategoryServices(t)
+	date := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	travel, _ := categories.LookupCategory(ctx, "u1", entities.SubCategoryKind, string(entities.Travel))
+	flights := &entities.Category{UserID: "u1", Name: "Flights", Kind: entities.SubCategoryKind, ParentID: travel.ID}
+	if err := categories.CreateCategory(ctx, flights); err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	old := newTestExpense("u1", "4200", date, entities.Self, "Flights")
+	if _, err := expenses.AddExpense(ctx, old); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+
+	if err := categories.ArchiveCategory(ctx, "u1", travel.ID, true); err != nil {
+		t.Fatalf("ArchiveCategory failed: %v", err)
+	}
+	if _, err := expenses.AddExpense(ctx, newTestExpense("u1", "1", date, entities.Self, "Flights")); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected archiving to cascade to children, got %v", err)
+	}
+
+	// Existing expenses can still be edited without moving them out of an archived category
+	old.Amount = old.Amount.Add(old.Amount)
+	if err := expenses.UpdateExpense(ctx, old.ID, old); err != nil {
+		t.Errorf("expected update within an archived category to succeed, got %v", err)
+	}
+
+	tree, _ := categories.GetCategoryTree(ctx, "u1", false)
+	for _, root := range tree {
+		if root.ID == travel.ID {
+			t.Error("archived categories should be hidden by default")
+		}
+	}
+
+	if err := categories.UpdateCategory(ctx, travel.ID, &entities.Category{UserID: "u1", Name: "Travel", ParentID: flights.ID}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected nesting a category under its child to fail, got %v", err)
+	}
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// conversionPrecision is the number of decimal places kept for converted amounts
+const conversionPrecision = 2
+
+// rateColumns are the columns required in an exchange rate CSV, in any order
+var rateColumns = []string{"date", "from", "to", "rate"}
+
+// CurrencyService defines the interface for exchange rates and currency conversion
+type CurrencyService interface {
+	BaseCurrency(ctx context.Context, userID string) (string, error)
+	Convert(ctx context.Context, userID string, amount decimal.Decimal, from, to string, on time.Time) (decimal.Decimal, error)
+	GetRates(ctx context.Context, userID string) ([]*entities.ExchangeRate, error)
+	SetRate(ctx context.Context, rate *entities.ExchangeRate) error
+	ImportRates(ctx context.Context, userID string, r io.Reader) ([]*entities.ExchangeRate, error)
+	DeleteRate(ctx context.Context, userID, id string) error
+}
+
+// currencyService implements the CurrencyService interface
+type currencyService struct {
+	rateRepo        repositories.ExchangeRateRepository
+	settingsService SettingsService
+}
+
+// NewCurrencyService creates a new currency service
+func NewCurrencyService(rateRepo repositories.ExchangeRateRepository, settingsService SettingsService) CurrencyService {
+	return &currencyService{
+		rateRepo:        rateRepo,
+		settingsService: settingsService,
+	}
+}
+
+// BaseCurrency gets the currency the user's totals are reported in
+func (s *currencyService) BaseCurrency(ctx context.Context, userID string) (string, error) {
+	settings, err := s.settingsService.GetSettings(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return settings.BaseCurrency, nil
+}
+
+// Convert converts an amount between currencies using the latest rate on or before the given day.
+// A stored rate for the reverse pair is inverted when there's none for the pair itself.
+func (s *currencyService) Convert(ctx context.Context, userID string, amount decimal.Decimal, from, to string, on time.Time) (decimal.Decimal, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	rate, err := s.rateRepo.GetLatest(ctx, userID, from, to, on)
+	if err == nil {
+		return amount.Mul(rate.Rate).Round(conversionPrecision), nil
+	}
+	if !errors.Is(err, repositories.ErrNotFound) {
+		return decimal.Zero, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	inverse, err := s.rateRepo.GetLatest(ctx, userID, to, from, on)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return decimal.Zero, fmt.Errorf("%w: no exchange rate from %s to %s on or before %s",
+			ErrInvalidInput, from, to, on.Format("2006-01-02"))
+	}
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return amount.DivRound(inverse.Rate, conversionPrecision), nil
+}
+
+// GetRates gets every exchange rate the user has recorded
+func (s *currencyService) GetRates(ctx context.Context, userID string) ([]*entities.ExchangeRate, error) {
+	stored, err := s.rateRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+
+	rates := make([]*entities.ExchangeRate, 0, len(stored))
+	for _, rate := range stored {
+		rates = append(rates, toEntityExchangeRate(rate))
+	}
+	return rates, nil
+}
+
+// SetRate records a rate for a currency pair and day, replacing any existing one
+func (s *currencyService) SetRate(ctx context.Context, rate *entities.ExchangeRate) error {
+	if err := validateExchangeRate(rate); err != nil {
+		return err
+	}
+	return s.saveRates(ctx, []*entities.ExchangeRate{rate})
+}
+
+// ImportRates records every rate in a CSV with date, from, to and rate columns.
+// Nothing is saved unless every row is valid.
+func (s *currencyService) ImportRates(ctx context.Context, userID string, r io.Reader) ([]*entities.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: CSV is empty", ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range rateColumns {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("%w: CSV is missing the %q column", ErrInvalidInput, name)
+		}
+	}
+
+	var rates []*entities.ExchangeRate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(record[index["date"]]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: date must be YYYY-MM-DD", ErrInvalidInput, line)
+		}
+		value, err := decimal.NewFromString(strings.TrimSpace(record[index["rate"]]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid rate %q", ErrInvalidInput, line, record[index["rate"]])
+		}
+
+		rate := &entities.ExchangeRate{
+			UserID:       userID,
+			FromCurrency: record[index["from"]],
+			ToCurrency:   record[index["to"]],
+			Date:         date,
+			Rate:         value,
+		}
+		if err := validateExchangeRate(rate); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rates = append(rates, rate)
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("%w: CSV has no rates", ErrInvalidInput)
+	}
+
+	if err := s.saveRates(ctx, rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// DeleteRate deletes one of the user's exchange rates
+func (s *currencyService) DeleteRate(ctx context.Context, userID, id string) error {
+	stored, err := s.rateRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return fmt.Errorf("exchange rate %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	if stored.UserID != userID {
+		return fmt.Errorf("exchange rate %s: %w", id, ErrNotFound)
+	}
+
+	if err := s.rateRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete exchange rate: %w", err)
+	}
+	return nil
+}
+
+// saveRates stores validated rates and copies the assigned IDs back
+func (s *currencyService) saveRates(ctx context.Context, rates []*entities.ExchangeRate) error {
+	now := time.Now()
+	stored := make([]*repositories.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		rate.ID = ""
+		rate.CreatedAt = now
+		rate.UpdatedAt = now
+		stored = append(stored, toRepositoryExchangeRate(rate))
+	}
+
+	if err := s.rateRepo.Upsert(ctx, stored); err != nil {
+		return fmt.Errorf("failed to save exchange rates: %w", err)
+	}
+	for i, rate := range rates {
+		rate.ID = stored[i].ID
+	}
+	return nil
+}
+
+// resolveCurrency normalizes a currency code, defaulting to the user's base currency when empty
+func resolveCurrency(ctx context.Context, currencyService CurrencyService, userID, code string) (string, error) {
+	if code == "" {
+		return currencyService.BaseCurrency(ctx, userID)
+	}
+	return normalizeCurrency(code)
+}
+
+// requireRate checks that an amount in currency dated on can be converted to
+// the user's base currency, so it doesn't break every report it later appears in
+func requireRate(ctx context.Context, currencyService CurrencyService, userID, currency string, on time.Time) error {
+	base, err := currencyService.BaseCurrency(ctx, userID)
+	if err != nil {
+		return err
+	}
+	_, err = currencyService.Convert(ctx, userID, decimal.NewFromInt(1), currency, base, on)
+	return err
+}
+
+// validateExchangeRate checks a rate and normalizes its currencies and date
+func validateExchangeRate(rate *entities.ExchangeRate) error {
+	if rate.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+
+	var err error
+	if rate.FromCurrency, err = normalizeCurrency(rate.FromCurrency); err != nil {
+		return err
+	}
+	if rate.ToCurrency, err = normalizeCurrency(rate.ToCurrency); err != nil {
+		return err
+	}
+	if rate.FromCurrency == rate.ToCurrency {
+		return fmt.Errorf("%w: from and to currencies must differ", ErrInvalidInput)
+	}
+	if !rate.Rate.IsPositive() {
+		return fmt.Errorf("%w: rate must be greater than zero", ErrInvalidInput)
+	}
+	if rate.Date.IsZero() {
+		return fmt.Errorf("%w: date is required", ErrInvalidInput)
+	}
+
+	rate.Date = time.Date(rate.Date.Year(), rate.Date.Month(), rate.Date.Day(), 0, 0, 0, 0, time.UTC)
+	return nil
+}
+
+// toRepositoryExchangeRate converts an exchange rate entity to its repository model
+func toRepositoryExchangeRate(rate *entities.ExchangeRate) *repositories.ExchangeRate {
+	return &repositories.ExchangeRate{
+		ID:           rate.ID,
+		UserID:       rate.UserID,
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+		Date:         rate.Date,
+		Rate:         rate.Rate,
+		CreatedAt:    rate.CreatedAt,
+		UpdatedAt:    rate.UpdatedAt,
+	}
+}
+
+// toEntityExchangeRate converts a repository exchange rate model to an entity
+func toEntityExchangeRate(rate *repositories.ExchangeRate) *entities.ExchangeRate {
+	return &entities.ExchangeRate{
+		ID:           rate.ID,
+		UserID:       rate.UserID,
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+		Date:         rate.Date,
+		Rate:         rate.Rate,
+		CreatedAt:    rate.CreatedAt,
+		UpdatedAt:    rate.UpdatedAt,
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/infrastructure/repositories/sqlite"
+)
+
+func newTestCurrencyService(db *sql.DB) CurrencyService {
+	settingsService := NewSettingsService(sqlite.NewUserSettingsRepository(db))
+	return NewCurrencyService(sqlite.NewExchangeRateRepository(db), settingsService)
+}
+
+func TestConvertUsesHistoricalRates(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.Open(ctx, ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	defer db.Close()
+	svc := newTestCurrencyService(db)
+
+	csv := "Date,From,To,Rate\n2024-01-01,usd,INR,83.00\n2024-03-01,USD,INR,82.50\n2024-03-01,EUR,USD,1.10\n"
+	rates, err := svc.ImportRates(ctx, "u1", strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ImportRates failed: %v", err)
+	}
+	if len(rates) != 3 || rates[0].FromCurrency != "USD" {
+		t.Fatalf("expected 3 normalized rates, got %+v", rates)
+	}
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 15, 0, 0, 0, time.UTC) }
+	cases := []struct {
+		from, to string
+		on       time.Time
+		expected string
+	}{
+		{"USD", "INR", day(time.February, 10), "830"},
+		{"USD", "INR", day(time.March, 1), "825"},
+		{"INR", "USD", day(time.March, 20), "0.12"},
+		{"INR", "INR", day(time.March, 20), "10"},
+	}
+	for _, tc := range cases {
+		got, err := svc.Convert(ctx, "u1", decimal.NewFromInt(10), tc.from, tc.to, tc.on)
+		if err != nil {
+			t.Fatalf("Convert %s->%s failed: %v", tc.from, tc.to, err)
+		}
+		if got.String() != tc.expected {
+			t.Errorf("Convert %s->%s on %s: expected %s, got %s", tc.from, tc.to, tc.on.Format("2006-01-02"), tc.expected, got)
+		}
+	}
+
+	if _, err := svc.Convert(ctx, "u1", decimal.NewFromInt(10), "USD", "INR", day(time.January, 0)); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput before the first rate, got %v", err)
+	}
+
+	// A bad row rejects the whole file
+	bad := "date,from,to,rate\n2024-04-01,USD,INR,83\n2024-04-02,USD,INR,-1\n"
+	if _, err := svc.ImportRates(ctx, "u1", strings.NewReader(bad)); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for a negative rate, got %v", err)
+	}
+	all, _ := svc.GetRates(ctx, "u1")
+	if len(all) != 3 {
+		t.Errorf("expected a failed import to save nothing, got %d rates", len(all))
+	}
+}
+
+func TestBreakdownIsInBaseCurrency(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	settingsService, currencyService, incomeService, expenseService := svc.settings, svc.currency, svc.incomes, svc.expenses
+
+	march := month(2024, 3)
+	rate := &entities.ExchangeRate{UserID: "u1", FromCurrency: "USD", ToCurrency: "INR", Date: march, Rate: decimal.NewFromInt(80)}
+	if err := currencyService.SetRate(ctx, rate); err != nil {
+		t.Fatalf("SetRate failed: %v", err)
+	}
+
+	income := &entities.Income{UserID: "u1", Amount: decimal.NewFromInt(1000), Currency: "USD", Month: march, Source: "Consulting"}
+	if err := incomeService.AddIncome(ctx, income); err != nil {
+		t.Fatalf("AddIncome failed: %v", err)
+	}
+	local := newTestExpense("u1", "2000", march.AddDate(0, 0, 2), entities.Self, entities.Food)
+	abroad := newTestExpense("u1", "50", march.AddDate(0, 0, 5), entities.Self, entities.Travel)
+	abroad.Currency = "usd"
+	for _, expense := range []*entities.Expense{local, abroad} {
+		if _, err := expenseService.AddExpense(ctx, expense); err != nil {
+			t.Fatalf("AddExpense failed: %v", err)
+		}
+	}
+	if local.Currency != entities.DefaultCurrency || abroad.Currency != "USD" {
+		t.Errorf("expected currencies INR and USD, got %s and %s", local.Currency, abroad.Currency)
+	}
+
+	// Expenses that couldn't be converted would break every report they're in
+	unconvertible := map[string]*entities.Expense{
+		"no rate for the currency": newTestExpense("u1", "10", march.AddDate(0, 0, 6), entities.Self, entities.Travel),
+		"before the first rate":    newTestExpense("u1", "10", march.AddDate(0, 0, -1), entities.Self, entities.Travel),
+	}
+	unconvertible["no rate for the currency"].Currency = "EUR"
+	unconvertible["before the first rate"].Currency = "USD"
+	for name, expense := range unconvertible {
+		if _, err := expenseService.AddExpense(ctx, expense); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+	moved := *abroad
+	moved.Currency = "EUR"
+	if err := expenseService.UpdateExpense(ctx, abroad.ID, &moved); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected moving an expense to a currency without a rate to fail, got %v", err)
+	}
+
+	breakdown, err := expenseService.GetMonthlyBreakdown(ctx, "u1", march, entities.CashBasis)
+	if err != nil {
+		t.Fatalf("GetMonthlyBreakdown failed: %v", err)
+	}
+	if breakdown.Currency != "INR" || breakdown.TotalIncome.String() != "80000" || breakdown.TotalExpenses.String() != "6000" {
+		t.Errorf("expected INR totals 80000/6000, got %s %s/%s", breakdown.Currency, breakdown.TotalIncome, breakdown.TotalExpenses)
+	}
+	if breakdown.OriginalAmounts["USD"].String() != "50" || breakdown.OriginalAmounts["INR"].String() != "2000" {
+		t.Errorf("expected original amounts to be kept, got %v", breakdown.OriginalAmounts)
+	}
+
+	// Switching the base currency re-expresses every total
+	if err := settingsService.UpdateSettings(ctx, &entities.UserSettings{UserID: "u1", BaseCurrency: "usd"}); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	breakdown, err = expenseService.GetMonthlyBreakdown(ctx, "u1", march, entities.CashBasis)
+	if err != nil {
+		t.Fatalf("GetMonthlyBreakdown failed: %v", err)
+	}
+	if breakdown.Currency != "USD" || breakdown.TotalExpenses.String() != "75" {
+		t.Errorf("expected USD total 75, got %s %s", breakdown.Currency, breakdown.TotalExpenses)
+	}
+}
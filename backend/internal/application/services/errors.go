@@ -0,0 +1,17 @@
+package services
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when a resource does not exist or belongs to another user
+	ErrNotFound = errors.New("not found")
+
+	// ErrInvalidInput is returned when a request fails validation
+	ErrInvalidInput = errors.New("invalid input")
+
+	// ErrPasswordRequired is returned when a document needs a password that wasn't given
+	ErrPasswordRequired = errors.New("password required")
+
+	// ErrWrongPassword is returned when the password given for a document doesn't open it
+	ErrWrongPassword = errors.New("wrong password")
+)
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// EventService defines the interface for trip and event operations
+type EventService interface {
+	CreateEvent(ctx context.Context, event *entities.Event) error
+	GetEvents(ctx context.Context, userID string) ([]*entities.EventSummary, error)
+	GetEvent(ctx context.Context, userID, id string) (*entities.EventSummary, error)
+	UpdateEvent(ctx context.Context, id string, event *entities.Event) error
+	DeleteEvent(ctx context.Context, userID, id string) error
+	AttachExpense(ctx context.Context, userID, eventID, expenseID string) error
+	DetachExpense(ctx context.Context, userID, eventID, expenseID string) error
+}
+
+// eventService implements the EventService interface
+type eventService struct {
+	eventRepo       repositories.EventRepository
+	expenseRepo     repositories.ExpenseRepository
+	currencyService CurrencyService
+}
+
+// NewEventService creates a new event service
+func NewEventService(eventRepo repositories.EventRepository, expenseRepo repositories.ExpenseRepository, currencyService CurrencyService) EventService {
+	return &eventService{
+		eventRepo:       eventRepo,
+		expenseRepo:     expenseRepo,
+		currencyService: currencyService,
+	}
+}
+
+// CreateEvent creates an event. The currency defaults to the user's base
+// currency. An auto-attaching event picks up the expenses already recorded
+// in its dates that aren't in another event.
+func (s *eventService) CreateEvent(ctx context.Context, event *entities.Event) error {
+	if event.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	if err := validateEvent(event); err != nil {
+		return err
+	}
+	currency, err := resolveCurrency(ctx, s.currencyService, event.UserID, event.Currency)
+	if err != nil {
+		return err
+	}
+	event.Currency = currency
+
+	now := time.Now()
+	event.ID = uuid.NewString()
+	event.CreatedAt = now
+	event.UpdatedAt = now
+
+	if err := s.eventRepo.Create(ctx, toRepositoryEvent(event)); err != nil {
+		return fmt.Errorf("failed to save event: %w", err)
+	}
+	return s.attachUnassigned(ctx, event)
+}
+
+// GetEvents gets the user's events with their totals, most recent first
+func (s *eventService) GetEvents(ctx context.Context, userID string) ([]*entities.EventSummary, error) {
+	stored, err := s.eventRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	summaries := make([]*entities.EventSummary, 0, len(stored))
+	for _, event := range stored {
+		summary, err := s.summarize(ctx, toEntityEvent(event))
+		if err != nil {
+			return nil, err
+		}
+		summary.Expenses = nil
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// GetEvent gets an event owned by the user with its totals and expenses
+func (s *eventService) GetEvent(ctx context.Context, userID, id string) (*entities.EventSummary, error) {
+	stored, err := getOwnedEvent(ctx, s.eventRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.summarize(ctx, toEntityEvent(stored))
+}
+
+// UpdateEvent updates an event. Expenses already attached stay attached even
+// if the dates change; an auto-attaching event picks up any unassigned
+// expenses in its new dates.
+func (s *eventService) UpdateEvent(ctx context.Context, id string, event *entities.Event) error {
+	existing, err := getOwnedEvent(ctx, s.eventRepo, event.UserID, id)
+	if err != nil {
+		return err
+	}
+	if err := validateEvent(event); err != nil {
+		return err
+	}
+	if event.Currency == "" {
+		event.Currency = existing.Currency
+	}
+	if event.Currency, err = normalizeCurrency(event.Currency); err != nil {
+		return err
+	}
+
+	event.ID = id
+	event.CreatedAt = existing.CreatedAt
+	event.UpdatedAt = time.Now()
+
+	if err := s.eventRepo.Update(ctx, toRepositoryEvent(event)); err != nil {
+		return fmt.Errorf("failed to update event: %w", err)
+	}
+	return s.attachUnassigned(ctx, event)
+}
+
+// DeleteEvent deletes an event. Its expenses are kept but no longer belong to an event.
+func (s *eventService) DeleteEvent(ctx context.Context, userID, id string) error {
+	if _, err := getOwnedEvent(ctx, s.eventRepo, userID, id); err != nil {
+		return err
+	}
+	if err := s.eventRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+	return nil
+}
+
+// AttachExpense moves an expense into an event, whatever its date and
+// whichever event it was in before
+func (s *eventService) AttachExpense(ctx context.Context, userID, eventID, expenseID string) error {
+	if _, err := getOwnedEvent(ctx, s.eventRepo, userID, eventID); err != nil {
+		return err
+	}
+	if _, err := getOwnedExpense(ctx, s.expenseRepo, userID, expenseID); err != nil {
+		return err
+	}
+	if err := s.eventRepo.SetExpenseEvent(ctx, expenseID, eventID); err != nil {
+		return fmt.Errorf("failed to attach expense: %w", err)
+	}
+	return nil
+}
+
+// DetachExpense takes an expense out of an event. It isn't attached again
+// automatically, even though its date may still fall in the event.
+func (s *eventService) DetachExpense(ctx context.Context, userID, eventID, expenseID string) error {
+	if _, err := getOwnedEvent(ctx, s.eventRepo, userID, eventID); err != nil {
+		return err
+	}
+	expense, err := getOwnedExpense(ctx, s.expenseRepo, userID, expenseID)
+	if err != nil {
+		return err
+	}
+	if expense.EventID != eventID {
+		return fmt.Errorf("%w: expense %s isn't part of event %s", ErrInvalidInput, expenseID, eventID)
+	}
+	if err := s.eventRepo.SetExpenseEvent(ctx, expenseID, ""); err != nil {
+		return fmt.Errorf("failed to detach expense: %w", err)
+	}
+	return nil
+}
+
+// attachUnassigned attaches the expenses in an auto-attaching event's dates
+// that aren't in any event yet
+func (s *eventService) attachUnassigned(ctx context.Context, event *entities.Event) error {
+	if !event.AutoAttach {
+		return nil
+	}
+	if _, err := s.eventRepo.AttachUnassigned(ctx, event.ID, event.UserID, event.StartDate, event.EndDate.AddDate(0, 0, 1)); err != nil {
+		return fmt.Errorf("failed to attach expenses to event: %w", err)
+	}
+	return nil
+}
+
+// summarize totals an event's expenses in its currency, each converted at its
+// own date, and compares the total with the event's budget
+func (s *eventService) summarize(ctx context.Context, event *entities.Event) (*entities.EventSummary, error) {
+	stored, err := s.expenseRepo.GetByUserID(ctx, event.UserID, &repositories.ExpenseFilters{EventID: event.ID, OldestFirst: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event expenses: %w", err)
+	}
+	expenses := toEntityExpenses(stored)
+
+	converted := make([]*entities.Expense, 0, len(expenses))
+	for _, expense := range expenses {
+		inCurrency, err := convertExpense(ctx, s.currencyService, event.UserID, expense, event.Currency)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, inCurrency)
+	}
+	breakdown := buildBreakdown(startOfMonth(event.StartDate), decimal.Zero, converted)
+
+	summary := &entities.EventSummary{
+		Event:                event,
+		Currency:             event.Currency,
+		TotalExpenses:        breakdown.TotalExpenses,
+		ExpenseCount:         len(expenses),
+		CategoryBreakdown:    breakdown.CategoryBreakdown,
+		SubCategoryBreakdown: breakdown.SubCategoryBreakdown,
+		CategoryCrossTab:     breakdown.CategoryCrossTab,
+		Expenses:             expenses,
+	}
+	if event.Budget != nil {
+		remaining := event.Budget.Sub(breakdown.TotalExpenses)
+		percent := percentOf(breakdown.TotalExpenses, *event.Budget)
+		summary.Remaining = &remaining
+		summary.PercentUsed = &percent
+		summary.BudgetState = budgetState(percent)
+	}
+	return summary, nil
+}
+
+// validateEvent checks an event's details and trims its dates to whole days
+func validateEvent(event *entities.Event) error {
+	event.Name = strings.TrimSpace(event.Name)
+	if event.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if event.StartDate.IsZero() || event.EndDate.IsZero() {
+		return fmt.Errorf("%w: start and end dates are required", ErrInvalidInput)
+	}
+	event.StartDate = startOfDay(event.StartDate)
+	event.EndDate = startOfDay(event.EndDate)
+	if event.EndDate.Before(event.StartDate) {
+		return fmt.Errorf("%w: end date can't be before the start date", ErrInvalidInput)
+	}
+	if event.Budget != nil && !event.Budget.IsPositive() {
+		return fmt.Errorf("%w: budget must be greater than zero", ErrInvalidInput)
+	}
+	event.Note = strings.TrimSpace(event.Note)
+	return nil
+}
+
+// attachToEvent checks the event a new expense names or, when it names none,
+// puts it in the latest-starting auto-attaching event that covers its date
+func attachToEvent(ctx context.Context, eventRepo repositories.EventRepository, expense *entities.Expense) error {
+	if expense.EventID != "" {
+		_, err := getOwnedEvent(ctx, eventRepo, expense.UserID, expense.EventID)
+		return err
+	}
+	covering, err := eventRepo.GetAutoAttaching(ctx, expense.UserID, startOfDay(expense.Date))
+	if err != nil {
+		return fmt.Errorf("failed to get events: %w", err)
+	}
+	if len(covering) > 0 {
+		expense.EventID = covering[0].ID
+	}
+	return nil
+}
+
+// getOwnedEvent loads an event and hides it unless it belongs to userID
+func getOwnedEvent(ctx context.Context, eventRepo repositories.EventRepository, userID, id string) (*repositories.Event, error) {
+	stored, err := eventRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("event %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	if stored.UserID != userID {
+		return nil, fmt.Errorf("event %s: %w", id, ErrNotFound)
+	}
+	return stored, nil
+}
+
+// toRepositoryEvent converts an event entity to its repository model
+func toRepositoryEvent(event *entities.Event) *repositories.Event {
+	return &repositories.Event{
+		ID:         event.ID,
+		UserID:     event.UserID,
+		Name:       event.Name,
+		StartDate:  event.StartDate,
+		EndDate:    event.EndDate,
+		AutoAttach: event.AutoAttach,
+		Budget:     event.Budget,
+		Currency:   event.Currency,
+		Note:       event.Note,
+		CreatedAt:  event.CreatedAt,
+		UpdatedAt:  event.UpdatedAt,
+	}
+}
+
+// toEntityEvent converts a repository event model to an entity
+func toEntityEvent(event *repositories.Event) *entities.Event {
+	return &entities.Event{
+		ID:         event.ID,
+		UserID:     event.UserID,
+		Name:       event.Name,
+		StartDate:  event.StartDate,
+		EndDate:    event.EndDate,
+		AutoAttach: event.AutoAttach,
+		Budget:     event.Budget,
+		Currency:   event.Currency,
+		Note:       event.Note,
+		CreatedAt:  event.CreatedAt,
+		UpdatedAt:  event.UpdatedAt,
+	}
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestEventAttachesExpenses(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	// Recorded before the trip was created, so it's picked up on creation
+	dinner := newTestExpense("u1", "1200", date(2024, 5, 3), entities.Self, entities.Food)
+	if _, err := svc.expenses.AddExpense(ctx, dinner); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	before := newTestExpense("u1", "300", date(2024, 4, 30), entities.Self, entities.Food)
+	if _, err := svc.expenses.AddExpense(ctx, before); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+
+	budget := decimal.NewFromInt(5000)
+	trip := &entities.Event{UserID: "u1", Name: " Bangalore trip ", StartDate: date(2024, 5, 1), EndDate: date(2024, 5, 7), AutoAttach: true, Budget: &budget}
+	if err := svc.events.CreateEvent(ctx, trip); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	if trip.Name != "Bangalore trip" || trip.Currency != "INR" {
+		t.Errorf("got name %q and currency %s, want trimmed name and INR", trip.Name, trip.Currency)
+	}
+
+	// Added afterwards on the last day, so it's attached as it's saved
+	cab := newTestExpense("u1", "2800", date(2024, 5, 7), entities.BangaloreHouse, entities.Travel)
+	if _, err := svc.expenses.AddExpense(ctx, cab); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	if cab.EventID != trip.ID {
+		t.Errorf("expected the cab to join the trip, got event %q", cab.EventID)
+	}
+	// Outside the dates, attached by hand
+	gift := newTestExpense("u1", "1500", date(2024, 5, 20), entities.Self, entities.Misc)
+	if _, err := svc.expenses.AddExpense(ctx, gift); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	if err := svc.events.AttachExpense(ctx, "u1", trip.ID, gift.ID); err != nil {
+		t.Fatalf("AttachExpense failed: %v", err)
+	}
+
+	summary, err := svc.events.GetEvent(ctx, "u1", trip.ID)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if summary.ExpenseCount != 3 || !summary.TotalExpenses.Equal(decimal.NewFromInt(5500)) {
+		t.Errorf("got %d expenses for %s, want 3 for 5500", summary.ExpenseCount, summary.TotalExpenses)
+	}
+	if !summary.CategoryBreakdown[entities.Self].Equal(decimal.NewFromInt(2700)) || !summary.SubCategoryBreakdown[entities.Travel].Equal(decimal.NewFromInt(2800)) {
+		t.Errorf("unexpected breakdown: %v %v", summary.CategoryBreakdown, summary.SubCategoryBreakdown)
+	}
+	if summary.Remaining == nil || !summary.Remaining.Equal(decimal.NewFromInt(-500)) || summary.BudgetState != entities.BudgetStateExceeded {
+		t.Errorf("expected the trip 500 over budget, got %v %s", summary.Remaining, summary.BudgetState)
+	}
+
+	// Detached expenses stay out of the event
+	if err := svc.events.DetachExpense(ctx, "u1", trip.ID, dinner.ID); err != nil {
+		t.Fatalf("DetachExpense failed: %v", err)
+	}
+	if err := svc.events.DetachExpense(ctx, "u1", trip.ID, before.ID); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput detaching an expense outside the event, got %v", err)
+	}
+	list, total, err := svc.expenses.ListExpenses(ctx, "u1", &ExpenseFilter{EventID: trip.ID})
+	if err != nil {
+		t.Fatalf("ListExpenses failed: %v", err)
+	}
+	if total != 2 || len(list) != 2 {
+		t.Errorf("expected 2 expenses left in the trip, got %d", total)
+	}
+
+	if err := svc.events.DeleteEvent(ctx, "u1", trip.ID); err != nil {
+		t.Fatalf("DeleteEvent failed: %v", err)
+	}
+	kept, err := svc.expenses.GetExpense(ctx, "u1", cab.ID)
+	if err != nil {
+		t.Fatalf("expected the cab to outlive the trip: %v", err)
+	}
+	if kept.EventID != "" {
+		t.Errorf("expected the cab to be detached, got event %q", kept.EventID)
+	}
+}
+
+func TestEventAttachesGeneratedAndImportedExpenses(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	trip := &entities.Event{UserID: "u1", Name: "Goa trip", StartDate: date(2024, 5, 1), EndDate: date(2024, 5, 7), AutoAttach: true}
+	if err := svc.events.CreateEvent(ctx, trip); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+
+	parking := newTestRecurringExpense("u1", "200", date(2024, 4, 3), entities.RecurrenceRule{Frequency: entities.Monthly})
+	if err := svc.recurring.CreateRecurringExpense(ctx, parking); err != nil {
+		t.Fatalf("CreateRecurringExpense failed: %v", err)
+	}
+	if _, err := svc.recurring.GenerateDue(ctx, date(2024, 5, 31)); err != nil {
+		t.Fatalf("GenerateDue failed: %v", err)
+	}
+
+	mapping := &entities.ImportMapping{UserID: "u1", Name: "Card", DateColumn: "date", AmountColumn: "amount", DefaultMainCategory: "Self", DefaultSubCategory: "Food"}
+	if err := svc.imports.CreateMapping(ctx, mapping); err != nil {
+		t.Fatalf("CreateMapping failed: %v", err)
+	}
+	csv := "date,amount\n2024-05-05,450\n2024-05-15,90\n"
+	if _, _, err := svc.imports.CommitImport(ctx, "u1", mapping.ID, "may.csv", strings.NewReader(csv)); err != nil {
+		t.Fatalf("CommitImport failed: %v", err)
+	}
+
+	summary, err := svc.events.GetEvent(ctx, "u1", trip.ID)
+	if err != nil {
+		t.Fatalf("GetEvent failed: %v", err)
+	}
+	if summary.ExpenseCount != 2 || !summary.TotalExpenses.Equal(decimal.NewFromInt(650)) {
+		t.Errorf("got %d expenses for %s, want May's parking and the imported 450", summary.ExpenseCount, summary.TotalExpenses)
+	}
+}
+
+func TestEventValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	zero := decimal.Zero
+	tests := []struct {
+		name  string
+		event *entities.Event
+	}{
+		{"missing name", &entities.Event{UserID: "u1", StartDate: date(2024, 5, 1), EndDate: date(2024, 5, 2)}},
+		{"missing dates", &entities.Event{UserID: "u1", Name: "Wedding"}},
+		{"ends before it starts", &entities.Event{UserID: "u1", Name: "Wedding", StartDate: date(2024, 5, 2), EndDate: date(2024, 5, 1)}},
+		{"zero budget", &entities.Event{UserID: "u1", Name: "Wedding", StartDate: date(2024, 5, 1), EndDate: date(2024, 5, 1), Budget: &zero}},
+	}
+	for _, tt := range tests {
+		if err := svc.events.CreateEvent(ctx, tt.event); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+
+	move := &entities.Event{UserID: "u1", Name: "House move", StartDate: date(2024, 6, 1), EndDate: date(2024, 6, 1)}
+	if err := svc.events.CreateEvent(ctx, move); err != nil {
+		t.Fatalf("CreateEvent failed: %v", err)
+	}
+	if _, err := svc.events.GetEvent(ctx, "u2", move.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for another user's event, got %v", err)
+	}
+	expense := newTestExpense("u2", "100", date(2024, 6, 1), entities.Self, entities.Misc)
+	expense.EventID = move.ID
+	if _, err := svc.expenses.AddExpense(ctx, expense); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound adding to another user's event, got %v", err)
+	}
+
+	// Manual-only events don't pick up expenses by date
+	packers := newTestExpense("u1", "9000", date(2024, 6, 1), entities.Self, entities.Misc)
+	if _, err := svc.expenses.AddExpense(ctx, packers); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	if packers.EventID != "" {
+		t.Errorf("expected no event for an expense during a manual-only event, got %q", packers.EventID)
+	}
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+
+	"github.com/shopspring/decimal"
+)
+
+// validateItems checks an itemized expense's line items. The expense's amount
+// defaults to the items' total and its sub-category to the largest item's.
+func validateItems(expense *entities.Expense) error {
+	if len(expense.Items) == 0 {
+		return nil
+	}
+
+	total := decimal.Zero
+	var largest *entities.ExpenseItem
+	for i, item := range expense.Items {
+		if item == nil {
+			return fmt.Errorf("%w: item %d is empty", ErrInvalidInput, i+1)
+		}
+		if !item.Amount.IsPositive() {
+			return fmt.Errorf("%w: item %d amount must be greater than zero", ErrInvalidInput, i+1)
+		}
+		if item.SubCategory == "" {
+			return fmt.Errorf("%w: item %d sub-category is required", ErrInvalidInput, i+1)
+		}
+		total = total.Add(item.Amount)
+		if largest == nil || item.Amount.GreaterThan(largest.Amount) {
+			largest = item
+		}
+	}
+
+	if expense.Amount.IsZero() {
+		expense.Amount = total
+	}
+	if !total.Equal(expense.Amount) {
+		return fmt.Errorf("%w: items add up to %s but the expense amount is %s", ErrInvalidInput, total, expense.Amount)
+	}
+	if expense.SubCategory == "" {
+		expense.SubCategory = largest.SubCategory
+	}
+	return nil
+}
+
+// resolveItemCategories checks each line item's sub-category against the user's
+// own and normalizes its name. Archived sub-categories are rejected unless the
+// expense being updated (existing) already used them.
+func resolveItemCategories(ctx context.Context, categoryService CategoryService, expense *entities.Expense, existing *repositories.Expense) error {
+	for _, item := range expense.Items {
+		sub, err := lookupCategory(ctx, categoryService, expense.UserID, entities.SubCategoryKind, string(item.SubCategory))
+		if err != nil {
+			return err
+		}
+		if sub.Archived && !usesSubCategory(existing, sub.Name) {
+			return fmt.Errorf("%w: sub-category %q is archived", ErrInvalidInput, sub.Name)
+		}
+		item.SubCategory = entities.SubCategory(sub.Name)
+	}
+	return nil
+}
+
+// usesSubCategory reports whether a stored expense or any of its line items is
+// filed under the named sub-category
+func usesSubCategory(expense *repositories.Expense, name string) bool {
+	if expense == nil {
+		return false
+	}
+	if expense.SubCategory == name {
+		return true
+	}
+	for _, item := range expense.Items {
+		if item.SubCategory == name {
+			return true
+		}
+	}
+	return false
+}
+
+// subCategoryAmounts splits an expense's amount across the sub-categories of its
+// line items, or returns it whole under the expense's own sub-category
+func subCategoryAmounts(expense *entities.Expense) map[entities.SubCategory]decimal.Decimal {
+	if len(expense.Items) == 0 {
+		return map[entities.SubCategory]decimal.Decimal{expense.SubCategory: expense.Amount}
+	}
+	amounts := make(map[entities.SubCategory]decimal.Decimal, len(expense.Items))
+	for _, item := range expense.Items {
+		amounts[item.SubCategory] = amounts[item.SubCategory].Add(item.Amount)
+	}
+	return amounts
+}
+
+// convertExpense copies an expense with its amount and line items converted to
+// currency at the expense's own date. The last item absorbs any rounding so the
+// items still add up to the converted amount.
+func convertExpense(ctx context.Context, currencyService CurrencyService, userID string, expense *entities.Expense, currency string) (*entities.Expense, error) {
+	amount, err := currencyService.Convert(ctx, userID, expense.Amount, expense.Currency, currency, expense.Date)
+	if err != nil {
+		return nil, err
+	}
+	converted := *expense
+	converted.Amount = amount
+	if len(expense.Items) == 0 || expense.Currency == currency {
+		return &converted, nil
+	}
+
+	converted.Items = make([]*entities.ExpenseItem, len(expense.Items))
+	remaining := amount
+	for i, item := range expense.Items {
+		convertedItem := *item
+		if i == len(expense.Items)-1 {
+			convertedItem.Amount = remaining
+		} else {
+			if convertedItem.Amount, err = currencyService.Convert(ctx, userID, item.Amount, expense.Currency, currency, expense.Date); err != nil {
+				return nil, err
+			}
+			remaining = remaining.Sub(convertedItem.Amount)
+		}
+		converted.Items[i] = &convertedItem
+	}
+	return &converted, nil
+}
+
+// toRepositoryItems converts expense line items to their repository models
+func toRepositoryItems(items []*entities.ExpenseItem) []*repositories.ExpenseItem {
+	if len(items) == 0 {
+		return nil
+	}
+	stored := make([]*repositories.ExpenseItem, 0, len(items))
+	for _, item := range items {
+		stored = append(stored, &repositories.ExpenseItem{
+			ID:          item.ID,
+			Description: item.Description,
+			Amount:      item.Amount,
+			SubCategory: string(item.SubCategory),
+		})
+	}
+	return stored
+}
+
+// toEntityItems converts repository expense line item models to entities
+func toEntityItems(stored []*repositories.ExpenseItem) []*entities.ExpenseItem {
+	if len(stored) == 0 {
+		return nil
+	}
+	items := make([]*entities.ExpenseItem, 0, len(stored))
+	for _, item := range stored {
+		items = append(items, &entities.ExpenseItem{
+			ID:          item.ID,
+			Description: item.Description,
+			Amount:      item.Amount,
+			SubCategory: entities.SubCategory(item.SubCategory),
+		})
+	}
+	return items
+}
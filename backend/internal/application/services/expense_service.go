@@ -3,71 +3,600 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"log"
 	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"sort"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
 
+const (
+	// DefaultExpensePageSize is used when a list request doesn't specify a limit
+	DefaultExpensePageSize = 50
+
+	// MaxExpensePageSize caps the number of expenses returned by a single list request
+	MaxExpensePageSize = 200
+)
+
 // ExpenseService defines the interface for expense operations
 type ExpenseService interface {
-	AddExpense(ctx context.Context, expense *entities.Expense) error
+	AddExpense(ctx context.Context, expense *entities.Expense) ([]*entities.BudgetWarning, error)
+	GetExpense(ctx context.Context, userID, id string) (*entities.Expense, error)
+	ListExpenses(ctx context.Context, userID string, filter *ExpenseFilter) ([]*entities.Expense, int, error)
 	UpdateExpense(ctx context.Context, id string, expense *entities.Expense) error
-	DeleteExpense(ctx context.Context, id string) error
+	DeleteExpense(ctx context.Context, userID, id string) error
 	GetExpensesByPeriod(ctx context.Context, userID string, start, end time.Time) ([]*entities.Expense, error)
-	GetMonthlyBreakdown(ctx context.Context, userID string, month time.Time) (*entities.MonthlyBreakdown, error)
-	CalculateSavings(ctx context.Context, userID string, month time.Time) (decimal.Decimal, error)
-	ExportData(ctx context.Context, userID string, format string) ([]byte, error)
+	GetMonthlyBreakdown(ctx context.Context, userID string, month time.Time, basis entities.Basis) (*entities.MonthlyBreakdown, error)
+	CalculateSavings(ctx context.Context, userID string, month time.Time, basis entities.Basis) (decimal.Decimal, error)
+	ExportData(ctx context.Context, userID string, w io.Writer, options *ExportOptions) error
+	GetTrends(ctx context.Context, userID string, query *TrendQuery) (*entities.SpendingTrends, error)
+}
+
+// ExpenseFilter represents filters for expense queries
+type ExpenseFilter struct {
+	StartDate    *time.Time // inclusive
+	EndDate      *time.Time // exclusive
+	MainCategory entities.MainCategory
+	SubCategory  entities.SubCategory
+	AccountID    string
+	EventID      string
+	Limit        int
+	Offset       int
 }
 
 // expenseService implements the ExpenseService interface
 type expenseService struct {
-	// Dependencies will be injected here
+	expenseRepo       repositories.ExpenseRepository
+	incomeService     IncomeService
+	categoryService   CategoryService
+	currencyService   CurrencyService
+	budgetService     BudgetService
+	recurringRepo     repositories.RecurringExpenseRepository
+	accountRepo       repositories.AccountRepository
+	reimbursementRepo repositories.ReimbursementRepository
+	eventRepo         repositories.EventRepository
+	goalRepo          repositories.SavingsGoalRepository
 }
 
 // NewExpenseService creates a new expense service
-func NewExpenseService() ExpenseService {
-	return &expenseService{}
+func NewExpenseService(expenseRepo repositories.ExpenseRepository, incomeService IncomeService, categoryService CategoryService, currencyService CurrencyService, budgetService BudgetService, recurringRepo repositories.RecurringExpenseRepository, accountRepo repositories.AccountRepository, reimbursementRepo repositories.ReimbursementRepository, eventRepo repositories.EventRepository, goalRepo repositories.SavingsGoalRepository) ExpenseService {
+	return &expenseService{
+		expenseRepo:       expenseRepo,
+		incomeService:     incomeService,
+		categoryService:   categoryService,
+		currencyService:   currencyService,
+		budgetService:     budgetService,
+		recurringRepo:     recurringRepo,
+		accountRepo:       accountRepo,
+		reimbursementRepo: reimbursementRepo,
+		eventRepo:         eventRepo,
+		goalRepo:          goalRepo,
+	}
+}
+
+// AddExpense adds a new expense and returns a warning for every budget it pushed past 80% or 100%
+func (s *expenseService) AddExpense(ctx context.Context, expense *entities.Expense) ([]*entities.BudgetWarning, error) {
+	if err := validateExpense(expense); err != nil {
+		return nil, err
+	}
+	if err := resolveCategories(ctx, s.categoryService, expense, nil); err != nil {
+		return nil, err
+	}
+	if err := checkAccount(ctx, s.accountRepo, expense.UserID, expense.AccountID, ""); err != nil {
+		return nil, err
+	}
+	if err := attachToEvent(ctx, s.eventRepo, expense); err != nil {
+		return nil, err
+	}
+	section, err := normalizeTaxSection(expense.TaxSection)
+	if err != nil {
+		return nil, err
+	}
+	expense.TaxSection = section
+	currency, err := resolveCurrency(ctx, s.currencyService, expense.UserID, expense.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRate(ctx, s.currencyService, expense.UserID, currency, expense.Date); err != nil {
+		return nil, err
+	}
+	expense.Currency = currency
+
+	now := time.Now()
+	expense.ID = uuid.NewString()
+	for _, item := range expense.Items {
+		item.ID = uuid.NewString()
+	}
+	expense.CreatedAt = now
+	expense.UpdatedAt = now
+
+	if err := s.expenseRepo.Create(ctx, toRepositoryExpense(expense)); err != nil {
+		return nil, fmt.Errorf("failed to save expense: %w", err)
+	}
+
+	// The expense is saved either way, so a failed check only costs the warnings
+	warnings, err := s.budgetService.CheckExpense(ctx, expense)
+	if err != nil {
+		log.Printf("Warning: failed to check budgets for expense %s: %v", expense.ID, err)
+		return nil, nil
+	}
+	return warnings, nil
 }
 
-// AddExpense adds a new expense
-func (s *expenseService) AddExpense(ctx context.Context, expense *entities.Expense) error {
-	// Implementation will be added in a future task
-	return errors.New("not implemented")
+// GetExpense gets a single expense owned by the user
+func (s *expenseService) GetExpense(ctx context.Context, userID, id string) (*entities.Expense, error) {
+	stored, err := getOwnedExpense(ctx, s.expenseRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toEntityExpense(stored), nil
+}
+
+// ListExpenses gets a page of the user's expenses along with the total number of matches.
+// filter.Limit is updated to the page size actually applied.
+func (s *expenseService) ListExpenses(ctx context.Context, userID string, filter *ExpenseFilter) ([]*entities.Expense, int, error) {
+	if filter == nil {
+		filter = &ExpenseFilter{}
+	}
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidInput)
+	}
+	repoFilters, err := s.resolveFilter(ctx, userID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = DefaultExpensePageSize
+	}
+	if filter.Limit > MaxExpensePageSize {
+		filter.Limit = MaxExpensePageSize
+	}
+	repoFilters.Limit = filter.Limit
+	repoFilters.Offset = filter.Offset
+
+	total, err := s.expenseRepo.CountByUserID(ctx, userID, repoFilters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count expenses: %w", err)
+	}
+
+	stored, err := s.expenseRepo.GetByUserID(ctx, userID, repoFilters)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list expenses: %w", err)
+	}
+
+	return toEntityExpenses(stored), total, nil
 }
 
 // UpdateExpense updates an existing expense
 func (s *expenseService) UpdateExpense(ctx context.Context, id string, expense *entities.Expense) error {
-	// Implementation will be added in a future task
-	return errors.New("not implemented")
+	existing, err := getOwnedExpense(ctx, s.expenseRepo, expense.UserID, id)
+	if err != nil {
+		return err
+	}
+	if err := validateExpense(expense); err != nil {
+		return err
+	}
+	if err := resolveCategories(ctx, s.categoryService, expense, existing); err != nil {
+		return err
+	}
+	if err := checkAccount(ctx, s.accountRepo, expense.UserID, expense.AccountID, existing.AccountID); err != nil {
+		return err
+	}
+	if expense.EventID != "" {
+		if _, err := getOwnedEvent(ctx, s.eventRepo, expense.UserID, expense.EventID); err != nil {
+			return err
+		}
+	}
+	if expense.TaxSection, err = normalizeTaxSection(expense.TaxSection); err != nil {
+		return err
+	}
+	currency, err := resolveCurrency(ctx, s.currencyService, expense.UserID, expense.Currency)
+	if err != nil {
+		return err
+	}
+	if err := requireRate(ctx, s.currencyService, expense.UserID, currency, expense.Date); err != nil {
+		return err
+	}
+	expense.Currency = currency
+
+	expense.ID = id
+	if err := checkGoalContributions(ctx, s.goalRepo, s.categoryService, expense); err != nil {
+		return err
+	}
+	if err := checkReimbursements(ctx, s.reimbursementRepo, s.currencyService, expense); err != nil {
+		return err
+	}
+	for _, item := range expense.Items {
+		item.ID = uuid.NewString()
+	}
+	expense.ImportBatchID = existing.ImportBatchID
+	expense.CreatedAt = existing.CreatedAt
+	expense.UpdatedAt = time.Now()
+
+	if err := s.expenseRepo.Update(ctx, toRepositoryExpense(expense)); err != nil {
+		return fmt.Errorf("failed to update expense: %w", err)
+	}
+	return nil
 }
 
 // DeleteExpense deletes an expense
-func (s *expenseService) DeleteExpense(ctx context.Context, id string) error {
-	// Implementation will be added in a future task
-	return errors.New("not implemented")
+func (s *expenseService) DeleteExpense(ctx context.Context, userID, id string) error {
+	if _, err := getOwnedExpense(ctx, s.expenseRepo, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.expenseRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete expense: %w", err)
+	}
+	return nil
 }
 
 // GetExpensesByPeriod gets expenses for a specific period
 func (s *expenseService) GetExpensesByPeriod(ctx context.Context, userID string, start, end time.Time) ([]*entities.Expense, error) {
-	// Implementation will be added in a future task
-	return nil, errors.New("not implemented")
+	stored, err := s.expenseRepo.GetByUserIDAndDateRange(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+	return toEntityExpenses(stored), nil
+}
+
+// GetMonthlyBreakdown gets a monthly breakdown of income, expenses and savings,
+// compared against the previous month on the same basis. The current month also
+// projects the recurring expenses still to come.
+func (s *expenseService) GetMonthlyBreakdown(ctx context.Context, userID string, month time.Time, basis entities.Basis) (*entities.MonthlyBreakdown, error) {
+	month = startOfMonth(month)
+	basis, err := normalizeBasis(basis)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.computeBreakdown(ctx, userID, month, basis)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.computeBreakdown(ctx, userID, month.AddDate(0, -1, 0), basis)
+	if err != nil {
+		return nil, err
+	}
+
+	current.Comparison = compareBreakdowns(current, previous)
+	if month.Equal(startOfMonth(time.Now())) {
+		if current.Projection, err = s.projectRecurring(ctx, userID, current); err != nil {
+			return nil, err
+		}
+	}
+	return current, nil
+}
+
+// CalculateSavings calculates savings for a month as total income minus total expenses
+func (s *expenseService) CalculateSavings(ctx context.Context, userID string, month time.Time, basis entities.Basis) (decimal.Decimal, error) {
+	basis, err := normalizeBasis(basis)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	breakdown, err := s.computeBreakdown(ctx, userID, startOfMonth(month), basis)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return breakdown.Savings, nil
+}
+
+// computeBreakdown builds the breakdown for a single month starting at month
+func (s *expenseService) computeBreakdown(ctx context.Context, userID string, month time.Time, basis entities.Basis) (*entities.MonthlyBreakdown, error) {
+	income, err := s.incomeService.GetMonthlySummary(ctx, userID, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get income: %w", err)
+	}
+
+	expenses, err := s.GetExpensesByPeriod(ctx, userID, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+	if basis == entities.AmortizedBasis {
+		if expenses, err = s.amortizedExpenses(ctx, userID, month, expenses); err != nil {
+			return nil, err
+		}
+	}
+
+	// Totals are in the base currency, converted at each expense's own date
+	converted := make([]*entities.Expense, 0, len(expenses))
+	original := make(map[string]decimal.Decimal)
+	for _, expense := range expenses {
+		original[expense.Currency] = original[expense.Currency].Add(expense.Amount)
+
+		inBase, err := convertExpense(ctx, s.currencyService, userID, expense, income.Currency)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, inBase)
+	}
+
+	breakdown := buildBreakdown(month, income.Total, converted)
+	breakdown.Currency = income.Currency
+	breakdown.Basis = basis
+	breakdown.OriginalAmounts = original
+
+	// Reimbursements count against the month of the expense they pay back
+	since := month
+	if basis == entities.AmortizedBasis {
+		since = month.AddDate(0, 1-MaxAmortizeMonths, 0)
+	}
+	reimbursements, err := s.reimbursementRepo.GetByExpenseDateRange(ctx, userID, since, month.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reimbursements: %w", err)
+	}
+	if breakdown.Reimbursed, err = reimbursedIn(ctx, s.currencyService, userID, month, basis, expenses, reimbursements, income.Currency); err != nil {
+		return nil, err
+	}
+	breakdown.NetExpenses = breakdown.TotalExpenses.Sub(breakdown.Reimbursed)
+	breakdown.NetSavings = breakdown.TotalIncome.Sub(breakdown.NetExpenses)
+	return breakdown, nil
+}
+
+// projectRecurring works out what the recurring expenses not yet generated
+// this month will add to the breakdown
+func (s *expenseService) projectRecurring(ctx context.Context, userID string, breakdown *entities.MonthlyBreakdown) (*entities.RecurringProjection, error) {
+	stored, err := s.recurringRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring expenses: %w", err)
+	}
+
+	end := breakdown.Month.AddDate(0, 1, 0)
+	projection := &entities.RecurringProjection{Upcoming: []*entities.RecurringOccurrence{}}
+	for _, recurring := range stored {
+		if recurring.Paused || recurring.NextDate == nil || !recurring.NextDate.Before(end) {
+			continue
+		}
+		from := *recurring.NextDate
+		if from.Before(breakdown.Month) {
+			from = breakdown.Month
+		}
+		occurrences, err := recurringOccurrences(ctx, s.recurringRepo, toEntityRecurringExpense(recurring), from, end)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, occurrence := range occurrences {
+			if occurrence.Skipped || occurrence.ExpenseID != "" {
+				continue
+			}
+			amount, err := s.currencyService.Convert(ctx, userID, occurrence.Amount, occurrence.Currency, breakdown.Currency, occurrence.Date)
+			if err != nil {
+				return nil, err
+			}
+			projection.Upcoming = append(projection.Upcoming, occurrence)
+			projection.Total = projection.Total.Add(amount)
+		}
+	}
+
+	sort.SliceStable(projection.Upcoming, func(i, j int) bool {
+		return projection.Upcoming[i].Date.Before(projection.Upcoming[j].Date)
+	})
+	projection.Expenses = breakdown.TotalExpenses.Add(projection.Total)
+	projection.Savings = breakdown.TotalIncome.Sub(projection.Expenses)
+	return projection, nil
 }
 
-// GetMonthlyBreakdown gets a monthly breakdown of expenses
-func (s *expenseService) GetMonthlyBreakdown(ctx context.Context, userID string, month time.Time) (*entities.MonthlyBreakdown, error) {
-	// Implementation will be added in a future task
-	return nil, errors.New("not implemented")
+// ExportData streams the user's expenses matching options to w, oldest first.
+// Expenses are read a page at a time so large histories are never held in
+// memory, and invalid options are rejected before anything is written.
+func (s *expenseService) ExportData(ctx context.Context, userID string, w io.Writer, options *ExportOptions) error {
+	if options == nil {
+		options = &ExportOptions{}
+	}
+	filter := options.Filter
+	if filter == nil {
+		filter = &ExpenseFilter{}
+	}
+	repoFilters, err := s.resolveFilter(ctx, userID, filter)
+	if err != nil {
+		return err
+	}
+
+	exporter, err := s.newExporter(ctx, userID, w, options, repoFilters)
+	if err != nil {
+		return err
+	}
+	if err := exporter.begin(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	repoFilters.OldestFirst = true
+	repoFilters.Limit = exportPageSize
+	for {
+		page, err := s.expenseRepo.GetByUserID(ctx, userID, repoFilters)
+		if err != nil {
+			return fmt.Errorf("failed to list expenses: %w", err)
+		}
+		for _, stored := range page {
+			if err := exporter.write(toEntityExpense(stored)); err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+		}
+		if err := exporter.flush(); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		if len(page) < exportPageSize {
+			break
+		}
+		repoFilters.Offset += exportPageSize
+	}
+
+	if err := exporter.end(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
 }
 
-// CalculateSavings calculates savings for a month
-func (s *expenseService) CalculateSavings(ctx context.Context, userID string, month time.Time) (decimal.Decimal, error) {
-	// Implementation will be added in a future task
-	return decimal.Zero, errors.New("not implemented")
+// resolveFilter normalizes the filter's category names and checks its date
+// range, returning the matching repository filters without paging
+func (s *expenseService) resolveFilter(ctx context.Context, userID string, filter *ExpenseFilter) (*repositories.ExpenseFilters, error) {
+	if filter.MainCategory != "" {
+		category, err := lookupCategory(ctx, s.categoryService, userID, entities.MainCategoryKind, string(filter.MainCategory))
+		if err != nil {
+			return nil, err
+		}
+		filter.MainCategory = entities.MainCategory(category.Name)
+	}
+	if filter.SubCategory != "" {
+		category, err := lookupCategory(ctx, s.categoryService, userID, entities.SubCategoryKind, string(filter.SubCategory))
+		if err != nil {
+			return nil, err
+		}
+		filter.SubCategory = entities.SubCategory(category.Name)
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && !filter.StartDate.Before(*filter.EndDate) {
+		return nil, fmt.Errorf("%w: start date must be before end date", ErrInvalidInput)
+	}
+	if filter.AccountID != "" {
+		if _, err := getOwnedAccount(ctx, s.accountRepo, userID, filter.AccountID); err != nil {
+			return nil, err
+		}
+	}
+	if filter.EventID != "" {
+		if _, err := getOwnedEvent(ctx, s.eventRepo, userID, filter.EventID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &repositories.ExpenseFilters{
+		StartDate:    filter.StartDate,
+		EndDate:      filter.EndDate,
+		MainCategory: string(filter.MainCategory),
+		SubCategory:  string(filter.SubCategory),
+		AccountID:    filter.AccountID,
+		EventID:      filter.EventID,
+	}, nil
 }
 
-// ExportData exports expense data in the specified format
-func (s *expenseService) ExportData(ctx context.Context, userID string, format string) ([]byte, error) {
-	// Implementation will be added in a future task
-	return nil, errors.New("not implemented")
-}
\ No newline at end of file
+// getOwnedExpense loads an expense and hides it unless it belongs to userID
+func getOwnedExpense(ctx context.Context, expenseRepo repositories.ExpenseRepository, userID, id string) (*repositories.Expense, error) {
+	stored, err := expenseRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("expense %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense: %w", err)
+	}
+	if stored.UserID != userID {
+		return nil, fmt.Errorf("expense %s: %w", id, ErrNotFound)
+	}
+	return stored, nil
+}
+
+// resolveCategories checks the expense's categories against the user's own and
+// normalizes their names. Archived categories are rejected unless the expense
+// being updated (existing) was already filed under them.
+func resolveCategories(ctx context.Context, categoryService CategoryService, expense *entities.Expense, existing *repositories.Expense) error {
+	main, err := lookupCategory(ctx, categoryService, expense.UserID, entities.MainCategoryKind, string(expense.MainCategory))
+	if err != nil {
+		return err
+	}
+	if main.Archived && (existing == nil || existing.MainCategory != main.Name) {
+		return fmt.Errorf("%w: main category %q is archived", ErrInvalidInput, main.Name)
+	}
+
+	sub, err := lookupCategory(ctx, categoryService, expense.UserID, entities.SubCategoryKind, string(expense.SubCategory))
+	if err != nil {
+		return err
+	}
+	if sub.Archived && (existing == nil || existing.SubCategory != sub.Name) {
+		return fmt.Errorf("%w: sub-category %q is archived", ErrInvalidInput, sub.Name)
+	}
+
+	expense.MainCategory = entities.MainCategory(main.Name)
+	expense.SubCategory = entities.SubCategory(sub.Name)
+	return resolveItemCategories(ctx, categoryService, expense, existing)
+}
+
+// validateExpense checks the fields required for every stored expense
+func validateExpense(expense *entities.Expense) error {
+	if expense.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	if err := validateItems(expense); err != nil {
+		return err
+	}
+	if err := validateAmortization(expense); err != nil {
+		return err
+	}
+	if err := validateReimbursable(expense); err != nil {
+		return err
+	}
+	if !expense.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidInput)
+	}
+	if expense.Date.IsZero() {
+		return fmt.Errorf("%w: date is required", ErrInvalidInput)
+	}
+	if expense.MainCategory == "" {
+		return fmt.Errorf("%w: main category is required", ErrInvalidInput)
+	}
+	if expense.SubCategory == "" {
+		return fmt.Errorf("%w: sub-category is required", ErrInvalidInput)
+	}
+	return nil
+}
+
+// toRepositoryExpense converts an expense entity to its repository model
+func toRepositoryExpense(expense *entities.Expense) *repositories.Expense {
+	return &repositories.Expense{
+		ID:             expense.ID,
+		UserID:         expense.UserID,
+		Amount:         expense.Amount,
+		Currency:       expense.Currency,
+		Description:    expense.Description,
+		Date:           expense.Date,
+		MainCategory:   string(expense.MainCategory),
+		SubCategory:    string(expense.SubCategory),
+		AccountID:      expense.AccountID,
+		TaxSection:     string(expense.TaxSection),
+		Items:          toRepositoryItems(expense.Items),
+		AmortizeMonths: expense.AmortizeMonths,
+		Reimbursable:   expense.Reimbursable,
+		ExpectedPayer:  expense.ExpectedPayer,
+		EventID:        expense.EventID,
+		ImportBatchID:  expense.ImportBatchID,
+		CreatedAt:      expense.CreatedAt,
+		UpdatedAt:      expense.UpdatedAt,
+	}
+}
+
+// toEntityExpense converts a repository expense model to an entity
+func toEntityExpense(expense *repositories.Expense) *entities.Expense {
+	return &entities.Expense{
+		ID:             expense.ID,
+		UserID:         expense.UserID,
+		Amount:         expense.Amount,
+		Currency:       expense.Currency,
+		Description:    expense.Description,
+		Date:           expense.Date,
+		MainCategory:   entities.MainCategory(expense.MainCategory),
+		SubCategory:    entities.SubCategory(expense.SubCategory),
+		AccountID:      expense.AccountID,
+		TaxSection:     entities.TaxSection(expense.TaxSection),
+		Items:          toEntityItems(expense.Items),
+		AmortizeMonths: expense.AmortizeMonths,
+		Reimbursable:   expense.Reimbursable,
+		ExpectedPayer:  expense.ExpectedPayer,
+		EventID:        expense.EventID,
+		ImportBatchID:  expense.ImportBatchID,
+		CreatedAt:      expense.CreatedAt,
+		UpdatedAt:      expense.UpdatedAt,
+	}
+}
+
+// toEntityExpenses converts a slice of repository expense models to entities
+func toEntityExpenses(stored []*repositories.Expense) []*entities.Expense {
+	expenses := make([]*entities.Expense, 0, len(stored))
+	for _, expense := range stored {
+		expenses = append(expenses, toEntityExpense(expense))
+	}
+	return expenses
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/infrastructure/repositories/sqlite"
+	"nestmate-backend/internal/infrastructure/secrets"
+)
+
+// testServices wires every service over a single in-memory database
+type testServices struct {
+	settings       SettingsService
+	currency       CurrencyService
+	incomes        IncomeService
+	categories     CategoryService
+	budgets        BudgetService
+	expenses       ExpenseService
+	imports        ImportService
+	households     HouseholdService
+	recurring      RecurringExpenseService
+	goals          SavingsGoalService
+	accounts       AccountService
+	fiscal         FiscalYearService
+	reimbursements ReimbursementService
+	events         EventService
+	subscriptions  SubscriptionService
+	rules          CategorizationRuleService
+	statements     PDFParserService
+	reviews        StatementImportService
+	hints          PasswordHintService
+}
+
+func newTestServices(t *testing.T) *testServices {
+	t.Helper()
+	db, err := sqlite.Open(context.Background(), ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	svc := &testServices{}
+	expenseRepo := sqlite.NewExpenseRepository(db)
+	recurringRepo := sqlite.NewRecurringExpenseRepository(db)
+	accountRepo := sqlite.NewAccountRepository(db)
+	reimbursementRepo := sqlite.NewReimbursementRepository(db)
+	eventRepo := sqlite.NewEventRepository(db)
+	ruleRepo := sqlite.NewCategorizationRuleRepository(db)
+	importRepo := sqlite.NewImportRepository(db)
+	correctionRepo := sqlite.NewCategoryCorrectionRepository(db)
+	svc.settings = NewSettingsService(sqlite.NewUserSettingsRepository(db))
+	svc.currency = NewCurrencyService(sqlite.NewExchangeRateRepository(db), svc.settings)
+	svc.incomes = NewIncomeService(sqlite.NewIncomeRepository(db), svc.currency, accountRepo)
+	svc.categories = NewCategoryService(sqlite.NewCategoryRepository(db))
+	svc.budgets = NewBudgetService(sqlite.NewBudgetRepository(db), expenseRepo, svc.categories, svc.currency)
+	goalRepo := sqlite.NewSavingsGoalRepository(db)
+	svc.expenses = NewExpenseService(expenseRepo, svc.incomes, svc.categories, svc.currency, svc.budgets, recurringRepo, accountRepo, reimbursementRepo, eventRepo, goalRepo)
+	svc.imports = NewImportService(importRepo, eventRepo, svc.categories, svc.currency)
+	svc.households = NewHouseholdService(sqlite.NewHouseholdRepository(db), expenseRepo, svc.currency)
+	svc.recurring = NewRecurringExpenseService(recurringRepo, eventRepo, svc.categories, svc.currency)
+	svc.goals = NewSavingsGoalService(goalRepo, svc.expenses, svc.categories, svc.currency)
+	svc.accounts = NewAccountService(accountRepo, expenseRepo, svc.incomes, svc.currency)
+	svc.fiscal = NewFiscalYearService(svc.expenses, svc.incomes, svc.settings, svc.currency)
+	svc.reimbursements = NewReimbursementService(reimbursementRepo, expenseRepo, svc.currency)
+	svc.events = NewEventService(eventRepo, expenseRepo, svc.currency)
+	svc.subscriptions = NewSubscriptionService(svc.expenses, recurringRepo, svc.recurring, svc.currency)
+	svc.rules = NewCategorizationRuleService(ruleRepo, expenseRepo, accountRepo, svc.categories)
+	svc.statements = NewPDFParserService(DefaultBankParsers(), ruleRepo, expenseRepo, correctionRepo, svc.categories)
+	svc.reviews = NewStatementImportService(importRepo, accountRepo, eventRepo, svc.statements, svc.categories, svc.currency)
+	box, err := secrets.NewBox("test-key")
+	if err != nil {
+		t.Fatalf("failed to create secrets box: %v", err)
+	}
+	svc.hints = NewPasswordHintService(sqlite.NewPasswordHintRepository(db), DefaultBankParsers(), box)
+	return svc
+}
+
+func newTestExpenseService(t *testing.T) ExpenseService {
+	t.Helper()
+	return newTestServices(t).expenses
+}
+
+func newTestExpense(userID, amount string, date time.Time, main entities.MainCategory, sub entities.SubCategory) *entities.Expense {
+	return &entities.Expense{
+		UserID:       userID,
+		Amount:       decimal.RequireFromString(amount),
+		Description:  "test",
+		Date:         date,
+		MainCategory: main,
+		SubCategory:  sub,
+	}
+}
+
+func TestAddExpenseValidation(t *testing.T) {
+	svc := newTestExpenseService(t)
+	ctx := context.Background()
+	date := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]*entities.Expense{
+		"zero amount":     newTestExpense("u1", "0", date, entities.Self, entities.Food),
+		"negative amount": newTestExpense("u1", "-5", date, entities.Self, entities.Food),
+		"unknown main":    newTestExpense("u1", "5", date, "Mumbai House", entities.Food),
+		"unknown sub":     newTestExpense("u1", "5", date, entities.Self, "Gadgets"),
+		"missing date":    newTestExpense("u1", "5", time.Time{}, entities.Self, entities.Food),
+		"missing user":    newTestExpense("", "5", date, entities.Self, entities.Food),
+	}
+	for name, expense := range cases {
+		if _, err := svc.AddExpense(ctx, expense); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+
+	valid := newTestExpense("u1", "12.50", date, entities.Self, entities.Food)
+	if _, err := svc.AddExpense(ctx, valid); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	if valid.ID == "" {
+		t.Error("AddExpense should assign an ID")
+	}
+}
+
+func TestExpenseOwnership(t *testing.T) {
+	svc := newTestExpenseService(t)
+	ctx := context.Background()
+
+	expense := newTestExpense("owner", "100", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), entities.Self, entities.Food)
+	if _, err := svc.AddExpense(ctx, expense); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+
+	if _, err := svc.GetExpense(ctx, "intruder", expense.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound reading another user's expense, got %v", err)
+	}
+
+	update := newTestExpense("intruder", "1", expense.Date, entities.Self, entities.Food)
+	if err := svc.UpdateExpense(ctx, expense.ID, update); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound updating another user's expense, got %v", err)
+	}
+
+	if err := svc.DeleteExpense(ctx, "intruder", expense.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting another user's expense, got %v", err)
+	}
+
+	got, err := svc.GetExpense(ctx, "owner", expense.ID)
+	if err != nil {
+		t.Fatalf("GetExpense failed: %v", err)
+	}
+	if !got.Amount.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("expense was modified by another user: %s", got.Amount)
+	}
+}
+
+func TestListExpensesFiltersAndPaginates(t *testing.T) {
+	svc := newTestExpenseService(t)
+	ctx := context.Background()
+
+	for day := 1; day <= 5; day++ {
+		sub := entities.Food
+		if day%2 == 0 {
+			sub = entities.Travel
+		}
+		expense := newTestExpense("u1", "10", time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC), entities.Self, sub)
+		if _, err := svc.AddExpense(ctx, expense); err != nil {
+			t.Fatalf("AddExpense failed: %v", err)
+		}
+	}
+
+	expenses, total, err := svc.ListExpenses(ctx, "u1", &ExpenseFilter{SubCategory: entities.Food, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListExpenses failed: %v", err)
+	}
+	if total != 3 || len(expenses) != 2 {
+		t.Fatalf("expected 2 of 3 food expenses, got %d of %d", len(expenses), total)
+	}
+	if expenses[0].Date.Day() != 5 {
+		t.Errorf("expected most recent expense first, got day %d", expenses[0].Date.Day())
+	}
+
+	start := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	_, total, err = svc.ListExpenses(ctx, "u1", &ExpenseFilter{StartDate: &start, EndDate: &end})
+	if err != nil {
+		t.Fatalf("ListExpenses failed: %v", err)
+	}
+	if total != 2 {
+		t.Errorf("expected 2 expenses between the 2nd and 4th, got %d", total)
+	}
+
+	if _, _, err := svc.ListExpenses(ctx, "u1", &ExpenseFilter{MainCategory: "Nowhere"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for unknown category filter, got %v", err)
+	}
+}
+
+func TestItemizedExpense(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	march := month(2024, 3)
+
+	expense := newTestExpense("u1", "1500", date(2024, 3, 10), entities.Self, "")
+	expense.Items = []*entities.ExpenseItem{
+		{Description: "Vegetables", Amount: decimal.NewFromInt(900), SubCategory: "food"},
+		{Description: "Soap", Amount: decimal.NewFromInt(200), SubCategory: entities.Misc},
+		{Description: "Notebooks", Amount: decimal.NewFromInt(300), SubCategory: entities.Education},
+	}
+	if _, err := svc.expenses.AddExpense(ctx, expense); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput when items don't add up, got %v", err)
+	}
+
+	expense.Items[2].Amount = decimal.NewFromInt(400)
+	if _, err := svc.expenses.AddExpense(ctx, expense); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	if expense.SubCategory != entities.Food || expense.Items[0].SubCategory != entities.Food || expense.Items[0].ID == "" {
+		t.Errorf("expected categories to be normalized and defaulted, got %+v", expense)
+	}
+	if _, err := svc.expenses.AddExpense(ctx, newTestExpense("u1", "100", date(2024, 3, 11), entities.Self, entities.Food)); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+
+	breakdown, err := svc.expenses.GetMonthlyBreakdown(ctx, "u1", march, entities.CashBasis)
+	if err != nil {
+		t.Fatalf("GetMonthlyBreakdown failed: %v", err)
+	}
+	want := map[entities.SubCategory]int64{entities.Food: 1000, entities.Misc: 200, entities.Education: 400}
+	for sub, amount := range want {
+		if got := breakdown.SubCategoryBreakdown[sub]; !got.Equal(decimal.NewFromInt(amount)) {
+			t.Errorf("%s = %s, want %d", sub, got, amount)
+		}
+		if got := breakdown.CategoryCrossTab[entities.Self][sub]; !got.Equal(decimal.NewFromInt(amount)) {
+			t.Errorf("Self/%s = %s, want %d", sub, got, amount)
+		}
+	}
+	if !breakdown.TotalExpenses.Equal(decimal.NewFromInt(1600)) {
+		t.Errorf("total expenses = %s, want 1600", breakdown.TotalExpenses)
+	}
+
+	budget := &entities.Budget{UserID: "u1", Kind: entities.SubCategoryKind, Category: "Education", Month: march, Amount: decimal.NewFromInt(1000)}
+	if err := svc.budgets.CreateBudget(ctx, budget); err != nil {
+		t.Fatalf("CreateBudget failed: %v", err)
+	}
+	report, err := svc.budgets.GetBudgetReport(ctx, "u1", march)
+	if err != nil {
+		t.Fatalf("GetBudgetReport failed: %v", err)
+	}
+	if !report.Budgets[0].Spent.Equal(decimal.NewFromInt(400)) {
+		t.Errorf("budget should count only the Education item, spent %s", report.Budgets[0].Spent)
+	}
+
+	update := newTestExpense("u1", "0", date(2024, 3, 10), entities.Self, entities.Misc)
+	update.Items = []*entities.ExpenseItem{{Amount: decimal.NewFromInt(50), SubCategory: entities.Misc}}
+	if err := svc.expenses.UpdateExpense(ctx, expense.ID, update); err != nil {
+		t.Fatalf("UpdateExpense failed: %v", err)
+	}
+	stored, err := svc.expenses.GetExpense(ctx, "u1", expense.ID)
+	if err != nil {
+		t.Fatalf("GetExpense failed: %v", err)
+	}
+	if !stored.Amount.Equal(decimal.NewFromInt(50)) || len(stored.Items) != 1 {
+		t.Errorf("expected the amount to default to the items' total and items to be replaced, got %+v", stored)
+	}
+}
+
+func TestConvertExpenseKeepsItemsBalanced(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	rate := &entities.ExchangeRate{UserID: "u1", FromCurrency: "INR", ToCurrency: "USD", Date: date(2024, 1, 1), Rate: decimal.RequireFromString("0.0123456")}
+	if err := svc.currency.SetRate(ctx, rate); err != nil {
+		t.Fatalf("SetRate failed: %v", err)
+	}
+
+	expense := newTestExpense("u1", "100", date(2024, 3, 10), entities.Self, entities.Food)
+	expense.Currency = "INR"
+	expense.Items = []*entities.ExpenseItem{
+		{Amount: decimal.RequireFromString("33.33"), SubCategory: entities.Food},
+		{Amount: decimal.RequireFromString("33.33"), SubCategory: entities.Misc},
+		{Amount: decimal.RequireFromString("33.34"), SubCategory: entities.Education},
+	}
+	converted, err := convertExpense(ctx, svc.currency, "u1", expense, "USD")
+	if err != nil {
+		t.Fatalf("convertExpense failed: %v", err)
+	}
+	total := decimal.Zero
+	for _, item := range converted.Items {
+		total = total.Add(item.Amount)
+	}
+	if !total.Equal(converted.Amount) {
+		t.Errorf("converted items add up to %s, want %s", total, converted.Amount)
+	}
+	if !expense.Items[0].Amount.Equal(decimal.RequireFromString("33.33")) {
+		t.Error("convertExpense must not modify the original items")
+	}
+}
@@ -0,0 +1,381 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+)
+
+// ExportFormat is a file format expenses can be exported to
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "csv"
+	ExportJSON ExportFormat = "json"
+	ExportOFX  ExportFormat = "ofx"
+)
+
+// ExportColumns lists every column that can be chosen for CSV and JSON exports
+var ExportColumns = []string{"id", "date", "description", "amount", "currency", "main_category", "sub_category", "tax_section", "created_at", "updated_at"}
+
+// defaultExportColumns are exported when no columns are chosen
+var defaultExportColumns = []string{"date", "description", "amount", "currency", "main_category", "sub_category"}
+
+const (
+	// exportPageSize is the number of expenses read from the database at a time
+	exportPageSize = 500
+
+	// ofxRatePrecision is the number of decimal places kept for OFX exchange rates
+	ofxRatePrecision = 6
+
+	// ofxNameLength is the longest payee name OFX allows
+	ofxNameLength = 32
+)
+
+// ExportOptions selects what ExportData writes
+type ExportOptions struct {
+	Format  ExportFormat   // defaults to CSV
+	Filter  *ExpenseFilter // Limit and Offset are ignored
+	Columns []string       // CSV and JSON only, defaults to date, description, amount, currency and both categories
+}
+
+// expenseExporter writes expenses in one file format. Nothing reaches the
+// underlying writer before begin, and flush pushes out everything written so far.
+type expenseExporter interface {
+	begin() error
+	write(expense *entities.Expense) error
+	flush() error
+	end() error
+}
+
+// newExporter validates the export options and builds the exporter for their format
+func (s *expenseService) newExporter(ctx context.Context, userID string, w io.Writer, options *ExportOptions, filters *repositories.ExpenseFilters) (expenseExporter, error) {
+	format := ExportFormat(strings.ToLower(string(options.Format)))
+	switch format {
+	case ExportCSV, "":
+		columns, err := exportColumns(options.Columns)
+		if err != nil {
+			return nil, err
+		}
+		return &csvExporter{w: csv.NewWriter(w), columns: columns}, nil
+	case ExportJSON:
+		columns, err := exportColumns(options.Columns)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonExporter{w: bufio.NewWriter(w), columns: columns}, nil
+	case ExportOFX:
+		if len(options.Columns) > 0 {
+			return nil, fmt.Errorf("%w: columns can't be chosen for OFX exports", ErrInvalidInput)
+		}
+		currency, err := s.currencyService.BaseCurrency(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		start, end, err := s.exportPeriod(ctx, userID, filters)
+		if err != nil {
+			return nil, err
+		}
+		return &ofxExporter{
+			w:        bufio.NewWriter(w),
+			userID:   userID,
+			currency: currency,
+			from:     start,
+			to:       end,
+			convert: func(expense *entities.Expense) (decimal.Decimal, error) {
+				return s.currencyService.Convert(ctx, userID, expense.Amount, expense.Currency, currency, expense.Date)
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported export format %q", ErrInvalidInput, options.Format)
+	}
+}
+
+// exportPeriod finds the first and last day covered by an export, taken from
+// the filter where it is bounded and from the matching expenses otherwise
+func (s *expenseService) exportPeriod(ctx context.Context, userID string, filters *repositories.ExpenseFilters) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	start, end := now, now
+
+	if filters.StartDate != nil {
+		start = *filters.StartDate
+	} else {
+		bounds := *filters
+		bounds.OldestFirst, bounds.Limit = true, 1
+		first, err := s.expenseRepo.GetByUserID(ctx, userID, &bounds)
+		if err != nil {
+			return start, end, fmt.Errorf("failed to list expenses: %w", err)
+		}
+		if len(first) > 0 {
+			start = first[0].Date
+		}
+	}
+
+	if filters.EndDate != nil {
+		end = filters.EndDate.AddDate(0, 0, -1)
+	} else {
+		bounds := *filters
+		bounds.OldestFirst, bounds.Limit = false, 1
+		last, err := s.expenseRepo.GetByUserID(ctx, userID, &bounds)
+		if err != nil {
+			return start, end, fmt.Errorf("failed to list expenses: %w", err)
+		}
+		if len(last) > 0 {
+			end = last[0].Date
+		}
+	}
+	return start, end, nil
+}
+
+// exportColumns normalizes the chosen columns, rejecting unknown and repeated ones
+func exportColumns(columns []string) ([]string, error) {
+	if len(columns) == 0 {
+		return defaultExportColumns, nil
+	}
+
+	normalized := make([]string, 0, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		column = strings.ToLower(strings.TrimSpace(column))
+		known := false
+		for _, candidate := range ExportColumns {
+			if column == candidate {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: unknown export column %q", ErrInvalidInput, column)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("%w: export column %q is repeated", ErrInvalidInput, column)
+		}
+		seen[column] = true
+		normalized = append(normalized, column)
+	}
+	return normalized, nil
+}
+
+// exportValue formats one column of an expense
+func exportValue(expense *entities.Expense, column string) string {
+	switch column {
+	case "id":
+		return expense.ID
+	case "date":
+		return expense.Date.Format("2006-01-02")
+	case "description":
+		return expense.Description
+	case "amount":
+		return formatExportAmount(expense.Amount)
+	case "currency":
+		return expense.Currency
+	case "main_category":
+		return string(expense.MainCategory)
+	case "sub_category":
+		return string(expense.SubCategory)
+	case "tax_section":
+		return string(expense.TaxSection)
+	case "created_at":
+		return expense.CreatedAt.Format(time.RFC3339)
+	case "updated_at":
+		return expense.UpdatedAt.Format(time.RFC3339)
+	}
+	return ""
+}
+
+// formatExportAmount writes an amount with at least two decimal places and
+// never rounds away digits that were actually stored
+func formatExportAmount(amount decimal.Decimal) string {
+	if amount.Exponent() >= -2 {
+		return amount.StringFixed(2)
+	}
+	return amount.String()
+}
+
+// csvExporter writes a header row followed by one row per expense
+type csvExporter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (e *csvExporter) begin() error {
+	return e.w.Write(e.columns)
+}
+
+func (e *csvExporter) write(expense *entities.Expense) error {
+	record := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		record[i] = csvCell(exportValue(expense, column))
+	}
+	return e.w.Write(record)
+}
+
+// csvCell neutralizes values a spreadsheet would run as a formula by
+// prefixing them with a quote, which spreadsheets show as plain text
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (e *csvExporter) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExporter) end() error {
+	return e.flush()
+}
+
+// jsonExporter writes an array of objects keyed by column. Every value,
+// amounts included, is a string so decimals survive JSON number parsing.
+type jsonExporter struct {
+	w       *bufio.Writer
+	columns []string
+	written int
+}
+
+func (e *jsonExporter) begin() error {
+	_, err := e.w.WriteString("[")
+	return err
+}
+
+func (e *jsonExporter) write(expense *entities.Expense) error {
+	if e.written > 0 {
+		if _, err := e.w.WriteString(","); err != nil {
+			return err
+		}
+	}
+	if _, err := e.w.WriteString("\n{"); err != nil {
+		return err
+	}
+	for i, column := range e.columns {
+		if i > 0 {
+			if _, err := e.w.WriteString(","); err != nil {
+				return err
+			}
+		}
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(exportValue(expense, column))
+		if err != nil {
+			return err
+		}
+		if _, err := e.w.Write(key); err != nil {
+			return err
+		}
+		if _, err := e.w.WriteString(":"); err != nil {
+			return err
+		}
+		if _, err := e.w.Write(value); err != nil {
+			return err
+		}
+	}
+	if _, err := e.w.WriteString("}"); err != nil {
+		return err
+	}
+	e.written++
+	return nil
+}
+
+func (e *jsonExporter) flush() error {
+	return e.w.Flush()
+}
+
+func (e *jsonExporter) end() error {
+	if e.written > 0 {
+		if _, err := e.w.WriteString("\n"); err != nil {
+			return err
+		}
+	}
+	if _, err := e.w.WriteString("]\n"); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// ofxExporter writes an OFX 2.2 bank statement with one debit per expense.
+// Amounts are converted to the base currency, keeping the original currency
+// and rate on foreign expenses, and the ledger balance is the negated total.
+type ofxExporter struct {
+	w        *bufio.Writer
+	userID   string
+	currency string
+	from     time.Time
+	to       time.Time
+	convert  func(expense *entities.Expense) (decimal.Decimal, error)
+	total    decimal.Decimal
+}
+
+func (e *ofxExporter) begin() error {
+	status := "<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n"
+	fmt.Fprint(e.w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`+"\n")
+	fmt.Fprint(e.w, `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>`+"\n")
+	fmt.Fprint(e.w, "<OFX>\n<SIGNONMSGSRSV1>\n<SONRS>\n"+status)
+	fmt.Fprintf(e.w, "<DTSERVER>%s</DTSERVER>\n<LANGUAGE>ENG</LANGUAGE>\n", time.Now().UTC().Format("20060102150405"))
+	fmt.Fprint(e.w, "</SONRS>\n</SIGNONMSGSRSV1>\n<BANKMSGSRSV1>\n<STMTTRNRS>\n<TRNUID>0</TRNUID>\n"+status)
+	fmt.Fprintf(e.w, "<STMTRS>\n<CURDEF>%s</CURDEF>\n", e.currency)
+	fmt.Fprintf(e.w, "<BANKACCTFROM><BANKID>NESTMATE</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n", ofxText(e.userID))
+	_, err := fmt.Fprintf(e.w, "<BANKTRANLIST>\n<DTSTART>%s</DTSTART>\n<DTEND>%s</DTEND>\n", e.from.Format("20060102"), e.to.Format("20060102"))
+	return err
+}
+
+func (e *ofxExporter) write(expense *entities.Expense) error {
+	amount, err := e.convert(expense)
+	if err != nil {
+		return err
+	}
+	e.total = e.total.Add(amount)
+
+	name := expense.Description
+	if name == "" {
+		name = string(expense.SubCategory)
+	}
+	if runes := []rune(name); len(runes) > ofxNameLength {
+		name = string(runes[:ofxNameLength])
+	}
+
+	fmt.Fprint(e.w, "<STMTTRN>\n<TRNTYPE>DEBIT</TRNTYPE>\n")
+	fmt.Fprintf(e.w, "<DTPOSTED>%s</DTPOSTED>\n", expense.Date.Format("20060102"))
+	fmt.Fprintf(e.w, "<TRNAMT>-%s</TRNAMT>\n", formatExportAmount(amount))
+	fmt.Fprintf(e.w, "<FITID>%s</FITID>\n", ofxText(expense.ID))
+	fmt.Fprintf(e.w, "<NAME>%s</NAME>\n", ofxText(name))
+	fmt.Fprintf(e.w, "<MEMO>%s / %s</MEMO>\n", ofxText(string(expense.MainCategory)), ofxText(string(expense.SubCategory)))
+	if expense.Currency != e.currency {
+		rate := amount.DivRound(expense.Amount, ofxRatePrecision)
+		fmt.Fprintf(e.w, "<ORIGCURRENCY><CURRATE>%s</CURRATE><CURSYM>%s</CURSYM></ORIGCURRENCY>\n", rate.String(), expense.Currency)
+	}
+	_, err = fmt.Fprint(e.w, "</STMTTRN>\n")
+	return err
+}
+
+func (e *ofxExporter) flush() error {
+	return e.w.Flush()
+}
+
+func (e *ofxExporter) end() error {
+	fmt.Fprint(e.w, "</BANKTRANLIST>\n")
+	fmt.Fprintf(e.w, "<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n", formatExportAmount(e.total.Neg()), e.to.Format("20060102"))
+	fmt.Fprint(e.w, "</STMTRS>\n</STMTTRNRS>\n</BANKMSGSRSV1>\n</OFX>\n")
+	return e.w.Flush()
+}
+
+// ofxText escapes a value for use between OFX tags
+func ofxText(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestExportDataFormats(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	march := month(2024, 3)
+
+	rate := &entities.ExchangeRate{UserID: "u1", FromCurrency: "USD", ToCurrency: "INR", Date: march, Rate: decimal.RequireFromString("82.5")}
+	if err := svc.currency.SetRate(ctx, rate); err != nil {
+		t.Fatalf("SetRate failed: %v", err)
+	}
+	later := newTestExpense("u1", "1499.9", march.AddDate(0, 0, 20), entities.Self, entities.Food)
+	later.Description = `Dinner, "Saravana" & co`
+	earlier := newTestExpense("u1", "0.125", march.AddDate(0, 0, 2), entities.Self, entities.Travel)
+	earlier.Currency = "USD"
+	other := newTestExpense("u2", "10", march, entities.Self, entities.Food)
+	for _, expense := range []*entities.Expense{later, earlier, other} {
+		if _, err := svc.expenses.AddExpense(ctx, expense); err != nil {
+			t.Fatalf("AddExpense failed: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := svc.expenses.ExportData(ctx, "u1", &out, &ExportOptions{Columns: []string{"date", " Amount", "currency", "description"}}); err != nil {
+		t.Fatalf("CSV export failed: %v", err)
+	}
+	expectedCSV := "date,amount,currency,description\n" +
+		"2024-03-03,0.125,USD,test\n" +
+		"2024-03-21,1499.90,INR,\"Dinner, \"\"Saravana\"\" & co\"\n"
+	if out.String() != expectedCSV {
+		t.Errorf("unexpected CSV export:\n%s", out.String())
+	}
+
+	out.Reset()
+	options := &ExportOptions{Format: ExportJSON, Filter: &ExpenseFilter{SubCategory: "food"}, Columns: []string{"amount", "sub_category"}}
+	if err := svc.expenses.ExportData(ctx, "u1", &out, options); err != nil {
+		t.Fatalf("JSON export failed: %v", err)
+	}
+	var rows []map[string]string
+	if err := json.Unmarshal(out.Bytes(), &rows); err != nil {
+		t.Fatalf("JSON export is not valid JSON: %v\n%s", err, out.String())
+	}
+	if len(rows) != 1 || rows[0]["amount"] != "1499.90" || rows[0]["sub_category"] != "Food" {
+		t.Errorf("expected only the Food expense, got %v", rows)
+	}
+
+	out.Reset()
+	if err := svc.expenses.ExportData(ctx, "u1", &out, &ExportOptions{Format: ExportOFX}); err != nil {
+		t.Fatalf("OFX export failed: %v", err)
+	}
+	ofx := out.String()
+	for _, fragment := range []string{
+		"<CURDEF>INR</CURDEF>",
+		"<DTSTART>20240303</DTSTART>",
+		"<DTEND>20240321</DTEND>",
+		"<TRNAMT>-1499.90</TRNAMT>",
+		"<NAME>Dinner, &#34;Saravana&#34; &amp; co</NAME>",
+		"<TRNAMT>-10.31</TRNAMT>",
+		"<ORIGCURRENCY><CURRATE>82.48</CURRATE><CURSYM>USD</CURSYM></ORIGCURRENCY>",
+		"<BALAMT>-1510.21</BALAMT>",
+	} {
+		if !strings.Contains(ofx, fragment) {
+			t.Errorf("expected OFX export to contain %s:\n%s", fragment, ofx)
+		}
+	}
+
+	// Cells a spreadsheet would run as a formula are written as text
+	formula := newTestExpense("u3", "5", march, entities.Self, entities.Misc)
+	formula.Description = `=HYPERLINK("http://evil.example","refund")`
+	if _, err := svc.expenses.AddExpense(ctx, formula); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	out.Reset()
+	if err := svc.expenses.ExportData(ctx, "u3", &out, &ExportOptions{Columns: []string{"description", "amount"}}); err != nil {
+		t.Fatalf("CSV export failed: %v", err)
+	}
+	if expected := "description,amount\n\"'=HYPERLINK(\"\"http://evil.example\"\",\"\"refund\"\")\",5.00\n"; out.String() != expected {
+		t.Errorf("expected the formula to be escaped, got:\n%s", out.String())
+	}
+
+	// Bad options are rejected before anything is written
+	for _, options := range []*ExportOptions{
+		{Format: "xml"},
+		{Columns: []string{"amount", "password"}},
+		{Columns: []string{"amount", "amount"}},
+		{Format: ExportOFX, Columns: []string{"amount"}},
+		{Filter: &ExpenseFilter{MainCategory: "Nowhere"}},
+	} {
+		out.Reset()
+		if err := svc.expenses.ExportData(ctx, "u1", &out, options); !errors.Is(err, ErrInvalidInput) || out.Len() != 0 {
+			t.Errorf("expected %+v to be rejected without output, got %v and %q", options, err, out.String())
+		}
+	}
+}
+
+func TestExportDataReadsEveryPage(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	march := month(2024, 3)
+
+	count := exportPageSize + 1
+	for i := 0; i < count; i++ {
+		if _, err := svc.expenses.AddExpense(ctx, newTestExpense("u1", "1", march.AddDate(0, 0, i%28), entities.Self, entities.Food)); err != nil {
+			t.Fatalf("AddExpense failed: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := svc.expenses.ExportData(ctx, "u1", &out, &ExportOptions{Columns: []string{"id", "date"}}); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")[1:]
+	if len(lines) != count {
+		t.Fatalf("expected %d rows, got %d", count, len(lines))
+	}
+	seen := make(map[string]bool, count)
+	previous := ""
+	for _, line := range lines {
+		fields := strings.Split(line, ",")
+		if seen[fields[0]] {
+			t.Fatalf("expense %s was exported twice", fields[0])
+		}
+		seen[fields[0]] = true
+		if fields[1] < previous {
+			t.Fatalf("expected oldest first, got %s after %s", fields[1], previous)
+		}
+		previous = fields[1]
+	}
+}
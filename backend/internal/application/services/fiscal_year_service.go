@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"nestmate-backend/internal/domain/entities"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// FiscalYearService defines the interface for financial-year reports
+type FiscalYearService interface {
+	GetSummary(ctx context.Context, userID string, year int) (*entities.FiscalYearSummary, error)
+	ExportSummary(ctx context.Context, userID string, year int, w io.Writer) error
+}
+
+// fiscalYearService implements the FiscalYearService interface
+type fiscalYearService struct {
+	expenseService  ExpenseService
+	incomeService   IncomeService
+	settingsService SettingsService
+	currencyService CurrencyService
+}
+
+// NewFiscalYearService creates a new financial-year report service
+func NewFiscalYearService(expenseService ExpenseService, incomeService IncomeService, settingsService SettingsService, currencyService CurrencyService) FiscalYearService {
+	return &fiscalYearService{
+		expenseService:  expenseService,
+		incomeService:   incomeService,
+		settingsService: settingsService,
+		currencyService: currencyService,
+	}
+}
+
+// GetSummary totals the financial year starting in year, or the current
+// financial year when year is 0. The year starts in the month chosen in the
+// user's settings.
+func (s *fiscalYearService) GetSummary(ctx context.Context, userID string, year int) (*entities.FiscalYearSummary, error) {
+	settings, err := s.settingsService.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := fiscalYearStart(time.Now(), settings.FiscalYearStart)
+	if year != 0 {
+		if year < 1 || year > 9998 {
+			return nil, fmt.Errorf("%w: year %d is out of range", ErrInvalidInput, year)
+		}
+		start = time.Date(year, settings.FiscalYearStart, 1, 0, 0, 0, 0, time.UTC)
+	}
+	end := start.AddDate(1, 0, 0)
+
+	expenses, err := s.expenseService.GetExpensesByPeriod(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Totals are in the base currency and deductions in the currency their
+	// limits are set in, both converted at each expense's own date
+	converted := make([]*entities.Expense, 0, len(expenses))
+	monthlyExpenses := make(map[time.Time]decimal.Decimal)
+	claimed := make(map[entities.TaxSection]decimal.Decimal)
+	counts := make(map[entities.TaxSection]int)
+	for _, expense := range expenses {
+		inBase, err := convertExpense(ctx, s.currencyService, userID, expense, settings.BaseCurrency)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, inBase)
+		month := startOfMonth(expense.Date)
+		monthlyExpenses[month] = monthlyExpenses[month].Add(inBase.Amount)
+
+		if expense.TaxSection == "" {
+			continue
+		}
+		deductible, err := s.currencyService.Convert(ctx, userID, expense.Amount, expense.Currency, entities.TaxCurrency, expense.Date)
+		if err != nil {
+			return nil, err
+		}
+		claimed[expense.TaxSection] = claimed[expense.TaxSection].Add(deductible)
+		counts[expense.TaxSection]++
+	}
+
+	totalIncome := decimal.Zero
+	months := make([]*entities.FiscalMonth, 0, 12)
+	for month := start; month.Before(end); month = month.AddDate(0, 1, 0) {
+		income, err := s.incomeService.GetMonthlySummary(ctx, userID, month)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get income: %w", err)
+		}
+		totalIncome = totalIncome.Add(income.Total)
+		months = append(months, &entities.FiscalMonth{
+			Month:         month,
+			TotalIncome:   income.Total,
+			TotalExpenses: monthlyExpenses[month],
+			Savings:       income.Total.Sub(monthlyExpenses[month]),
+		})
+	}
+
+	breakdown := buildBreakdown(start, totalIncome, converted)
+	return &entities.FiscalYearSummary{
+		Year:                 fiscalYearLabel(start),
+		StartDate:            start,
+		EndDate:              end,
+		Currency:             settings.BaseCurrency,
+		TotalIncome:          breakdown.TotalIncome,
+		TotalExpenses:        breakdown.TotalExpenses,
+		Savings:              breakdown.Savings,
+		CategoryBreakdown:    breakdown.CategoryBreakdown,
+		SubCategoryBreakdown: breakdown.SubCategoryBreakdown,
+		CategoryCrossTab:     breakdown.CategoryCrossTab,
+		Months:               months,
+		DeductionCurrency:    entities.TaxCurrency,
+		Deductions:           buildDeductions(claimed, counts),
+	}, nil
+}
+
+// ExportSummary writes the financial-year summary as CSV
+func (s *fiscalYearService) ExportSummary(ctx context.Context, userID string, year int, w io.Writer) error {
+	summary, err := s.GetSummary(ctx, userID, year)
+	if err != nil {
+		return err
+	}
+	return writeFiscalYearCSV(w, summary)
+}
+
+// buildDeductions totals every supported section against its statutory limit
+func buildDeductions(claimed map[entities.TaxSection]decimal.Decimal, counts map[entities.TaxSection]int) []*entities.DeductionTotal {
+	totals := make([]*entities.DeductionTotal, 0, len(entities.DeductionSections))
+	for _, section := range entities.DeductionSections {
+		total := &entities.DeductionTotal{
+			Section:  section.Section,
+			Name:     section.Name,
+			Claimed:  claimed[section.Section],
+			Limit:    section.Limit,
+			Eligible: claimed[section.Section],
+			Expenses: counts[section.Section],
+		}
+		if section.Limit != nil {
+			if total.Eligible.GreaterThan(*section.Limit) {
+				total.Eligible = *section.Limit
+			}
+			remaining := section.Limit.Sub(total.Eligible)
+			total.Remaining = &remaining
+		}
+		totals = append(totals, total)
+	}
+	return totals
+}
+
+// normalizeTaxSection matches a section case-insensitively against the
+// supported ones, returning its canonical form
+func normalizeTaxSection(section entities.TaxSection) (entities.TaxSection, error) {
+	value := strings.TrimSpace(string(section))
+	if value == "" {
+		return "", nil
+	}
+	for _, known := range entities.DeductionSections {
+		if strings.EqualFold(value, string(known.Section)) {
+			return known.Section, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unsupported tax section %q", ErrInvalidInput, value)
+}
+
+// fiscalYearCSVHeader names the columns of a financial-year CSV export
+var fiscalYearCSVHeader = []string{"section", "item", "amount", "limit", "eligible"}
+
+// writeFiscalYearCSV writes the year's totals, then each month, category and
+// deduction section as one row
+func writeFiscalYearCSV(w io.Writer, summary *entities.FiscalYearSummary) error {
+	rows := [][]string{
+		fiscalYearCSVHeader,
+		{"summary", "year", summary.Year, "", ""},
+		{"summary", "currency", summary.Currency, "", ""},
+		{"summary", "total_income", formatExportAmount(summary.TotalIncome), "", ""},
+		{"summary", "total_expenses", formatExportAmount(summary.TotalExpenses), "", ""},
+		{"summary", "savings", formatExportAmount(summary.Savings), "", ""},
+		{"summary", "deduction_currency", summary.DeductionCurrency, "", ""},
+	}
+	for _, month := range summary.Months {
+		label := month.Month.Format("2006-01")
+		rows = append(rows,
+			[]string{"income", label, formatExportAmount(month.TotalIncome), "", ""},
+			[]string{"expenses", label, formatExportAmount(month.TotalExpenses), "", ""},
+			[]string{"savings", label, formatExportAmount(month.Savings), "", ""},
+		)
+	}
+
+	mains := make([]string, 0, len(summary.CategoryBreakdown))
+	for category := range summary.CategoryBreakdown {
+		mains = append(mains, string(category))
+	}
+	sort.Strings(mains)
+	for _, category := range mains {
+		rows = append(rows, []string{"main_category", category, formatExportAmount(summary.CategoryBreakdown[entities.MainCategory(category)]), "", ""})
+	}
+	subs := make([]string, 0, len(summary.SubCategoryBreakdown))
+	for category := range summary.SubCategoryBreakdown {
+		subs = append(subs, string(category))
+	}
+	sort.Strings(subs)
+	for _, category := range subs {
+		rows = append(rows, []string{"sub_category", category, formatExportAmount(summary.SubCategoryBreakdown[entities.SubCategory(category)]), "", ""})
+	}
+
+	for _, deduction := range summary.Deductions {
+		limit := ""
+		if deduction.Limit != nil {
+			limit = formatExportAmount(*deduction.Limit)
+		}
+		rows = append(rows, []string{"deduction", string(deduction.Section), formatExportAmount(deduction.Claimed), limit, formatExportAmount(deduction.Eligible)})
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
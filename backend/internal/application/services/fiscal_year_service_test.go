@@ -0,0 +1,173 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestFiscalYearStart(t *testing.T) {
+	tests := []struct {
+		at    time.Time
+		start time.Month
+		want  time.Time
+		label string
+	}{
+		{date(2024, 4, 1), time.April, date(2024, 4, 1), "2024-25"},
+		{date(2025, 3, 31), time.April, date(2024, 4, 1), "2024-25"},
+		{date(2024, 12, 15), time.April, date(2024, 4, 1), "2024-25"},
+		{date(2024, 6, 1), time.January, date(2024, 1, 1), "2024"},
+		{date(2099, 7, 1), time.July, date(2099, 7, 1), "2099-00"},
+	}
+	for _, tt := range tests {
+		got := fiscalYearStart(tt.at, tt.start)
+		if !got.Equal(tt.want) {
+			t.Errorf("fiscalYearStart(%s, %s) = %s, want %s", tt.at.Format("2006-01-02"), tt.start, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+		if label := fiscalYearLabel(got); label != tt.label {
+			t.Errorf("fiscalYearLabel(%s) = %q, want %q", got.Format("2006-01-02"), label, tt.label)
+		}
+	}
+}
+
+func TestBuildDeductions(t *testing.T) {
+	claimed := map[entities.TaxSection]decimal.Decimal{
+		entities.Section80C: decimal.NewFromInt(180000),
+		entities.Section80D: decimal.NewFromInt(12000),
+		entities.Section80G: decimal.NewFromInt(5000),
+	}
+	counts := map[entities.TaxSection]int{entities.Section80C: 3, entities.Section80D: 1, entities.Section80G: 1}
+
+	totals := make(map[entities.TaxSection]*entities.DeductionTotal)
+	for _, total := range buildDeductions(claimed, counts) {
+		totals[total.Section] = total
+	}
+	if len(totals) != len(entities.DeductionSections) {
+		t.Fatalf("expected every section, got %d", len(totals))
+	}
+
+	c := totals[entities.Section80C]
+	if !c.Eligible.Equal(decimal.NewFromInt(150000)) || !c.Remaining.IsZero() || c.Expenses != 3 {
+		t.Errorf("80C should be capped at its limit, got eligible %s remaining %s", c.Eligible, c.Remaining)
+	}
+	d := totals[entities.Section80D]
+	if !d.Eligible.Equal(decimal.NewFromInt(12000)) || !d.Remaining.Equal(decimal.NewFromInt(13000)) {
+		t.Errorf("80D eligible/remaining = %s/%s, want 12000/13000", d.Eligible, d.Remaining)
+	}
+	g := totals[entities.Section80G]
+	if g.Limit != nil || g.Remaining != nil || !g.Eligible.Equal(decimal.NewFromInt(5000)) {
+		t.Errorf("80G has no fixed cap, got %+v", g)
+	}
+	if e := totals[entities.Section80CCD1B]; !e.Claimed.IsZero() || !e.Remaining.Equal(decimal.NewFromInt(50000)) {
+		t.Errorf("unclaimed section should leave its whole limit, got %+v", e)
+	}
+}
+
+func TestFiscalYearSummary(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	// March 2024 belongs to FY 2023-24 and must be left out
+	for _, m := range []time.Time{month(2024, 3), month(2024, 4), month(2025, 3)} {
+		income := &entities.Income{UserID: "u1", Amount: decimal.NewFromInt(100000), Month: m, Source: "Salary"}
+		if err := svc.incomes.AddIncome(ctx, income); err != nil {
+			t.Fatalf("AddIncome failed: %v", err)
+		}
+	}
+	expenses := []*entities.Expense{
+		newTestExpense("u1", "5000", date(2024, 3, 31), entities.Self, entities.Food),
+		newTestExpense("u1", "100000", date(2024, 4, 10), entities.Savings, entities.Misc),
+		newTestExpense("u1", "90000", date(2025, 3, 20), entities.Savings, entities.Misc),
+		newTestExpense("u1", "20000", date(2024, 7, 1), entities.Self, entities.Misc),
+		newTestExpense("u1", "3000", date(2024, 9, 5), entities.Self, entities.Food),
+	}
+	expenses[1].TaxSection = "80c"
+	expenses[2].TaxSection = entities.Section80C
+	expenses[3].TaxSection = entities.Section80D
+	for _, expense := range expenses {
+		if _, err := svc.expenses.AddExpense(ctx, expense); err != nil {
+			t.Fatalf("AddExpense failed: %v", err)
+		}
+	}
+	if expenses[1].TaxSection != entities.Section80C {
+		t.Errorf("tax section should be normalized, got %q", expenses[1].TaxSection)
+	}
+
+	summary, err := svc.fiscal.GetSummary(ctx, "u1", 2024)
+	if err != nil {
+		t.Fatalf("GetSummary failed: %v", err)
+	}
+	if summary.Year != "2024-25" || !summary.StartDate.Equal(date(2024, 4, 1)) || !summary.EndDate.Equal(date(2025, 4, 1)) {
+		t.Errorf("unexpected period %s %s to %s", summary.Year, summary.StartDate, summary.EndDate)
+	}
+	if !summary.TotalIncome.Equal(decimal.NewFromInt(200000)) {
+		t.Errorf("total income = %s, want 200000", summary.TotalIncome)
+	}
+	if !summary.TotalExpenses.Equal(decimal.NewFromInt(213000)) || !summary.Savings.Equal(decimal.NewFromInt(-13000)) {
+		t.Errorf("expenses/savings = %s/%s, want 213000/-13000", summary.TotalExpenses, summary.Savings)
+	}
+	if got := summary.CategoryBreakdown[entities.Savings]; !got.Equal(decimal.NewFromInt(190000)) {
+		t.Errorf("Savings category = %s, want 190000", got)
+	}
+	if len(summary.Months) != 12 || !summary.Months[11].Savings.Equal(decimal.NewFromInt(10000)) {
+		t.Errorf("unexpected months %+v", summary.Months)
+	}
+
+	for _, deduction := range summary.Deductions {
+		switch deduction.Section {
+		case entities.Section80C:
+			if !deduction.Claimed.Equal(decimal.NewFromInt(190000)) || !deduction.Eligible.Equal(decimal.NewFromInt(150000)) {
+				t.Errorf("80C claimed/eligible = %s/%s", deduction.Claimed, deduction.Eligible)
+			}
+		case entities.Section80D:
+			if !deduction.Claimed.Equal(decimal.NewFromInt(20000)) || deduction.Expenses != 1 {
+				t.Errorf("80D claimed = %s over %d expenses", deduction.Claimed, deduction.Expenses)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := svc.fiscal.ExportSummary(ctx, "u1", 2024, &buf); err != nil {
+		t.Fatalf("ExportSummary failed: %v", err)
+	}
+	csv := buf.String()
+	for _, line := range []string{"section,item,amount,limit,eligible", "summary,year,2024-25,,", "main_category,Savings,190000.00,,", "deduction,80C,190000.00,150000.00,150000.00", "deduction,80E,0.00,,0.00"} {
+		if !strings.Contains(csv, line+"\n") {
+			t.Errorf("CSV is missing %q:\n%s", line, csv)
+		}
+	}
+}
+
+func TestFiscalYearFollowsSettings(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	settings := &entities.UserSettings{UserID: "u1", BaseCurrency: "INR", FiscalYearStart: time.January}
+	if err := svc.settings.UpdateSettings(ctx, settings); err != nil {
+		t.Fatalf("UpdateSettings failed: %v", err)
+	}
+	summary, err := svc.fiscal.GetSummary(ctx, "u1", 2024)
+	if err != nil {
+		t.Fatalf("GetSummary failed: %v", err)
+	}
+	if summary.Year != "2024" || !summary.StartDate.Equal(date(2024, 1, 1)) {
+		t.Errorf("calendar year expected, got %s from %s", summary.Year, summary.StartDate)
+	}
+
+	settings.FiscalYearStart = 13
+	if err := svc.settings.UpdateSettings(ctx, settings); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for month 13, got %v", err)
+	}
+	if _, err := svc.expenses.AddExpense(ctx, &entities.Expense{
+		UserID: "u1", Amount: decimal.NewFromInt(10), Date: date(2024, 5, 1),
+		MainCategory: entities.Self, SubCategory: entities.Food, TaxSection: "80Z",
+	}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown section, got %v", err)
+	}
+}
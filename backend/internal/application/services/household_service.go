@@ -0,0 +1,698 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// sharePrecision is the number of decimal places shares are rounded to,
+// unless the amount being split is itself more precise
+const sharePrecision = 2
+
+// HouseholdService defines the interface for shared household ledgers
+type HouseholdService interface {
+	CreateHousehold(ctx context.Context, household *entities.Household) error
+	GetHouseholds(ctx context.Context, userID string) ([]*entities.Household, error)
+	GetHousehold(ctx context.Context, userID, id string) (*entities.Household, error)
+	UpdateHousehold(ctx context.Context, userID, id string, household *entities.Household) error
+	AddMember(ctx context.Context, userID, householdID string, member *entities.HouseholdMember) error
+	RemoveMember(ctx context.Context, userID, householdID, memberID string) error
+	SplitExpense(ctx context.Context, userID string, split *entities.ExpenseSplit) error
+	RemoveSplit(ctx context.Context, userID, householdID, expenseID string) error
+	GetLedger(ctx context.Context, userID, householdID string) (*entities.HouseholdLedger, error)
+	RecordSettlement(ctx context.Context, userID string, settlement *entities.Settlement) error
+	DeleteSettlement(ctx context.Context, userID, householdID, id string) error
+	GetBalances(ctx context.Context, userID, householdID string) (*entities.HouseholdBalances, error)
+}
+
+// householdService implements the HouseholdService interface
+type householdService struct {
+	householdRepo   repositories.HouseholdRepository
+	expenseRepo     repositories.ExpenseRepository
+	currencyService CurrencyService
+}
+
+// NewHouseholdService creates a new household service
+func NewHouseholdService(householdRepo repositories.HouseholdRepository, expenseRepo repositories.ExpenseRepository, currencyService CurrencyService) HouseholdService {
+	return &householdService{
+		householdRepo:   householdRepo,
+		expenseRepo:     expenseRepo,
+		currencyService: currencyService,
+	}
+}
+
+// CreateHousehold creates a household with its creator as the first member.
+// The currency defaults to the creator's base currency.
+func (s *householdService) CreateHousehold(ctx context.Context, household *entities.Household) error {
+	household.Name = strings.TrimSpace(household.Name)
+	if household.CreatedBy == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	if household.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	currency, err := resolveCurrency(ctx, s.currencyService, household.CreatedBy, household.Currency)
+	if err != nil {
+		return err
+	}
+	household.Currency = currency
+
+	now := time.Now()
+	members := []*entities.HouseholdMember{{UserID: household.CreatedBy}}
+	for _, member := range household.Members {
+		if member.UserID != household.CreatedBy {
+			members = append(members, member)
+		}
+	}
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		if err := validateMember(member); err != nil {
+			return err
+		}
+		if seen[member.UserID] {
+			return fmt.Errorf("%w: %s is listed twice", ErrInvalidInput, member.UserID)
+		}
+		seen[member.UserID] = true
+		member.JoinedAt = now
+	}
+	household.ID = ""
+	household.Members = members
+	household.CreatedAt = now
+	household.UpdatedAt = now
+
+	stored := toRepositoryHousehold(household)
+	if err := s.householdRepo.Create(ctx, stored); err != nil {
+		return fmt.Errorf("failed to save household: %w", err)
+	}
+	household.ID = stored.ID
+	return nil
+}
+
+// GetHouseholds gets every household the user belongs to
+func (s *householdService) GetHouseholds(ctx context.Context, userID string) ([]*entities.Household, error) {
+	stored, err := s.householdRepo.GetByMember(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get households: %w", err)
+	}
+
+	households := make([]*entities.Household, 0, len(stored))
+	for _, household := range stored {
+		households = append(households, toEntityHousehold(household))
+	}
+	return households, nil
+}
+
+// GetHousehold gets a household the user belongs to
+func (s *householdService) GetHousehold(ctx context.Context, userID, id string) (*entities.Household, error) {
+	return s.getMemberHousehold(ctx, userID, id)
+}
+
+// UpdateHousehold renames a household or changes its currency. The currency
+// is fixed once anything has been split or settled in it.
+func (s *householdService) UpdateHousehold(ctx context.Context, userID, id string, household *entities.Household) error {
+	existing, err := s.getMemberHousehold(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	household.Name = strings.TrimSpace(household.Name)
+	if household.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if household.Currency == "" {
+		household.Currency = existing.Currency
+	}
+	if household.Currency, err = normalizeCurrency(household.Currency); err != nil {
+		return err
+	}
+
+	if household.Currency != existing.Currency {
+		splits, err := s.householdRepo.GetSplitsByHousehold(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get expense splits: %w", err)
+		}
+		settlements, err := s.householdRepo.GetSettlementsByHousehold(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get settlements: %w", err)
+		}
+		if len(splits) > 0 || len(settlements) > 0 {
+			return fmt.Errorf("%w: the currency can't change once expenses have been split", ErrInvalidInput)
+		}
+	}
+
+	household.ID = id
+	household.CreatedBy = existing.CreatedBy
+	household.Members = existing.Members
+	household.CreatedAt = existing.CreatedAt
+	household.UpdatedAt = time.Now()
+
+	if err := s.householdRepo.Update(ctx, toRepositoryHousehold(household)); err != nil {
+		return fmt.Errorf("failed to update household: %w", err)
+	}
+	return nil
+}
+
+// AddMember adds a user to a household the caller belongs to
+func (s *householdService) AddMember(ctx context.Context, userID, householdID string, member *entities.HouseholdMember) error {
+	household, err := s.getMemberHousehold(ctx, userID, householdID)
+	if err != nil {
+		return err
+	}
+	if err := validateMember(member); err != nil {
+		return err
+	}
+	if household.HasMember(member.UserID) {
+		return fmt.Errorf("%w: %s is already a member", ErrInvalidInput, member.UserID)
+	}
+
+	member.JoinedAt = time.Now()
+	if err := s.householdRepo.AddMember(ctx, toRepositoryHouseholdMember(householdID, member)); err != nil {
+		return fmt.Errorf("failed to add household member: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember removes a member once they are settled up. Their past
+// expenses and settlements stay in the ledger.
+func (s *householdService) RemoveMember(ctx context.Context, userID, householdID, memberID string) error {
+	household, err := s.getMemberHousehold(ctx, userID, householdID)
+	if err != nil {
+		return err
+	}
+	if !household.HasMember(memberID) {
+		return fmt.Errorf("household member %s: %w", memberID, ErrNotFound)
+	}
+	if len(household.Members) == 1 {
+		return fmt.Errorf("%w: a household needs at least one member", ErrInvalidInput)
+	}
+
+	balances, err := s.balances(ctx, household)
+	if err != nil {
+		return err
+	}
+	for _, balance := range balances.Members {
+		if balance.UserID == memberID && !balance.Net.IsZero() {
+			return fmt.Errorf("%w: %s must settle a balance of %s %s first", ErrInvalidInput, memberID, balance.Net, household.Currency)
+		}
+	}
+
+	if err := s.householdRepo.RemoveMember(ctx, householdID, memberID); err != nil {
+		return fmt.Errorf("failed to remove household member: %w", err)
+	}
+	return nil
+}
+
+// SplitExpense shares one of the caller's expenses between household members,
+// replacing any earlier split. Share amounts are filled in on success.
+func (s *householdService) SplitExpense(ctx context.Context, userID string, split *entities.ExpenseSplit) error {
+	household, err := s.getMemberHousehold(ctx, userID, split.HouseholdID)
+	if err != nil {
+		return err
+	}
+	expense, err := s.expenseRepo.GetByID(ctx, split.ExpenseID)
+	if errors.Is(err, repositories.ErrNotFound) || (err == nil && expense.UserID != userID) {
+		return fmt.Errorf("expense %s: %w", split.ExpenseID, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get expense: %w", err)
+	}
+	if err := validateSplit(household, expense.Amount, split); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	stored := &repositories.ExpenseSplit{
+		ExpenseID:   split.ExpenseID,
+		HouseholdID: split.HouseholdID,
+		Method:      string(split.Method),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for _, share := range split.Shares {
+		stored.Shares = append(stored.Shares, &repositories.ExpenseShare{UserID: share.UserID, Value: share.Value})
+	}
+	if existing, err := s.householdRepo.GetSplit(ctx, split.ExpenseID); err == nil {
+		stored.CreatedAt = existing.CreatedAt
+	}
+	if err := s.householdRepo.SaveSplit(ctx, stored); err != nil {
+		return fmt.Errorf("failed to save expense split: %w", err)
+	}
+
+	shared, err := s.sharedExpense(ctx, household, expense, stored)
+	if err != nil {
+		return err
+	}
+	split.Shares = shared.Split.Shares
+	return nil
+}
+
+// RemoveSplit makes one of the caller's expenses private again
+func (s *householdService) RemoveSplit(ctx context.Context, userID, householdID, expenseID string) error {
+	if _, err := s.getMemberHousehold(ctx, userID, householdID); err != nil {
+		return err
+	}
+	split, err := s.householdRepo.GetSplit(ctx, expenseID)
+	if errors.Is(err, repositories.ErrNotFound) || (err == nil && split.HouseholdID != householdID) {
+		return fmt.Errorf("expense split %s: %w", expenseID, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get expense split: %w", err)
+	}
+	expense, err := s.expenseRepo.GetByID(ctx, expenseID)
+	if err != nil {
+		return fmt.Errorf("failed to get expense: %w", err)
+	}
+	if expense.UserID != userID {
+		return fmt.Errorf("%w: only the member who paid can remove the split", ErrInvalidInput)
+	}
+
+	if err := s.householdRepo.DeleteSplit(ctx, expenseID); err != nil {
+		return fmt.Errorf("failed to delete expense split: %w", err)
+	}
+	return nil
+}
+
+// GetLedger lists a household's shared expenses and settlements
+func (s *householdService) GetLedger(ctx context.Context, userID, householdID string) (*entities.HouseholdLedger, error) {
+	household, err := s.getMemberHousehold(ctx, userID, householdID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ledger(ctx, household)
+}
+
+// RecordSettlement records a payment between two members in the household currency
+func (s *householdService) RecordSettlement(ctx context.Context, userID string, settlement *entities.Settlement) error {
+	household, err := s.getMemberHousehold(ctx, userID, settlement.HouseholdID)
+	if err != nil {
+		return err
+	}
+	if !household.HasMember(settlement.FromUserID) || !household.HasMember(settlement.ToUserID) {
+		return fmt.Errorf("%w: both sides of a settlement must be members", ErrInvalidInput)
+	}
+	if settlement.FromUserID == settlement.ToUserID {
+		return fmt.Errorf("%w: a member can't settle with themselves", ErrInvalidInput)
+	}
+	if !settlement.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidInput)
+	}
+
+	now := time.Now()
+	if settlement.Date.IsZero() {
+		settlement.Date = now
+	}
+	settlement.ID = ""
+	settlement.CreatedBy = userID
+	settlement.CreatedAt = now
+
+	stored := toRepositorySettlement(settlement)
+	if err := s.householdRepo.CreateSettlement(ctx, stored); err != nil {
+		return fmt.Errorf("failed to save settlement: %w", err)
+	}
+	settlement.ID = stored.ID
+	return nil
+}
+
+// DeleteSettlement deletes a settlement recorded by mistake
+func (s *householdService) DeleteSettlement(ctx context.Context, userID, householdID, id string) error {
+	if _, err := s.getMemberHousehold(ctx, userID, householdID); err != nil {
+		return err
+	}
+	settlement, err := s.householdRepo.GetSettlementByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) || (err == nil && settlement.HouseholdID != householdID) {
+		return fmt.Errorf("settlement %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get settlement: %w", err)
+	}
+
+	if err := s.householdRepo.DeleteSettlement(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete settlement: %w", err)
+	}
+	return nil
+}
+
+// GetBalances shows what each member is owed or owes, with a plan to settle up
+func (s *householdService) GetBalances(ctx context.Context, userID, householdID string) (*entities.HouseholdBalances, error) {
+	household, err := s.getMemberHousehold(ctx, userID, householdID)
+	if err != nil {
+		return nil, err
+	}
+	return s.balances(ctx, household)
+}
+
+// getMemberHousehold loads a household and hides it unless userID is a member
+func (s *householdService) getMemberHousehold(ctx context.Context, userID, id string) (*entities.Household, error) {
+	stored, err := s.householdRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("household %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household: %w", err)
+	}
+	household := toEntityHousehold(stored)
+	if !household.HasMember(userID) {
+		return nil, fmt.Errorf("household %s: %w", id, ErrNotFound)
+	}
+	return household, nil
+}
+
+// ledger loads every shared expense and settlement in a household, oldest first
+func (s *householdService) ledger(ctx context.Context, household *entities.Household) (*entities.HouseholdLedger, error) {
+	splits, err := s.householdRepo.GetSplitsByHousehold(ctx, household.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense splits: %w", err)
+	}
+	settlements, err := s.householdRepo.GetSettlementsByHousehold(ctx, household.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlements: %w", err)
+	}
+
+	ledger := &entities.HouseholdLedger{
+		HouseholdID: household.ID,
+		Currency:    household.Currency,
+		Expenses:    make([]*entities.SharedExpense, 0, len(splits)),
+		Settlements: make([]*entities.Settlement, 0, len(settlements)),
+	}
+	for _, split := range splits {
+		expense, err := s.expenseRepo.GetByID(ctx, split.ExpenseID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get expense: %w", err)
+		}
+		shared, err := s.sharedExpense(ctx, household, expense, split)
+		if err != nil {
+			return nil, err
+		}
+		ledger.Expenses = append(ledger.Expenses, shared)
+	}
+	sort.SliceStable(ledger.Expenses, func(i, j int) bool {
+		return ledger.Expenses[i].Expense.Date.Before(ledger.Expenses[j].Expense.Date)
+	})
+	for _, settlement := range settlements {
+		ledger.Settlements = append(ledger.Settlements, toEntitySettlement(settlement))
+	}
+	return ledger, nil
+}
+
+// sharedExpense converts an expense to the household currency and works out
+// what each share of it comes to
+func (s *householdService) sharedExpense(ctx context.Context, household *entities.Household, stored *repositories.Expense, split *repositories.ExpenseSplit) (*entities.SharedExpense, error) {
+	expense := toEntityExpense(stored)
+	amount, err := s.currencyService.Convert(ctx, expense.UserID, expense.Amount, expense.Currency, household.Currency, expense.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	method := entities.SplitMethod(split.Method)
+	weights := make([]decimal.Decimal, len(split.Shares))
+	for i, share := range split.Shares {
+		weights[i] = share.Value
+		if method == entities.SplitEqual {
+			weights[i] = decimal.NewFromInt(1)
+		}
+	}
+	amounts := allocateShares(amount, weights)
+
+	shares := make([]*entities.ExpenseShare, len(split.Shares))
+	for i, share := range split.Shares {
+		shares[i] = &entities.ExpenseShare{UserID: share.UserID, Value: share.Value, Amount: amounts[i]}
+	}
+	return &entities.SharedExpense{
+		Expense: expense,
+		PaidBy:  expense.UserID,
+		Amount:  amount,
+		Split: &entities.ExpenseSplit{
+			ExpenseID:   split.ExpenseID,
+			HouseholdID: split.HouseholdID,
+			Method:      method,
+			Shares:      shares,
+		},
+	}, nil
+}
+
+// balances nets every member's payments against their shares and settlements
+func (s *householdService) balances(ctx context.Context, household *entities.Household) (*entities.HouseholdBalances, error) {
+	ledger, err := s.ledger(ctx, household)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[string]*entities.MemberBalance)
+	var order []string
+	balance := func(userID string) *entities.MemberBalance {
+		if b, ok := byUser[userID]; ok {
+			return b
+		}
+		b := &entities.MemberBalance{UserID: userID, DisplayName: userID}
+		byUser[userID] = b
+		order = append(order, userID)
+		return b
+	}
+	for _, member := range household.Members {
+		balance(member.UserID).DisplayName = member.DisplayName
+	}
+
+	for _, shared := range ledger.Expenses {
+		payer := balance(shared.PaidBy)
+		payer.Paid = payer.Paid.Add(shared.Amount)
+		for _, share := range shared.Split.Shares {
+			member := balance(share.UserID)
+			member.Share = member.Share.Add(share.Amount)
+		}
+	}
+	for _, settlement := range ledger.Settlements {
+		from, to := balance(settlement.FromUserID), balance(settlement.ToUserID)
+		from.Sent = from.Sent.Add(settlement.Amount)
+		to.Received = to.Received.Add(settlement.Amount)
+	}
+
+	balances := &entities.HouseholdBalances{
+		HouseholdID: household.ID,
+		Currency:    household.Currency,
+		Members:     make([]*entities.MemberBalance, 0, len(order)),
+	}
+	for _, userID := range order {
+		b := byUser[userID]
+		b.Net = b.Paid.Sub(b.Share).Add(b.Sent).Sub(b.Received)
+		// Former members only show up while they still have a balance
+		if !household.HasMember(userID) && b.Net.IsZero() {
+			continue
+		}
+		balances.Members = append(balances.Members, b)
+	}
+	balances.SettleUp = settleUp(balances.Members)
+	return balances, nil
+}
+
+// validateMember checks a member being added and defaults its display name
+func validateMember(member *entities.HouseholdMember) error {
+	member.UserID = strings.TrimSpace(member.UserID)
+	member.DisplayName = strings.TrimSpace(member.DisplayName)
+	if member.UserID == "" {
+		return fmt.Errorf("%w: member user ID is required", ErrInvalidInput)
+	}
+	if member.DisplayName == "" {
+		member.DisplayName = member.UserID
+	}
+	return nil
+}
+
+// validateSplit checks that every share belongs to a distinct member and that
+// percentages add up to 100 and exact shares to the expense amount
+func validateSplit(household *entities.Household, amount decimal.Decimal, split *entities.ExpenseSplit) error {
+	if !split.Method.IsValid() {
+		return fmt.Errorf("%w: split method must be %q, %q or %q", ErrInvalidInput, entities.SplitEqual, entities.SplitPercentage, entities.SplitExact)
+	}
+	if len(split.Shares) == 0 {
+		return fmt.Errorf("%w: at least one share is required", ErrInvalidInput)
+	}
+
+	seen := make(map[string]bool, len(split.Shares))
+	total := decimal.Zero
+	for _, share := range split.Shares {
+		if !household.HasMember(share.UserID) {
+			return fmt.Errorf("%w: %s is not a member of the household", ErrInvalidInput, share.UserID)
+		}
+		if seen[share.UserID] {
+			return fmt.Errorf("%w: %s has more than one share", ErrInvalidInput, share.UserID)
+		}
+		seen[share.UserID] = true
+
+		if split.Method == entities.SplitEqual {
+			share.Value = decimal.Zero
+			continue
+		}
+		if !share.Value.IsPositive() {
+			return fmt.Errorf("%w: share values must be greater than zero", ErrInvalidInput)
+		}
+		total = total.Add(share.Value)
+	}
+
+	switch {
+	case split.Method == entities.SplitPercentage && !total.Equal(hundred):
+		return fmt.Errorf("%w: percentages add up to %s, not 100", ErrInvalidInput, total)
+	case split.Method == entities.SplitExact && !total.Equal(amount):
+		return fmt.Errorf("%w: shares add up to %s, not the expense amount %s", ErrInvalidInput, total, amount)
+	}
+	return nil
+}
+
+// allocateShares divides amount in proportion to weights so the parts always
+// add back up to amount. Each part is rounded down and the leftover units go
+// to the parts that lost the most to rounding, earliest first on ties.
+func allocateShares(amount decimal.Decimal, weights []decimal.Decimal) []decimal.Decimal {
+	places := int32(sharePrecision)
+	if exp := -amount.Exponent(); exp > places {
+		places = exp
+	}
+	unit := decimal.New(1, -places)
+
+	total := decimal.Zero
+	for _, weight := range weights {
+		total = total.Add(weight)
+	}
+
+	parts := make([]decimal.Decimal, len(weights))
+	lost := make([]decimal.Decimal, len(weights))
+	remaining := amount
+	for i, weight := range weights {
+		exact := amount.Mul(weight).Div(total)
+		parts[i] = exact.Truncate(places)
+		lost[i] = exact.Sub(parts[i])
+		remaining = remaining.Sub(parts[i])
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return lost[order[a]].GreaterThan(lost[order[b]]) })
+	for i := 0; remaining.GreaterThanOrEqual(unit) && len(order) > 0; i = (i + 1) % len(order) {
+		parts[order[i]] = parts[order[i]].Add(unit)
+		remaining = remaining.Sub(unit)
+	}
+	return parts
+}
+
+// settleUp plans payments that clear every balance by repeatedly having the
+// largest debtor pay the largest creditor. This takes at most one payment
+// fewer than there are members with a balance.
+func settleUp(balances []*entities.MemberBalance) []*entities.SettleUpPayment {
+	type position struct {
+		userID string
+		amount decimal.Decimal
+	}
+	var creditors, debtors []*position
+	for _, b := range balances {
+		switch {
+		case b.Net.IsPositive():
+			creditors = append(creditors, &position{b.UserID, b.Net})
+		case b.Net.IsNegative():
+			debtors = append(debtors, &position{b.UserID, b.Net.Neg()})
+		}
+	}
+	largestFirst := func(list []*position) {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].amount.GreaterThan(list[j].amount) })
+	}
+	largestFirst(creditors)
+	largestFirst(debtors)
+
+	payments := []*entities.SettleUpPayment{}
+	for i, j := 0, 0; i < len(creditors) && j < len(debtors); {
+		amount := decimal.Min(creditors[i].amount, debtors[j].amount)
+		payments = append(payments, &entities.SettleUpPayment{FromUserID: debtors[j].userID, ToUserID: creditors[i].userID, Amount: amount})
+		creditors[i].amount = creditors[i].amount.Sub(amount)
+		debtors[j].amount = debtors[j].amount.Sub(amount)
+		if creditors[i].amount.IsZero() {
+			i++
+		}
+		if debtors[j].amount.IsZero() {
+			j++
+		}
+	}
+	return payments
+}
+
+// toRepositoryHousehold converts a household entity to its repository model
+func toRepositoryHousehold(household *entities.Household) *repositories.Household {
+	stored := &repositories.Household{
+		ID:        household.ID,
+		Name:      household.Name,
+		Currency:  household.Currency,
+		CreatedBy: household.CreatedBy,
+		CreatedAt: household.CreatedAt,
+		UpdatedAt: household.UpdatedAt,
+	}
+	for _, member := range household.Members {
+		stored.Members = append(stored.Members, toRepositoryHouseholdMember(household.ID, member))
+	}
+	return stored
+}
+
+// toRepositoryHouseholdMember converts a household member entity to its repository model
+func toRepositoryHouseholdMember(householdID string, member *entities.HouseholdMember) *repositories.HouseholdMember {
+	return &repositories.HouseholdMember{
+		HouseholdID: householdID,
+		UserID:      member.UserID,
+		DisplayName: member.DisplayName,
+		JoinedAt:    member.JoinedAt,
+	}
+}
+
+// toEntityHousehold converts a repository household model to an entity
+func toEntityHousehold(household *repositories.Household) *entities.Household {
+	members := make([]*entities.HouseholdMember, 0, len(household.Members))
+	for _, member := range household.Members {
+		members = append(members, &entities.HouseholdMember{
+			UserID:      member.UserID,
+			DisplayName: member.DisplayName,
+			JoinedAt:    member.JoinedAt,
+		})
+	}
+	return &entities.Household{
+		ID:        household.ID,
+		Name:      household.Name,
+		Currency:  household.Currency,
+		CreatedBy: household.CreatedBy,
+		Members:   members,
+		CreatedAt: household.CreatedAt,
+		UpdatedAt: household.UpdatedAt,
+	}
+}
+
+// toRepositorySettlement converts a settlement entity to its repository model
+func toRepositorySettlement(settlement *entities.Settlement) *repositories.Settlement {
+	return &repositories.Settlement{
+		ID:          settlement.ID,
+		HouseholdID: settlement.HouseholdID,
+		FromUserID:  settlement.FromUserID,
+		ToUserID:    settlement.ToUserID,
+		Amount:      settlement.Amount,
+		Date:        settlement.Date,
+		Note:        settlement.Note,
+		CreatedBy:   settlement.CreatedBy,
+		CreatedAt:   settlement.CreatedAt,
+	}
+}
+
+// toEntitySettlement converts a repository settlement model to an entity
+func toEntitySettlement(settlement *repositories.Settlement) *entities.Settlement {
+	return &entities.Settlement{
+		ID:          settlement.ID,
+		HouseholdID: settlement.HouseholdID,
+		FromUserID:  settlement.FromUserID,
+		ToUserID:    settlement.ToUserID,
+		Amount:      settlement.Amount,
+		Date:        settlement.Date,
+		Note:        settlement.Note,
+		CreatedBy:   settlement.CreatedBy,
+		CreatedAt:   settlement.CreatedAt,
+	}
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func newTestHousehold(t *testing.T, svc *testServices, members ...string) *entities.Household {
+	t.Helper()
+	household := &entities.Household{Name: "Flat 4B", CreatedBy: members[0]}
+	for _, userID := range members[1:] {
+		household.Members = append(household.Members, &entities.HouseholdMember{UserID: userID})
+	}
+	if err := svc.households.CreateHousehold(context.Background(), household); err != nil {
+		t.Fatalf("CreateHousehold() error = %v", err)
+	}
+	return household
+}
+
+func addSharedExpense(t *testing.T, svc *testServices, household *entities.Household, payer, amount string, method entities.SplitMethod, shares map[string]string) *entities.ExpenseSplit {
+	t.Helper()
+	ctx := context.Background()
+	expense := newTestExpense(payer, amount, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), entities.Self, entities.Food)
+	if _, err := svc.expenses.AddExpense(ctx, expense); err != nil {
+		t.Fatalf("AddExpense() error = %v", err)
+	}
+
+	split := &entities.ExpenseSplit{ExpenseID: expense.ID, HouseholdID: household.ID, Method: method}
+	for _, member := range household.Members {
+		if value, ok := shares[member.UserID]; ok {
+			split.Shares = append(split.Shares, &entities.ExpenseShare{UserID: member.UserID, Value: decimal.RequireFromString(value)})
+		}
+	}
+	if err := svc.households.SplitExpense(ctx, payer, split); err != nil {
+		t.Fatalf("SplitExpense() error = %v", err)
+	}
+	return split
+}
+
+func TestSplitExpenseShares(t *testing.T) {
+	svc := newTestServices(t)
+	household := newTestHousehold(t, svc, "u1", "u2", "u3")
+
+	equal := addSharedExpense(t, svc, household, "u1", "100", entities.SplitEqual, map[string]string{"u1": "0", "u2": "0", "u3": "0"})
+	for i, want := range []string{"33.34", "33.33", "33.33"} {
+		if got := equal.Shares[i].Amount; !got.Equal(decimal.RequireFromString(want)) {
+			t.Errorf("equal share %d = %s, want %s", i, got, want)
+		}
+	}
+
+	percentage := addSharedExpense(t, svc, household, "u2", "250", entities.SplitPercentage, map[string]string{"u1": "20", "u3": "80"})
+	if got := percentage.Shares[1].Amount; !got.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("percentage share = %s, want 200", got)
+	}
+}
+
+func TestSplitExpenseValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	household := newTestHousehold(t, svc, "u1", "u2")
+
+	expense := newTestExpense("u1", "90", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), entities.Self, entities.Food)
+	if _, err := svc.expenses.AddExpense(ctx, expense); err != nil {
+		t.Fatalf("AddExpense() error = %v", err)
+	}
+	share := func(userID, value string) *entities.ExpenseShare {
+		return &entities.ExpenseShare{UserID: userID, Value: decimal.RequireFromString(value)}
+	}
+
+	cases := map[string]*entities.ExpenseSplit{
+		"unknown method":        {Method: "halves", Shares: []*entities.ExpenseShare{share("u1", "0")}},
+		"no shares":             {Method: entities.SplitEqual},
+		"non-member":            {Method: entities.SplitEqual, Shares: []*entities.ExpenseShare{share("u1", "0"), share("u9", "0")}},
+		"duplicate member":      {Method: entities.SplitEqual, Shares: []*entities.ExpenseShare{share("u1", "0"), share("u1", "0")}},
+		"percentages under 100": {Method: entities.SplitPercentage, Shares: []*entities.ExpenseShare{share("u1", "50"), share("u2", "40")}},
+		"exact over the amount": {Method: entities.SplitExact, Shares: []*entities.ExpenseShare{share("u1", "50"), share("u2", "50")}},
+		"negative exact share":  {Method: entities.SplitExact, Shares: []*entities.ExpenseShare{share("u1", "100"), share("u2", "-10")}},
+	}
+	for name, split := range cases {
+		split.ExpenseID = expense.ID
+		split.HouseholdID = household.ID
+		if err := svc.households.SplitExpense(ctx, "u1", split); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: SplitExpense() error = %v, want ErrInvalidInput", name, err)
+		}
+	}
+
+	// Only the payer can split their expense
+	split := &entities.ExpenseSplit{ExpenseID: expense.ID, HouseholdID: household.ID, Method: entities.SplitEqual, Shares: []*entities.ExpenseShare{share("u2", "0")}}
+	if err := svc.households.SplitExpense(ctx, "u2", split); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SplitExpense() by another member error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestHouseholdBalancesAndSettleUp(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	household := newTestHousehold(t, svc, "u1", "u2", "u3")
+
+	// u1 pays 90 for everyone, u2 pays 60 split exactly between u2 and u3
+	addSharedExpense(t, svc, household, "u1", "90", entities.SplitEqual, map[string]string{"u1": "0", "u2": "0", "u3": "0"})
+	addSharedExpense(t, svc, household, "u2", "60", entities.SplitExact, map[string]string{"u2": "15", "u3": "45"})
+
+	balances, err := svc.households.GetBalances(ctx, "u3", household.ID)
+	if err != nil {
+		t.Fatalf("GetBalances() error = %v", err)
+	}
+	wantNet := map[string]string{"u1": "60", "u2": "15", "u3": "-75"}
+	for _, balance := range balances.Members {
+		if !balance.Net.Equal(decimal.RequireFromString(wantNet[balance.UserID])) {
+			t.Errorf("net for %s = %s, want %s", balance.UserID, balance.Net, wantNet[balance.UserID])
+		}
+	}
+	if len(balances.SettleUp) != 2 {
+		t.Fatalf("settle up = %d payments, want 2", len(balances.SettleUp))
+	}
+	first := balances.SettleUp[0]
+	if first.FromUserID != "u3" || first.ToUserID != "u1" || !first.Amount.Equal(decimal.NewFromInt(60)) {
+		t.Errorf("first payment = %+v, want u3 pays u1 60", first)
+	}
+
+	// Recording the suggested payments clears every balance
+	for _, payment := range balances.SettleUp {
+		settlement := &entities.Settlement{HouseholdID: household.ID, FromUserID: payment.FromUserID, ToUserID: payment.ToUserID, Amount: payment.Amount}
+		if err := svc.households.RecordSettlement(ctx, "u3", settlement); err != nil {
+			t.Fatalf("RecordSettlement() error = %v", err)
+		}
+	}
+	balances, err = svc.households.GetBalances(ctx, "u1", household.ID)
+	if err != nil {
+		t.Fatalf("GetBalances() error = %v", err)
+	}
+	for _, balance := range balances.Members {
+		if !balance.Net.IsZero() {
+			t.Errorf("net for %s = %s after settling up, want 0", balance.UserID, balance.Net)
+		}
+	}
+	if len(balances.SettleUp) != 0 {
+		t.Errorf("settle up = %d payments after settling, want 0", len(balances.SettleUp))
+	}
+
+	ledger, err := svc.households.GetLedger(ctx, "u2", household.ID)
+	if err != nil {
+		t.Fatalf("GetLedger() error = %v", err)
+	}
+	if len(ledger.Expenses) != 2 || len(ledger.Settlements) != 2 {
+		t.Errorf("ledger = %d expenses and %d settlements, want 2 and 2", len(ledger.Expenses), len(ledger.Settlements))
+	}
+}
+
+func TestHouseholdMembership(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	household := newTestHousehold(t, svc, "u1", "u2")
+
+	if _, err := svc.households.GetHousehold(ctx, "u3", household.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetHousehold() by outsider error = %v, want ErrNotFound", err)
+	}
+	if err := svc.households.AddMember(ctx, "u1", household.ID, &entities.HouseholdMember{UserID: "u2"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("AddMember() of existing member error = %v, want ErrInvalidInput", err)
+	}
+
+	// u2 owes u1 and can't leave until that is settled
+	addSharedExpense(t, svc, household, "u1", "40", entities.SplitEqual, map[string]string{"u1": "0", "u2": "0"})
+	if err := svc.households.RemoveMember(ctx, "u1", household.ID, "u2"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("RemoveMember() with a balance error = %v, want ErrInvalidInput", err)
+	}
+	settlement := &entities.Settlement{HouseholdID: household.ID, FromUserID: "u2", ToUserID: "u1", Amount: decimal.NewFromInt(20)}
+	if err := svc.households.RecordSettlement(ctx, "u2", settlement); err != nil {
+		t.Fatalf("RecordSettlement() error = %v", err)
+	}
+	if err := svc.households.RemoveMember(ctx, "u1", household.ID, "u2"); err != nil {
+		t.Fatalf("RemoveMember() error = %v", err)
+	}
+	if _, err := svc.households.GetLedger(ctx, "u2", household.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetLedger() by former member error = %v, want ErrNotFound", err)
+	}
+	if err := svc.households.RemoveMember(ctx, "u1", household.ID, "u1"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("RemoveMember() of last member error = %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,640 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ImportService defines the interface for importing expenses from CSV files
+type ImportService interface {
+	CreateMapping(ctx context.Context, mapping *entities.ImportMapping) error
+	GetMappings(ctx context.Context, userID string) ([]*entities.ImportMapping, error)
+	GetMapping(ctx context.Context, userID, id string) (*entities.ImportMapping, error)
+	UpdateMapping(ctx context.Context, id string, mapping *entities.ImportMapping) error
+	DeleteMapping(ctx context.Context, userID, id string) error
+	PreviewImport(ctx context.Context, userID, mappingID string, r io.Reader) (*entities.ImportPreview, error)
+	CommitImport(ctx context.Context, userID, mappingID, fileName string, r io.Reader) (*entities.ImportBatch, *entities.ImportPreview, error)
+	GetBatches(ctx context.Context, userID string) ([]*entities.ImportBatch, error)
+	UndoBatch(ctx context.Context, userID, id string) (*entities.ImportBatch, error)
+}
+
+// importService implements the ImportService interface
+type importService struct {
+	importRepo      repositories.ImportRepository
+	eventRepo       repositories.EventRepository
+	categoryService CategoryService
+	currencyService CurrencyService
+}
+
+// NewImportService creates a new import service
+func NewImportService(importRepo repositories.ImportRepository, eventRepo repositories.EventRepository, categoryService CategoryService, currencyService CurrencyService) ImportService {
+	return &importService{
+		importRepo:      importRepo,
+		eventRepo:       eventRepo,
+		categoryService: categoryService,
+		currencyService: currencyService,
+	}
+}
+
+// dateFormatTokens turns a DD/MM/YYYY style date format into a Go layout.
+// Longer tokens come first so YYYY isn't read as two YYs.
+var dateFormatTokens = strings.NewReplacer("YYYY", "2006", "YY", "06", "MMM", "Jan", "MM", "01", "DD", "02")
+
+// CreateMapping saves a new column mapping for the user
+func (s *importService) CreateMapping(ctx context.Context, mapping *entities.ImportMapping) error {
+	if err := s.validateMapping(ctx, mapping, ""); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	mapping.ID = ""
+	mapping.CreatedAt = now
+	mapping.UpdatedAt = now
+
+	stored := toRepositoryImportMapping(mapping)
+	if err := s.importRepo.CreateMapping(ctx, stored); err != nil {
+		return fmt.Errorf("failed to save import mapping: %w", err)
+	}
+	mapping.ID = stored.ID
+	return nil
+}
+
+// GetMappings gets every column mapping the user has saved
+func (s *importService) GetMappings(ctx context.Context, userID string) ([]*entities.ImportMapping, error) {
+	stored, err := s.importRepo.GetMappingsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import mappings: %w", err)
+	}
+
+	mappings := make([]*entities.ImportMapping, 0, len(stored))
+	for _, mapping := range stored {
+		mappings = append(mappings, toEntityImportMapping(mapping))
+	}
+	return mappings, nil
+}
+
+// GetMapping gets a single column mapping owned by the user
+func (s *importService) GetMapping(ctx context.Context, userID, id string) (*entities.ImportMapping, error) {
+	stored, err := s.getOwnedMapping(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toEntityImportMapping(stored), nil
+}
+
+// UpdateMapping replaces a column mapping. Batches already imported with it are unaffected.
+func (s *importService) UpdateMapping(ctx context.Context, id string, mapping *entities.ImportMapping) error {
+	existing, err := s.getOwnedMapping(ctx, mapping.UserID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.validateMapping(ctx, mapping, id); err != nil {
+		return err
+	}
+
+	mapping.ID = id
+	mapping.CreatedAt = existing.CreatedAt
+	mapping.UpdatedAt = time.Now()
+
+	if err := s.importRepo.UpdateMapping(ctx, toRepositoryImportMapping(mapping)); err != nil {
+		return fmt.Errorf("failed to update import mapping: %w", err)
+	}
+	return nil
+}
+
+// DeleteMapping deletes a column mapping. Batches imported with it can still be undone.
+func (s *importService) DeleteMapping(ctx context.Context, userID, id string) error {
+	if _, err := s.getOwnedMapping(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.importRepo.DeleteMapping(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete import mapping: %w", err)
+	}
+	return nil
+}
+
+// PreviewImport parses a CSV file with a saved mapping without saving anything,
+// reporting every row that would be imported, skipped or rejected
+func (s *importService) PreviewImport(ctx context.Context, userID, mappingID string, r io.Reader) (*entities.ImportPreview, error) {
+	stored, err := s.getOwnedMapping(ctx, userID, mappingID)
+	if err != nil {
+		return nil, err
+	}
+	return s.parseImport(ctx, toEntityImportMapping(stored), r)
+}
+
+// CommitImport parses a CSV file and saves its expenses as one batch. Nothing
+// is saved unless every row is valid or skipped; the preview is returned
+// either way so the rejected rows can be fixed.
+func (s *importService) CommitImport(ctx context.Context, userID, mappingID, fileName string, r io.Reader) (*entities.ImportBatch, *entities.ImportPreview, error) {
+	preview, err := s.PreviewImport(ctx, userID, mappingID, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if preview.Errors > 0 {
+		return nil, preview, fmt.Errorf("%w: %d rows have errors", ErrInvalidInput, preview.Errors)
+	}
+	if preview.Valid == 0 {
+		return nil, preview, fmt.Errorf("%w: CSV has no expenses to import", ErrInvalidInput)
+	}
+
+	now := time.Now()
+	batch := &repositories.ImportBatch{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Source:    string(entities.ImportFromCSV),
+		Status:    string(entities.ImportCommitted),
+		MappingID: mappingID,
+		FileName:  fileName,
+		CreatedAt: now,
+	}
+	expenses := make([]*repositories.Expense, 0, preview.Valid)
+	for _, row := range preview.Rows {
+		if row.Status != entities.ImportRowValid {
+			continue
+		}
+		if err := attachToEvent(ctx, s.eventRepo, row.Expense); err != nil {
+			return nil, preview, err
+		}
+		row.Expense.ID = uuid.NewString()
+		row.Expense.ImportBatchID = batch.ID
+		row.Expense.CreatedAt = now
+		row.Expense.UpdatedAt = now
+		expenses = append(expenses, toRepositoryExpense(row.Expense))
+	}
+
+	if err := s.importRepo.CreateBatch(ctx, batch, expenses); err != nil {
+		return nil, preview, fmt.Errorf("failed to save import: %w", err)
+	}
+	return toEntityImportBatch(batch), preview, nil
+}
+
+// GetBatches gets every import the user has committed, most recent first
+func (s *importService) GetBatches(ctx context.Context, userID string) ([]*entities.ImportBatch, error) {
+	stored, err := s.importRepo.GetBatchesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import batches: %w", err)
+	}
+
+	batches := make([]*entities.ImportBatch, 0, len(stored))
+	for _, batch := range stored {
+		batches = append(batches, toEntityImportBatch(batch))
+	}
+	return batches, nil
+}
+
+// UndoBatch deletes every expense an import created, including ones edited
+// since, and keeps the batch as a record that it was undone. Undoing a staged
+// import discards it before any expenses are saved.
+func (s *importService) UndoBatch(ctx context.Context, userID, id string) (*entities.ImportBatch, error) {
+	stored, err := getOwnedImportBatch(ctx, s.importRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if stored.UndoneAt != nil {
+		return nil, fmt.Errorf("%w: import batch %s was already undone", ErrInvalidInput, id)
+	}
+
+	now := time.Now()
+	if err := s.importRepo.UndoBatch(ctx, id, now); err != nil {
+		return nil, fmt.Errorf("failed to undo import: %w", err)
+	}
+	stored.Status = string(entities.ImportUndone)
+	stored.UndoneAt = &now
+	return toEntityImportBatch(stored), nil
+}
+
+// getOwnedMapping loads a column mapping and hides it unless it belongs to userID
+func (s *importService) getOwnedMapping(ctx context.Context, userID, id string) (*repositories.ImportMapping, error) {
+	stored, err := s.importRepo.GetMappingByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("import mapping %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import mapping: %w", err)
+	}
+	if stored.UserID != userID {
+		return nil, fmt.Errorf("import mapping %s: %w", id, ErrNotFound)
+	}
+	return stored, nil
+}
+
+// validateMapping checks a mapping and normalizes its names in place.
+// id is the mapping being updated, if any, so it doesn't clash with its own name.
+func (s *importService) validateMapping(ctx context.Context, mapping *entities.ImportMapping, id string) error {
+	for _, field := range []*string{
+		&mapping.Name, &mapping.DateColumn, &mapping.DateFormat, &mapping.AmountColumn, &mapping.DebitColumn,
+		&mapping.CreditColumn, &mapping.DescriptionColumn, &mapping.CurrencyColumn, &mapping.MainCategoryColumn,
+		&mapping.SubCategoryColumn, &mapping.DefaultMainCategory, &mapping.DefaultSubCategory,
+	} {
+		*field = strings.TrimSpace(*field)
+	}
+
+	if mapping.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	if mapping.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if mapping.DateColumn == "" {
+		return fmt.Errorf("%w: date column is required", ErrInvalidInput)
+	}
+	if mapping.DateFormat == "" {
+		mapping.DateFormat = entities.DefaultImportDateFormat
+	}
+	if _, err := dateLayout(mapping.DateFormat); err != nil {
+		return err
+	}
+
+	switch {
+	case mapping.AmountColumn != "" && (mapping.DebitColumn != "" || mapping.CreditColumn != ""):
+		return fmt.Errorf("%w: use either an amount column or debit and credit columns, not both", ErrInvalidInput)
+	case mapping.AmountColumn != "":
+		if mapping.AmountSign == "" {
+			mapping.AmountSign = entities.AmountSignPositive
+		}
+		if !mapping.AmountSign.IsValid() {
+			return fmt.Errorf("%w: amount sign must be %q or %q", ErrInvalidInput, entities.AmountSignPositive, entities.AmountSignNegative)
+		}
+	case mapping.DebitColumn != "":
+		mapping.AmountSign = ""
+	default:
+		return fmt.Errorf("%w: an amount or debit column is required", ErrInvalidInput)
+	}
+
+	if mapping.MainCategoryColumn == "" && mapping.DefaultMainCategory == "" {
+		return fmt.Errorf("%w: a main category column or default is required", ErrInvalidInput)
+	}
+	if mapping.SubCategoryColumn == "" && mapping.DefaultSubCategory == "" {
+		return fmt.Errorf("%w: a sub-category column or default is required", ErrInvalidInput)
+	}
+	if mapping.DefaultMainCategory != "" {
+		category, err := lookupCategory(ctx, s.categoryService, mapping.UserID, entities.MainCategoryKind, mapping.DefaultMainCategory)
+		if err != nil {
+			return err
+		}
+		mapping.DefaultMainCategory = category.Name
+	}
+	if mapping.DefaultSubCategory != "" {
+		category, err := lookupCategory(ctx, s.categoryService, mapping.UserID, entities.SubCategoryKind, mapping.DefaultSubCategory)
+		if err != nil {
+			return err
+		}
+		mapping.DefaultSubCategory = category.Name
+	}
+
+	existing, err := s.importRepo.GetMappingsByUserID(ctx, mapping.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get import mappings: %w", err)
+	}
+	for _, other := range existing {
+		if other.ID != id && strings.EqualFold(other.Name, mapping.Name) {
+			return fmt.Errorf("%w: an import mapping named %q already exists", ErrInvalidInput, other.Name)
+		}
+	}
+	return nil
+}
+
+// dateLayout converts a mapping's date format to a Go time layout,
+// rejecting formats that don't pin down the year, month and day
+func dateLayout(format string) (string, error) {
+	layout := dateFormatTokens.Replace(format)
+	if !strings.Contains(layout, "06") || !(strings.Contains(layout, "01") || strings.Contains(layout, "Jan")) || !strings.Contains(layout, "02") {
+		return "", fmt.Errorf("%w: date format %q needs a year, month and day, e.g. DD/MM/YYYY", ErrInvalidInput, format)
+	}
+	return layout, nil
+}
+
+// toRepositoryImportMapping converts a column mapping entity to its repository model
+func toRepositoryImportMapping(mapping *entities.ImportMapping) *repositories.ImportMapping {
+	return &repositories.ImportMapping{
+		ID:                  mapping.ID,
+		UserID:              mapping.UserID,
+		Name:                mapping.Name,
+		DateColumn:          mapping.DateColumn,
+		DateFormat:          mapping.DateFormat,
+		AmountColumn:        mapping.AmountColumn,
+		AmountSign:          string(mapping.AmountSign),
+		DebitColumn:         mapping.DebitColumn,
+		CreditColumn:        mapping.CreditColumn,
+		DescriptionColumn:   mapping.DescriptionColumn,
+		CurrencyColumn:      mapping.CurrencyColumn,
+		MainCategoryColumn:  mapping.MainCategoryColumn,
+		SubCategoryColumn:   mapping.SubCategoryColumn,
+		DefaultMainCategory: mapping.DefaultMainCategory,
+		DefaultSubCategory:  mapping.DefaultSubCategory,
+		CreatedAt:           mapping.CreatedAt,
+		UpdatedAt:           mapping.UpdatedAt,
+	}
+}
+
+// toEntityImportMapping converts a repository column mapping model to an entity
+func toEntityImportMapping(mapping *repositories.ImportMapping) *entities.ImportMapping {
+	return &entities.ImportMapping{
+		ID:                  mapping.ID,
+		UserID:              mapping.UserID,
+		Name:                mapping.Name,
+		DateColumn:          mapping.DateColumn,
+		DateFormat:          mapping.DateFormat,
+		AmountColumn:        mapping.AmountColumn,
+		AmountSign:          entities.AmountSign(mapping.AmountSign),
+		DebitColumn:         mapping.DebitColumn,
+		CreditColumn:        mapping.CreditColumn,
+		DescriptionColumn:   mapping.DescriptionColumn,
+		CurrencyColumn:      mapping.CurrencyColumn,
+		MainCategoryColumn:  mapping.MainCategoryColumn,
+		SubCategoryColumn:   mapping.SubCategoryColumn,
+		DefaultMainCategory: mapping.DefaultMainCategory,
+		DefaultSubCategory:  mapping.DefaultSubCategory,
+		CreatedAt:           mapping.CreatedAt,
+		UpdatedAt:           mapping.UpdatedAt,
+	}
+}
+
+// toRepositoryImportBatch converts an import batch entity to its repository model
+func toRepositoryImportBatch(batch *entities.ImportBatch) *repositories.ImportBatch {
+	return &repositories.ImportBatch{
+		ID:           batch.ID,
+		UserID:       batch.UserID,
+		Source:       string(batch.Source),
+		Status:       string(batch.Status),
+		MappingID:    batch.MappingID,
+		FileName:     batch.FileName,
+		BankName:     batch.BankName,
+		AccountID:    batch.AccountID,
+		ExpenseCount: batch.ExpenseCount,
+		CreatedAt:    batch.CreatedAt,
+		UndoneAt:     batch.UndoneAt,
+	}
+}
+
+// toEntityImportBatch converts a repository import batch model to an entity
+func toEntityImportBatch(batch *repositories.ImportBatch) *entities.ImportBatch {
+	return &entities.ImportBatch{
+		ID:           batch.ID,
+		UserID:       batch.UserID,
+		Source:       entities.ImportSource(batch.Source),
+		Status:       entities.ImportStatus(batch.Status),
+		MappingID:    batch.MappingID,
+		FileName:     batch.FileName,
+		BankName:     batch.BankName,
+		AccountID:    batch.AccountID,
+		ExpenseCount: batch.ExpenseCount,
+		CreatedAt:    batch.CreatedAt,
+		UndoneAt:     batch.UndoneAt,
+	}
+}
+
+// parseImport reads a whole CSV file with mapping. Problems with the file as a
+// whole are returned as errors; problems with single rows are reported per row.
+func (s *importService) parseImport(ctx context.Context, mapping *entities.ImportMapping, r io.Reader) (*entities.ImportPreview, error) {
+	layout, err := dateLayout(mapping.DateFormat)
+	if err != nil {
+		return nil, err
+	}
+	baseCurrency, err := s.currencyService.BaseCurrency(ctx, mapping.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: CSV is empty", ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range []string{
+		mapping.DateColumn, mapping.AmountColumn, mapping.DebitColumn, mapping.CreditColumn, mapping.DescriptionColumn,
+		mapping.CurrencyColumn, mapping.MainCategoryColumn, mapping.SubCategoryColumn,
+	} {
+		if _, ok := index[strings.ToLower(column)]; column != "" && !ok {
+			return nil, fmt.Errorf("%w: CSV is missing the %q column", ErrInvalidInput, column)
+		}
+	}
+
+	parser := &importRowParser{
+		service:      s,
+		mapping:      mapping,
+		layout:       layout,
+		baseCurrency: baseCurrency,
+		index:        index,
+		categories:   make(map[string]*entities.Category),
+	}
+	preview := &entities.ImportPreview{
+		Rows:   []*entities.ImportRow{},
+		Totals: make(map[string]decimal.Decimal),
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		row := &entities.ImportRow{Status: entities.ImportRowError}
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			row.Line, row.Message = parseErr.Line, parseErr.Err.Error()
+		case err != nil:
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		default:
+			row.Line, _ = reader.FieldPos(0)
+			parser.parse(ctx, record, row)
+		}
+
+		switch row.Status {
+		case entities.ImportRowValid:
+			preview.Valid++
+			preview.Totals[row.Expense.Currency] = preview.Totals[row.Expense.Currency].Add(row.Expense.Amount)
+		case entities.ImportRowSkipped:
+			preview.Skipped++
+		default:
+			preview.Errors++
+		}
+		preview.Rows = append(preview.Rows, row)
+	}
+	return preview, nil
+}
+
+// importRowParser turns single CSV records into expenses for one import
+type importRowParser struct {
+	service      *importService
+	mapping      *entities.ImportMapping
+	layout       string
+	baseCurrency string
+	index        map[string]int
+	categories   map[string]*entities.Category // lookups so far, nil for unknown names
+}
+
+// parse fills in row from record
+func (p *importRowParser) parse(ctx context.Context, record []string, row *entities.ImportRow) {
+	fail := func(format string, args ...interface{}) {
+		row.Status, row.Message = entities.ImportRowError, fmt.Sprintf(format, args...)
+	}
+
+	date, err := time.Parse(p.layout, p.cell(record, p.mapping.DateColumn))
+	if err != nil {
+		fail("date %q doesn't match %s", p.cell(record, p.mapping.DateColumn), p.mapping.DateFormat)
+		return
+	}
+
+	amount, skip, err := p.amount(record)
+	if err != nil {
+		fail("%v", err)
+		return
+	}
+	if skip != "" {
+		row.Status, row.Message = entities.ImportRowSkipped, skip
+		return
+	}
+
+	currency := p.baseCurrency
+	if value := p.cell(record, p.mapping.CurrencyColumn); value != "" {
+		if currency, err = normalizeCurrency(value); err != nil {
+			fail("invalid currency %q", value)
+			return
+		}
+	}
+
+	main, err := p.category(ctx, entities.MainCategoryKind, p.cell(record, p.mapping.MainCategoryColumn), p.mapping.DefaultMainCategory)
+	if err != nil {
+		fail("%v", err)
+		return
+	}
+	sub, err := p.category(ctx, entities.SubCategoryKind, p.cell(record, p.mapping.SubCategoryColumn), p.mapping.DefaultSubCategory)
+	if err != nil {
+		fail("%v", err)
+		return
+	}
+
+	row.Status = entities.ImportRowValid
+	row.Expense = &entities.Expense{
+		UserID:       p.mapping.UserID,
+		Amount:       amount,
+		Currency:     currency,
+		Description:  p.cell(record, p.mapping.DescriptionColumn),
+		Date:         date,
+		MainCategory: entities.MainCategory(main),
+		SubCategory:  entities.SubCategory(sub),
+	}
+}
+
+// amount reads the amount spent from record. A non-empty skip reason means
+// the row is a credit or zero rather than an expense.
+func (p *importRowParser) amount(record []string) (decimal.Decimal, string, error) {
+	if p.mapping.AmountColumn != "" {
+		value, err := parseImportAmount(p.cell(record, p.mapping.AmountColumn))
+		if err != nil {
+			return decimal.Zero, "", err
+		}
+		if p.mapping.AmountSign == entities.AmountSignNegative {
+			value = value.Neg()
+		}
+		if value.IsNegative() {
+			return decimal.Zero, "credit", nil
+		}
+		if value.IsZero() {
+			return decimal.Zero, "zero amount", nil
+		}
+		return value, "", nil
+	}
+
+	if debit := p.cell(record, p.mapping.DebitColumn); debit != "" {
+		value, err := parseImportAmount(debit)
+		if err != nil {
+			return decimal.Zero, "", err
+		}
+		if value.IsZero() {
+			return decimal.Zero, "zero amount", nil
+		}
+		return value.Abs(), "", nil
+	}
+	if p.cell(record, p.mapping.CreditColumn) != "" {
+		return decimal.Zero, "credit", nil
+	}
+	return decimal.Zero, "", fmt.Errorf("no debit or credit amount")
+}
+
+// category resolves the named category, or fallback when the cell is empty,
+// rejecting unknown and archived categories
+func (p *importRowParser) category(ctx context.Context, kind entities.CategoryKind, name, fallback string) (string, error) {
+	if name == "" {
+		name = fallback
+	}
+	if name == "" {
+		return "", fmt.Errorf("%s category is required", kind)
+	}
+
+	key := string(kind) + "/" + strings.ToLower(name)
+	category, seen := p.categories[key]
+	if !seen {
+		found, err := p.service.categoryService.LookupCategory(ctx, p.mapping.UserID, kind, name)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return "", err
+		}
+		category = found
+		p.categories[key] = category
+	}
+	if category == nil {
+		return "", fmt.Errorf("unknown %s category %q", kind, name)
+	}
+	if category.Archived {
+		return "", fmt.Errorf("%s category %q is archived", kind, category.Name)
+	}
+	return category.Name, nil
+}
+
+// cell returns the trimmed value of column in record, or "" when the column
+// isn't mapped or the row is short
+func (p *importRowParser) cell(record []string, column string) string {
+	if column == "" {
+		return ""
+	}
+	i := p.index[strings.ToLower(column)]
+	if i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// parseImportAmount parses a spreadsheet amount, allowing thousands separators
+// and accounting-style parentheses for negative values
+func parseImportAmount(value string) (decimal.Decimal, error) {
+	cleaned := strings.NewReplacer(",", "", " ", "").Replace(value)
+	negative := strings.HasPrefix(cleaned, "(") && strings.HasSuffix(cleaned, ")")
+	if negative {
+		cleaned = cleaned[1 : len(cleaned)-1]
+	}
+
+	amount, err := decimal.NewFromString(cleaned)
+	if err != nil || cleaned == "" {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", value)
+	}
+	if negative {
+		amount = amount.Neg()
+	}
+	return amount, nil
+}
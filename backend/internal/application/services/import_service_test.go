@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestImportPreviewReportsEveryRow(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	mapping := &entities.ImportMapping{
+		UserID:              "u1",
+		Name:                "HDFC statement",
+		DateColumn:          "Txn Date",
+		DateFormat:          "DD/MM/YYYY",
+		DebitColumn:         "Withdrawal",
+		CreditColumn:        "Deposit",
+		DescriptionColumn:   "Narration",
+		SubCategoryColumn:   "Category",
+		DefaultMainCategory: "self",
+		DefaultSubCategory:  "misc",
+	}
+	if err := svc.imports.CreateMapping(ctx, mapping); err != nil {
+		t.Fatalf("CreateMapping failed: %v", err)
+	}
+	if mapping.DefaultMainCategory != string(entities.Self) || mapping.AmountSign != "" {
+		t.Errorf("expected mapping to be normalized, got %+v", mapping)
+	}
+	clash := &entities.ImportMapping{UserID: "u1", Name: "hdfc STATEMENT", DateColumn: "d", AmountColumn: "a", DefaultMainCategory: "Self", DefaultSubCategory: "Misc"}
+	if err := svc.imports.CreateMapping(ctx, clash); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a duplicate mapping name to be rejected, got %v", err)
+	}
+
+	csv := "\ufeffTxn Date,Narration,Withdrawal,Deposit,Category\n" +
+		"03/03/2024,Groceries,\"1,250.50\",,food\n" +
+		"05/03/2024,Salary,,50000,\n" +
+		"2024-03-07,Bad date,10,,\n" +
+		"08/03/2024,Cinema,abc,,Entertainment\n" +
+		"09/03/2024,Unknown,99,,Gadgets\n" +
+		"10/03/2024,ATM,500,,\n"
+	preview, err := svc.imports.PreviewImport(ctx, "u1", mapping.ID, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("PreviewImport failed: %v", err)
+	}
+	if preview.Valid != 2 || preview.Skipped != 1 || preview.Errors != 3 {
+		t.Fatalf("expected 2 valid, 1 skipped and 3 errors, got %d/%d/%d", preview.Valid, preview.Skipped, preview.Errors)
+	}
+	first := preview.Rows[0]
+	if first.Line != 2 || first.Expense.Amount.String() != "1250.5" || first.Expense.SubCategory != entities.Food || first.Expense.MainCategory != entities.Self {
+		t.Errorf("unexpected first row %+v / %+v", first, first.Expense)
+	}
+	if !first.Expense.Date.Equal(time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 3 March, got %s", first.Expense.Date)
+	}
+	if last := preview.Rows[5]; last.Expense == nil || last.Expense.SubCategory != entities.Misc {
+		t.Errorf("expected an empty category cell to use the default, got %+v", last)
+	}
+	for _, i := range []int{2, 3, 4} {
+		if row := preview.Rows[i]; row.Status != entities.ImportRowError || row.Message == "" {
+			t.Errorf("expected line %d to fail with a message, got %+v", row.Line, row)
+		}
+	}
+	if preview.Totals["INR"].String() != "1750.5" {
+		t.Errorf("expected INR total 1750.5, got %v", preview.Totals)
+	}
+
+	// A file with bad rows saves nothing
+	if _, preview, err := svc.imports.CommitImport(ctx, "u1", mapping.ID, "march.csv", strings.NewReader(csv)); !errors.Is(err, ErrInvalidInput) || preview == nil {
+		t.Fatalf("expected the commit to be rejected with a preview, got %v", err)
+	}
+	if _, total, _ := svc.expenses.ListExpenses(ctx, "u1", nil); total != 0 {
+		t.Errorf("expected a rejected import to save nothing, got %d expenses", total)
+	}
+
+	// Other users can't use the mapping
+	if _, err := svc.imports.PreviewImport(ctx, "u2", mapping.ID, strings.NewReader(csv)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for another user's mapping, got %v", err)
+	}
+	if _, err := svc.imports.PreviewImport(ctx, "u1", mapping.ID, strings.NewReader("Date,Amount\n")); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a CSV without the mapped columns to be rejected, got %v", err)
+	}
+}
+
+func TestCommitImportCanBeUndone(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	mapping := &entities.ImportMapping{
+		UserID:             "u1",
+		Name:               "Card",
+		DateColumn:         "date",
+		AmountColumn:       "amount",
+		AmountSign:         entities.AmountSignNegative,
+		CurrencyColumn:     "currency",
+		MainCategoryColumn: "main",
+		SubCategoryColumn:  "sub",
+	}
+	if err := svc.imports.CreateMapping(ctx, mapping); err != nil {
+		t.Fatalf("CreateMapping failed: %v", err)
+	}
+
+	csv := "date,amount,currency,main,sub\n" +
+		"2024-03-01,-20.10,usd,Self,Travel\n" +
+		"2024-03-02,(450),,Chennai House,Food\n" +
+		"2024-03-03,75,,Self,Food\n"
+	batch, preview, err := svc.imports.CommitImport(ctx, "u1", mapping.ID, "card.csv", strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("CommitImport failed: %v", err)
+	}
+	if batch.ExpenseCount != 2 || preview.Skipped != 1 || batch.FileName != "card.csv" {
+		t.Errorf("expected 2 expenses and a skipped refund, got %+v and %d skipped", batch, preview.Skipped)
+	}
+
+	// Expenses added by hand survive undoing the import
+	manual := newTestExpense("u1", "100", month(2024, 3), entities.Self, entities.Food)
+	if _, err := svc.expenses.AddExpense(ctx, manual); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	expenses, total, _ := svc.expenses.ListExpenses(ctx, "u1", &ExpenseFilter{SubCategory: entities.Travel})
+	if total != 1 || expenses[0].ImportBatchID != batch.ID || expenses[0].Currency != "USD" || expenses[0].Amount.String() != "20.1" {
+		t.Fatalf("expected the imported travel expense, got %+v", expenses)
+	}
+
+	undone, err := svc.imports.UndoBatch(ctx, "u1", batch.ID)
+	if err != nil {
+		t.Fatalf("UndoBatch failed: %v", err)
+	}
+	if undone.UndoneAt == nil {
+		t.Error("expected the batch to be marked undone")
+	}
+	expenses, total, _ = svc.expenses.ListExpenses(ctx, "u1", nil)
+	if total != 1 || expenses[0].ID != manual.ID {
+		t.Errorf("expected only the manual expense to remain, got %d", total)
+	}
+	if _, err := svc.imports.UndoBatch(ctx, "u1", batch.ID); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a second undo to be rejected, got %v", err)
+	}
+
+	batches, _ := svc.imports.GetBatches(ctx, "u1")
+	if len(batches) != 1 || batches[0].UndoneAt == nil {
+		t.Errorf("expected the undone batch to be listed, got %+v", batches)
+	}
+}
+
+func TestParseImportAmount(t *testing.T) {
+	cases := map[string]string{
+		"1,234.56": "1234.56",
+		"(12.50)":  "-12.5",
+		"-0.01":    "-0.01",
+		" 7 ":      "7",
+	}
+	for input, expected := range cases {
+		got, err := parseImportAmount(input)
+		if err != nil || !got.Equal(decimal.RequireFromString(expected)) {
+			t.Errorf("parseImportAmount(%q): expected %s, got %s (%v)", input, expected, got, err)
+		}
+	}
+	for _, input := range []string{"", "abc", "()"} {
+		if _, err := parseImportAmount(input); err == nil {
+			t.Errorf("parseImportAmount(%q): expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// IncomeService defines the interface for income operations
+type IncomeService interface {
+	AddIncome(ctx context.Context, income *entities.Income) error
+	GetIncome(ctx context.Context, userID, id string) (*entities.Income, error)
+	GetIncomes(ctx context.Context, userID string) ([]*entities.Income, error)
+	GetIncomesByMonth(ctx context.Context, userID string, month time.Time) ([]*entities.Income, error)
+	GetMonthlySummary(ctx context.Context, userID string, month time.Time) (*entities.IncomeSummary, error)
+	UpdateIncome(ctx context.Context, id string, income *entities.Income) error
+	DeleteIncome(ctx context.Context, userID, id string) error
+}
+
+// incomeService implements the IncomeService interface
+type incomeService struct {
+	incomeRepo      repositories.IncomeRepository
+	currencyService CurrencyService
+	accountRepo     repositories.AccountRepository
+	now             func() time.Time
+}
+
+// NewIncomeService creates a new income service
+func NewIncomeService(incomeRepo repositories.IncomeRepository, currencyService CurrencyService, accountRepo repositories.AccountRepository) IncomeService {
+	return &incomeService{
+		incomeRepo:      incomeRepo,
+		currencyService: currencyService,
+		accountRepo:     accountRepo,
+		now:             time.Now,
+	}
+}
+
+// AddIncome adds a new income for a month
+func (s *incomeService) AddIncome(ctx context.Context, income *entities.Income) error {
+	if err := validateIncome(income); err != nil {
+		return err
+	}
+	if err := checkAccount(ctx, s.accountRepo, income.UserID, income.AccountID, ""); err != nil {
+		return err
+	}
+	currency, err := resolveCurrency(ctx, s.currencyService, income.UserID, income.Currency)
+	if err != nil {
+		return err
+	}
+	income.Currency = currency
+
+	now := s.now()
+	income.ID = uuid.NewString()
+	income.Month = startOfMonth(income.Month)
+	income.CarriedFromID = ""
+	income.CreatedAt = now
+	income.UpdatedAt = now
+
+	if err := s.incomeRepo.Create(ctx, toRepositoryIncome(income)); err != nil {
+		return fmt.Errorf("failed to save income: %w", err)
+	}
+	return nil
+}
+
+// GetIncome gets a single income owned by the user
+func (s *incomeService) GetIncome(ctx context.Context, userID, id string) (*entities.Income, error) {
+	stored, err := s.getOwnedIncome(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toEntityIncome(stored), nil
+}
+
+// GetIncomes gets every income recorded by the user
+func (s *incomeService) GetIncomes(ctx context.Context, userID string) ([]*entities.Income, error) {
+	if err := s.carryForward(ctx, userID, s.now()); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.incomeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incomes: %w", err)
+	}
+	return toEntityIncomes(stored), nil
+}
+
+// GetIncomesByMonth gets every income source for a month, carrying recurring incomes forward first
+func (s *incomeService) GetIncomesByMonth(ctx context.Context, userID string, month time.Time) ([]*entities.Income, error) {
+	month = startOfMonth(month)
+	if err := s.carryForward(ctx, userID, month); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.incomeRepo.GetByMonth(ctx, userID, month)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incomes: %w", err)
+	}
+	return toEntityIncomes(stored), nil
+}
+
+// GetMonthlySummary sums every income source for a month in the user's base currency.
+// Incomes in other currencies are converted at the rate for the first of the month.
+func (s *incomeService) GetMonthlySummary(ctx context.Context, userID string, month time.Time) (*entities.IncomeSummary, error) {
+	incomes, err := s.GetIncomesByMonth(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+	base, err := s.currencyService.BaseCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &entities.IncomeSummary{
+		Month:    startOfMonth(month),
+		Currency: base,
+		Total:    decimal.Zero,
+		BySource: make(map[string]decimal.Decimal),
+	}
+	for _, income := range incomes {
+		amount, err := s.currencyService.Convert(ctx, userID, income.Amount, income.Currency, base, income.Month)
+		if err != nil {
+			return nil, err
+		}
+		summary.Total = summary.Total.Add(amount)
+		summary.BySource[income.Source] = summary.BySource[income.Source].Add(amount)
+	}
+	return summary, nil
+}
+
+// UpdateIncome updates an existing income
+func (s *incomeService) UpdateIncome(ctx context.Context, id string, income *entities.Income) error {
+	existing, err := s.getOwnedIncome(ctx, income.UserID, id)
+	if err != nil {
+		return err
+	}
+	if err := validateIncome(income); err != nil {
+		return err
+	}
+	if err := checkAccount(ctx, s.accountRepo, income.UserID, income.AccountID, existing.AccountID); err != nil {
+		return err
+	}
+	currency, err := resolveCurrency(ctx, s.currencyService, income.UserID, income.Currency)
+	if err != nil {
+		return err
+	}
+	income.Currency = currency
+
+	income.ID = id
+	income.Month = startOfMonth(income.Month)
+	income.CarriedFromID = existing.CarriedFromID
+	income.CreatedAt = existing.CreatedAt
+	income.UpdatedAt = s.now()
+
+	if err := s.incomeRepo.Update(ctx, toRepositoryIncome(income)); err != nil {
+		return fmt.Errorf("failed to update income: %w", err)
+	}
+	return nil
+}
+
+// DeleteIncome deletes an income.
+// Deleting a carried-forward income also stops the recurrence it came from,
+// otherwise it would be carried forward again on the next read.
+func (s *incomeService) DeleteIncome(ctx context.Context, userID, id string) error {
+	existing, err := s.getOwnedIncome(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if existing.CarriedFromID != "" {
+		previous, err := s.incomeRepo.GetByID(ctx, existing.CarriedFromID)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return fmt.Errorf("failed to get previous income: %w", err)
+		}
+		if previous != nil && previous.IsRecurring {
+			previous.IsRecurring = false
+			previous.UpdatedAt = s.now()
+			if err := s.incomeRepo.Update(ctx, previous); err != nil {
+				return fmt.Errorf("failed to stop recurring income: %w", err)
+			}
+		}
+	}
+
+	if err := s.incomeRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete income: %w", err)
+	}
+	return nil
+}
+
+// carryForward copies recurring incomes into every month up to the given month.
+// Months after the current one are never materialized.
+func (s *incomeService) carryForward(ctx context.Context, userID string, month time.Time) error {
+	target := startOfMonth(month)
+	if current := startOfMonth(s.now()); target.After(current) {
+		target = current
+	}
+
+	pending, err := s.incomeRepo.GetUncarriedRecurring(ctx, userID, target)
+	if err != nil {
+		return fmt.Errorf("failed to get recurring incomes: %w", err)
+	}
+
+	for _, previous := range pending {
+		for m := previous.Month.AddDate(0, 1, 0); !m.After(target); m = m.AddDate(0, 1, 0) {
+			now := s.now()
+			next := &repositories.Income{
+				ID:            uuid.NewString(),
+				UserID:        previous.UserID,
+				Amount:        previous.Amount,
+				Currency:      previous.Currency,
+				Month:         m,
+				Source:        previous.Source,
+				IsRecurring:   true,
+				AccountID:     previous.AccountID,
+				CarriedFromID: previous.ID,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+			if err := s.incomeRepo.Create(ctx, next); err != nil {
+				return fmt.Errorf("failed to carry forward income: %w", err)
+			}
+			previous = next
+		}
+	}
+	return nil
+}
+
+// getOwnedIncome loads an income and hides it unless it belongs to userID
+func (s *incomeService) getOwnedIncome(ctx context.Context, userID, id string) (*repositories.Income, error) {
+	stored, err := s.incomeRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("income %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get income: %w", err)
+	}
+	if stored.UserID != userID {
+		return nil, fmt.Errorf("income %s: %w", id, ErrNotFound)
+	}
+	return stored, nil
+}
+
+// validateIncome checks the fields required for every stored income
+func validateIncome(income *entities.Income) error {
+	income.Source = strings.TrimSpace(income.Source)
+	if income.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	if !income.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidInput)
+	}
+	if income.Month.IsZero() {
+		return fmt.Errorf("%w: month is required", ErrInvalidInput)
+	}
+	if income.Source == "" {
+		return fmt.Errorf("%w: source is required", ErrInvalidInput)
+	}
+	return nil
+}
+
+// toRepositoryIncome converts an income entity to its repository model
+func toRepositoryIncome(income *entities.Income) *repositories.Income {
+	return &repositories.Income{
+		ID:            income.ID,
+		UserID:        income.UserID,
+		Amount:        income.Amount,
+		Currency:      income.Currency,
+		Month:         income.Month,
+		Source:        income.Source,
+		IsRecurring:   income.IsRecurring,
+		AccountID:     income.AccountID,
+		CarriedFromID: income.CarriedFromID,
+		CreatedAt:     income.CreatedAt,
+		UpdatedAt:     income.UpdatedAt,
+	}
+}
+
+// toEntityIncome converts a repository income model to an entity
+func toEntityIncome(income *repositories.Income) *entities.Income {
+	return &entities.Income{
+		ID:            income.ID,
+		UserID:        income.UserID,
+		Amount:        income.Amount,
+		Currency:      income.Currency,
+		Month:         income.Month,
+		Source:        income.Source,
+		IsRecurring:   income.IsRecurring,
+		AccountID:     income.AccountID,
+		CarriedFromID: income.CarriedFromID,
+		CreatedAt:     income.CreatedAt,
+		UpdatedAt:     income.UpdatedAt,
+	}
+}
+
+// toEntityIncomes converts a slice of repository income models to entities
+func toEntityIncomes(stored []*repositories.Income) []*entities.Income {
+	incomes := make([]*entities.Income, 0, len(stored))
+	for _, income := range stored {
+		incomes = append(incomes, toEntityIncome(income))
+	}
+	return incomes
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/infrastructure/repositories/sqlite"
+)
+
+func newTestIncomeService(t *testing.T, now time.Time) *incomeService {
+	t.Helper()
+	db, err := sqlite.Open(context.Background(), ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	svc := NewIncomeService(sqlite.NewIncomeRepository(db), newTestCurrencyService(db), sqlite.NewAccountRepository(db)).(*incomeService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMonthlySummarySumsEverySource(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestIncomeService(t, month(2024, 3))
+
+	for source, amount := range map[string]string{"Salary": "85000", "Freelance": "12500.50", "Rent": "15000"} {
+		income := &entities.Income{UserID: "u1", Amount: decimal.RequireFromString(amount), Month: month(2024, 3), Source: source}
+		if err := svc.AddIncome(ctx, income); err != nil {
+			t.Fatalf("AddIncome failed: %v", err)
+		}
+	}
+
+	summary, err := svc.GetMonthlySummary(ctx, "u1", time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetMonthlySummary failed: %v", err)
+	}
+	if summary.Total.String() != "112500.5" {
+		t.Errorf("expected total 112500.5, got %s", summary.Total)
+	}
+	if len(summary.BySource) != 3 {
+		t.Errorf("expected 3 sources, got %d", len(summary.BySource))
+	}
+
+	bad := &entities.Income{UserID: "u1", Amount: decimal.NewFromInt(1), Month: month(2024, 3), Source: "  "}
+	if err := svc.AddIncome(ctx, bad); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for blank source, got %v", err)
+	}
+}
+
+func TestRecurringIncomeCarriesForward(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestIncomeService(t, month(2024, 4))
+
+	salary := &entities.Income{UserID: "u1", Amount: decimal.NewFromInt(90000), Month: month(2024, 1), Source: "Salary", IsRecurring: true}
+	bonus := &entities.Income{UserID: "u1", Amount: decimal.NewFromInt(5000), Month: month(2024, 1), Source: "Bonus"}
+	for _, income := range []*entities.Income{salary, bonus} {
+		if err := svc.AddIncome(ctx, income); err != nil {
+			t.Fatalf("AddIncome failed: %v", err)
+		}
+	}
+
+	// Reading March fills in February and March, one-off income stays in January
+	march, err := svc.GetIncomesByMonth(ctx, "u1", month(2024, 3))
+	if err != nil {
+		t.Fatalf("GetIncomesByMonth failed: %v", err)
+	}
+	if len(march) != 1 || march[0].Source != "Salary" || march[0].CarriedFromID == "" {
+		t.Fatalf("expected carried-forward salary in March, got %+v", march)
+	}
+	february, _ := svc.GetIncomesByMonth(ctx, "u1", month(2024, 2))
+	if len(february) != 1 {
+		t.Fatalf("expected salary in February, got %d incomes", len(february))
+	}
+
+	// Reading March again must not duplicate anything
+	march, _ = svc.GetIncomesByMonth(ctx, "u1", month(2024, 3))
+	if len(march) != 1 {
+		t.Fatalf("expected carry forward to be idempotent, got %d incomes", len(march))
+	}
+
+	// Future months are not materialized beyond the current month
+	june, _ := svc.GetIncomesByMonth(ctx, "u1", month(2024, 6))
+	if len(june) != 0 {
+		t.Errorf("expected no incomes materialized for a future month, got %d", len(june))
+	}
+
+	// Deleting April's salary stops the recurrence
+	april, _ := svc.GetIncomesByMonth(ctx, "u1", month(2024, 4))
+	if len(april) != 1 {
+		t.Fatalf("expected salary in April, got %d incomes", len(april))
+	}
+	if err := svc.DeleteIncome(ctx, "u1", april[0].ID); err != nil {
+		t.Fatalf("DeleteIncome failed: %v", err)
+	}
+	april, _ = svc.GetIncomesByMonth(ctx, "u1", month(2024, 4))
+	if len(april) != 0 {
+		t.Errorf("expected deleted salary to stay deleted, got %d incomes", len(april))
+	}
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"nestmate-backend/internal/infrastructure/secrets"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxPasswordHintLength = 256
+
+// PasswordHintService defines the interface for the per-bank reminders of
+// how statement passwords are built
+type PasswordHintService interface {
+	SetHint(ctx context.Context, userID, bank, hint string) (*entities.PasswordHint, error)
+	GetHint(ctx context.Context, userID, bank string) (*entities.PasswordHint, error)
+	GetHints(ctx context.Context, userID string) ([]*entities.PasswordHint, error)
+	DeleteHint(ctx context.Context, userID, bank string) error
+}
+
+// passwordHintService implements the PasswordHintService interface
+type passwordHintService struct {
+	hintRepo repositories.PasswordHintRepository
+	parsers  *BankParserRegistry
+	box      *secrets.Box
+}
+
+// NewPasswordHintService creates a new password hint service that keeps one
+// hint for each bank in parsers, sealed with box
+func NewPasswordHintService(hintRepo repositories.PasswordHintRepository, parsers *BankParserRegistry, box *secrets.Box) PasswordHintService {
+	return &passwordHintService{
+		hintRepo: hintRepo,
+		parsers:  parsers,
+		box:      box,
+	}
+}
+
+// SetHint saves the user's hint for bank, replacing any earlier one
+func (s *passwordHintService) SetHint(ctx context.Context, userID, bank, hint string) (*entities.PasswordHint, error) {
+	bank, err := s.bankName(bank)
+	if err != nil {
+		return nil, err
+	}
+	hint = strings.TrimSpace(hint)
+	if hint == "" {
+		return nil, fmt.Errorf("%w: hint is required", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(hint) > maxPasswordHintLength {
+		return nil, fmt.Errorf("%w: hint must be at most %d characters", ErrInvalidInput, maxPasswordHintLength)
+	}
+
+	sealed, err := s.box.Seal(hint, hintContext(userID, bank))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt password hint: %w", err)
+	}
+	stored := &repositories.PasswordHint{UserID: userID, Bank: bank, SealedHint: sealed}
+	if err := s.hintRepo.Upsert(ctx, stored); err != nil {
+		return nil, fmt.Errorf("failed to save password hint: %w", err)
+	}
+	return &entities.PasswordHint{
+		ID:        stored.ID,
+		UserID:    userID,
+		Bank:      bank,
+		Hint:      hint,
+		CreatedAt: stored.CreatedAt,
+		UpdatedAt: stored.UpdatedAt,
+	}, nil
+}
+
+// GetHint gets the user's hint for bank
+func (s *passwordHintService) GetHint(ctx context.Context, userID, bank string) (*entities.PasswordHint, error) {
+	bank, err := s.bankName(bank)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.hintRepo.GetByBank(ctx, userID, bank)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("password hint for %s: %w", bank, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password hint: %w", err)
+	}
+	return s.toEntityPasswordHint(stored)
+}
+
+// GetHints gets all of the user's hints, ordered by bank. Hints that no
+// longer open, such as ones sealed before the encryption key was changed,
+// are left out so the rest can still be shown.
+func (s *passwordHintService) GetHints(ctx context.Context, userID string) ([]*entities.PasswordHint, error) {
+	stored, err := s.hintRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password hints: %w", err)
+	}
+
+	hints := make([]*entities.PasswordHint, 0, len(stored))
+	for _, hint := range stored {
+		entity, err := s.toEntityPasswordHint(hint)
+		if err != nil {
+			log.Printf("Warning: skipping password hint %s: %v", hint.ID, err)
+			continue
+		}
+		hints = append(hints, entity)
+	}
+	return hints, nil
+}
+
+// DeleteHint deletes the user's hint for bank
+func (s *passwordHintService) DeleteHint(ctx context.Context, userID, bank string) error {
+	bank, err := s.bankName(bank)
+	if err != nil {
+		return err
+	}
+	err = s.hintRepo.Delete(ctx, userID, bank)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return fmt.Errorf("password hint for %s: %w", bank, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete password hint: %w", err)
+	}
+	return nil
+}
+
+// bankName checks bank against the supported statement banks and returns
+// its canonical name
+func (s *passwordHintService) bankName(bank string) (string, error) {
+	parser, ok := s.parsers.Lookup(strings.TrimSpace(bank))
+	if !ok {
+		return "", fmt.Errorf("%w: unsupported bank %q, expected one of %s",
+			ErrInvalidInput, bank, strings.Join(s.parsers.Banks(), ", "))
+	}
+	return parser.Bank(), nil
+}
+
+// toEntityPasswordHint opens a stored hint
+func (s *passwordHintService) toEntityPasswordHint(hint *repositories.PasswordHint) (*entities.PasswordHint, error) {
+	plain, err := s.box.Open(hint.SealedHint, hintContext(hint.UserID, hint.Bank))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt password hint for %s: %w", hint.Bank, err)
+	}
+	return &entities.PasswordHint{
+		ID:        hint.ID,
+		UserID:    hint.UserID,
+		Bank:      hint.Bank,
+		Hint:      plain,
+		CreatedAt: hint.CreatedAt,
+		UpdatedAt: hint.UpdatedAt,
+	}, nil
+}
+
+// hintContext binds a sealed hint to its owner and bank, so a hint copied
+// to another row doesn't open
+func hintContext(userID, bank string) string {
+	return userID + "/" + bank
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"nestmate-backend/internal/infrastructure/repositories/sqlite"
+	"nestmate-backend/internal/infrastructure/secrets"
+)
+
+func TestPasswordHints(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+
+	hint, err := svc.hints.SetHint(ctx, "u1", "HDFC", "  customer ID  ")
+	if err != nil {
+		t.Fatalf("SetHint() error = %v", err)
+	}
+	if hint.Bank != "hdfc" || hint.Hint != "customer ID" || hint.ID == "" {
+		t.Errorf("expected a trimmed hint for hdfc, got %+v", hint)
+	}
+
+	updated, err := svc.hints.SetHint(ctx, "u1", "hdfc", "date of birth as DDMM")
+	if err != nil {
+		t.Fatalf("SetHint() error = %v", err)
+	}
+	if updated.ID != hint.ID || !updated.CreatedAt.Equal(hint.CreatedAt) {
+		t.Errorf("expected the hint to be replaced in place, got %+v", updated)
+	}
+	if _, err := svc.hints.SetHint(ctx, "u1", "sbi", "last five digits of the mobile number"); err != nil {
+		t.Fatalf("SetHint() error = %v", err)
+	}
+
+	got, err := svc.hints.GetHint(ctx, "u1", "Hdfc")
+	if err != nil || got.Hint != "date of birth as DDMM" {
+		t.Errorf("expected the updated hint back, got %+v, %v", got, err)
+	}
+	hints, err := svc.hints.GetHints(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetHints() error = %v", err)
+	}
+	if len(hints) != 2 || hints[0].Bank != "hdfc" || hints[1].Bank != "sbi" {
+		t.Errorf("expected hints for hdfc and sbi, got %+v", hints)
+	}
+	if others, _ := svc.hints.GetHints(ctx, "u2"); len(others) != 0 {
+		t.Errorf("expected another user to have no hints, got %+v", others)
+	}
+
+	if err := svc.hints.DeleteHint(ctx, "u1", "sbi"); err != nil {
+		t.Fatalf("DeleteHint() error = %v", err)
+	}
+	if _, err := svc.hints.GetHint(ctx, "u1", "sbi"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a deleted hint to be not found, got %v", err)
+	}
+	if err := svc.hints.DeleteHint(ctx, "u2", "hdfc"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected deleting another user's hint to fail, got %v", err)
+	}
+}
+
+func TestPasswordHintValidation(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+
+	cases := map[string][2]string{
+		"unknown bank": {"citi", "customer ID"},
+		"empty hint":   {"hdfc", "   "},
+		"long hint":    {"hdfc", strings.Repeat("x", maxPasswordHintLength+1)},
+	}
+	for name, c := range cases {
+		if _, err := svc.hints.SetHint(ctx, "u1", c[0], c[1]); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", name, err)
+		}
+	}
+}
+
+func TestGetHintsSkipsHintsThatNoLongerOpen(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.Open(ctx, ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	hintRepo := sqlite.NewPasswordHintRepository(db)
+
+	oldBox, err := secrets.NewBox("old-key")
+	if err != nil {
+		t.Fatalf("failed to create secrets box: %v", err)
+	}
+	newBox, err := secrets.NewBox("new-key")
+	if err != nil {
+		t.Fatalf("failed to create secrets box: %v", err)
+	}
+	before := NewPasswordHintService(hintRepo, DefaultBankParsers(), oldBox)
+	after := NewPasswordHintService(hintRepo, DefaultBankParsers(), newBox)
+
+	if _, err := before.SetHint(ctx, "u1", "hdfc", "customer ID"); err != nil {
+		t.Fatalf("SetHint() error = %v", err)
+	}
+	if _, err := after.SetHint(ctx, "u1", "sbi", "date of birth as DDMM"); err != nil {
+		t.Fatalf("SetHint() error = %v", err)
+	}
+
+	hints, err := after.GetHints(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetHints() error = %v", err)
+	}
+	if len(hints) != 1 || hints[0].Bank != "sbi" {
+		t.Errorf("expected only the sbi hint, got %+v", hints)
+	}
+	if _, err := after.GetHint(ctx, "u1", "hdfc"); err == nil {
+		t.Error("expected the hint sealed with the old key not to open")
+	}
+}
@@ -3,64 +3,183 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"nestmate-backend/internal/infrastructure/pdf"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 // Transaction represents a parsed bank transaction
 type Transaction struct {
-	Date        string
-	Description string
-	Amount      string
-	Type        string // Credit or Debit
+	Date        string `json:"date"`
+	Description string `json:"description"`
+	Amount      string `json:"amount"`
+	Type        string `json:"type"` // Credit or Debit
+	AccountID   string `json:"account_id,omitempty"`
 }
 
 // CategorizedTransaction represents a transaction with category
 type CategorizedTransaction struct {
 	Transaction
-	MainCategory entities.MainCategory
-	SubCategory  entities.SubCategory
-	Confidence   float64 // Confidence score of categorization
+	MainCategory entities.MainCategory `json:"main_category"`
+	SubCategory  entities.SubCategory  `json:"sub_category"`
+	Confidence   float64               `json:"confidence"` // Confidence score of categorization
+	RuleID       string                `json:"rule_id,omitempty"` // rule that matched, if any
+	NeedsReview  bool                  `json:"needs_review"` // confidence is too low to trust
 }
 
 // ParsedTransactions represents the result of parsing a bank statement
 type ParsedTransactions struct {
-	Transactions []*Transaction
-	BankName     string
-	AccountLast4 string
-	StatementPeriod string
+	Transactions []*Transaction `json:"transactions"`
+	BankName     string `json:"bank_name"`
+	AccountLast4 string `json:"account_last4"`
+	StatementPeriod string `json:"statement_period"`
 }
 
 // PDFParserService defines the interface for PDF parsing operations
 type PDFParserService interface {
-	ParseBankStatement(ctx context.Context, file []byte, bankType string) (*ParsedTransactions, error)
-	CategorizeTransactions(ctx context.Context, transactions []*Transaction) ([]*CategorizedTransaction, error)
-	ValidateAndSave(ctx context.Context, userID string, transactions []*CategorizedTransaction) error
+	ParseBankStatement(ctx context.Context, file []byte, bankType, password string) (*ParsedTransactions, error)
+	CategorizeTransactions(ctx context.Context, userID string, transactions []*Transaction) ([]*CategorizedTransaction, error)
+	RecordCorrection(ctx context.Context, correction *entities.CategoryCorrection) error
 }
 
 // pdfParserService implements the PDFParserService interface
 type pdfParserService struct {
-	// Dependencies will be injected here
+	parsers         *BankParserRegistry
+	ruleRepo        repositories.CategorizationRuleRepository
+	expenseRepo     repositories.ExpenseRepository
+	correctionRepo  repositories.CategoryCorrectionRepository
+	categoryService CategoryService
 }
 
-// NewPDFParserService creates a new PDF parser service
-func NewPDFParserService() PDFParserService {
-	return &pdfParserService{}
+// NewPDFParserService creates a new PDF parser service choosing from parsers
+func NewPDFParserService(parsers *BankParserRegistry, ruleRepo repositories.CategorizationRuleRepository, expenseRepo repositories.ExpenseRepository, correctionRepo repositories.CategoryCorrectionRepository, categoryService CategoryService) PDFParserService {
+	return &pdfParserService{
+		parsers:         parsers,
+		ruleRepo:        ruleRepo,
+		expenseRepo:     expenseRepo,
+		correctionRepo:  correctionRepo,
+		categoryService: categoryService,
+	}
 }
 
-// ParseBankStatement parses a bank statement PDF
-func (s *pdfParserService) ParseBankStatement(ctx context.Context, file []byte, bankType string) (*ParsedTransactions, error) {
-	// Implementation will be added in a future task
-	return nil, errors.New("not implemented")
+// ParseBankStatement parses a bank statement PDF with the parser registered
+// for bankType, or with the one recognising the statement's header when
+// bankType is empty. Password protected statements are decrypted with
+// password, which is ignored for statements without one.
+func (s *pdfParserService) ParseBankStatement(ctx context.Context, file []byte, bankType, password string) (*ParsedTransactions, error) {
+	doc, err := pdf.OpenWithPassword(file, password)
+	switch {
+	case errors.Is(err, pdf.ErrPasswordRequired):
+		return nil, fmt.Errorf("%w: the statement is password protected", ErrPasswordRequired)
+	case errors.Is(err, pdf.ErrWrongPassword):
+		return nil, fmt.Errorf("%w: the password doesn't open the statement", ErrWrongPassword)
+	case errors.Is(err, pdf.ErrUnsupportedEncryption):
+		return nil, fmt.Errorf("%w: the statement's encryption isn't supported: %v", ErrInvalidInput, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: the statement isn't a readable PDF: %v", ErrInvalidInput, err)
+	}
+	pages, err := doc.Pages()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read the statement: %v", ErrInvalidInput, err)
+	}
+
+	var parser BankStatementParser
+	var ok bool
+	if bankType = strings.TrimSpace(bankType); bankType != "" {
+		if parser, ok = s.parsers.Lookup(bankType); !ok {
+			return nil, fmt.Errorf("%w: unsupported bank type %q, expected one of %s",
+				ErrInvalidInput, bankType, strings.Join(s.parsers.Banks(), ", "))
+		}
+	} else if parser, ok = s.parsers.Detect(pages); !ok {
+		return nil, fmt.Errorf("%w: couldn't tell which bank the statement is from, pass a bank type: one of %s",
+			ErrInvalidInput, strings.Join(s.parsers.Banks(), ", "))
+	}
+	return parser.Parse(pages)
 }
 
-// CategorizeTransactions categorizes transactions based on rules
-func (s *pdfParserService) CategorizeTransactions(ctx context.Context, transactions []*Transaction) ([]*CategorizedTransaction, error) {
-	// Implementation will be added in a future task
-	return nil, errors.New("not implemented")
+// CategorizeTransactions categorizes transactions with the user's rules. Each
+// takes the categories of the first rule it matches, in priority order.
+// Transactions no rule matches fall back to a classifier trained on the
+// user's expenses and review corrections, whose probability becomes the
+// confidence. Those it can't place are left uncategorized with zero
+// confidence. Anything below reviewConfidence is flagged for review.
+func (s *pdfParserService) CategorizeTransactions(ctx context.Context, userID string, transactions []*Transaction) ([]*CategorizedTransaction, error) {
+	rules, err := loadRules(ctx, s.ruleRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	model, err := trainCategorizer(ctx, s.expenseRepo, s.correctionRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categorized := make([]*CategorizedTransaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		result := &CategorizedTransaction{Transaction: *transaction}
+		subject := transactionSubject(transaction)
+		if rule := matchRule(rules, subject); rule != nil {
+			result.MainCategory = entities.MainCategory(rule.rule.MainCategory)
+			result.SubCategory = entities.SubCategory(rule.rule.SubCategory)
+			result.Confidence = rule.confidence()
+			result.RuleID = rule.rule.ID
+		} else if class, probability, ok := model.predict(subjectFeatures(subject)); ok {
+			result.MainCategory = class.main
+			result.SubCategory = class.sub
+			result.Confidence = probability
+		}
+		result.NeedsReview = result.Confidence < reviewConfidence
+		categorized = append(categorized, result)
+	}
+	return categorized, nil
 }
 
-// ValidateAndSave validates and saves categorized transactions
-func (s *pdfParserService) ValidateAndSave(ctx context.Context, userID string, transactions []*CategorizedTransaction) error {
-	// Implementation will be added in a future task
-	return errors.New("not implemented")
-}
\ No newline at end of file
+// RecordCorrection saves the categories the user chose for a transaction
+// while reviewing a statement, so later suggestions learn from it
+func (s *pdfParserService) RecordCorrection(ctx context.Context, correction *entities.CategoryCorrection) error {
+	if correction.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	correction.Description = strings.TrimSpace(correction.Description)
+	if correction.Description == "" {
+		return fmt.Errorf("%w: description is required", ErrInvalidInput)
+	}
+	correction.Amount = correction.Amount.Abs()
+	correction.TransactionType = entities.TransactionType(strings.ToLower(string(correction.TransactionType)))
+	switch correction.TransactionType {
+	case "":
+		correction.TransactionType = entities.DebitTransaction
+	case entities.DebitTransaction, entities.CreditTransaction:
+	default:
+		return fmt.Errorf("%w: transaction type must be %q or %q", ErrInvalidInput, entities.DebitTransaction, entities.CreditTransaction)
+	}
+
+	main, err := lookupCategory(ctx, s.categoryService, correction.UserID, entities.MainCategoryKind, string(correction.MainCategory))
+	if err != nil {
+		return err
+	}
+	if main.Archived {
+		return fmt.Errorf("%w: main category %q is archived", ErrInvalidInput, main.Name)
+	}
+	sub, err := lookupCategory(ctx, s.categoryService, correction.UserID, entities.SubCategoryKind, string(correction.SubCategory))
+	if err != nil {
+		return err
+	}
+	if sub.Archived {
+		return fmt.Errorf("%w: sub-category %q is archived", ErrInvalidInput, sub.Name)
+	}
+	correction.MainCategory = entities.MainCategory(main.Name)
+	correction.SubCategory = entities.SubCategory(sub.Name)
+	correction.ID = uuid.NewString()
+	correction.CreatedAt = time.Now()
+
+	if err := s.correctionRepo.Create(ctx, toRepositoryCategoryCorrection(correction)); err != nil {
+		return fmt.Errorf("failed to save category correction: %w", err)
+	}
+	return nil
+}
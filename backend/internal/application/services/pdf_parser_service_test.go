@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/infrastructure/pdf"
+	"strings"
+	"testing"
+)
+
+// statementPDF draws a one-page statement. Each row is drawn on its own
+// baseline, 15 points below the one before, with its cells at columns.
+func statementPDF(columns []float64, rows ...[]string) []byte {
+	var content strings.Builder
+	y := 800
+	for _, row := range rows {
+		for i, text := range row {
+			if text == "" {
+				continue
+			}
+			escaped := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(text)
+			fmt.Fprintf(&content, "BT /F1 10 Tf 1 0 0 1 %g %d Tm (%s) Tj ET\n", columns[i], y, escaped)
+		}
+		y -= 15
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	out.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	out.WriteString("2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n")
+	out.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>\nendobj\n")
+	out.WriteString("4 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>\nendobj\n")
+	fmt.Fprintf(&out, "5 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", content.Len(), content.String())
+	out.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return out.Bytes()
+}
+
+// hdfcColumns are the column positions of the HDFC statements drawn in tests
+var hdfcColumns = []float64{30, 90, 280, 360, 430, 530, 630}
+
+func hdfcStatement() []byte {
+	return statementPDF(hdfcColumns,
+		[]string{"HDFC BANK Ltd."},
+		[]string{"Account No : 50100123456789"},
+		[]string{"Statement From : 01/04/2024 To : 30/04/2024"},
+		[]string{"Date", "Narration", "Chq./Ref.No.", "Value Dt", "Withdrawal Amt.", "Deposit Amt.", "Closing Balance"},
+		[]string{"01/04/24", "UPI-SWIGGY-swiggy@axis", "0000409112", "01/04/24", "450.00", "", "24,550.00"},
+		[]string{"", "-Food order"},
+		[]string{"03/04/24", "NEFT CR-ACME CORP SALARY", "N093456", "03/04/24", "", "85,000.00", "1,09,550.00"},
+		[]string{"05/04/24", "ATM WDL", "", "05/04/24", "2,000.00", "", "1,07,550.00"},
+		[]string{"STATEMENT SUMMARY :-"},
+	)
+}
+
+// lockedStatement is a statement encrypted with RC4 under a password no test knows
+func lockedStatement() []byte {
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	out.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	out.WriteString("2 0 obj\n<< /Type /Pages /Kids [] /Count 0 >>\nendobj\n")
+	fmt.Fprintf(&out, "3 0 obj\n<< /Filter /Standard /V 2 /R 3 /Length 128 /P -4 /O <%s> /U <%s> >>\nendobj\n",
+		strings.Repeat("ab", 32), strings.Repeat("cd", 32))
+	out.WriteString("trailer\n<< /Root 1 0 R /Encrypt 3 0 R /ID [<0123456789abcdef> <0123456789abcdef>] >>\n%%EOF\n")
+	return out.Bytes()
+}
+
+func TestParseBankStatementDetectsBank(t *testing.T) {
+	ctx := context.Background()
+	service := NewPDFParserService(DefaultBankParsers(), nil, nil, nil, nil)
+
+	parsed, err := service.ParseBankStatement(ctx, hdfcStatement(), "", "")
+	if err != nil {
+		t.Fatalf("ParseBankStatement: %v", err)
+	}
+	if parsed.BankName != "HDFC Bank" || parsed.AccountLast4 != "6789" || parsed.StatementPeriod != "2024-04-01 to 2024-04-30" {
+		t.Errorf("unexpected statement details %q, %q, %q", parsed.BankName, parsed.AccountLast4, parsed.StatementPeriod)
+	}
+
+	want := []Transaction{
+		{Date: "2024-04-01", Description: "UPI-SWIGGY-swiggy@axis -Food order", Amount: "450.00", Type: "Debit"},
+		{Date: "2024-04-03", Description: "NEFT CR-ACME CORP SALARY", Amount: "85000.00", Type: "Credit"},
+		{Date: "2024-04-05", Description: "ATM WDL", Amount: "2000.00", Type: "Debit"},
+	}
+	if len(parsed.Transactions) != len(want) {
+		t.Fatalf("expected %d transactions, got %d", len(want), len(parsed.Transactions))
+	}
+	for i, transaction := range parsed.Transactions {
+		if *transaction != want[i] {
+			t.Errorf("transaction %d: expected %+v, got %+v", i, want[i], *transaction)
+		}
+	}
+}
+
+func TestParseBankStatementWithBankType(t *testing.T) {
+	ctx := context.Background()
+	service := NewPDFParserService(DefaultBankParsers(), nil, nil, nil, nil)
+
+	sbi := statementPDF([]float64{30, 110, 190, 350, 430, 510, 590},
+		[]string{"STATE BANK OF INDIA"},
+		[]string{"Account Number : XXXXXXX4321"},
+		[]string{"Account Statement from 1 Apr 2024 to 30 Apr 2024"},
+		[]string{"Txn Date", "Value Date", "Description", "Ref No.", "Debit", "Credit", "Balance"},
+		[]string{"2 Apr 2024", "2 Apr 2024", "TO TRANSFER-INB Electricity", "", "1,180.00", "", "8,820.00"},
+		[]string{"9 Apr 2024", "9 Apr 2024", "BY TRANSFER-NEFT Refund", "", "", "220.50", "9,040.50"},
+	)
+	parsed, err := service.ParseBankStatement(ctx, sbi, "SBI", "")
+	if err != nil {
+		t.Fatalf("ParseBankStatement: %v", err)
+	}
+	if parsed.BankName != "State Bank of India" || parsed.AccountLast4 != "4321" || parsed.StatementPeriod != "2024-04-01 to 2024-04-30" {
+		t.Errorf("unexpected statement details %q, %q, %q", parsed.BankName, parsed.AccountLast4, parsed.StatementPeriod)
+	}
+	if len(parsed.Transactions) != 2 {
+		t.Fatalf("expected 2 transactions, got %d", len(parsed.Transactions))
+	}
+	if got := parsed.Transactions[1]; got.Date != "2024-04-09" || got.Amount != "220.50" || got.Type != "Credit" {
+		t.Errorf("unexpected transaction %+v", got)
+	}
+
+	// The wrong bank's parser finds no table it recognises
+	if _, err := service.ParseBankStatement(ctx, hdfcStatement(), "sbi", ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for the wrong bank, got %v", err)
+	}
+	if _, err := service.ParseBankStatement(ctx, hdfcStatement(), "unknown", ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an unknown bank, got %v", err)
+	}
+}
+
+func TestParseBankStatementRejectsUnreadableFiles(t *testing.T) {
+	ctx := context.Background()
+	service := NewPDFParserService(DefaultBankParsers(), nil, nil, nil, nil)
+
+	if _, err := service.ParseBankStatement(ctx, []byte("not a pdf"), "", ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+	unknown := statementPDF([]float64{30}, []string{"Some Cooperative Bank"})
+	if _, err := service.ParseBankStatement(ctx, unknown, "", ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for an undetected bank, got %v", err)
+	}
+}
+
+// fixedParser is a stand-in bank parser returning one transaction
+type fixedParser struct {
+	bank string
+}
+
+func (p fixedParser) Bank() string              { return p.bank }
+func (p fixedParser) Detect(header string) bool { return strings.Contains(header, "demo bank") }
+func (p fixedParser) Parse(pages []*pdf.Page) (*ParsedTransactions, error) {
+	return &ParsedTransactions{BankName: "Demo Bank", Transactions: []*Transaction{{Date: "2024-04-01", Amount: "1.00", Type: "Debit"}}}, nil
+}
+
+func TestBankParserRegistry(t *testing.T) {
+	registry := DefaultBankParsers()
+	if got := strings.Join(registry.Banks(), ","); got != "axis,hdfc,icici,kotak,sbi" {
+		t.Errorf("unexpected banks %s", got)
+	}
+	if parser, ok := registry.Lookup("ICICI"); !ok || parser.Bank() != "icici" {
+		t.Errorf("expected lookup to ignore case")
+	}
+
+	// Registering a parser is all a new bank needs
+	registry.Register(fixedParser{bank: "demo"})
+	service := NewPDFParserService(registry, nil, nil, nil, nil)
+	parsed, err := service.ParseBankStatement(context.Background(), statementPDF([]float64{30}, []string{"Demo Bank"}), "", "")
+	if err != nil {
+		t.Fatalf("ParseBankStatement: %v", err)
+	}
+	if parsed.BankName != "Demo Bank" || len(parsed.Transactions) != 1 {
+		t.Errorf("expected the registered parser to be used, got %+v", parsed)
+	}
+}
+
+func TestParseBankStatementPasswordErrors(t *testing.T) {
+	ctx := context.Background()
+	service := NewPDFParserService(DefaultBankParsers(), nil, nil, nil, nil)
+
+	if _, err := service.ParseBankStatement(ctx, lockedStatement(), "hdfc", ""); !errors.Is(err, ErrPasswordRequired) {
+		t.Errorf("expected ErrPasswordRequired without a password, got %v", err)
+	}
+	if _, err := service.ParseBankStatement(ctx, lockedStatement(), "hdfc", "guess"); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("expected ErrWrongPassword, got %v", err)
+	}
+
+	// The password is ignored for statements that aren't protected
+	if _, err := service.ParseBankStatement(ctx, hdfcStatement(), "", "guess"); err != nil {
+		t.Errorf("expected an unprotected statement to parse, got %v", err)
+	}
+}
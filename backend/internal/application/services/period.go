@@ -0,0 +1,30 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// startOfMonth normalizes t to midnight UTC on the first day of its month
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// fiscalYearStart returns midnight UTC on the first day of the financial year
+// containing t, for years beginning in startMonth
+func fiscalYearStart(t time.Time, startMonth time.Month) time.Time {
+	year := t.Year()
+	if t.Month() < startMonth {
+		year--
+	}
+	return time.Date(year, startMonth, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// fiscalYearLabel names the financial year starting at start, such as "2024-25",
+// or just "2024" when it is a calendar year
+func fiscalYearLabel(start time.Time) string {
+	if start.Month() == time.January {
+		return fmt.Sprintf("%d", start.Year())
+	}
+	return fmt.Sprintf("%d-%02d", start.Year(), (start.Year()+1)%100)
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"sort"
+	"time"
+)
+
+// startOfDay truncates t to midnight UTC, the form every recurrence date takes
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// validateRecurrenceRule checks a rule starting on start and fills in its defaults
+func validateRecurrenceRule(rule *entities.RecurrenceRule, start time.Time) error {
+	if rule == nil {
+		return fmt.Errorf("%w: recurrence rule is required", ErrInvalidInput)
+	}
+	if !rule.Frequency.IsValid() {
+		return fmt.Errorf("%w: frequency must be %q, %q, %q or %q", ErrInvalidInput, entities.Daily, entities.Weekly, entities.Monthly, entities.Yearly)
+	}
+	if rule.Interval < 0 {
+		return fmt.Errorf("%w: interval must not be negative", ErrInvalidInput)
+	}
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+	if rule.Count < 0 {
+		return fmt.Errorf("%w: count must not be negative", ErrInvalidInput)
+	}
+
+	if len(rule.DaysOfWeek) > 0 && rule.Frequency != entities.Weekly {
+		return fmt.Errorf("%w: days of week only apply to weekly rules", ErrInvalidInput)
+	}
+	seen := make(map[time.Weekday]bool, len(rule.DaysOfWeek))
+	for _, day := range rule.DaysOfWeek {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("%w: day of week %d must be between 0 (Sunday) and 6 (Saturday)", ErrInvalidInput, day)
+		}
+		if seen[day] {
+			return fmt.Errorf("%w: %s is listed twice", ErrInvalidInput, day)
+		}
+		seen[day] = true
+	}
+	sort.Slice(rule.DaysOfWeek, func(i, j int) bool { return rule.DaysOfWeek[i] < rule.DaysOfWeek[j] })
+
+	if rule.MonthDay != 0 && rule.Frequency != entities.Monthly {
+		return fmt.Errorf("%w: day of month only applies to monthly rules", ErrInvalidInput)
+	}
+	if rule.MonthDay < 0 || rule.MonthDay > 31 {
+		return fmt.Errorf("%w: day of month must be between 1 and 31", ErrInvalidInput)
+	}
+
+	if rule.EndDate != nil {
+		end := startOfDay(*rule.EndDate)
+		if end.Before(startOfDay(start)) {
+			return fmt.Errorf("%w: end date is before the start date", ErrInvalidInput)
+		}
+		rule.EndDate = &end
+	}
+	return nil
+}
+
+// walkRecurrence calls fn with every date rule produces from start, in order,
+// until fn returns false or the rule ends
+func walkRecurrence(rule *entities.RecurrenceRule, start time.Time, fn func(date time.Time) bool) {
+	start = startOfDay(start)
+	interval := rule.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	count := 0
+	for period := 0; ; period += interval {
+		for _, date := range periodDates(rule, start, period) {
+			if date.Before(start) {
+				continue
+			}
+			if rule.EndDate != nil && date.After(*rule.EndDate) {
+				return
+			}
+			if rule.Count > 0 && count >= rule.Count {
+				return
+			}
+			count++
+			if !fn(date) {
+				return
+			}
+		}
+	}
+}
+
+// periodDates lists the dates rule produces in the period-th day, week, month
+// or year after start, in order
+func periodDates(rule *entities.RecurrenceRule, start time.Time, period int) []time.Time {
+	switch rule.Frequency {
+	case entities.Weekly:
+		week := start.AddDate(0, 0, 7*period-int(start.Weekday()))
+		days := rule.DaysOfWeek
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		dates := make([]time.Time, len(days))
+		for i, day := range days {
+			dates[i] = week.AddDate(0, 0, int(day))
+		}
+		return dates
+	case entities.Monthly:
+		day := rule.MonthDay
+		if day == 0 {
+			day = start.Day()
+		}
+		return []time.Time{clampedDate(start.Year(), start.Month()+time.Month(period), day)}
+	case entities.Yearly:
+		return []time.Time{clampedDate(start.Year()+period, start.Month(), start.Day())}
+	default:
+		return []time.Time{start.AddDate(0, 0, period)}
+	}
+}
+
+// clampedDate builds a date, moving days past the end of the month back to
+// its last day so the 31st recurs on the 30th or 28th in shorter months
+func clampedDate(year int, month time.Month, day int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// recurrenceDates lists the dates rule produces from start that fall in [from, to)
+func recurrenceDates(rule *entities.RecurrenceRule, start, from, to time.Time) []time.Time {
+	var dates []time.Time
+	walkRecurrence(rule, start, func(date time.Time) bool {
+		if !date.Before(to) {
+			return false
+		}
+		if !date.Before(from) {
+			dates = append(dates, date)
+		}
+		return true
+	})
+	return dates
+}
+
+// nextRecurrence returns the first date rule produces from start that falls
+// after after, or nil once the rule has ended
+func nextRecurrence(rule *entities.RecurrenceRule, start, after time.Time) *time.Time {
+	var next *time.Time
+	walkRecurrence(rule, start, func(date time.Time) bool {
+		if date.After(after) {
+			next = &date
+			return false
+		}
+		return true
+	})
+	return next
+}
+
+// hasRecurrence reports whether rule produces date
+func hasRecurrence(rule *entities.RecurrenceRule, start, date time.Time) bool {
+	date = startOfDay(date)
+	return len(recurrenceDates(rule, start, date, date.AddDate(0, 0, 1))) == 1
+}
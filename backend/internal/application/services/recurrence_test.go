@@ -0,0 +1,111 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"nestmate-backend/internal/domain/entities"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRecurrenceDates(t *testing.T) {
+	end := date(2024, 3, 20)
+	cases := map[string]struct {
+		rule  entities.RecurrenceRule
+		start time.Time
+		want  []time.Time
+	}{
+		"monthly on the 31st clamps to month end": {
+			rule:  entities.RecurrenceRule{Frequency: entities.Monthly, Interval: 1},
+			start: date(2024, 1, 31),
+			want:  []time.Time{date(2024, 1, 31), date(2024, 2, 29), date(2024, 3, 31), date(2024, 4, 30)},
+		},
+		"monthly day before the start begins next month": {
+			rule:  entities.RecurrenceRule{Frequency: entities.Monthly, Interval: 2, MonthDay: 5},
+			start: date(2024, 1, 10),
+			want:  []time.Time{date(2024, 3, 5), date(2024, 5, 5)},
+		},
+		"fortnightly on two weekdays": {
+			rule:  entities.RecurrenceRule{Frequency: entities.Weekly, Interval: 2, DaysOfWeek: []time.Weekday{time.Monday, time.Thursday}},
+			start: date(2024, 3, 5), // a Tuesday
+			want:  []time.Time{date(2024, 3, 7), date(2024, 3, 18), date(2024, 3, 21), date(2024, 4, 1)},
+		},
+		"yearly from a leap day": {
+			rule:  entities.RecurrenceRule{Frequency: entities.Yearly, Interval: 1},
+			start: date(2024, 2, 29),
+			want:  []time.Time{date(2024, 2, 29), date(2025, 2, 28), date(2026, 2, 28), date(2027, 2, 28)},
+		},
+		"daily stops after count": {
+			rule:  entities.RecurrenceRule{Frequency: entities.Daily, Interval: 3, Count: 2},
+			start: date(2024, 3, 1),
+			want:  []time.Time{date(2024, 3, 1), date(2024, 3, 4)},
+		},
+		"daily stops at the end date": {
+			rule:  entities.RecurrenceRule{Frequency: entities.Daily, Interval: 10, EndDate: &end},
+			start: date(2024, 3, 1),
+			want:  []time.Time{date(2024, 3, 1), date(2024, 3, 11)},
+		},
+	}
+
+	for name, tc := range cases {
+		got := recurrenceDates(&tc.rule, tc.start, tc.start, date(2030, 1, 1))
+		if len(got) > len(tc.want) {
+			got = got[:len(tc.want)]
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got %v, want %v", name, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].Equal(tc.want[i]) {
+				t.Errorf("%s: date %d = %s, want %s", name, i, got[i].Format("2006-01-02"), tc.want[i].Format("2006-01-02"))
+			}
+		}
+	}
+}
+
+func TestNextRecurrence(t *testing.T) {
+	rule := &entities.RecurrenceRule{Frequency: entities.Monthly, Interval: 1, Count: 3}
+	start := date(2024, 1, 15)
+
+	if next := nextRecurrence(rule, start, date(2024, 1, 15)); next == nil || !next.Equal(date(2024, 2, 15)) {
+		t.Errorf("next after the first date = %v, want 2024-02-15", next)
+	}
+	if next := nextRecurrence(rule, start, date(2024, 3, 15)); next != nil {
+		t.Errorf("next after the last date = %v, want nil", next)
+	}
+	if !hasRecurrence(rule, start, date(2024, 2, 15)) || hasRecurrence(rule, start, date(2024, 2, 16)) {
+		t.Error("hasRecurrence should only match dates the rule produces")
+	}
+}
+
+func TestValidateRecurrenceRule(t *testing.T) {
+	start := date(2024, 3, 1)
+	before := date(2024, 2, 1)
+	cases := map[string]*entities.RecurrenceRule{
+		"missing":              nil,
+		"unknown frequency":    {Frequency: "hourly"},
+		"negative interval":    {Frequency: entities.Daily, Interval: -1},
+		"weekdays on monthly":  {Frequency: entities.Monthly, DaysOfWeek: []time.Weekday{time.Monday}},
+		"month day on weekly":  {Frequency: entities.Weekly, MonthDay: 3},
+		"month day too large":  {Frequency: entities.Monthly, MonthDay: 32},
+		"repeated weekday":     {Frequency: entities.Weekly, DaysOfWeek: []time.Weekday{time.Monday, time.Monday}},
+		"end before the start": {Frequency: entities.Daily, EndDate: &before},
+	}
+	for name, rule := range cases {
+		if err := validateRecurrenceRule(rule, start); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	rule := &entities.RecurrenceRule{Frequency: entities.Weekly, DaysOfWeek: []time.Weekday{time.Friday, time.Monday}}
+	if err := validateRecurrenceRule(rule, start); err != nil {
+		t.Fatalf("validateRecurrenceRule() error = %v", err)
+	}
+	if rule.Interval != 1 || rule.DaysOfWeek[0] != time.Monday {
+		t.Errorf("expected interval 1 and sorted weekdays, got %d and %v", rule.Interval, rule.DaysOfWeek)
+	}
+}
@@ -0,0 +1,478 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecurringExpenseService defines the interface for recurring expense operations
+type RecurringExpenseService interface {
+	CreateRecurringExpense(ctx context.Context, recurring *entities.RecurringExpense) error
+	GetRecurringExpenses(ctx context.Context, userID string) ([]*entities.RecurringExpense, error)
+	GetRecurringExpense(ctx context.Context, userID, id string) (*entities.RecurringExpense, error)
+	UpdateRecurringExpense(ctx context.Context, id string, recurring *entities.RecurringExpense) error
+	DeleteRecurringExpense(ctx context.Context, userID, id string) error
+	GetOccurrences(ctx context.Context, userID, id string, from, to time.Time) ([]*entities.RecurringOccurrence, error)
+	UpdateOccurrence(ctx context.Context, userID, id string, date time.Time, change *entities.OccurrenceChange) (*entities.RecurringOccurrence, error)
+	ResetOccurrence(ctx context.Context, userID, id string, date time.Time) error
+	GenerateDue(ctx context.Context, asOf time.Time) (int, error)
+}
+
+// recurringExpenseService implements the RecurringExpenseService interface
+type recurringExpenseService struct {
+	recurringRepo   repositories.RecurringExpenseRepository
+	eventRepo       repositories.EventRepository
+	categoryService CategoryService
+	currencyService CurrencyService
+}
+
+// NewRecurringExpenseService creates a new recurring expense service
+func NewRecurringExpenseService(recurringRepo repositories.RecurringExpenseRepository, eventRepo repositories.EventRepository, categoryService CategoryService, currencyService CurrencyService) RecurringExpenseService {
+	return &recurringExpenseService{
+		recurringRepo:   recurringRepo,
+		eventRepo:       eventRepo,
+		categoryService: categoryService,
+		currencyService: currencyService,
+	}
+}
+
+// CreateRecurringExpense creates a recurring expense. Occurrences from a start
+// date in the past are generated on the next run, so history can be backfilled.
+func (s *recurringExpenseService) CreateRecurringExpense(ctx context.Context, recurring *entities.RecurringExpense) error {
+	if err := s.validateRecurringExpense(ctx, recurring, nil); err != nil {
+		return err
+	}
+	recurring.NextDate = nextRecurrence(recurring.Rule, recurring.StartDate, recurring.StartDate.AddDate(0, 0, -1))
+	if recurring.NextDate == nil {
+		return fmt.Errorf("%w: the recurrence rule never produces a date", ErrInvalidInput)
+	}
+
+	now := time.Now()
+	recurring.ID = uuid.NewString()
+	recurring.CreatedAt = now
+	recurring.UpdatedAt = now
+
+	if err := s.recurringRepo.Create(ctx, toRepositoryRecurringExpense(recurring)); err != nil {
+		return fmt.Errorf("failed to save recurring expense: %w", err)
+	}
+	return nil
+}
+
+// GetRecurringExpenses gets the user's recurring expenses, soonest first
+func (s *recurringExpenseService) GetRecurringExpenses(ctx context.Context, userID string) ([]*entities.RecurringExpense, error) {
+	stored, err := s.recurringRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring expenses: %w", err)
+	}
+
+	list := make([]*entities.RecurringExpense, 0, len(stored))
+	for _, recurring := range stored {
+		list = append(list, toEntityRecurringExpense(recurring))
+	}
+	return list, nil
+}
+
+// GetRecurringExpense gets a single recurring expense owned by the user
+func (s *recurringExpenseService) GetRecurringExpense(ctx context.Context, userID, id string) (*entities.RecurringExpense, error) {
+	stored, err := s.getOwnedRecurringExpense(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return toEntityRecurringExpense(stored), nil
+}
+
+// UpdateRecurringExpense updates a recurring expense. Expenses already
+// generated are left alone; the new values apply from the next occurrence on.
+func (s *recurringExpenseService) UpdateRecurringExpense(ctx context.Context, id string, recurring *entities.RecurringExpense) error {
+	existing, err := s.getOwnedRecurringExpense(ctx, recurring.UserID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.validateRecurringExpense(ctx, recurring, existing); err != nil {
+		return err
+	}
+
+	// Pick up after the last date already generated, under the new rule
+	after := recurring.StartDate.AddDate(0, 0, -1)
+	if through, err := s.generatedThrough(ctx, existing); err != nil {
+		return err
+	} else if through != nil && through.After(after) {
+		after = *through
+	}
+	// Dates missed while paused are dropped rather than generated on resume
+	if existing.Paused && !recurring.Paused {
+		if yesterday := startOfDay(time.Now()).AddDate(0, 0, -1); yesterday.After(after) {
+			after = yesterday
+		}
+	}
+	recurring.NextDate = nextRecurrence(recurring.Rule, recurring.StartDate, after)
+
+	recurring.ID = id
+	recurring.CreatedAt = existing.CreatedAt
+	recurring.UpdatedAt = time.Now()
+
+	if err := s.recurringRepo.Update(ctx, toRepositoryRecurringExpense(recurring)); err != nil {
+		return fmt.Errorf("failed to update recurring expense: %w", err)
+	}
+	return nil
+}
+
+// DeleteRecurringExpense deletes a recurring expense, keeping the expenses it generated
+func (s *recurringExpenseService) DeleteRecurringExpense(ctx context.Context, userID, id string) error {
+	if _, err := s.getOwnedRecurringExpense(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.recurringRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete recurring expense: %w", err)
+	}
+	return nil
+}
+
+// GetOccurrences lists the occurrences of a recurring expense dated in [from, to)
+func (s *recurringExpenseService) GetOccurrences(ctx context.Context, userID, id string, from, to time.Time) ([]*entities.RecurringOccurrence, error) {
+	stored, err := s.getOwnedRecurringExpense(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: the range must end after it starts", ErrInvalidInput)
+	}
+	return recurringOccurrences(ctx, s.recurringRepo, toEntityRecurringExpense(stored), startOfDay(from), startOfDay(to))
+}
+
+// UpdateOccurrence skips or edits a single occurrence that hasn't been generated yet
+func (s *recurringExpenseService) UpdateOccurrence(ctx context.Context, userID, id string, date time.Time, change *entities.OccurrenceChange) (*entities.RecurringOccurrence, error) {
+	recurring, err := s.getPendingOccurrence(ctx, userID, id, date)
+	if err != nil {
+		return nil, err
+	}
+	if change.Amount != nil && !change.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be greater than zero", ErrInvalidInput)
+	}
+
+	date = startOfDay(date)
+	occurrence := &repositories.RecurringOccurrence{
+		RecurringID: id,
+		Date:        date,
+		Skipped:     change.Skip,
+		Amount:      change.Amount,
+		Description: change.Description,
+	}
+	if err := s.recurringRepo.SaveOccurrence(ctx, occurrence); err != nil {
+		return nil, fmt.Errorf("failed to save occurrence: %w", err)
+	}
+
+	occurrences, err := recurringOccurrences(ctx, s.recurringRepo, recurring, date, date.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	return occurrences[0], nil
+}
+
+// ResetOccurrence undoes any skip or edit made to a single upcoming occurrence
+func (s *recurringExpenseService) ResetOccurrence(ctx context.Context, userID, id string, date time.Time) error {
+	if _, err := s.getPendingOccurrence(ctx, userID, id, date); err != nil {
+		return err
+	}
+	err := s.recurringRepo.DeleteOccurrence(ctx, id, startOfDay(date))
+	if errors.Is(err, repositories.ErrNotFound) {
+		return fmt.Errorf("occurrence on %s has no changes: %w", date.Format("2006-01-02"), ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reset occurrence: %w", err)
+	}
+	return nil
+}
+
+// GenerateDue generates the expenses for every occurrence due on or before
+// asOf, across all users, and returns how many were created. A recurring
+// expense that fails is logged and retried on the next run.
+func (s *recurringExpenseService) GenerateDue(ctx context.Context, asOf time.Time) (int, error) {
+	asOf = startOfDay(asOf)
+	due, err := s.recurringRepo.GetDue(ctx, asOf)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due recurring expenses: %w", err)
+	}
+
+	generated := 0
+	for _, stored := range due {
+		n, err := s.generate(ctx, toEntityRecurringExpense(stored), asOf)
+		generated += n
+		if err != nil {
+			log.Printf("Warning: failed to generate recurring expense %s: %v", stored.ID, err)
+		}
+	}
+	return generated, nil
+}
+
+// generate creates the expenses for a recurring expense's occurrences up to
+// and including asOf, one transaction per occurrence
+func (s *recurringExpenseService) generate(ctx context.Context, recurring *entities.RecurringExpense, asOf time.Time) (int, error) {
+	occurrences, err := recurringOccurrences(ctx, s.recurringRepo, recurring, *recurring.NextDate, asOf.AddDate(0, 0, 1))
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, occurrence := range occurrences {
+		if occurrence.ExpenseID != "" {
+			continue
+		}
+		stored := &repositories.RecurringOccurrence{
+			RecurringID: recurring.ID,
+			Date:        occurrence.Date,
+			Skipped:     occurrence.Skipped,
+		}
+		if occurrence.Edited {
+			stored.Amount = &occurrence.Amount
+			stored.Description = &occurrence.Description
+		}
+
+		var expense *repositories.Expense
+		if !occurrence.Skipped {
+			now := time.Now()
+			generatedExpense := &entities.Expense{
+				ID:           uuid.NewString(),
+				UserID:       recurring.UserID,
+				Amount:       occurrence.Amount,
+				Currency:     occurrence.Currency,
+				Description:  occurrence.Description,
+				Date:         occurrence.Date,
+				MainCategory: occurrence.MainCategory,
+				SubCategory:  occurrence.SubCategory,
+				CreatedAt:    now,
+				UpdatedAt:    now,
+			}
+			if err := attachToEvent(ctx, s.eventRepo, generatedExpense); err != nil {
+				return generated, err
+			}
+			expense = toRepositoryExpense(generatedExpense)
+		}
+
+		next := nextRecurrence(recurring.Rule, recurring.StartDate, occurrence.Date)
+		if err := s.recurringRepo.Generate(ctx, stored, expense, next); err != nil {
+			return generated, err
+		}
+		if expense != nil {
+			generated++
+		}
+	}
+	return generated, nil
+}
+
+// getOwnedRecurringExpense loads a recurring expense and hides it from other users
+func (s *recurringExpenseService) getOwnedRecurringExpense(ctx context.Context, userID, id string) (*repositories.RecurringExpense, error) {
+	stored, err := s.recurringRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("recurring expense %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring expense: %w", err)
+	}
+	if stored.UserID != userID {
+		return nil, fmt.Errorf("recurring expense %s: %w", id, ErrNotFound)
+	}
+	return stored, nil
+}
+
+// getPendingOccurrence checks that date is an occurrence of the user's
+// recurring expense that hasn't been generated yet
+func (s *recurringExpenseService) getPendingOccurrence(ctx context.Context, userID, id string, date time.Time) (*entities.RecurringExpense, error) {
+	stored, err := s.getOwnedRecurringExpense(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	recurring := toEntityRecurringExpense(stored)
+	if !hasRecurrence(recurring.Rule, recurring.StartDate, date) {
+		return nil, fmt.Errorf("no occurrence on %s: %w", date.Format("2006-01-02"), ErrNotFound)
+	}
+	if recurring.NextDate == nil || startOfDay(date).Before(*recurring.NextDate) {
+		return nil, fmt.Errorf("%w: the occurrence on %s has already been generated", ErrInvalidInput, date.Format("2006-01-02"))
+	}
+	return recurring, nil
+}
+
+// generatedThrough returns the date of the last occurrence already generated
+// or skipped, or nil if none has been
+func (s *recurringExpenseService) generatedThrough(ctx context.Context, stored *repositories.RecurringExpense) (*time.Time, error) {
+	if stored.NextDate != nil {
+		through := stored.NextDate.AddDate(0, 0, -1)
+		return &through, nil
+	}
+
+	// Every date up to the end of the rule has been handled, and each one is
+	// recorded as an occurrence
+	occurrences, err := s.recurringRepo.GetOccurrences(ctx, stored.ID, stored.StartDate, stored.StartDate.AddDate(1000, 0, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrences: %w", err)
+	}
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+	return &occurrences[len(occurrences)-1].Date, nil
+}
+
+// validateRecurringExpense checks a recurring expense and normalizes its
+// categories, currency and dates. existing is the stored version when updating.
+func (s *recurringExpenseService) validateRecurringExpense(ctx context.Context, recurring *entities.RecurringExpense, existing *repositories.RecurringExpense) error {
+	if recurring.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	if !recurring.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidInput)
+	}
+	if recurring.StartDate.IsZero() {
+		return fmt.Errorf("%w: start date is required", ErrInvalidInput)
+	}
+	recurring.StartDate = startOfDay(recurring.StartDate)
+	if err := validateRecurrenceRule(recurring.Rule, recurring.StartDate); err != nil {
+		return err
+	}
+
+	// Reuse the expense checks so a template can always produce a valid expense
+	expense := &entities.Expense{
+		UserID:       recurring.UserID,
+		Amount:       recurring.Amount,
+		Date:         recurring.StartDate,
+		MainCategory: recurring.MainCategory,
+		SubCategory:  recurring.SubCategory,
+	}
+	if err := validateExpense(expense); err != nil {
+		return err
+	}
+	var current *repositories.Expense
+	if existing != nil {
+		current = &repositories.Expense{MainCategory: existing.MainCategory, SubCategory: existing.SubCategory}
+	}
+	if err := resolveCategories(ctx, s.categoryService, expense, current); err != nil {
+		return err
+	}
+	recurring.MainCategory = expense.MainCategory
+	recurring.SubCategory = expense.SubCategory
+
+	currency, err := resolveCurrency(ctx, s.currencyService, recurring.UserID, recurring.Currency)
+	if err != nil {
+		return err
+	}
+	recurring.Currency = currency
+	return nil
+}
+
+// recurringOccurrences lists the occurrences of a recurring expense dated in
+// [from, to), with any change made to a single date applied. Generated
+// occurrences are included even if the rule has since changed.
+func recurringOccurrences(ctx context.Context, repo repositories.RecurringExpenseRepository, recurring *entities.RecurringExpense, from, to time.Time) ([]*entities.RecurringOccurrence, error) {
+	stored, err := repo.GetOccurrences(ctx, recurring.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrences: %w", err)
+	}
+	changes := make(map[int64]*repositories.RecurringOccurrence, len(stored))
+	for _, occurrence := range stored {
+		changes[occurrence.Date.Unix()] = occurrence
+	}
+
+	dates := recurrenceDates(recurring.Rule, recurring.StartDate, from, to)
+	produced := make(map[int64]bool, len(dates))
+	for _, date := range dates {
+		produced[date.Unix()] = true
+	}
+	for _, occurrence := range stored {
+		if occurrence.ExpenseID != "" && !produced[occurrence.Date.Unix()] {
+			dates = append(dates, occurrence.Date)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	occurrences := make([]*entities.RecurringOccurrence, 0, len(dates))
+	for _, date := range dates {
+		occurrence := &entities.RecurringOccurrence{
+			RecurringID:  recurring.ID,
+			Date:         date,
+			Amount:       recurring.Amount,
+			Currency:     recurring.Currency,
+			Description:  recurring.Description,
+			MainCategory: recurring.MainCategory,
+			SubCategory:  recurring.SubCategory,
+		}
+		if change, ok := changes[date.Unix()]; ok {
+			occurrence.Skipped = change.Skipped
+			occurrence.ExpenseID = change.ExpenseID
+			if change.Amount != nil {
+				occurrence.Amount = *change.Amount
+				occurrence.Edited = true
+			}
+			if change.Description != nil {
+				occurrence.Description = *change.Description
+				occurrence.Edited = true
+			}
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences, nil
+}
+
+// toRepositoryRecurringExpense converts a recurring expense entity to its repository model
+func toRepositoryRecurringExpense(recurring *entities.RecurringExpense) *repositories.RecurringExpense {
+	days := make([]int, len(recurring.Rule.DaysOfWeek))
+	for i, day := range recurring.Rule.DaysOfWeek {
+		days[i] = int(day)
+	}
+	return &repositories.RecurringExpense{
+		ID:           recurring.ID,
+		UserID:       recurring.UserID,
+		Amount:       recurring.Amount,
+		Currency:     recurring.Currency,
+		Description:  recurring.Description,
+		MainCategory: string(recurring.MainCategory),
+		SubCategory:  string(recurring.SubCategory),
+		Frequency:    string(recurring.Rule.Frequency),
+		Interval:     recurring.Rule.Interval,
+		DaysOfWeek:   days,
+		MonthDay:     recurring.Rule.MonthDay,
+		EndDate:      recurring.Rule.EndDate,
+		Count:        recurring.Rule.Count,
+		StartDate:    recurring.StartDate,
+		NextDate:     recurring.NextDate,
+		Paused:       recurring.Paused,
+		CreatedAt:    recurring.CreatedAt,
+		UpdatedAt:    recurring.UpdatedAt,
+	}
+}
+
+// toEntityRecurringExpense converts a repository recurring expense model to an entity
+func toEntityRecurringExpense(recurring *repositories.RecurringExpense) *entities.RecurringExpense {
+	days := make([]time.Weekday, len(recurring.DaysOfWeek))
+	for i, day := range recurring.DaysOfWeek {
+		days[i] = time.Weekday(day)
+	}
+	return &entities.RecurringExpense{
+		ID:           recurring.ID,
+		UserID:       recurring.UserID,
+		Amount:       recurring.Amount,
+		Currency:     recurring.Currency,
+		Description:  recurring.Description,
+		MainCategory: entities.MainCategory(recurring.MainCategory),
+		SubCategory:  entities.SubCategory(recurring.SubCategory),
+		Rule: &entities.RecurrenceRule{
+			Frequency:  entities.RecurrenceFrequency(recurring.Frequency),
+			Interval:   recurring.Interval,
+			DaysOfWeek: days,
+			MonthDay:   recurring.MonthDay,
+			EndDate:    recurring.EndDate,
+			Count:      recurring.Count,
+		},
+		StartDate: recurring.StartDate,
+		NextDate:  recurring.NextDate,
+		Paused:    recurring.Paused,
+		CreatedAt: recurring.CreatedAt,
+		UpdatedAt: recurring.UpdatedAt,
+	}
+}
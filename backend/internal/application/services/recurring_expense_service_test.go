@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func newTestRecurringExpense(userID, amount string, start time.Time, rule entities.RecurrenceRule) *entities.RecurringExpense {
+	return &entities.RecurringExpense{
+		UserID:       userID,
+		Amount:       decimal.RequireFromString(amount),
+		Description:  "Rent",
+		MainCategory: entities.ChennaiHouse,
+		SubCategory:  entities.Misc,
+		Rule:         &rule,
+		StartDate:    start,
+	}
+}
+
+func TestGenerateDueRecurringExpenses(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	rent := newTestRecurringExpense("u1", "25000", date(2024, 1, 31), entities.RecurrenceRule{Frequency: entities.Monthly})
+	if err := svc.recurring.CreateRecurringExpense(ctx, rent); err != nil {
+		t.Fatalf("CreateRecurringExpense() error = %v", err)
+	}
+	if rent.NextDate == nil || !rent.NextDate.Equal(date(2024, 1, 31)) {
+		t.Fatalf("next date = %v, want 2024-01-31", rent.NextDate)
+	}
+
+	// Skip February and pay a different amount in March
+	if _, err := svc.recurring.UpdateOccurrence(ctx, "u1", rent.ID, date(2024, 2, 29), &entities.OccurrenceChange{Skip: true}); err != nil {
+		t.Fatalf("UpdateOccurrence() skip error = %v", err)
+	}
+	raised := decimal.NewFromInt(26000)
+	edited, err := svc.recurring.UpdateOccurrence(ctx, "u1", rent.ID, date(2024, 3, 31), &entities.OccurrenceChange{Amount: &raised})
+	if err != nil {
+		t.Fatalf("UpdateOccurrence() edit error = %v", err)
+	}
+	if !edited.Edited || !edited.Amount.Equal(raised) {
+		t.Errorf("edited occurrence = %+v, want amount 26000", edited)
+	}
+	if _, err := svc.recurring.UpdateOccurrence(ctx, "u1", rent.ID, date(2024, 3, 15), &entities.OccurrenceChange{Skip: true}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("UpdateOccurrence() on a date the rule skips error = %v, want ErrNotFound", err)
+	}
+
+	generated, err := svc.recurring.GenerateDue(ctx, date(2024, 4, 15))
+	if err != nil {
+		t.Fatalf("GenerateDue() error = %v", err)
+	}
+	if generated != 2 {
+		t.Errorf("generated %d expenses, want 2", generated)
+	}
+	if again, _ := svc.recurring.GenerateDue(ctx, date(2024, 4, 15)); again != 0 {
+		t.Errorf("second run generated %d expenses, want 0", again)
+	}
+
+	expenses, err := svc.expenses.GetExpensesByPeriod(ctx, "u1", date(2024, 1, 1), date(2024, 5, 1))
+	if err != nil {
+		t.Fatalf("GetExpensesByPeriod() error = %v", err)
+	}
+	if len(expenses) != 2 {
+		t.Fatalf("got %d expenses, want 2", len(expenses))
+	}
+	total := expenses[0].Amount.Add(expenses[1].Amount)
+	if !total.Equal(decimal.NewFromInt(51000)) {
+		t.Errorf("generated total = %s, want 51000", total)
+	}
+
+	occurrences, err := svc.recurring.GetOccurrences(ctx, "u1", rent.ID, date(2024, 1, 1), date(2024, 5, 1))
+	if err != nil {
+		t.Fatalf("GetOccurrences() error = %v", err)
+	}
+	if len(occurrences) != 4 || occurrences[0].ExpenseID == "" || !occurrences[1].Skipped || occurrences[3].ExpenseID != "" {
+		t.Errorf("occurrences = %+v, want Jan generated, Feb skipped and Apr pending", occurrences)
+	}
+
+	// Generated occurrences can no longer change
+	if _, err := svc.recurring.UpdateOccurrence(ctx, "u1", rent.ID, date(2024, 1, 31), &entities.OccurrenceChange{Skip: true}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("UpdateOccurrence() on a generated date error = %v, want ErrInvalidInput", err)
+	}
+	stored, err := svc.recurring.GetRecurringExpense(ctx, "u1", rent.ID)
+	if err != nil {
+		t.Fatalf("GetRecurringExpense() error = %v", err)
+	}
+	if stored.NextDate == nil || !stored.NextDate.Equal(date(2024, 4, 30)) {
+		t.Errorf("next date = %v, want 2024-04-30", stored.NextDate)
+	}
+}
+
+func TestRecurringExpenseEndsAfterCount(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	rule := entities.RecurrenceRule{Frequency: entities.Weekly, DaysOfWeek: []time.Weekday{time.Monday, time.Thursday}, Count: 3}
+	recurring := newTestRecurringExpense("u1", "500", date(2024, 3, 4), rule)
+	if err := svc.recurring.CreateRecurringExpense(ctx, recurring); err != nil {
+		t.Fatalf("CreateRecurringExpense() error = %v", err)
+	}
+
+	if generated, err := svc.recurring.GenerateDue(ctx, date(2024, 4, 1)); err != nil || generated != 3 {
+		t.Fatalf("GenerateDue() = %d, %v, want 3", generated, err)
+	}
+	stored, err := svc.recurring.GetRecurringExpense(ctx, "u1", recurring.ID)
+	if err != nil {
+		t.Fatalf("GetRecurringExpense() error = %v", err)
+	}
+	if stored.NextDate != nil {
+		t.Errorf("next date = %v, want nil once the count is reached", stored.NextDate)
+	}
+
+	// Raising the count picks up after the last generated date
+	stored.Rule.Count = 4
+	if err := svc.recurring.UpdateRecurringExpense(ctx, stored.ID, stored); err != nil {
+		t.Fatalf("UpdateRecurringExpense() error = %v", err)
+	}
+	if stored.NextDate == nil || !stored.NextDate.Equal(date(2024, 3, 14)) {
+		t.Errorf("next date after raising the count = %v, want 2024-03-14", stored.NextDate)
+	}
+}
+
+func TestRecurringExpenseValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	start := date(2024, 3, 1)
+
+	unknownCategory := newTestRecurringExpense("u1", "100", start, entities.RecurrenceRule{Frequency: entities.Monthly})
+	unknownCategory.SubCategory = "Gadgets"
+	cases := map[string]*entities.RecurringExpense{
+		"zero amount":      newTestRecurringExpense("u1", "0", start, entities.RecurrenceRule{Frequency: entities.Monthly}),
+		"missing start":    newTestRecurringExpense("u1", "100", time.Time{}, entities.RecurrenceRule{Frequency: entities.Monthly}),
+		"bad rule":         newTestRecurringExpense("u1", "100", start, entities.RecurrenceRule{Frequency: entities.Weekly, MonthDay: 2}),
+		"unknown category": unknownCategory,
+	}
+	for name, recurring := range cases {
+		if err := svc.recurring.CreateRecurringExpense(ctx, recurring); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: CreateRecurringExpense() error = %v, want ErrInvalidInput", name, err)
+		}
+	}
+
+	recurring := newTestRecurringExpense("u1", "100", start, entities.RecurrenceRule{Frequency: entities.Monthly})
+	if err := svc.recurring.CreateRecurringExpense(ctx, recurring); err != nil {
+		t.Fatalf("CreateRecurringExpense() error = %v", err)
+	}
+	if _, err := svc.recurring.GetRecurringExpense(ctx, "u2", recurring.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRecurringExpense() by another user error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestBreakdownProjectsRecurringExpenses(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	// The last day of the current month is always still to come
+	current := startOfMonth(time.Now())
+	recurring := newTestRecurringExpense("u1", "799", current.AddDate(0, 1, -1), entities.RecurrenceRule{Frequency: entities.Monthly})
+	if err := svc.recurring.CreateRecurringExpense(ctx, recurring); err != nil {
+		t.Fatalf("CreateRecurringExpense() error = %v", err)
+	}
+	if _, err := svc.expenses.AddExpense(ctx, newTestExpense("u1", "200", current, entities.Self, entities.Food)); err != nil {
+		t.Fatalf("AddExpense() error = %v", err)
+	}
+
+	breakdown, err := svc.expenses.GetMonthlyBreakdown(ctx, "u1", current, entities.CashBasis)
+	if err != nil {
+		t.Fatalf("GetMonthlyBreakdown() error = %v", err)
+	}
+	projection := breakdown.Projection
+	if projection == nil || len(projection.Upcoming) != 1 {
+		t.Fatalf("projection = %+v, want one upcoming occurrence", projection)
+	}
+	if !projection.Total.Equal(decimal.NewFromInt(799)) || !projection.Expenses.Equal(decimal.NewFromInt(999)) {
+		t.Errorf("projection total %s and expenses %s, want 799 and 999", projection.Total, projection.Expenses)
+	}
+
+	past, err := svc.expenses.GetMonthlyBreakdown(ctx, "u1", current.AddDate(0, -1, 0), entities.CashBasis)
+	if err != nil {
+		t.Fatalf("GetMonthlyBreakdown() error = %v", err)
+	}
+	if past.Projection != nil {
+		t.Error("only the current month should be projected")
+	}
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ReimbursementService defines the interface for reimbursement and refund operations
+type ReimbursementService interface {
+	AddReimbursement(ctx context.Context, reimbursement *entities.Reimbursement) error
+	GetReimbursements(ctx context.Context, userID, expenseID string) ([]*entities.Reimbursement, error)
+	DeleteReimbursement(ctx context.Context, userID, id string) error
+	GetPending(ctx context.Context, userID, payer string) (*entities.PendingReimbursements, error)
+}
+
+// reimbursementService implements the ReimbursementService interface
+type reimbursementService struct {
+	reimbursementRepo repositories.ReimbursementRepository
+	expenseRepo       repositories.ExpenseRepository
+	currencyService   CurrencyService
+}
+
+// NewReimbursementService creates a new reimbursement service
+func NewReimbursementService(reimbursementRepo repositories.ReimbursementRepository, expenseRepo repositories.ExpenseRepository, currencyService CurrencyService) ReimbursementService {
+	return &reimbursementService{
+		reimbursementRepo: reimbursementRepo,
+		expenseRepo:       expenseRepo,
+		currencyService:   currencyService,
+	}
+}
+
+// AddReimbursement records money received back against an expense. It defaults
+// to today, the expense's currency and expected payer, and to a reimbursement
+// for reimbursable expenses or a refund otherwise. Everything received can't
+// add up to more than the expense.
+func (s *reimbursementService) AddReimbursement(ctx context.Context, reimbursement *entities.Reimbursement) error {
+	if reimbursement.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	if reimbursement.ExpenseID == "" {
+		return fmt.Errorf("%w: expense ID is required", ErrInvalidInput)
+	}
+	expense, err := getOwnedExpense(ctx, s.expenseRepo, reimbursement.UserID, reimbursement.ExpenseID)
+	if err != nil {
+		return err
+	}
+
+	if reimbursement.Kind == "" {
+		reimbursement.Kind = entities.ReimbursementKindRefund
+		if expense.Reimbursable {
+			reimbursement.Kind = entities.ReimbursementKindReimbursement
+		}
+	}
+	if reimbursement.Kind != entities.ReimbursementKindReimbursement && reimbursement.Kind != entities.ReimbursementKindRefund {
+		return fmt.Errorf("%w: kind must be %q or %q", ErrInvalidInput, entities.ReimbursementKindReimbursement, entities.ReimbursementKindRefund)
+	}
+	if !reimbursement.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidInput)
+	}
+	if reimbursement.Date.IsZero() {
+		reimbursement.Date = time.Now()
+	}
+	if startOfDay(reimbursement.Date).Before(startOfDay(expense.Date)) {
+		return fmt.Errorf("%w: date can't be before the expense", ErrInvalidInput)
+	}
+
+	if reimbursement.Currency == "" {
+		reimbursement.Currency = expense.Currency
+	}
+	if reimbursement.Currency, err = normalizeCurrency(reimbursement.Currency); err != nil {
+		return err
+	}
+	reimbursement.Payer = strings.TrimSpace(reimbursement.Payer)
+	if reimbursement.Payer == "" && reimbursement.Kind == entities.ReimbursementKindReimbursement {
+		reimbursement.Payer = expense.ExpectedPayer
+	}
+	reimbursement.Note = strings.TrimSpace(reimbursement.Note)
+
+	existing, err := s.reimbursementRepo.GetByExpense(ctx, expense.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get reimbursements: %w", err)
+	}
+	received, err := totalReceived(ctx, s.currencyService, expense.UserID, expense.Currency, append(existing, toRepositoryReimbursement(reimbursement)))
+	if err != nil {
+		return err
+	}
+	if received.GreaterThan(expense.Amount) {
+		return fmt.Errorf("%w: reimbursements would add up to %s %s, more than the expense's %s",
+			ErrInvalidInput, received, expense.Currency, expense.Amount)
+	}
+
+	reimbursement.ID = uuid.NewString()
+	reimbursement.CreatedAt = time.Now()
+	if err := s.reimbursementRepo.Create(ctx, toRepositoryReimbursement(reimbursement)); err != nil {
+		return fmt.Errorf("failed to save reimbursement: %w", err)
+	}
+	return nil
+}
+
+// GetReimbursements gets everything received back against an expense, oldest first
+func (s *reimbursementService) GetReimbursements(ctx context.Context, userID, expenseID string) ([]*entities.Reimbursement, error) {
+	if _, err := getOwnedExpense(ctx, s.expenseRepo, userID, expenseID); err != nil {
+		return nil, err
+	}
+	stored, err := s.reimbursementRepo.GetByExpense(ctx, expenseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reimbursements: %w", err)
+	}
+
+	reimbursements := make([]*entities.Reimbursement, 0, len(stored))
+	for _, reimbursement := range stored {
+		reimbursements = append(reimbursements, toEntityReimbursement(reimbursement))
+	}
+	return reimbursements, nil
+}
+
+// DeleteReimbursement deletes a reimbursement owned by the user
+func (s *reimbursementService) DeleteReimbursement(ctx context.Context, userID, id string) error {
+	stored, err := s.reimbursementRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return fmt.Errorf("reimbursement %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get reimbursement: %w", err)
+	}
+	if stored.UserID != userID {
+		return fmt.Errorf("reimbursement %s: %w", id, ErrNotFound)
+	}
+
+	if err := s.reimbursementRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete reimbursement: %w", err)
+	}
+	return nil
+}
+
+// GetPending lists reimbursable expenses that haven't been fully paid back,
+// optionally only those expected from payer
+func (s *reimbursementService) GetPending(ctx context.Context, userID, payer string) (*entities.PendingReimbursements, error) {
+	base, err := s.currencyService.BaseCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.expenseRepo.GetByUserID(ctx, userID, &repositories.ExpenseFilters{Reimbursable: true, OldestFirst: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+
+	payer = strings.TrimSpace(payer)
+	today := startOfDay(time.Now())
+	pending := &entities.PendingReimbursements{
+		Currency: base,
+		Total:    decimal.Zero,
+		Expenses: []*entities.PendingReimbursement{},
+	}
+	for _, expense := range stored {
+		if payer != "" && !strings.EqualFold(expense.ExpectedPayer, payer) {
+			continue
+		}
+
+		reimbursements, err := s.reimbursementRepo.GetByExpense(ctx, expense.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reimbursements: %w", err)
+		}
+		received, err := totalReceived(ctx, s.currencyService, expense.UserID, expense.Currency, reimbursements)
+		if err != nil {
+			return nil, err
+		}
+		outstanding := expense.Amount.Sub(received)
+		if !outstanding.IsPositive() {
+			continue
+		}
+
+		inBase, err := s.currencyService.Convert(ctx, userID, outstanding, expense.Currency, base, expense.Date)
+		if err != nil {
+			return nil, err
+		}
+		pending.Total = pending.Total.Add(inBase)
+		pending.Expenses = append(pending.Expenses, &entities.PendingReimbursement{
+			Expense:     toEntityExpense(expense),
+			Received:    received,
+			Outstanding: outstanding,
+			DaysPending: int(today.Sub(startOfDay(expense.Date)).Hours() / 24),
+		})
+	}
+	return pending, nil
+}
+
+// totalReceived totals reimbursements in currency, each converted at its own date
+func totalReceived(ctx context.Context, currencyService CurrencyService, userID, currency string, reimbursements []*repositories.Reimbursement) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for _, reimbursement := range reimbursements {
+		amount, err := currencyService.Convert(ctx, userID, reimbursement.Amount, reimbursement.Currency, currency, reimbursement.Date)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		total = total.Add(amount)
+	}
+	return total, nil
+}
+
+// checkReimbursements stops an edit from leaving an expense smaller than what
+// has already been received back against it
+func checkReimbursements(ctx context.Context, reimbursementRepo repositories.ReimbursementRepository, currencyService CurrencyService, expense *entities.Expense) error {
+	existing, err := reimbursementRepo.GetByExpense(ctx, expense.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get reimbursements: %w", err)
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+	received, err := totalReceived(ctx, currencyService, expense.UserID, expense.Currency, existing)
+	if err != nil {
+		return err
+	}
+	if received.GreaterThan(expense.Amount) {
+		return fmt.Errorf("%w: %s %s has already been received back against expense %s",
+			ErrInvalidInput, received, expense.Currency, expense.ID)
+	}
+	return nil
+}
+
+// validateReimbursable checks an expense's reimbursement details. An expected
+// payer only makes sense for reimbursable expenses.
+func validateReimbursable(expense *entities.Expense) error {
+	expense.ExpectedPayer = strings.TrimSpace(expense.ExpectedPayer)
+	if expense.ExpectedPayer != "" && !expense.Reimbursable {
+		return fmt.Errorf("%w: expected payer requires a reimbursable expense", ErrInvalidInput)
+	}
+	return nil
+}
+
+// reimbursedIn totals what was received back against a breakdown's expenses,
+// converted to currency. Amortized shares count the same share of what their
+// expense got back.
+func reimbursedIn(ctx context.Context, currencyService CurrencyService, userID string, month time.Time, basis entities.Basis, expenses []*entities.Expense, reimbursements []*repositories.Reimbursement, currency string) (decimal.Decimal, error) {
+	received := make(map[string]decimal.Decimal)
+	for _, reimbursement := range reimbursements {
+		amount, err := currencyService.Convert(ctx, userID, reimbursement.Amount, reimbursement.Currency, currency, reimbursement.Date)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		received[reimbursement.ExpenseID] = received[reimbursement.ExpenseID].Add(amount)
+	}
+
+	total := decimal.Zero
+	for _, expense := range expenses {
+		amount, ok := received[expense.ID]
+		if !ok {
+			continue
+		}
+		if basis == entities.AmortizedBasis && expense.AmortizeMonths > 1 {
+			whole := &entities.Expense{Amount: amount, Date: expense.Date, AmortizeMonths: expense.AmortizeMonths}
+			share, ok := amortizedShare(whole, month)
+			if !ok {
+				continue
+			}
+			amount = share.Amount
+		}
+		total = total.Add(amount)
+	}
+	return total, nil
+}
+
+// toRepositoryReimbursement converts a reimbursement entity to its repository model
+func toRepositoryReimbursement(reimbursement *entities.Reimbursement) *repositories.Reimbursement {
+	return &repositories.Reimbursement{
+		ID:        reimbursement.ID,
+		UserID:    reimbursement.UserID,
+		ExpenseID: reimbursement.ExpenseID,
+		Kind:      string(reimbursement.Kind),
+		Amount:    reimbursement.Amount,
+		Currency:  reimbursement.Currency,
+		Date:      reimbursement.Date,
+		Payer:     reimbursement.Payer,
+		Note:      reimbursement.Note,
+		CreatedAt: reimbursement.CreatedAt,
+	}
+}
+
+// toEntityReimbursement converts a repository reimbursement model to an entity
+func toEntityReimbursement(reimbursement *repositories.Reimbursement) *entities.Reimbursement {
+	return &entities.Reimbursement{
+		ID:        reimbursement.ID,
+		UserID:    reimbursement.UserID,
+		ExpenseID: reimbursement.ExpenseID,
+		Kind:      entities.ReimbursementKind(reimbursement.Kind),
+		Amount:    reimbursement.Amount,
+		Currency:  reimbursement.Currency,
+		Date:      reimbursement.Date,
+		Payer:     reimbursement.Payer,
+		Note:      reimbursement.Note,
+		CreatedAt: reimbursement.CreatedAt,
+	}
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestAddReimbursement(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	dinner := newTestExpense("u1", "3000", date(2024, 3, 10), entities.Self, entities.Food)
+	dinner.Reimbursable = true
+	dinner.ExpectedPayer = "Office"
+	if _, err := svc.expenses.AddExpense(ctx, dinner); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+
+	first := &entities.Reimbursement{UserID: "u1", ExpenseID: dinner.ID, Amount: decimal.NewFromInt(1000), Date: date(2024, 3, 20)}
+	if err := svc.reimbursements.AddReimbursement(ctx, first); err != nil {
+		t.Fatalf("AddReimbursement failed: %v", err)
+	}
+	if first.Kind != entities.ReimbursementKindReimbursement || first.Currency != "INR" || first.Payer != "Office" {
+		t.Errorf("defaults = %s %s %q, want reimbursement INR Office", first.Kind, first.Currency, first.Payer)
+	}
+
+	tests := []struct {
+		name          string
+		reimbursement *entities.Reimbursement
+		wantErr       error
+	}{
+		{"more than the expense", &entities.Reimbursement{UserID: "u1", ExpenseID: dinner.ID, Amount: decimal.NewFromInt(2001), Date: date(2024, 3, 21)}, ErrInvalidInput},
+		{"before the expense", &entities.Reimbursement{UserID: "u1", ExpenseID: dinner.ID, Amount: decimal.NewFromInt(10), Date: date(2024, 3, 9)}, ErrInvalidInput},
+		{"zero amount", &entities.Reimbursement{UserID: "u1", ExpenseID: dinner.ID, Date: date(2024, 3, 21)}, ErrInvalidInput},
+		{"unknown kind", &entities.Reimbursement{UserID: "u1", ExpenseID: dinner.ID, Kind: "gift", Amount: decimal.NewFromInt(10), Date: date(2024, 3, 21)}, ErrInvalidInput},
+		{"another user's expense", &entities.Reimbursement{UserID: "u2", ExpenseID: dinner.ID, Amount: decimal.NewFromInt(10), Date: date(2024, 3, 21)}, ErrNotFound},
+	}
+	for _, tt := range tests {
+		if err := svc.reimbursements.AddReimbursement(ctx, tt.reimbursement); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+
+	list, err := svc.reimbursements.GetReimbursements(ctx, "u1", dinner.ID)
+	if err != nil {
+		t.Fatalf("GetReimbursements failed: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != first.ID {
+		t.Errorf("expected only the first reimbursement, got %d", len(list))
+	}
+	if err := svc.reimbursements.DeleteReimbursement(ctx, "u2", first.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting another user's reimbursement, got %v", err)
+	}
+
+	notReimbursable := newTestExpense("u1", "100", date(2024, 3, 10), entities.Self, entities.Food)
+	notReimbursable.ExpectedPayer = "Office"
+	if _, err := svc.expenses.AddExpense(ctx, notReimbursable); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a payer on a non-reimbursable expense, got %v", err)
+	}
+
+	// Refunds are the default for ordinary expenses
+	shoes := newTestExpense("u1", "2500", date(2024, 3, 12), entities.Self, entities.Misc)
+	if _, err := svc.expenses.AddExpense(ctx, shoes); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	refund := &entities.Reimbursement{UserID: "u1", ExpenseID: shoes.ID, Amount: decimal.NewFromInt(2500), Date: date(2024, 3, 15)}
+	if err := svc.reimbursements.AddReimbursement(ctx, refund); err != nil {
+		t.Fatalf("AddReimbursement failed: %v", err)
+	}
+	if refund.Kind != entities.ReimbursementKindRefund {
+		t.Errorf("kind = %s, want refund", refund.Kind)
+	}
+
+	// An expense can't be edited below what was already received against it
+	shoes.Amount = decimal.NewFromInt(2000)
+	if err := svc.expenses.UpdateExpense(ctx, shoes.ID, shoes); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput shrinking a refunded expense, got %v", err)
+	}
+	dinner.Amount = decimal.NewFromInt(1000)
+	if err := svc.expenses.UpdateExpense(ctx, dinner.ID, dinner); err != nil {
+		t.Errorf("UpdateExpense down to the amount received failed: %v", err)
+	}
+}
+
+func TestPendingReimbursements(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	add := func(amount, payer string) *entities.Expense {
+		expense := newTestExpense("u1", amount, date(2024, 3, 10), entities.Self, entities.Travel)
+		expense.Reimbursable = true
+		expense.ExpectedPayer = payer
+		if _, err := svc.expenses.AddExpense(ctx, expense); err != nil {
+			t.Fatalf("AddExpense failed: %v", err)
+		}
+		return expense
+	}
+	flight := add("8000", "Office")
+	cab := add("600", "Ravi")
+	hotel := add("4000", "Office")
+
+	for _, r := range []*entities.Reimbursement{
+		{UserID: "u1", ExpenseID: flight.ID, Amount: decimal.NewFromInt(3000), Date: date(2024, 3, 20)},
+		{UserID: "u1", ExpenseID: hotel.ID, Amount: decimal.NewFromInt(4000), Date: date(2024, 3, 20)},
+	} {
+		if err := svc.reimbursements.AddReimbursement(ctx, r); err != nil {
+			t.Fatalf("AddReimbursement failed: %v", err)
+		}
+	}
+
+	pending, err := svc.reimbursements.GetPending(ctx, "u1", "")
+	if err != nil {
+		t.Fatalf("GetPending failed: %v", err)
+	}
+	if len(pending.Expenses) != 2 || !pending.Total.Equal(decimal.NewFromInt(5600)) {
+		t.Fatalf("expected flight and cab pending for 5600, got %d for %s", len(pending.Expenses), pending.Total)
+	}
+	if !pending.Expenses[0].Outstanding.Equal(decimal.NewFromInt(5000)) || !pending.Expenses[0].Received.Equal(decimal.NewFromInt(3000)) {
+		t.Errorf("flight: received %s, outstanding %s, want 3000 and 5000", pending.Expenses[0].Received, pending.Expenses[0].Outstanding)
+	}
+
+	fromRavi, err := svc.reimbursements.GetPending(ctx, "u1", "ravi")
+	if err != nil {
+		t.Fatalf("GetPending failed: %v", err)
+	}
+	if len(fromRavi.Expenses) != 1 || fromRavi.Expenses[0].Expense.ID != cab.ID {
+		t.Errorf("expected only the cab pending from Ravi, got %d", len(fromRavi.Expenses))
+	}
+}
+
+func TestBreakdownNetOfReimbursements(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	income := &entities.Income{UserID: "u1", Amount: decimal.NewFromInt(10000), Month: month(2024, 3), Source: "Salary"}
+	if err := svc.incomes.AddIncome(ctx, income); err != nil {
+		t.Fatalf("AddIncome failed: %v", err)
+	}
+	dinner := newTestExpense("u1", "3000", date(2024, 3, 10), entities.Self, entities.Food)
+	dinner.Reimbursable = true
+	if _, err := svc.expenses.AddExpense(ctx, dinner); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+	// Received in April, but still nets against March's expense
+	back := &entities.Reimbursement{UserID: "u1", ExpenseID: dinner.ID, Amount: decimal.NewFromInt(1200), Date: date(2024, 4, 2)}
+	if err := svc.reimbursements.AddReimbursement(ctx, back); err != nil {
+		t.Fatalf("AddReimbursement failed: %v", err)
+	}
+
+	breakdown, err := svc.expenses.GetMonthlyBreakdown(ctx, "u1", month(2024, 3), entities.CashBasis)
+	if err != nil {
+		t.Fatalf("GetMonthlyBreakdown failed: %v", err)
+	}
+	if !breakdown.TotalExpenses.Equal(decimal.NewFromInt(3000)) {
+		t.Errorf("gross expenses = %s, want 3000", breakdown.TotalExpenses)
+	}
+	if !breakdown.Reimbursed.Equal(decimal.NewFromInt(1200)) || !breakdown.NetExpenses.Equal(decimal.NewFromInt(1800)) {
+		t.Errorf("reimbursed %s, net %s, want 1200 and 1800", breakdown.Reimbursed, breakdown.NetExpenses)
+	}
+	if !breakdown.NetSavings.Equal(decimal.NewFromInt(8200)) {
+		t.Errorf("net savings = %s, want 8200", breakdown.NetSavings)
+	}
+}
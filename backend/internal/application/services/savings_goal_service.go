@@ -0,0 +1,501 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// GoalSurplusMonths is the number of complete months averaged to project
+// how quickly a savings goal fills up
+const GoalSurplusMonths = 3
+
+// SavingsGoalService defines the interface for savings goal operations
+type SavingsGoalService interface {
+	CreateGoal(ctx context.Context, goal *entities.SavingsGoal) error
+	GetGoals(ctx context.Context, userID string) ([]*entities.GoalProgress, error)
+	GetGoal(ctx context.Context, userID, id string) (*entities.GoalProgress, error)
+	UpdateGoal(ctx context.Context, id string, goal *entities.SavingsGoal) error
+	DeleteGoal(ctx context.Context, userID, id string) error
+	AddContribution(ctx context.Context, userID string, contribution *entities.GoalContribution) error
+	DeleteContribution(ctx context.Context, userID, goalID, id string) error
+}
+
+// surplusRate is the average amount left over each month
+type surplusRate struct {
+	Amount   decimal.Decimal
+	Currency string
+	Months   int
+}
+
+// savingsGoalService implements the SavingsGoalService interface
+type savingsGoalService struct {
+	goalRepo        repositories.SavingsGoalRepository
+	expenseService  ExpenseService
+	categoryService CategoryService
+	currencyService CurrencyService
+}
+
+// NewSavingsGoalService creates a new savings goal service
+func NewSavingsGoalService(goalRepo repositories.SavingsGoalRepository, expenseService ExpenseService, categoryService CategoryService, currencyService CurrencyService) SavingsGoalService {
+	return &savingsGoalService{
+		goalRepo:        goalRepo,
+		expenseService:  expenseService,
+		categoryService: categoryService,
+		currencyService: currencyService,
+	}
+}
+
+// CreateGoal creates a savings goal. The currency defaults to the user's base currency.
+func (s *savingsGoalService) CreateGoal(ctx context.Context, goal *entities.SavingsGoal) error {
+	if goal.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	if err := validateSavingsGoal(goal); err != nil {
+		return err
+	}
+	currency, err := resolveCurrency(ctx, s.currencyService, goal.UserID, goal.Currency)
+	if err != nil {
+		return err
+	}
+	goal.Currency = currency
+
+	now := time.Now()
+	goal.ID = uuid.NewString()
+	goal.CreatedAt = now
+	goal.UpdatedAt = now
+
+	if err := s.goalRepo.Create(ctx, toRepositorySavingsGoal(goal)); err != nil {
+		return fmt.Errorf("failed to save savings goal: %w", err)
+	}
+	return nil
+}
+
+// GetGoals gets the user's savings goals with their progress, soonest target first
+func (s *savingsGoalService) GetGoals(ctx context.Context, userID string) ([]*entities.GoalProgress, error) {
+	stored, err := s.goalRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get savings goals: %w", err)
+	}
+	if len(stored) == 0 {
+		return []*entities.GoalProgress{}, nil
+	}
+
+	now := time.Now()
+	surplus, err := s.monthlySurplus(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*entities.GoalProgress, 0, len(stored))
+	for _, goal := range stored {
+		progress, _, err := s.progress(ctx, toEntitySavingsGoal(goal), surplus, now)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, progress)
+	}
+	return list, nil
+}
+
+// GetGoal gets a savings goal owned by the user with its progress and contributions
+func (s *savingsGoalService) GetGoal(ctx context.Context, userID, id string) (*entities.GoalProgress, error) {
+	stored, err := s.getOwnedGoal(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	surplus, err := s.monthlySurplus(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	progress, contributions, err := s.progress(ctx, toEntitySavingsGoal(stored), surplus, now)
+	if err != nil {
+		return nil, err
+	}
+	progress.Contributions = contributions
+	return progress, nil
+}
+
+// UpdateGoal updates a savings goal. Contributions are kept in their own
+// currencies, so the goal's currency can change at any time.
+func (s *savingsGoalService) UpdateGoal(ctx context.Context, id string, goal *entities.SavingsGoal) error {
+	existing, err := s.getOwnedGoal(ctx, goal.UserID, id)
+	if err != nil {
+		return err
+	}
+	if err := validateSavingsGoal(goal); err != nil {
+		return err
+	}
+	if goal.Currency == "" {
+		goal.Currency = existing.Currency
+	}
+	if goal.Currency, err = normalizeCurrency(goal.Currency); err != nil {
+		return err
+	}
+
+	goal.ID = id
+	goal.CreatedAt = existing.CreatedAt
+	goal.UpdatedAt = time.Now()
+
+	if err := s.goalRepo.Update(ctx, toRepositorySavingsGoal(goal)); err != nil {
+		return fmt.Errorf("failed to update savings goal: %w", err)
+	}
+	return nil
+}
+
+// DeleteGoal deletes a savings goal and its contributions. Linked expenses are kept.
+func (s *savingsGoalService) DeleteGoal(ctx context.Context, userID, id string) error {
+	if _, err := s.getOwnedGoal(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.goalRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete savings goal: %w", err)
+	}
+	return nil
+}
+
+// AddContribution puts money towards a goal. A contribution linked to an
+// expense in the Savings category takes its currency and date from the
+// expense and defaults to the part of it not yet given to a goal; an expense
+// can't be given out for more than its amount.
+func (s *savingsGoalService) AddContribution(ctx context.Context, userID string, contribution *entities.GoalContribution) error {
+	goal, err := s.getOwnedGoal(ctx, userID, contribution.GoalID)
+	if err != nil {
+		return err
+	}
+	contribution.Note = strings.TrimSpace(contribution.Note)
+
+	if contribution.ExpenseID != "" {
+		if err := s.fromExpense(ctx, userID, contribution); err != nil {
+			return err
+		}
+	} else {
+		if contribution.Currency == "" {
+			contribution.Currency = goal.Currency
+		}
+		if contribution.Currency, err = normalizeCurrency(contribution.Currency); err != nil {
+			return err
+		}
+		if contribution.Date.IsZero() {
+			contribution.Date = time.Now()
+		}
+	}
+	if !contribution.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidInput)
+	}
+
+	contribution.ID = uuid.NewString()
+	contribution.CreatedAt = time.Now()
+	if err := s.goalRepo.CreateContribution(ctx, toRepositoryGoalContribution(contribution)); err != nil {
+		return fmt.Errorf("failed to save goal contribution: %w", err)
+	}
+	return nil
+}
+
+// DeleteContribution removes a contribution from a goal owned by the user
+func (s *savingsGoalService) DeleteContribution(ctx context.Context, userID, goalID, id string) error {
+	if _, err := s.getOwnedGoal(ctx, userID, goalID); err != nil {
+		return err
+	}
+	contribution, err := s.goalRepo.GetContributionByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) || (err == nil && contribution.GoalID != goalID) {
+		return fmt.Errorf("goal contribution %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get goal contribution: %w", err)
+	}
+	if err := s.goalRepo.DeleteContribution(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete goal contribution: %w", err)
+	}
+	return nil
+}
+
+// fromExpense fills in a contribution from the Savings expense it is linked to
+func (s *savingsGoalService) fromExpense(ctx context.Context, userID string, contribution *entities.GoalContribution) error {
+	expense, err := s.expenseService.GetExpense(ctx, userID, contribution.ExpenseID)
+	if err != nil {
+		return err
+	}
+	savings, err := savingsCategory(ctx, s.categoryService, userID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(string(expense.MainCategory), savings.Name) {
+		return fmt.Errorf("%w: only expenses in the %s category can count towards a goal", ErrInvalidInput, savings.Name)
+	}
+	if contribution.Currency != "" && !strings.EqualFold(contribution.Currency, expense.Currency) {
+		return fmt.Errorf("%w: the contribution must be in the expense's currency, %s", ErrInvalidInput, expense.Currency)
+	}
+
+	linked, err := s.goalRepo.GetContributionsByExpense(ctx, expense.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get goal contributions: %w", err)
+	}
+	unallocated := expense.Amount
+	for _, other := range linked {
+		unallocated = unallocated.Sub(other.Amount)
+	}
+	if !unallocated.IsPositive() {
+		return fmt.Errorf("%w: expense %s has already been given to goals in full", ErrInvalidInput, expense.ID)
+	}
+	if contribution.Amount.IsZero() {
+		contribution.Amount = unallocated
+	}
+	if contribution.Amount.GreaterThan(unallocated) {
+		return fmt.Errorf("%w: only %s %s of expense %s is left to give to goals", ErrInvalidInput, unallocated, expense.Currency, expense.ID)
+	}
+
+	contribution.Currency = expense.Currency
+	contribution.Date = expense.Date
+	if contribution.Note == "" {
+		contribution.Note = expense.Description
+	}
+	return nil
+}
+
+// savingsCategory gets the main category whose expenses can fund goals,
+// found by its role so that renaming it doesn't matter
+func savingsCategory(ctx context.Context, categoryService CategoryService, userID string) (*entities.Category, error) {
+	category, err := categoryService.GetCategoryByRole(ctx, userID, entities.SavingsRole)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: there's no savings category for goals to draw from", ErrInvalidInput)
+	}
+	return category, err
+}
+
+// checkGoalContributions keeps an expense update from taking back money
+// already given to savings goals: a linked expense must stay in the
+// savings category and currency, and cover everything given out of it
+func checkGoalContributions(ctx context.Context, goalRepo repositories.SavingsGoalRepository, categoryService CategoryService, expense *entities.Expense) error {
+	linked, err := goalRepo.GetContributionsByExpense(ctx, expense.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get goal contributions: %w", err)
+	}
+	if len(linked) == 0 {
+		return nil
+	}
+
+	savings, err := savingsCategory(ctx, categoryService, expense.UserID)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(string(expense.MainCategory), savings.Name) {
+		return fmt.Errorf("%w: expense %s funds savings goals, so it must stay in the %s category", ErrInvalidInput, expense.ID, savings.Name)
+	}
+	allocated := decimal.Zero
+	for _, contribution := range linked {
+		if !strings.EqualFold(contribution.Currency, expense.Currency) {
+			return fmt.Errorf("%w: expense %s funds savings goals in %s, so its currency can't change", ErrInvalidInput, expense.ID, contribution.Currency)
+		}
+		allocated = allocated.Add(contribution.Amount)
+	}
+	if expense.Amount.LessThan(allocated) {
+		return fmt.Errorf("%w: %s %s of expense %s has been given to savings goals", ErrInvalidInput, allocated, expense.Currency, expense.ID)
+	}
+	return nil
+}
+
+// monthlySurplus averages CalculateSavings over the complete months before
+// now. The result is in the user's base currency.
+func (s *savingsGoalService) monthlySurplus(ctx context.Context, userID string, now time.Time) (*surplusRate, error) {
+	currency, err := s.currencyService.BaseCurrency(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base currency: %w", err)
+	}
+
+	total := decimal.Zero
+	current := startOfMonth(now)
+	for i := 1; i <= GoalSurplusMonths; i++ {
+		savings, err := s.expenseService.CalculateSavings(ctx, userID, current.AddDate(0, -i, 0), entities.CashBasis)
+		if err != nil {
+			return nil, err
+		}
+		total = total.Add(savings)
+	}
+	return &surplusRate{
+		Amount:   total.DivRound(decimal.NewFromInt(GoalSurplusMonths), averagePrecision),
+		Currency: currency,
+		Months:   GoalSurplusMonths,
+	}, nil
+}
+
+// progress totals a goal's contributions in its currency and projects it
+// against surplus. The contributions are returned oldest first.
+func (s *savingsGoalService) progress(ctx context.Context, goal *entities.SavingsGoal, surplus *surplusRate, now time.Time) (*entities.GoalProgress, []*entities.GoalContribution, error) {
+	stored, err := s.goalRepo.GetContributions(ctx, goal.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get goal contributions: %w", err)
+	}
+
+	saved := decimal.Zero
+	contributions := make([]*entities.GoalContribution, 0, len(stored))
+	for _, contribution := range stored {
+		amount, err := s.currencyService.Convert(ctx, goal.UserID, contribution.Amount, contribution.Currency, goal.Currency, contribution.Date)
+		if err != nil {
+			return nil, nil, err
+		}
+		saved = saved.Add(amount)
+		contributions = append(contributions, toEntityGoalContribution(contribution))
+	}
+
+	rate, err := s.currencyService.Convert(ctx, goal.UserID, surplus.Amount, surplus.Currency, goal.Currency, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	return buildGoalProgress(goal, saved, rate, surplus.Months, now), contributions, nil
+}
+
+// buildGoalProgress works out how far a goal is from its target with saved
+// put towards it so far, and when it will be reached if surplus is saved
+// every month from now on
+func buildGoalProgress(goal *entities.SavingsGoal, saved, surplus decimal.Decimal, surplusMonths int, now time.Time) *entities.GoalProgress {
+	progress := &entities.GoalProgress{
+		Goal:      goal,
+		Saved:     saved,
+		Remaining: decimal.Max(goal.TargetAmount.Sub(saved), decimal.Zero),
+		Percent:   percentOf(saved, goal.TargetAmount),
+	}
+	if !progress.Remaining.IsPositive() {
+		progress.Status = entities.GoalAchieved
+		return progress
+	}
+
+	today := startOfDay(now)
+	projection := &entities.GoalProjection{
+		MonthlySurplus: surplus,
+		SurplusMonths:  surplusMonths,
+	}
+	if surplus.IsPositive() {
+		months := progress.Remaining.Div(surplus).Ceil().IntPart()
+		projected := today.AddDate(0, int(months), 0)
+		projection.ProjectedDate = &projected
+	}
+	if goal.TargetDate != nil {
+		projection.MonthsLeft = monthsUntil(today, *goal.TargetDate)
+		months := int64(projection.MonthsLeft)
+		if months < 1 {
+			months = 1 // due this month or already overdue
+		}
+		required := progress.Remaining.DivRound(decimal.NewFromInt(months), averagePrecision)
+		projection.RequiredMonthly = &required
+	}
+	progress.Projection = projection
+
+	switch {
+	case projection.ProjectedDate == nil:
+		progress.Status = entities.GoalUnreachable
+	case goal.TargetDate != nil && projection.ProjectedDate.After(*goal.TargetDate):
+		progress.Status = entities.GoalBehind
+	default:
+		progress.Status = entities.GoalOnTrack
+	}
+	return progress
+}
+
+// monthsUntil counts the whole months from from to to, or zero once to has passed
+func monthsUntil(from, to time.Time) int {
+	months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
+
+// getOwnedGoal loads a savings goal and checks it belongs to the user
+func (s *savingsGoalService) getOwnedGoal(ctx context.Context, userID, id string) (*repositories.SavingsGoal, error) {
+	stored, err := s.goalRepo.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, fmt.Errorf("savings goal %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get savings goal: %w", err)
+	}
+	if stored.UserID != userID {
+		return nil, fmt.Errorf("savings goal %s: %w", id, ErrNotFound)
+	}
+	return stored, nil
+}
+
+// validateSavingsGoal checks a goal's name and target and normalizes its target date
+func validateSavingsGoal(goal *entities.SavingsGoal) error {
+	goal.Name = strings.TrimSpace(goal.Name)
+	if goal.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if !goal.TargetAmount.IsPositive() {
+		return fmt.Errorf("%w: target amount must be greater than zero", ErrInvalidInput)
+	}
+	if goal.TargetDate != nil {
+		date := startOfDay(*goal.TargetDate)
+		goal.TargetDate = &date
+	}
+	return nil
+}
+
+// toRepositorySavingsGoal converts a savings goal entity to a repository model
+func toRepositorySavingsGoal(goal *entities.SavingsGoal) *repositories.SavingsGoal {
+	return &repositories.SavingsGoal{
+		ID:           goal.ID,
+		UserID:       goal.UserID,
+		Name:         goal.Name,
+		TargetAmount: goal.TargetAmount,
+		Currency:     goal.Currency,
+		TargetDate:   goal.TargetDate,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+	}
+}
+
+// toEntitySavingsGoal converts a repository savings goal model to an entity
+func toEntitySavingsGoal(goal *repositories.SavingsGoal) *entities.SavingsGoal {
+	return &entities.SavingsGoal{
+		ID:           goal.ID,
+		UserID:       goal.UserID,
+		Name:         goal.Name,
+		TargetAmount: goal.TargetAmount,
+		Currency:     goal.Currency,
+		TargetDate:   goal.TargetDate,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+	}
+}
+
+// toRepositoryGoalContribution converts a goal contribution entity to a repository model
+func toRepositoryGoalContribution(contribution *entities.GoalContribution) *repositories.GoalContribution {
+	return &repositories.GoalContribution{
+		ID:        contribution.ID,
+		GoalID:    contribution.GoalID,
+		ExpenseID: contribution.ExpenseID,
+		Amount:    contribution.Amount,
+		Currency:  contribution.Currency,
+		Date:      contribution.Date,
+		Note:      contribution.Note,
+		CreatedAt: contribution.CreatedAt,
+	}
+}
+
+// toEntityGoalContribution converts a repository goal contribution model to an entity
+func toEntityGoalContribution(contribution *repositories.GoalContribution) *entities.GoalContribution {
+	return &entities.GoalContribution{
+		ID:        contribution.ID,
+		GoalID:    contribution.GoalID,
+		ExpenseID: contribution.ExpenseID,
+		Amount:    contribution.Amount,
+		Currency:  contribution.Currency,
+		Date:      contribution.Date,
+		Note:      contribution.Note,
+		CreatedAt: contribution.CreatedAt,
+	}
+}
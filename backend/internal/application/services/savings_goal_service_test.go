@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func newTestGoal(t *testing.T, svc *testServices, userID, target string, targetDate *time.Time) *entities.SavingsGoal {
+	t.Helper()
+	goal := &entities.SavingsGoal{UserID: userID, Name: "Emergency fund", TargetAmount: decimal.RequireFromString(target), TargetDate: targetDate}
+	if err := svc.goals.CreateGoal(context.Background(), goal); err != nil {
+		t.Fatalf("CreateGoal() error = %v", err)
+	}
+	return goal
+}
+
+func TestBuildGoalProgress(t *testing.T) {
+	now := date(2024, 3, 15)
+	december, october := date(2024, 12, 15), date(2024, 10, 1)
+	goal := func(targetDate *time.Time) *entities.SavingsGoal {
+		return &entities.SavingsGoal{TargetAmount: decimal.NewFromInt(12000), TargetDate: targetDate}
+	}
+
+	tests := []struct {
+		name       string
+		goal       *entities.SavingsGoal
+		saved      string
+		surplus    string
+		want       entities.GoalStatus
+		monthsLeft int
+		required   string
+	}{
+		{"on track", goal(&december), "3000", "1000", entities.GoalOnTrack, 9, "1000"},
+		{"behind", goal(&october), "3000", "1000", entities.GoalBehind, 6, "1500"},
+		{"no surplus", goal(&december), "3000", "-200", entities.GoalUnreachable, 9, "1000"},
+		{"no target date", goal(nil), "3000", "1000", entities.GoalOnTrack, 0, ""},
+		{"achieved", goal(&october), "12500", "0", entities.GoalAchieved, 0, ""},
+	}
+	for _, tt := range tests {
+		progress := buildGoalProgress(tt.goal, decimal.RequireFromString(tt.saved), decimal.RequireFromString(tt.surplus), GoalSurplusMonths, now)
+		if progress.Status != tt.want {
+			t.Errorf("%s: status = %s, want %s", tt.name, progress.Status, tt.want)
+		}
+		if tt.want == entities.GoalAchieved {
+			if progress.Projection != nil || !progress.Remaining.IsZero() {
+				t.Errorf("%s: got projection %+v and remaining %s, want neither", tt.name, progress.Projection, progress.Remaining)
+			}
+			continue
+		}
+
+		projection := progress.Projection
+		if projection.MonthsLeft != tt.monthsLeft {
+			t.Errorf("%s: months left = %d, want %d", tt.name, projection.MonthsLeft, tt.monthsLeft)
+		}
+		got := ""
+		if projection.RequiredMonthly != nil {
+			got = projection.RequiredMonthly.String()
+		}
+		if got != tt.required {
+			t.Errorf("%s: required monthly = %q, want %q", tt.name, got, tt.required)
+		}
+	}
+
+	onTrack := buildGoalProgress(goal(&december), decimal.NewFromInt(3000), decimal.NewFromInt(1000), GoalSurplusMonths, now)
+	if onTrack.Percent.String() != "25" || onTrack.Remaining.String() != "9000" {
+		t.Errorf("progress = %s%% with %s remaining, want 25%% with 9000", onTrack.Percent, onTrack.Remaining)
+	}
+	if projected := onTrack.Projection.ProjectedDate; projected == nil || !projected.Equal(december) {
+		t.Errorf("projected date = %v, want %s", projected, december.Format("2006-01-02"))
+	}
+}
+
+func TestAddContributionFromExpense(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+	goal := newTestGoal(t, svc, "u1", "1000", nil)
+
+	deposit := newTestExpense("u1", "500", date(2024, 3, 1), entities.Savings, entities.Misc)
+	groceries := newTestExpense("u1", "80", date(2024, 3, 2), entities.Self, entities.Food)
+	for _, expense := range []*entities.Expense{deposit, groceries} {
+		if _, err := svc.expenses.AddExpense(ctx, expense); err != nil {
+			t.Fatalf("AddExpense() error = %v", err)
+		}
+	}
+
+	partial := &entities.GoalContribution{GoalID: goal.ID, ExpenseID: deposit.ID, Amount: decimal.NewFromInt(300)}
+	if err := svc.goals.AddContribution(ctx, "u1", partial); err != nil {
+		t.Fatalf("AddContribution() error = %v", err)
+	}
+	rest := &entities.GoalContribution{GoalID: goal.ID, ExpenseID: deposit.ID}
+	if err := svc.goals.AddContribution(ctx, "u1", rest); err != nil {
+		t.Fatalf("AddContribution() error = %v", err)
+	}
+	if rest.Amount.String() != "200" || !rest.Date.Equal(deposit.Date) {
+		t.Errorf("rest = %s on %s, want the remaining 200 on the expense date", rest.Amount, rest.Date)
+	}
+
+	invalid := map[string]*entities.GoalContribution{
+		"fully given":      {GoalID: goal.ID, ExpenseID: deposit.ID},
+		"not savings":      {GoalID: goal.ID, ExpenseID: groceries.ID},
+		"manual, no money": {GoalID: goal.ID},
+	}
+	for name, contribution := range invalid {
+		if err := svc.goals.AddContribution(ctx, "u1", contribution); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: AddContribution() error = %v, want ErrInvalidInput", name, err)
+		}
+	}
+	other := &entities.GoalContribution{GoalID: goal.ID, Amount: decimal.NewFromInt(10)}
+	if err := svc.goals.AddContribution(ctx, "u2", other); !errors.Is(err, ErrNotFound) {
+		t.Errorf("AddContribution() by another user error = %v, want ErrNotFound", err)
+	}
+
+	progress, err := svc.goals.GetGoal(ctx, "u1", goal.ID)
+	if err != nil {
+		t.Fatalf("GetGoal() error = %v", err)
+	}
+	if progress.Saved.String() != "500" || progress.Percent.String() != "50" || len(progress.Contributions) != 2 {
+		t.Errorf("progress = %s saved (%s%%) over %d contributions, want 500 (50%%) over 2", progress.Saved, progress.Percent, len(progress.Contributions))
+	}
+
+	// Renaming the savings category doesn't stop it funding goals
+	savings, _ := svc.categories.LookupCategory(ctx, "u1", entities.MainCategoryKind, string(entities.Savings))
+	savings.Name = "Investments"
+	if err := svc.categories.UpdateCategory(ctx, savings.ID, savings); err != nil {
+		t.Fatalf("UpdateCategory() error = %v", err)
+	}
+	renamed := newTestExpense("u1", "50", date(2024, 3, 3), "Investments", entities.Misc)
+	if _, err := svc.expenses.AddExpense(ctx, renamed); err != nil {
+		t.Fatalf("AddExpense() error = %v", err)
+	}
+	if err := svc.goals.AddContribution(ctx, "u1", &entities.GoalContribution{GoalID: goal.ID, ExpenseID: renamed.ID}); err != nil {
+		t.Errorf("AddContribution() after renaming the savings category error = %v", err)
+	}
+
+	// An expense can't be edited out from under the goals it funds
+	deposit, _ = svc.expenses.GetExpense(ctx, "u1", deposit.ID)
+	edits := map[string]func(e *entities.Expense){
+		"below contributions": func(e *entities.Expense) { e.Amount = decimal.NewFromInt(400) },
+		"out of savings":      func(e *entities.Expense) { e.MainCategory = entities.Self },
+		"other currency":      func(e *entities.Expense) { e.Currency = "USD" },
+	}
+	for name, edit := range edits {
+		edited := *deposit
+		edit(&edited)
+		if err := svc.expenses.UpdateExpense(ctx, deposit.ID, &edited); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: UpdateExpense() error = %v, want ErrInvalidInput", name, err)
+		}
+	}
+	deposit.Amount = decimal.NewFromInt(600)
+	if err := svc.expenses.UpdateExpense(ctx, deposit.ID, deposit); err != nil {
+		t.Errorf("UpdateExpense() raising the amount error = %v", err)
+	}
+
+	// Contributions go with the expense they were taken from
+	if err := svc.expenses.DeleteExpense(ctx, "u1", deposit.ID); err != nil {
+		t.Fatalf("DeleteExpense() error = %v", err)
+	}
+	if progress, err = svc.goals.GetGoal(ctx, "u1", goal.ID); err != nil {
+		t.Fatalf("GetGoal() error = %v", err)
+	}
+	if progress.Saved.String() != "50" {
+		t.Errorf("saved = %s after deleting the expense, want the other expense's 50", progress.Saved)
+	}
+}
+
+func TestGetGoalsProjectsFromRecentSurplus(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	current := startOfMonth(time.Now())
+	for i := 1; i <= GoalSurplusMonths; i++ {
+		income := &entities.Income{UserID: "u1", Amount: decimal.NewFromInt(5000), Month: current.AddDate(0, -i, 0), Source: "Salary"}
+		if err := svc.incomes.AddIncome(ctx, income); err != nil {
+			t.Fatalf("AddIncome() error = %v", err)
+		}
+		expense := newTestExpense("u1", "4000", current.AddDate(0, -i, 2), entities.Self, entities.Food)
+		if _, err := svc.expenses.AddExpense(ctx, expense); err != nil {
+			t.Fatalf("AddExpense() error = %v", err)
+		}
+	}
+
+	soon := startOfDay(time.Now()).AddDate(0, 2, 0)
+	newTestGoal(t, svc, "u1", "6000", &soon)
+	newTestGoal(t, svc, "u1", "1500", nil)
+
+	goals, err := svc.goals.GetGoals(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetGoals() error = %v", err)
+	}
+	if len(goals) != 2 {
+		t.Fatalf("got %d goals, want 2", len(goals))
+	}
+	for _, progress := range goals {
+		if got := progress.Projection.MonthlySurplus.String(); got != "1000" {
+			t.Errorf("%s: monthly surplus = %s, want 1000", progress.Goal.TargetAmount, got)
+		}
+	}
+	if goals[0].Status != entities.GoalBehind || goals[1].Status != entities.GoalOnTrack {
+		t.Errorf("statuses = %s and %s, want behind for the dated goal and on track for the other", goals[0].Status, goals[1].Status)
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// currencyPattern matches ISO 4217 style currency codes
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// SettingsService defines the interface for user settings operations
+type SettingsService interface {
+	GetSettings(ctx context.Context, userID string) (*entities.UserSettings, error)
+	UpdateSettings(ctx context.Context, settings *entities.UserSettings) error
+}
+
+// settingsService implements the SettingsService interface
+type settingsService struct {
+	settingsRepo repositories.UserSettingsRepository
+}
+
+// NewSettingsService creates a new settings service
+func NewSettingsService(settingsRepo repositories.UserSettingsRepository) SettingsService {
+	return &settingsService{
+		settingsRepo: settingsRepo,
+	}
+}
+
+// GetSettings gets a user's settings, falling back to the defaults if none were saved
+func (s *settingsService) GetSettings(ctx context.Context, userID string) (*entities.UserSettings, error) {
+	stored, err := s.settingsRepo.Get(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return &entities.UserSettings{
+			UserID:          userID,
+			BaseCurrency:    entities.DefaultCurrency,
+			FiscalYearStart: entities.DefaultFiscalYearStart,
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+	return toEntitySettings(stored), nil
+}
+
+// UpdateSettings saves a user's settings
+func (s *settingsService) UpdateSettings(ctx context.Context, settings *entities.UserSettings) error {
+	if settings.UserID == "" {
+		return fmt.Errorf("%w: user ID is required", ErrInvalidInput)
+	}
+	currency, err := normalizeCurrency(settings.BaseCurrency)
+	if err != nil {
+		return err
+	}
+	settings.BaseCurrency = currency
+	if settings.FiscalYearStart == 0 {
+		settings.FiscalYearStart = entities.DefaultFiscalYearStart
+	}
+	if settings.FiscalYearStart < time.January || settings.FiscalYearStart > time.December {
+		return fmt.Errorf("%w: fiscal year start must be a month between 1 and 12", ErrInvalidInput)
+	}
+	settings.UpdatedAt = time.Now()
+
+	if err := s.settingsRepo.Save(ctx, toRepositorySettings(settings)); err != nil {
+		return fmt.Errorf("failed to save settings: %w", err)
+	}
+	return nil
+}
+
+// normalizeCurrency upper-cases a currency code and checks its format
+func normalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !currencyPattern.MatchString(code) {
+		return "", fmt.Errorf("%w: currency must be a three-letter code such as INR", ErrInvalidInput)
+	}
+	return code, nil
+}
+
+// toRepositorySettings converts user settings to their repository model
+func toRepositorySettings(settings *entities.UserSettings) *repositories.UserSettings {
+	return &repositories.UserSettings{
+		UserID:          settings.UserID,
+		BaseCurrency:    settings.BaseCurrency,
+		FiscalYearStart: int(settings.FiscalYearStart),
+		UpdatedAt:       settings.UpdatedAt,
+	}
+}
+
+// toEntitySettings converts a repository user settings model to an entity
+func toEntitySettings(settings *repositories.UserSettings) *entities.UserSettings {
+	return &entities.UserSettings{
+		UserID:          settings.UserID,
+		BaseCurrency:    settings.BaseCurrency,
+		FiscalYearStart: time.Month(settings.FiscalYearStart),
+		UpdatedAt:       settings.UpdatedAt,
+	}
+}
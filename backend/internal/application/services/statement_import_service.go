@@ -0,0 +1,414 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatementImportService defines the interface for importing bank statements
+// through a review step. A staged import is rolled back, or discarded before
+// it's confirmed, with ImportService.UndoBatch like any other import.
+type StatementImportService interface {
+	StageStatement(ctx context.Context, userID, fileName string, file []byte, bankType, password, accountID string) (*entities.StagedImport, error)
+	GetStagedImport(ctx context.Context, userID, batchID string) (*entities.StagedImport, error)
+	UpdateStagedTransaction(ctx context.Context, userID, batchID, transactionID string, update *entities.StagedTransactionUpdate) (*entities.StagedTransaction, error)
+	ConfirmImport(ctx context.Context, userID, batchID string) (*entities.ImportBatch, error)
+}
+
+// statementImportService implements the StatementImportService interface
+type statementImportService struct {
+	importRepo       repositories.ImportRepository
+	accountRepo      repositories.AccountRepository
+	eventRepo        repositories.EventRepository
+	pdfParserService PDFParserService
+	categoryService  CategoryService
+	currencyService  CurrencyService
+}
+
+// NewStatementImportService creates a new statement import service
+func NewStatementImportService(importRepo repositories.ImportRepository, accountRepo repositories.AccountRepository, eventRepo repositories.EventRepository, pdfParserService PDFParserService, categoryService CategoryService, currencyService CurrencyService) StatementImportService {
+	return &statementImportService{
+		importRepo:       importRepo,
+		accountRepo:      accountRepo,
+		eventRepo:        eventRepo,
+		pdfParserService: pdfParserService,
+		categoryService:  categoryService,
+		currencyService:  currencyService,
+	}
+}
+
+// StageStatement parses a PDF bank statement, decrypting it with password
+// when it's protected, suggests categories for its transactions and stages
+// them for review without saving any expenses. The expenses are recorded
+// against accountID, or else against the user's only open account with the
+// statement's last four digits, if there is one.
+func (s *statementImportService) StageStatement(ctx context.Context, userID, fileName string, file []byte, bankType, password, accountID string) (*entities.StagedImport, error) {
+	statement, err := s.pdfParserService.ParseBankStatement(ctx, file, bankType, password)
+	if err != nil {
+		return nil, err
+	}
+	if len(statement.Transactions) == 0 {
+		return nil, fmt.Errorf("%w: the statement has no transactions", ErrInvalidInput)
+	}
+
+	if accountID != "" {
+		account, err := getOwnedAccount(ctx, s.accountRepo, userID, accountID)
+		if err != nil {
+			return nil, err
+		}
+		if account.Archived {
+			return nil, fmt.Errorf("%w: account %q is archived", ErrInvalidInput, account.Name)
+		}
+	} else if accountID, err = s.statementAccount(ctx, userID, statement.AccountLast4); err != nil {
+		return nil, err
+	}
+	for _, transaction := range statement.Transactions {
+		transaction.AccountID = accountID
+	}
+
+	categorized, err := s.pdfParserService.CategorizeTransactions(ctx, userID, statement.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	staged := make([]*entities.StagedTransaction, 0, len(categorized))
+	for i, transaction := range categorized {
+		row, err := stageTransaction(transaction)
+		if err != nil {
+			return nil, fmt.Errorf("%w: transaction %d: %v", ErrInvalidInput, i+1, err)
+		}
+		row.Line = i + 1
+		staged = append(staged, row)
+	}
+
+	batch := &entities.ImportBatch{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Source:    entities.ImportFromStatement,
+		Status:    entities.ImportStaged,
+		FileName:  fileName,
+		BankName:  statement.BankName,
+		AccountID: accountID,
+		CreatedAt: time.Now(),
+	}
+	stored := make([]*repositories.StagedTransaction, 0, len(staged))
+	for _, row := range staged {
+		row.ID = uuid.NewString()
+		row.BatchID = batch.ID
+		stored = append(stored, toRepositoryStagedTransaction(row))
+	}
+	if err := s.importRepo.CreateStagedBatch(ctx, toRepositoryImportBatch(batch), stored); err != nil {
+		return nil, fmt.Errorf("failed to stage statement: %w", err)
+	}
+	return &entities.StagedImport{Batch: batch, Transactions: staged}, nil
+}
+
+// GetStagedImport gets an import batch with its staged transactions
+func (s *statementImportService) GetStagedImport(ctx context.Context, userID, batchID string) (*entities.StagedImport, error) {
+	batch, err := getOwnedImportBatch(ctx, s.importRepo, userID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.importRepo.GetStagedTransactions(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staged transactions: %w", err)
+	}
+
+	transactions := make([]*entities.StagedTransaction, 0, len(stored))
+	for _, transaction := range stored {
+		transactions = append(transactions, toEntityStagedTransaction(transaction))
+	}
+	return &entities.StagedImport{Batch: toEntityImportBatch(batch), Transactions: transactions}, nil
+}
+
+// UpdateStagedTransaction applies a review edit to a transaction of a staged
+// import. Choosing categories marks the transaction as reviewed.
+func (s *statementImportService) UpdateStagedTransaction(ctx context.Context, userID, batchID, transactionID string, update *entities.StagedTransactionUpdate) (*entities.StagedTransaction, error) {
+	if _, err := s.getStagedBatch(ctx, userID, batchID); err != nil {
+		return nil, err
+	}
+	stored, err := s.importRepo.GetStagedTransactions(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staged transactions: %w", err)
+	}
+	var transaction *repositories.StagedTransaction
+	for _, candidate := range stored {
+		if candidate.ID == transactionID {
+			transaction = candidate
+			break
+		}
+	}
+	if transaction == nil {
+		return nil, fmt.Errorf("staged transaction %s: %w", transactionID, ErrNotFound)
+	}
+
+	if update.Description != nil {
+		description := strings.TrimSpace(*update.Description)
+		if description == "" {
+			return nil, fmt.Errorf("%w: description must not be empty", ErrInvalidInput)
+		}
+		transaction.Description = description
+	}
+	if update.MainCategory != nil {
+		main, err := s.openCategory(ctx, userID, entities.MainCategoryKind, string(*update.MainCategory))
+		if err != nil {
+			return nil, err
+		}
+		transaction.MainCategory = main
+		transaction.NeedsReview = false
+	}
+	if update.SubCategory != nil {
+		sub, err := s.openCategory(ctx, userID, entities.SubCategoryKind, string(*update.SubCategory))
+		if err != nil {
+			return nil, err
+		}
+		transaction.SubCategory = sub
+		transaction.NeedsReview = false
+	}
+	if update.Excluded != nil {
+		if !*update.Excluded && entities.TransactionType(transaction.Type) == entities.CreditTransaction {
+			return nil, fmt.Errorf("%w: credits can't be imported as expenses", ErrInvalidInput)
+		}
+		transaction.Excluded = *update.Excluded
+	}
+
+	if err := s.importRepo.UpdateStagedTransaction(ctx, transaction); err != nil {
+		return nil, fmt.Errorf("failed to update staged transaction: %w", err)
+	}
+	return toEntityStagedTransaction(transaction), nil
+}
+
+// ConfirmImport saves every included transaction of a staged import as an
+// expense tagged with the batch. Nothing is saved unless every included
+// transaction has valid categories. Categories the user changed from the
+// suggestion are recorded as corrections for the categorizer to learn from.
+func (s *statementImportService) ConfirmImport(ctx context.Context, userID, batchID string) (*entities.ImportBatch, error) {
+	batch, err := s.getStagedBatch(ctx, userID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.importRepo.GetStagedTransactions(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staged transactions: %w", err)
+	}
+	// The account may have been archived since the statement was staged
+	if err := checkAccount(ctx, s.accountRepo, userID, batch.AccountID, ""); err != nil {
+		return nil, err
+	}
+	currency, err := s.importCurrency(ctx, userID, batch.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var expenses []*repositories.Expense
+	var corrections []*repositories.CategoryCorrection
+	for _, transaction := range stored {
+		if transaction.Excluded {
+			continue
+		}
+		if transaction.MainCategory == "" || transaction.SubCategory == "" {
+			return nil, fmt.Errorf("%w: transaction %d has no category, categorize or exclude it", ErrInvalidInput, transaction.Line)
+		}
+		expense := &entities.Expense{
+			ID:            uuid.NewString(),
+			UserID:        userID,
+			Amount:        transaction.Amount,
+			Currency:      currency,
+			Description:   transaction.Description,
+			Date:          transaction.Date,
+			MainCategory:  entities.MainCategory(transaction.MainCategory),
+			SubCategory:   entities.SubCategory(transaction.SubCategory),
+			AccountID:     batch.AccountID,
+			ImportBatchID: batchID,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := validateExpense(expense); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", transaction.Line, err)
+		}
+		if err := resolveCategories(ctx, s.categoryService, expense, nil); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", transaction.Line, err)
+		}
+		if err := attachToEvent(ctx, s.eventRepo, expense); err != nil {
+			return nil, err
+		}
+		expenses = append(expenses, toRepositoryExpense(expense))
+
+		if transaction.MainCategory != transaction.SuggestedMainCategory || transaction.SubCategory != transaction.SuggestedSubCategory {
+			corrections = append(corrections, &repositories.CategoryCorrection{
+				ID:              uuid.NewString(),
+				UserID:          userID,
+				Description:     transaction.Description,
+				Amount:          transaction.Amount,
+				TransactionType: transaction.Type,
+				MainCategory:    string(expense.MainCategory),
+				SubCategory:     string(expense.SubCategory),
+				CreatedAt:       now,
+			})
+		}
+	}
+	if len(expenses) == 0 {
+		return nil, fmt.Errorf("%w: every transaction is excluded, there's nothing to import", ErrInvalidInput)
+	}
+
+	if err := s.importRepo.ConfirmBatch(ctx, batchID, expenses, corrections); err != nil {
+		return nil, fmt.Errorf("failed to confirm import: %w", err)
+	}
+
+	batch.Status = string(entities.ImportCommitted)
+	batch.ExpenseCount = len(expenses)
+	return toEntityImportBatch(batch), nil
+}
+
+// importCurrency is the currency statement expenses are recorded in: the
+// account's when the import has one, otherwise the user's base currency
+func (s *statementImportService) importCurrency(ctx context.Context, userID, accountID string) (string, error) {
+	if accountID == "" {
+		return s.currencyService.BaseCurrency(ctx, userID)
+	}
+	account, err := getOwnedAccount(ctx, s.accountRepo, userID, accountID)
+	if err != nil {
+		return "", err
+	}
+	return account.Currency, nil
+}
+
+// getStagedBatch loads a batch owned by userID that's still awaiting review
+func (s *statementImportService) getStagedBatch(ctx context.Context, userID, id string) (*repositories.ImportBatch, error) {
+	batch, err := getOwnedImportBatch(ctx, s.importRepo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if entities.ImportStatus(batch.Status) != entities.ImportStaged {
+		return nil, fmt.Errorf("%w: import batch %s is %s, only staged imports can be reviewed", ErrInvalidInput, id, batch.Status)
+	}
+	return batch, nil
+}
+
+// statementAccount finds the user's only open account ending in last4, or
+// returns an empty ID when there's none or more than one
+func (s *statementImportService) statementAccount(ctx context.Context, userID, last4 string) (string, error) {
+	if last4 == "" {
+		return "", nil
+	}
+	accounts, err := s.accountRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get accounts: %w", err)
+	}
+	var match string
+	for _, account := range accounts {
+		if account.Archived || account.Last4 != last4 {
+			continue
+		}
+		if match != "" {
+			return "", nil
+		}
+		match = account.ID
+	}
+	return match, nil
+}
+
+// openCategory looks up a category by name and rejects archived ones
+func (s *statementImportService) openCategory(ctx context.Context, userID string, kind entities.CategoryKind, name string) (string, error) {
+	category, err := lookupCategory(ctx, s.categoryService, userID, kind, name)
+	if err != nil {
+		return "", err
+	}
+	if category.Archived {
+		return "", fmt.Errorf("%w: %s category %q is archived", ErrInvalidInput, kind, category.Name)
+	}
+	return category.Name, nil
+}
+
+// stageTransaction turns a categorized statement transaction into a staged
+// one. Credits and zero amounts start out excluded since they aren't spending.
+func stageTransaction(transaction *CategorizedTransaction) (*entities.StagedTransaction, error) {
+	date, err := time.Parse("2006-01-02", transaction.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", transaction.Date)
+	}
+	amount, err := parseImportAmount(transaction.Amount)
+	if err != nil {
+		return nil, err
+	}
+	kind := entities.TransactionType(strings.ToLower(transaction.Type))
+	if kind != entities.DebitTransaction && kind != entities.CreditTransaction {
+		return nil, fmt.Errorf("unknown transaction type %q", transaction.Type)
+	}
+
+	return &entities.StagedTransaction{
+		Date:                  date,
+		Description:           strings.TrimSpace(transaction.Description),
+		Amount:                amount.Abs(),
+		Type:                  kind,
+		MainCategory:          transaction.MainCategory,
+		SubCategory:           transaction.SubCategory,
+		SuggestedMainCategory: transaction.MainCategory,
+		SuggestedSubCategory:  transaction.SubCategory,
+		Confidence:            transaction.Confidence,
+		RuleID:                transaction.RuleID,
+		NeedsReview:           transaction.NeedsReview,
+		Excluded:              kind == entities.CreditTransaction || amount.IsZero(),
+	}, nil
+}
+
+// getOwnedImportBatch loads an import batch and hides it unless it belongs to userID
+func getOwnedImportBatch(ctx context.Context, importRepo repositories.ImportRepository, userID, id string) (*repositories.ImportBatch, error) {
+	stored, err := importRepo.GetBatchByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) || (err == nil && stored.UserID != userID) {
+		return nil, fmt.Errorf("import batch %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import batch: %w", err)
+	}
+	return stored, nil
+}
+
+// toRepositoryStagedTransaction converts a staged transaction entity to its repository model
+func toRepositoryStagedTransaction(transaction *entities.StagedTransaction) *repositories.StagedTransaction {
+	return &repositories.StagedTransaction{
+		ID:                    transaction.ID,
+		BatchID:               transaction.BatchID,
+		Line:                  transaction.Line,
+		Date:                  transaction.Date,
+		Description:           transaction.Description,
+		Amount:                transaction.Amount,
+		Type:                  string(transaction.Type),
+		MainCategory:          string(transaction.MainCategory),
+		SubCategory:           string(transaction.SubCategory),
+		SuggestedMainCategory: string(transaction.SuggestedMainCategory),
+		SuggestedSubCategory:  string(transaction.SuggestedSubCategory),
+		Confidence:            transaction.Confidence,
+		RuleID:                transaction.RuleID,
+		NeedsReview:           transaction.NeedsReview,
+		Excluded:              transaction.Excluded,
+	}
+}
+
+// toEntityStagedTransaction converts a repository staged transaction model to an entity
+func toEntityStagedTransaction(transaction *repositories.StagedTransaction) *entities.StagedTransaction {
+	return &entities.StagedTransaction{
+		ID:                    transaction.ID,
+		BatchID:               transaction.BatchID,
+		Line:                  transaction.Line,
+		Date:                  transaction.Date,
+		Description:           transaction.Description,
+		Amount:                transaction.Amount,
+		Type:                  entities.TransactionType(transaction.Type),
+		MainCategory:          entities.MainCategory(transaction.MainCategory),
+		SubCategory:           entities.SubCategory(transaction.SubCategory),
+		SuggestedMainCategory: entities.MainCategory(transaction.SuggestedMainCategory),
+		SuggestedSubCategory:  entities.SubCategory(transaction.SuggestedSubCategory),
+		Confidence:            transaction.Confidence,
+		RuleID:                transaction.RuleID,
+		NeedsReview:           transaction.NeedsReview,
+		Excluded:              transaction.Excluded,
+	}
+}
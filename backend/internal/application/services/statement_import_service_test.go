@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestStatementImportReviewAndConfirm(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+	account := &entities.Account{UserID: "u1", Name: "HDFC savings", Type: entities.BankAccount, Last4: "6789", OpeningDate: date(2024, 3, 1)}
+	if err := svc.accounts.CreateAccount(ctx, account); err != nil {
+		t.Fatalf("CreateAccount() error = %v", err)
+	}
+	rule := newTestRule(t, svc, &entities.CategorizationRule{Pattern: "swiggy", MainCategory: entities.Self, SubCategory: entities.Food})
+
+	staged, err := svc.reviews.StageStatement(ctx, "u1", "april.pdf", hdfcStatement(), "", "", "")
+	if err != nil {
+		t.Fatalf("StageStatement() error = %v", err)
+	}
+	batch := staged.Batch
+	if batch.Status != entities.ImportStaged || batch.Source != entities.ImportFromStatement || batch.BankName != "HDFC Bank" || batch.AccountID != account.ID {
+		t.Errorf("unexpected staged batch %+v", batch)
+	}
+	if len(staged.Transactions) != 3 {
+		t.Fatalf("expected 3 staged transactions, got %d", len(staged.Transactions))
+	}
+	swiggy, salary, atm := staged.Transactions[0], staged.Transactions[1], staged.Transactions[2]
+	if swiggy.RuleID != rule.ID || swiggy.SubCategory != entities.Food || swiggy.Excluded || !swiggy.Date.Equal(date(2024, 4, 1)) {
+		t.Errorf("expected the rule's categories on the first transaction, got %+v", swiggy)
+	}
+	if !salary.Excluded || salary.Type != entities.CreditTransaction {
+		t.Errorf("expected the salary credit to be excluded, got %+v", salary)
+	}
+	if atm.MainCategory != "" || !atm.NeedsReview {
+		t.Errorf("expected the withdrawal to need review, got %+v", atm)
+	}
+	if _, total, _ := svc.expenses.ListExpenses(ctx, "u1", nil); total != 0 {
+		t.Errorf("expected staging to save no expenses, got %d", total)
+	}
+
+	if _, err := svc.reviews.ConfirmImport(ctx, "u1", batch.ID); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected an uncategorized transaction to block confirming, got %v", err)
+	}
+
+	description, main, sub := "Cash withdrawal", entities.Self, entities.Misc
+	edited, err := svc.reviews.UpdateStagedTransaction(ctx, "u1", batch.ID, atm.ID, &entities.StagedTransactionUpdate{
+		Description: &description, MainCategory: &main, SubCategory: &sub,
+	})
+	if err != nil {
+		t.Fatalf("UpdateStagedTransaction() error = %v", err)
+	}
+	if edited.Description != description || edited.MainCategory != main || edited.SubCategory != sub || edited.NeedsReview {
+		t.Errorf("expected the edit to be applied, got %+v", edited)
+	}
+	included := false
+	if _, err := svc.reviews.UpdateStagedTransaction(ctx, "u1", batch.ID, salary.ID, &entities.StagedTransactionUpdate{Excluded: &included}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected including a credit to fail, got %v", err)
+	}
+	if _, err := svc.reviews.UpdateStagedTransaction(ctx, "u1", batch.ID, "missing", &entities.StagedTransactionUpdate{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected an unknown transaction to be not found, got %v", err)
+	}
+	if _, err := svc.reviews.GetStagedImport(ctx, "u2", batch.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected another user's import to be hidden, got %v", err)
+	}
+
+	confirmed, err := svc.reviews.ConfirmImport(ctx, "u1", batch.ID)
+	if err != nil {
+		t.Fatalf("ConfirmImport() error = %v", err)
+	}
+	if confirmed.Status != entities.ImportCommitted || confirmed.ExpenseCount != 2 {
+		t.Errorf("expected 2 committed expenses, got %+v", confirmed)
+	}
+	expenses, total, _ := svc.expenses.ListExpenses(ctx, "u1", &ExpenseFilter{SubCategory: entities.Misc})
+	if total != 1 || expenses[0].Description != description || expenses[0].ImportBatchID != batch.ID || expenses[0].AccountID != account.ID {
+		t.Errorf("expected the edited withdrawal to be saved, got %+v", expenses)
+	}
+
+	// A confirmed import can't be edited or confirmed again
+	if _, err := svc.reviews.ConfirmImport(ctx, "u1", batch.ID); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected confirming twice to fail, got %v", err)
+	}
+	if _, err := svc.reviews.UpdateStagedTransaction(ctx, "u1", batch.ID, atm.ID, &entities.StagedTransactionUpdate{Description: &description}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected editing a confirmed import to fail, got %v", err)
+	}
+
+	undone, err := svc.imports.UndoBatch(ctx, "u1", batch.ID)
+	if err != nil {
+		t.Fatalf("UndoBatch() error = %v", err)
+	}
+	if undone.Status != entities.ImportUndone {
+		t.Errorf("expected the batch to be undone, got %q", undone.Status)
+	}
+	if _, total, _ := svc.expenses.ListExpenses(ctx, "u1", nil); total != 0 {
+		t.Errorf("expected rolling back to remove the imported expenses, got %d", total)
+	}
+}
+
+func TestConfirmStatementImportUsesAccountAndEvents(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+	account := &entities.Account{UserID: "u1", Name: "HDFC NRE", Type: entities.BankAccount, Currency: "USD", Last4: "6789", OpeningDate: date(2024, 3, 1)}
+	if err := svc.accounts.CreateAccount(ctx, account); err != nil {
+		t.Fatalf("CreateAccount() error = %v", err)
+	}
+	trip := &entities.Event{UserID: "u1", Name: "Goa trip", StartDate: date(2024, 4, 4), EndDate: date(2024, 4, 6), AutoAttach: true}
+	if err := svc.events.CreateEvent(ctx, trip); err != nil {
+		t.Fatalf("CreateEvent() error = %v", err)
+	}
+
+	staged, err := svc.reviews.StageStatement(ctx, "u1", "april.pdf", hdfcStatement(), "", "", "")
+	if err != nil {
+		t.Fatalf("StageStatement() error = %v", err)
+	}
+	batch, swiggy, atm := staged.Batch, staged.Transactions[0], staged.Transactions[2]
+	excluded, main, sub := true, entities.Self, entities.Misc
+	if _, err := svc.reviews.UpdateStagedTransaction(ctx, "u1", batch.ID, swiggy.ID, &entities.StagedTransactionUpdate{Excluded: &excluded}); err != nil {
+		t.Fatalf("UpdateStagedTransaction() error = %v", err)
+	}
+	if _, err := svc.reviews.UpdateStagedTransaction(ctx, "u1", batch.ID, atm.ID, &entities.StagedTransactionUpdate{MainCategory: &main, SubCategory: &sub}); err != nil {
+		t.Fatalf("UpdateStagedTransaction() error = %v", err)
+	}
+
+	// Staged categories follow a rename made during review
+	self, _ := svc.categories.LookupCategory(ctx, "u1", entities.MainCategoryKind, string(entities.Self))
+	self.Name = "Me"
+	if err := svc.categories.UpdateCategory(ctx, self.ID, self); err != nil {
+		t.Fatalf("UpdateCategory() error = %v", err)
+	}
+	review, err := svc.reviews.GetStagedImport(ctx, "u1", batch.ID)
+	if err != nil {
+		t.Fatalf("GetStagedImport() error = %v", err)
+	}
+	if got := review.Transactions[2]; got.MainCategory != "Me" {
+		t.Errorf("expected the staged category to follow the rename, got %q", got.MainCategory)
+	}
+
+	// The account is rechecked, as it may have been archived since staging
+	account.Archived = true
+	if err := svc.accounts.UpdateAccount(ctx, account.ID, account); err != nil {
+		t.Fatalf("UpdateAccount() error = %v", err)
+	}
+	if _, err := svc.reviews.ConfirmImport(ctx, "u1", batch.ID); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected confirming into an archived account to fail, got %v", err)
+	}
+	account.Archived = false
+	if err := svc.accounts.UpdateAccount(ctx, account.ID, account); err != nil {
+		t.Fatalf("UpdateAccount() error = %v", err)
+	}
+
+	if _, err := svc.reviews.ConfirmImport(ctx, "u1", batch.ID); err != nil {
+		t.Fatalf("ConfirmImport() error = %v", err)
+	}
+	expenses, total, _ := svc.expenses.ListExpenses(ctx, "u1", &ExpenseFilter{SubCategory: entities.Misc})
+	if total != 1 || expenses[0].Currency != "USD" || expenses[0].EventID != trip.ID {
+		t.Errorf("expected the withdrawal in the account's USD and on the trip, got %+v", expenses)
+	}
+
+	// The batch keeps the account from being deleted even once it's rolled back
+	if _, err := svc.imports.UndoBatch(ctx, "u1", batch.ID); err != nil {
+		t.Fatalf("UndoBatch() error = %v", err)
+	}
+	if err := svc.accounts.DeleteAccount(ctx, "u1", account.ID); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected deleting an account with imports to fail, got %v", err)
+	}
+}
+
+func TestDiscardStagedStatementImport(t *testing.T) {
+	svc := newTestServices(t)
+	ctx := context.Background()
+
+	staged, err := svc.reviews.StageStatement(ctx, "u1", "april.pdf", hdfcStatement(), "hdfc", "", "")
+	if err != nil {
+		t.Fatalf("StageStatement() error = %v", err)
+	}
+	if staged.Batch.AccountID != "" {
+		t.Errorf("expected no account without a matching one, got %q", staged.Batch.AccountID)
+	}
+	if _, err := svc.imports.UndoBatch(ctx, "u1", staged.Batch.ID); err != nil {
+		t.Fatalf("UndoBatch() error = %v", err)
+	}
+
+	discarded, err := svc.reviews.GetStagedImport(ctx, "u1", staged.Batch.ID)
+	if err != nil {
+		t.Fatalf("GetStagedImport() error = %v", err)
+	}
+	if discarded.Batch.Status != entities.ImportUndone || len(discarded.Transactions) != 3 {
+		t.Errorf("expected the discarded import to keep its transactions, got %+v", discarded.Batch)
+	}
+	if _, err := svc.reviews.ConfirmImport(ctx, "u1", staged.Batch.ID); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected a discarded import not to be confirmable, got %v", err)
+	}
+
+	if _, err := svc.reviews.StageStatement(ctx, "u1", "april.pdf", hdfcStatement(), "", "", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected an unknown account to be rejected, got %v", err)
+	}
+}
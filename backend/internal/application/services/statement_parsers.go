@@ -0,0 +1,180 @@
+package services
+
+import (
+	"nestmate-backend/internal/infrastructure/pdf"
+	"sort"
+	"strings"
+)
+
+// statementHeaderLines is how many lines from the top of a statement's first
+// page are searched to detect the bank. Keeping to the top leaves out
+// transactions whose narrations name other banks.
+const statementHeaderLines = 15
+
+// BankStatementParser reads the transactions out of one bank's PDF statements
+type BankStatementParser interface {
+	// Bank is the bank type the parser is selected by, such as "hdfc"
+	Bank() string
+	// Detect reports whether a statement header, in lower case, is from this bank
+	Detect(header string) bool
+	// Parse reads the transactions and account details from a statement's pages
+	Parse(pages []*pdf.Page) (*ParsedTransactions, error)
+}
+
+// BankParserRegistry holds the statement parsers ParseBankStatement chooses from
+type BankParserRegistry struct {
+	parsers []BankStatementParser
+}
+
+// NewBankParserRegistry creates a registry holding parsers
+func NewBankParserRegistry(parsers ...BankStatementParser) *BankParserRegistry {
+	registry := &BankParserRegistry{}
+	for _, parser := range parsers {
+		registry.Register(parser)
+	}
+	return registry
+}
+
+// DefaultBankParsers creates a registry holding every built-in bank parser
+func DefaultBankParsers() *BankParserRegistry {
+	return NewBankParserRegistry(
+		hdfcStatementParser,
+		iciciStatementParser,
+		sbiStatementParser,
+		axisStatementParser,
+		kotakStatementParser,
+	)
+}
+
+// Register adds a parser, replacing any already registered for the same bank
+func (r *BankParserRegistry) Register(parser BankStatementParser) {
+	for i, existing := range r.parsers {
+		if strings.EqualFold(existing.Bank(), parser.Bank()) {
+			r.parsers[i] = parser
+			return
+		}
+	}
+	r.parsers = append(r.parsers, parser)
+}
+
+// Lookup finds the parser for a bank type, ignoring case
+func (r *BankParserRegistry) Lookup(bankType string) (BankStatementParser, bool) {
+	for _, parser := range r.parsers {
+		if strings.EqualFold(parser.Bank(), bankType) {
+			return parser, true
+		}
+	}
+	return nil, false
+}
+
+// Detect finds the first registered parser recognising a statement's header
+func (r *BankParserRegistry) Detect(pages []*pdf.Page) (BankStatementParser, bool) {
+	header := strings.ToLower(statementHeader(pages))
+	for _, parser := range r.parsers {
+		if parser.Detect(header) {
+			return parser, true
+		}
+	}
+	return nil, false
+}
+
+// Banks lists the registered bank types in alphabetical order
+func (r *BankParserRegistry) Banks() []string {
+	banks := make([]string, 0, len(r.parsers))
+	for _, parser := range r.parsers {
+		banks = append(banks, parser.Bank())
+	}
+	sort.Strings(banks)
+	return banks
+}
+
+// statementHeader returns the text at the top of a statement's first page
+func statementHeader(pages []*pdf.Page) string {
+	if len(pages) == 0 {
+		return ""
+	}
+	lines := pages[0].Lines()
+	if len(lines) > statementHeaderLines {
+		lines = lines[:statementHeaderLines]
+	}
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		texts[i] = line.Text()
+	}
+	return strings.Join(texts, "\n")
+}
+
+// hdfcStatementParser reads HDFC Bank account statements
+var hdfcStatementParser = &tableStatementParser{
+	bank:        "hdfc",
+	name:        "HDFC Bank",
+	markers:     []string{"hdfc bank", "hdfc0"},
+	dateLayouts: []string{"02/01/06", "02/01/2006"},
+	columns: statementColumns{
+		date:      []string{"date"},
+		narration: []string{"narration"},
+		debit:     []string{"withdrawal"},
+		credit:    []string{"deposit"},
+		balance:   []string{"closing balance"},
+	},
+}
+
+// iciciStatementParser reads ICICI Bank detailed account statements
+var iciciStatementParser = &tableStatementParser{
+	bank:        "icici",
+	name:        "ICICI Bank",
+	markers:     []string{"icici bank", "icic0"},
+	dateLayouts: []string{"02/01/2006", "02-01-2006", "02-Jan-2006", "02/01/06"},
+	columns: statementColumns{
+		date:      []string{"transaction date", "txn date", "date"},
+		narration: []string{"transaction remarks", "remarks", "particulars", "description"},
+		debit:     []string{"withdrawal"},
+		credit:    []string{"deposit"},
+		balance:   []string{"balance"},
+	},
+}
+
+// sbiStatementParser reads State Bank of India account statements
+var sbiStatementParser = &tableStatementParser{
+	bank:        "sbi",
+	name:        "State Bank of India",
+	markers:     []string{"state bank of india", "sbin0"},
+	dateLayouts: []string{"2 Jan 2006", "2-Jan-2006", "02/01/2006", "02-01-2006"},
+	columns: statementColumns{
+		date:      []string{"txn date", "date"},
+		narration: []string{"description", "narration"},
+		debit:     []string{"debit"},
+		credit:    []string{"credit"},
+		balance:   []string{"balance"},
+	},
+}
+
+// axisStatementParser reads Axis Bank account statements
+var axisStatementParser = &tableStatementParser{
+	bank:        "axis",
+	name:        "Axis Bank",
+	markers:     []string{"axis bank", "utib0"},
+	dateLayouts: []string{"02-01-2006", "02/01/2006", "02-01-06"},
+	columns: statementColumns{
+		date:      []string{"tran date", "txn date", "date"},
+		narration: []string{"particulars", "description"},
+		debit:     []string{"debit", "withdrawal"},
+		credit:    []string{"credit", "deposit"},
+		balance:   []string{"balance"},
+	},
+}
+
+// kotakStatementParser reads Kotak Mahindra Bank account statements
+var kotakStatementParser = &tableStatementParser{
+	bank:        "kotak",
+	name:        "Kotak Mahindra Bank",
+	markers:     []string{"kotak mahindra bank", "kkbk0"},
+	dateLayouts: []string{"02-01-2006", "02 Jan 2006", "02/01/2006", "02-Jan-2006"},
+	columns: statementColumns{
+		date:      []string{"date", "transaction date"},
+		narration: []string{"narration", "description"},
+		debit:     []string{"withdrawal", "debit"},
+		credit:    []string{"deposit", "credit"},
+		balance:   []string{"balance"},
+	},
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"nestmate-backend/internal/infrastructure/pdf"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// narrationIndent is how far, in points, wrapped narration text may start
+	// left of its column header
+	narrationIndent = 3.0
+
+	// statementDate matches the dates printed in statement headers, such as
+	// 01/04/2024, 01-04-24 or 1 Apr 2024
+	statementDate = `\d{1,2}[/\-. ](?:\d{1,2}|[A-Za-z]{3,9})[/\-. ]\d{2,4}`
+)
+
+var (
+	// statementAccount finds the account number in a statement header. Masked
+	// digits are kept so the last four can still be found.
+	statementAccount = regexp.MustCompile(`(?i)(?:a/c|account)\s*(?:no\.?|number|#)?\s*:?\s*([0-9Xx*][0-9Xx* -]{3,})`)
+
+	// statementPeriod finds the first and last dates a statement covers
+	statementPeriod = regexp.MustCompile(`(?i)(?:period|from)\s*(?:of\s*)?:?\s*(` + statementDate + `)\s*(?:to|-|–)\s*:?\s*(` + statementDate + `)`)
+)
+
+// statementColumns holds the lower-case header labels that identify each
+// column of a transaction table. A header cell belongs to a column when it
+// starts with one of its labels.
+type statementColumns struct {
+	date      []string
+	narration []string
+	debit     []string
+	credit    []string
+	balance   []string
+}
+
+// columnRole is the part of a transaction a table column holds
+type columnRole int
+
+const (
+	otherColumn columnRole = iota
+	dateColumn
+	narrationColumn
+	debitColumn
+	creditColumn
+	balanceColumn
+)
+
+// tableColumn is one column of a transaction table, spanning its header cell
+type tableColumn struct {
+	role columnRole
+	x    float64
+	endX float64
+}
+
+// tableStatementParser reads statements laid out as a transaction table: a
+// header row naming the columns, then one row per transaction starting with
+// its date, with long narrations wrapping onto the rows below. Cells are
+// matched to columns by position, so empty debit or credit cells don't shift
+// the amounts.
+type tableStatementParser struct {
+	bank        string
+	name        string
+	markers     []string
+	dateLayouts []string
+	columns     statementColumns
+}
+
+// Bank returns the bank type the parser is registered under
+func (p *tableStatementParser) Bank() string {
+	return p.bank
+}
+
+// Detect reports whether header mentions one of the bank's markers
+func (p *tableStatementParser) Detect(header string) bool {
+	for _, marker := range p.markers {
+		if strings.Contains(header, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse reads every transaction row under the table headers, along with the
+// account number and period from the text above the first table
+func (p *tableStatementParser) Parse(pages []*pdf.Page) (*ParsedTransactions, error) {
+	parsed := &ParsedTransactions{
+		Transactions: []*Transaction{},
+		BankName:     p.name,
+	}
+
+	var header []string
+	var columns []tableColumn
+	for _, page := range pages {
+		var last *Transaction
+		for _, line := range page.Lines() {
+			if found := p.headerColumns(line); found != nil {
+				columns, last = found, nil
+				continue
+			}
+			if columns == nil {
+				header = append(header, line.Text())
+				continue
+			}
+
+			row := p.readRow(line, columns)
+			switch {
+			case row.transaction != nil:
+				parsed.Transactions = append(parsed.Transactions, row.transaction)
+				last = row.transaction
+			case row.continuation != "" && last != nil:
+				last.Description = strings.TrimSpace(last.Description + " " + row.continuation)
+			default:
+				last = nil
+			}
+		}
+	}
+
+	if columns == nil {
+		return nil, fmt.Errorf("%w: no %s transaction table found in the statement", ErrInvalidInput, p.name)
+	}
+	text := strings.Join(header, "\n")
+	parsed.AccountLast4 = accountLast4(text)
+	parsed.StatementPeriod = p.period(text)
+	return parsed, nil
+}
+
+// headerColumns returns the columns of a table header line, or nil if the
+// line isn't one. A header needs narration, debit and credit columns.
+func (p *tableStatementParser) headerColumns(line pdf.Line) []tableColumn {
+	columns := make([]tableColumn, 0, len(line.Cells))
+	found := make(map[columnRole]bool)
+	for _, cell := range line.Cells {
+		role := p.columnRole(cell.Text)
+		columns = append(columns, tableColumn{role: role, x: cell.X, endX: cell.EndX})
+		found[role] = true
+	}
+	if !found[narrationColumn] || !found[debitColumn] || !found[creditColumn] {
+		return nil
+	}
+	return columns
+}
+
+// columnRole identifies a header cell by its label
+func (p *tableStatementParser) columnRole(label string) columnRole {
+	label = strings.ToLower(strings.TrimSpace(label))
+	roles := []struct {
+		role   columnRole
+		labels []string
+	}{
+		{narrationColumn, p.columns.narration},
+		{debitColumn, p.columns.debit},
+		{creditColumn, p.columns.credit},
+		{balanceColumn, p.columns.balance},
+		{dateColumn, p.columns.date},
+	}
+	for _, r := range roles {
+		for _, prefix := range r.labels {
+			if strings.HasPrefix(label, prefix) {
+				return r.role
+			}
+		}
+	}
+	return otherColumn
+}
+
+// tableRow is what one line under a table header turned out to be: a new
+// transaction, more narration for the one above, or neither
+type tableRow struct {
+	transaction  *Transaction
+	continuation string
+}
+
+// readRow matches a line's cells to columns and reads the transaction on it.
+// Lines without a date whose text is all in the narration column continue the
+// transaction above.
+func (p *tableStatementParser) readRow(line pdf.Line, columns []tableColumn) tableRow {
+	var date time.Time
+	var narration []string
+	debit, credit := decimal.Zero, decimal.Zero
+	onlyNarration := true
+	for _, cell := range line.Cells {
+		column := nearestColumn(cell, columns)
+		// Wrapped narrations line up with the column; text starting further
+		// left, such as a summary under the table, doesn't belong to it
+		if column.role != narrationColumn || cell.X < column.x-narrationIndent {
+			onlyNarration = false
+		}
+		switch column.role {
+		case narrationColumn:
+			narration = append(narration, cell.Text)
+		case debitColumn:
+			debit = statementAmount(cell.Text)
+		case creditColumn:
+			credit = statementAmount(cell.Text)
+		case dateColumn:
+			if date.IsZero() {
+				date, _ = p.parseDate(cell.Text)
+			}
+		}
+	}
+	if date.IsZero() && len(line.Cells) > 0 {
+		// Dates printed slightly left of their column header still start the row
+		date, _ = p.parseDate(line.Cells[0].Text)
+	}
+
+	if date.IsZero() {
+		if onlyNarration {
+			return tableRow{continuation: strings.Join(narration, " ")}
+		}
+		return tableRow{}
+	}
+
+	transaction := &Transaction{
+		Date:        date.Format("2006-01-02"),
+		Description: strings.Join(narration, " "),
+	}
+	switch {
+	case debit.IsPositive():
+		transaction.Amount, transaction.Type = debit.StringFixed(2), "Debit"
+	case credit.IsPositive():
+		transaction.Amount, transaction.Type = credit.StringFixed(2), "Credit"
+	default:
+		// Opening balances and other rows without an amount aren't transactions
+		return tableRow{}
+	}
+	return tableRow{transaction: transaction}
+}
+
+// nearestColumn returns the column a cell sits in: the one it overlaps most,
+// or failing that the one whose centre is closest
+func nearestColumn(cell pdf.Cell, columns []tableColumn) tableColumn {
+	best, bestOverlap, bestDistance := tableColumn{}, 0.0, math.Inf(1)
+	for _, column := range columns {
+		overlap := math.Min(cell.EndX, column.endX) - math.Max(cell.X, column.x)
+		distance := math.Abs((cell.X+cell.EndX)/2 - (column.x+column.endX)/2)
+		switch {
+		case overlap > bestOverlap:
+			best, bestOverlap, bestDistance = column, overlap, distance
+		case bestOverlap <= 0 && overlap <= 0 && distance < bestDistance:
+			best, bestDistance = column, distance
+		}
+	}
+	return best
+}
+
+// parseDate reads a date in one of the bank's layouts, ignoring any time
+// printed after it
+func (p *tableStatementParser) parseDate(text string) (time.Time, error) {
+	fields := strings.Fields(text)
+	for n := len(fields); n > 0; n-- {
+		candidate := strings.Join(fields[:n], " ")
+		for _, layout := range p.dateLayouts {
+			if date, err := time.Parse(layout, candidate); err == nil {
+				return date, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", text)
+}
+
+// period reads the statement period from the header as "start to end" in
+// ISO dates, or as printed if the dates don't parse
+func (p *tableStatementParser) period(header string) string {
+	match := statementPeriod.FindStringSubmatch(header)
+	if match == nil {
+		return ""
+	}
+	start, err1 := p.parseDate(match[1])
+	end, err2 := p.parseDate(match[2])
+	if err1 != nil || err2 != nil {
+		return match[1] + " to " + match[2]
+	}
+	return start.Format("2006-01-02") + " to " + end.Format("2006-01-02")
+}
+
+// accountLast4 returns the last four digits of the account number in a
+// statement header
+func accountLast4(header string) string {
+	for _, match := range statementAccount.FindAllStringSubmatch(header, -1) {
+		digits := strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, match[1])
+		if len(digits) >= 4 {
+			return digits[len(digits)-4:]
+		}
+	}
+	return ""
+}
+
+// statementAmount reads an amount cell, ignoring currency symbols and Dr/Cr
+// markers. Blank or unreadable cells count as zero.
+func statementAmount(text string) decimal.Decimal {
+	cleaned := strings.ToLower(strings.TrimSpace(text))
+	for _, noise := range []string{"(dr)", "(cr)", "dr", "cr", "inr", "rs.", "₹"} {
+		cleaned = strings.ReplaceAll(cleaned, noise, "")
+	}
+	amount, err := parseImportAmount(strings.TrimSpace(cleaned))
+	if err != nil {
+		return decimal.Zero
+	}
+	return amount.Abs()
+}
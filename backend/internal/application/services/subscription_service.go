@@ -0,0 +1,336 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/domain/repositories"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// SubscriptionLookbackMonths is how many months of expenses are scanned for
+	// subscriptions, enough to see a yearly one charge twice
+	SubscriptionLookbackMonths = 25
+
+	// minSubscriptionCharges is the fewest charges that make a subscription.
+	// Yearly ones only need two.
+	minSubscriptionCharges = 3
+)
+
+// subscriptionAmountTolerance is how far a charge may move from the one before
+// it, as a fraction, and still belong to the same subscription. It leaves room
+// for price increases while keeping out merchants with varying bills.
+var subscriptionAmountTolerance = decimal.NewFromFloat(0.5)
+
+// subscriptionCadence describes the gaps between charges accepted for a cadence
+// and the recurrence rule that reproduces it
+type subscriptionCadence struct {
+	cadence   entities.SubscriptionCadence
+	minDays   int
+	maxDays   int
+	perYear   int64
+	frequency entities.RecurrenceFrequency
+	interval  int
+}
+
+// subscriptionCadences lists the cadences a subscription can be detected at
+var subscriptionCadences = []subscriptionCadence{
+	{entities.WeeklyCadence, 6, 8, 52, entities.Weekly, 1},
+	{entities.MonthlyCadence, 27, 33, 12, entities.Monthly, 1},
+	{entities.QuarterlyCadence, 85, 96, 4, entities.Monthly, 3},
+	{entities.YearlyCadence, 355, 375, 1, entities.Yearly, 1},
+}
+
+// next returns the date a charge on t is expected to be followed by
+func (c subscriptionCadence) next(t time.Time) time.Time {
+	switch c.frequency {
+	case entities.Weekly:
+		return t.AddDate(0, 0, 7*c.interval)
+	case entities.Yearly:
+		return t.AddDate(c.interval, 0, 0)
+	}
+	return t.AddDate(0, c.interval, 0)
+}
+
+// SubscriptionService defines the interface for subscription detection
+type SubscriptionService interface {
+	DetectSubscriptions(ctx context.Context, userID string) (*entities.SubscriptionReport, error)
+	ConvertToRecurring(ctx context.Context, userID, key string) (*entities.RecurringExpense, error)
+}
+
+// subscriptionService implements the SubscriptionService interface
+type subscriptionService struct {
+	expenseService   ExpenseService
+	recurringRepo    repositories.RecurringExpenseRepository
+	recurringService RecurringExpenseService
+	currencyService  CurrencyService
+}
+
+// NewSubscriptionService creates a new subscription detection service
+func NewSubscriptionService(expenseService ExpenseService, recurringRepo repositories.RecurringExpenseRepository, recurringService RecurringExpenseService, currencyService CurrencyService) SubscriptionService {
+	return &subscriptionService{
+		expenseService:   expenseService,
+		recurringRepo:    recurringRepo,
+		recurringService: recurringService,
+		currencyService:  currencyService,
+	}
+}
+
+// DetectSubscriptions scans recent expenses for merchants charging at a
+// regular cadence with similar amounts. Active subscriptions come first,
+// costliest first; the annualized total covers only the active ones.
+func (s *subscriptionService) DetectSubscriptions(ctx context.Context, userID string) (*entities.SubscriptionReport, error) {
+	now := time.Now()
+	expenses, err := s.expenseService.GetExpensesByPeriod(ctx, userID, startOfMonth(now).AddDate(0, -SubscriptionLookbackMonths, 0), now.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	base, err := s.currencyService.BaseCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tracked, err := s.trackedMerchants(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	byMerchant := make(map[string][]*entities.Expense)
+	for _, expense := range expenses {
+		key := merchantKey(expense.Description)
+		if key == "" {
+			continue
+		}
+		if _, ok := byMerchant[key]; !ok {
+			keys = append(keys, key)
+		}
+		byMerchant[key] = append(byMerchant[key], expense)
+	}
+
+	report := &entities.SubscriptionReport{
+		Currency:        base,
+		AnnualizedTotal: decimal.Zero,
+		Subscriptions:   []*entities.Subscription{},
+	}
+	annualInBase := make(map[string]decimal.Decimal)
+	for _, key := range keys {
+		charges := byMerchant[key]
+		// Charges are compared in the currency of the latest one
+		currency := charges[len(charges)-1].Currency
+		converted := make([]*entities.Expense, 0, len(charges))
+		for _, charge := range charges {
+			inCurrency, err := convertExpense(ctx, s.currencyService, userID, charge, currency)
+			if err != nil {
+				return nil, err
+			}
+			converted = append(converted, inCurrency)
+		}
+
+		subscription := detectSubscription(key, converted, now)
+		if subscription == nil {
+			continue
+		}
+		subscription.RecurringID = tracked[key]
+
+		annual, err := s.currencyService.Convert(ctx, userID, subscription.AnnualizedCost, subscription.Currency, base, subscription.LastCharge)
+		if err != nil {
+			return nil, err
+		}
+		annualInBase[key] = annual
+		if subscription.Active {
+			report.AnnualizedTotal = report.AnnualizedTotal.Add(annual)
+		}
+		report.Subscriptions = append(report.Subscriptions, subscription)
+	}
+
+	sort.SliceStable(report.Subscriptions, func(i, j int) bool {
+		a, b := report.Subscriptions[i], report.Subscriptions[j]
+		if a.Active != b.Active {
+			return a.Active
+		}
+		return annualInBase[a.Key].GreaterThan(annualInBase[b.Key])
+	})
+	return report, nil
+}
+
+// ConvertToRecurring turns an active detected subscription into a recurring
+// expense, starting from its next expected charge that hasn't happened yet so
+// past charges aren't generated a second time
+func (s *subscriptionService) ConvertToRecurring(ctx context.Context, userID, key string) (*entities.RecurringExpense, error) {
+	report, err := s.DetectSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var subscription *entities.Subscription
+	for _, candidate := range report.Subscriptions {
+		if candidate.Key == key {
+			subscription = candidate
+			break
+		}
+	}
+	if subscription == nil {
+		return nil, fmt.Errorf("subscription %s: %w", key, ErrNotFound)
+	}
+	if subscription.RecurringID != "" {
+		return nil, fmt.Errorf("%w: subscription %s is already tracked by recurring expense %s", ErrInvalidInput, key, subscription.RecurringID)
+	}
+	if !subscription.Active {
+		return nil, fmt.Errorf("%w: subscription %s has stopped charging", ErrInvalidInput, key)
+	}
+
+	cadence := cadenceFor(subscription.Cadence)
+	start := subscription.NextCharge
+	for today := startOfDay(time.Now()); start.Before(today); {
+		start = cadence.next(start)
+	}
+	recurring := &entities.RecurringExpense{
+		UserID:       userID,
+		Amount:       subscription.Amount,
+		Currency:     subscription.Currency,
+		Description:  subscription.Merchant,
+		MainCategory: subscription.MainCategory,
+		SubCategory:  subscription.SubCategory,
+		Rule:         &entities.RecurrenceRule{Frequency: cadence.frequency, Interval: cadence.interval},
+		StartDate:    start,
+	}
+	if err := s.recurringService.CreateRecurringExpense(ctx, recurring); err != nil {
+		return nil, err
+	}
+	return recurring, nil
+}
+
+// trackedMerchants maps merchant keys to the recurring expense already
+// generating their charges
+func (s *subscriptionService) trackedMerchants(ctx context.Context, userID string) (map[string]string, error) {
+	stored, err := s.recurringRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring expenses: %w", err)
+	}
+	tracked := make(map[string]string, len(stored))
+	for _, recurring := range stored {
+		if key := merchantKey(recurring.Description); key != "" {
+			tracked[key] = recurring.ID
+		}
+	}
+	return tracked, nil
+}
+
+// detectSubscription decides whether one merchant's charges, oldest first and
+// all in the same currency, form a subscription. It returns nil if they don't.
+func detectSubscription(key string, charges []*entities.Expense, now time.Time) *entities.Subscription {
+	if len(charges) < 2 {
+		return nil
+	}
+	// The cadence is read off the latest gap and the subscription is the run of
+	// charges leading up to it, so a skipped month or a one-off charge from the
+	// same merchant further back doesn't hide it
+	cadence := cadenceOf(daysBetween(charges[len(charges)-2].Date, charges[len(charges)-1].Date))
+	if cadence == nil {
+		return nil
+	}
+	consistent := func(i int) bool {
+		gap := daysBetween(charges[i-1].Date, charges[i].Date)
+		previous := charges[i-1].Amount
+		return gap >= cadence.minDays && gap <= cadence.maxDays &&
+			!charges[i].Amount.Sub(previous).Abs().GreaterThan(previous.Mul(subscriptionAmountTolerance))
+	}
+	start := len(charges) - 1
+	for start > 0 && consistent(start) {
+		start--
+	}
+	charges = charges[start:]
+	if len(charges) < 2 || (len(charges) < minSubscriptionCharges && cadence.cadence != entities.YearlyCadence) {
+		return nil
+	}
+
+	first, last := charges[0], charges[len(charges)-1]
+	next := cadence.next(startOfDay(last.Date))
+	subscription := &entities.Subscription{
+		Key:            key,
+		Merchant:       last.Description,
+		Cadence:        cadence.cadence,
+		Currency:       last.Currency,
+		Amount:         last.Amount,
+		AnnualizedCost: last.Amount.Mul(decimal.NewFromInt(cadence.perYear)),
+		Charges:        len(charges),
+		FirstCharge:    first.Date,
+		LastCharge:     last.Date,
+		NextCharge:     next,
+		Active:         now.Before(startOfDay(last.Date).AddDate(0, 0, cadence.maxDays*3/2)),
+		MainCategory:   last.MainCategory,
+		SubCategory:    last.SubCategory,
+		ExpenseIDs:     make([]string, 0, len(charges)),
+	}
+	for _, charge := range charges {
+		subscription.ExpenseIDs = append(subscription.ExpenseIDs, charge.ID)
+	}
+	if previous := charges[len(charges)-2].Amount; last.Amount.GreaterThan(previous) {
+		subscription.PriceIncrease = &entities.PriceIncrease{
+			PreviousAmount: previous,
+			NewAmount:      last.Amount,
+			Percent:        percentOf(last.Amount.Sub(previous), previous),
+			Date:           last.Date,
+		}
+	}
+	return subscription
+}
+
+// cadenceOf returns the cadence a gap between two charges fits, or nil if it
+// fits none
+func cadenceOf(gap int) *subscriptionCadence {
+	for i := range subscriptionCadences {
+		if gap >= subscriptionCadences[i].minDays && gap <= subscriptionCadences[i].maxDays {
+			return &subscriptionCadences[i]
+		}
+	}
+	return nil
+}
+
+// daysBetween counts the calendar days from one charge to the next
+func daysBetween(from, to time.Time) int {
+	return int(startOfDay(to).Sub(startOfDay(from)).Hours() / 24)
+}
+
+// cadenceFor looks up a detected cadence's gaps and recurrence rule
+func cadenceFor(cadence entities.SubscriptionCadence) subscriptionCadence {
+	for _, c := range subscriptionCadences {
+		if c.cadence == cadence {
+			return c
+		}
+	}
+	return subscriptionCadences[1]
+}
+
+// dateWords are the month and day names bills often carry, such as "Airtel
+// Broadband - Jan 24", which would otherwise split a merchant up by month
+var dateWords = map[string]bool{
+	"jan": true, "january": true, "feb": true, "february": true, "mar": true, "march": true,
+	"apr": true, "april": true, "may": true, "jun": true, "june": true, "jul": true, "july": true,
+	"aug": true, "august": true, "sep": true, "sept": true, "september": true, "oct": true,
+	"october": true, "nov": true, "november": true, "dec": true, "december": true,
+	"monday": true, "tuesday": true, "wednesday": true, "thursday": true, "friday": true,
+	"saturday": true, "sunday": true,
+}
+
+// merchantKey reduces an expense description to the merchant it names: its
+// lower-cased words without punctuation, skipping any containing digits and
+// month or day names, so reference numbers and billing periods that change
+// from charge to charge don't split a merchant up
+func merchantKey(description string) string {
+	tokens := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	words := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if strings.IndexFunc(token, unicode.IsDigit) < 0 && !dateWords[token] {
+			words = append(words, token)
+		}
+	}
+	return strings.Join(words, "-")
+}
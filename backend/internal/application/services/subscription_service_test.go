@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestMerchantKey(t *testing.T) {
+	tests := map[string]string{
+		"NETFLIX.COM 8834120":             "netflix-com",
+		"Netflix.com   #99":               "netflix-com",
+		"SPOTIFY P1A2B3":                  "spotify",
+		"UPI/SPOTIFY INDIA/4411":          "upi-spotify-india",
+		"12345":                           "",
+		"Airtel Broadband - Jan 24":       "airtel-broadband",
+		"ACT Fibernet bill for September": "act-fibernet-bill-for",
+	}
+	for description, want := range tests {
+		if got := merchantKey(description); got != want {
+			t.Errorf("merchantKey(%q) = %q, want %q", description, got, want)
+		}
+	}
+}
+
+func TestDetectSubscription(t *testing.T) {
+	now := date(2024, 7, 1)
+	charges := func(amounts []string, dates ...time.Time) []*entities.Expense {
+		list := make([]*entities.Expense, 0, len(dates))
+		for i, d := range dates {
+			list = append(list, newTestExpense("u1", amounts[i%len(amounts)], d, entities.Self, entities.Entertainment))
+		}
+		return list
+	}
+
+	tests := []struct {
+		name     string
+		charges  []*entities.Expense
+		cadence  entities.SubscriptionCadence
+		active   bool
+		increase bool
+	}{
+		{"monthly", charges([]string{"499"}, date(2024, 3, 5), date(2024, 4, 5), date(2024, 5, 6), date(2024, 6, 5)), entities.MonthlyCadence, true, false},
+		{"price increase", charges([]string{"499", "499", "649"}, date(2024, 4, 5), date(2024, 5, 5), date(2024, 6, 5)), entities.MonthlyCadence, true, true},
+		{"weekly", charges([]string{"99"}, date(2024, 6, 3), date(2024, 6, 10), date(2024, 6, 17), date(2024, 6, 24)), entities.WeeklyCadence, true, false},
+		{"quarterly", charges([]string{"1200"}, date(2023, 10, 1), date(2024, 1, 1), date(2024, 4, 1)), entities.QuarterlyCadence, true, false},
+		{"yearly needs two", charges([]string{"1499"}, date(2022, 9, 1), date(2023, 9, 1)), entities.YearlyCadence, true, false},
+		{"stopped", charges([]string{"299"}, date(2023, 11, 1), date(2023, 12, 1), date(2024, 1, 1)), entities.MonthlyCadence, false, false},
+		{"skipped month", charges([]string{"499"}, date(2024, 1, 5), date(2024, 2, 5), date(2024, 4, 5), date(2024, 5, 5), date(2024, 6, 5)), entities.MonthlyCadence, true, false},
+		{"stray charge", charges([]string{"499", "2999", "499", "499", "499"}, date(2024, 2, 20), date(2024, 3, 1), date(2024, 4, 5), date(2024, 5, 5), date(2024, 6, 5)), entities.MonthlyCadence, true, false},
+		{"skipped latest month", charges([]string{"499"}, date(2024, 2, 5), date(2024, 3, 5), date(2024, 4, 5), date(2024, 6, 5)), "", false, false},
+		{"two monthly charges", charges([]string{"499"}, date(2024, 5, 5), date(2024, 6, 5)), "", false, false},
+		{"irregular", charges([]string{"499"}, date(2024, 3, 5), date(2024, 4, 5), date(2024, 4, 20), date(2024, 6, 5)), "", false, false},
+		{"varying amounts", charges([]string{"400", "1500"}, date(2024, 3, 5), date(2024, 4, 5), date(2024, 5, 5)), "", false, false},
+	}
+	for _, tt := range tests {
+		got := detectSubscription("merchant", tt.charges, now)
+		if tt.cadence == "" {
+			if got != nil {
+				t.Errorf("%s: expected no subscription, got %s", tt.name, got.Cadence)
+			}
+			continue
+		}
+		if got == nil {
+			t.Errorf("%s: expected a %s subscription, got none", tt.name, tt.cadence)
+			continue
+		}
+		if got.Cadence != tt.cadence || got.Active != tt.active || (got.PriceIncrease != nil) != tt.increase {
+			t.Errorf("%s: got %s active=%v increase=%v", tt.name, got.Cadence, got.Active, got.PriceIncrease != nil)
+		}
+	}
+
+	skipped := detectSubscription("merchant", tests[6].charges, now)
+	if skipped.Charges != 3 || !skipped.FirstCharge.Equal(date(2024, 4, 5)) {
+		t.Errorf("skipped month: got %d charges from %s, want 3 from 2024-04-05", skipped.Charges, skipped.FirstCharge.Format("2006-01-02"))
+	}
+
+	increased := detectSubscription("netflix", tests[1].charges, now)
+	if !increased.AnnualizedCost.Equal(decimal.NewFromInt(7788)) || !increased.PriceIncrease.Percent.Equal(decimal.RequireFromString("30.06")) {
+		t.Errorf("got annualized %s and increase %s%%, want 7788 and 30.06%%", increased.AnnualizedCost, increased.PriceIncrease.Percent)
+	}
+	if !increased.NextCharge.Equal(date(2024, 7, 5)) {
+		t.Errorf("next charge = %s, want 2024-07-05", increased.NextCharge.Format("2006-01-02"))
+	}
+}
+
+func TestConvertSubscription(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	yesterday := startOfDay(time.Now()).AddDate(0, 0, -1)
+	for i := 3; i >= 0; i-- {
+		charge := newTestExpense("u1", "199", yesterday.AddDate(0, -i, 0), entities.Self, entities.Entertainment)
+		charge.Description = "SPOTIFY P1A2B3"
+		if _, err := svc.expenses.AddExpense(ctx, charge); err != nil {
+			t.Fatalf("AddExpense failed: %v", err)
+		}
+	}
+	if _, err := svc.expenses.AddExpense(ctx, newTestExpense("u1", "850", yesterday, entities.Self, entities.Food)); err != nil {
+		t.Fatalf("AddExpense failed: %v", err)
+	}
+
+	report, err := svc.subscriptions.DetectSubscriptions(ctx, "u1")
+	if err != nil {
+		t.Fatalf("DetectSubscriptions failed: %v", err)
+	}
+	if len(report.Subscriptions) != 1 || report.Subscriptions[0].Key != "spotify" {
+		t.Fatalf("expected only the Spotify subscription, got %d", len(report.Subscriptions))
+	}
+	if !report.AnnualizedTotal.Equal(decimal.NewFromInt(2388)) {
+		t.Errorf("annualized total = %s, want 2388", report.AnnualizedTotal)
+	}
+
+	recurring, err := svc.subscriptions.ConvertToRecurring(ctx, "u1", "spotify")
+	if err != nil {
+		t.Fatalf("ConvertToRecurring failed: %v", err)
+	}
+	if recurring.Rule.Frequency != entities.Monthly || recurring.StartDate.Before(startOfDay(time.Now())) {
+		t.Errorf("expected a monthly rule starting from today on, got %s from %s", recurring.Rule.Frequency, recurring.StartDate.Format("2006-01-02"))
+	}
+
+	report, err = svc.subscriptions.DetectSubscriptions(ctx, "u1")
+	if err != nil {
+		t.Fatalf("DetectSubscriptions failed: %v", err)
+	}
+	if report.Subscriptions[0].RecurringID != recurring.ID {
+		t.Errorf("expected the subscription to be tracked by %s, got %q", recurring.ID, report.Subscriptions[0].RecurringID)
+	}
+	if _, err := svc.subscriptions.ConvertToRecurring(ctx, "u1", "spotify"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput converting twice, got %v", err)
+	}
+	if _, err := svc.subscriptions.ConvertToRecurring(ctx, "u1", "netflix"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown subscription, got %v", err)
+	}
+}
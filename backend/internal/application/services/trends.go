@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"nestmate-backend/internal/domain/entities"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DefaultTrendMonths is used when a trends request doesn't say how many months to cover
+	DefaultTrendMonths = 12
+
+	// MaxTrendMonths caps the number of months a single trends request covers
+	MaxTrendMonths = 60
+
+	// DefaultTrendWindow is the number of months in a rolling average by default
+	DefaultTrendWindow = 3
+
+	// trendLookback is how many months before the range are loaded, for
+	// year-over-year changes and anomaly history
+	trendLookback = 12
+
+	// minAnomalyHistory is the fewest earlier months a month is judged against
+	minAnomalyHistory = 3
+
+	// averagePrecision is the number of decimal places kept for averages and forecasts
+	averagePrecision = 2
+)
+
+// DefaultAnomalyThreshold is the z-score beyond which a month is flagged by default
+var DefaultAnomalyThreshold = decimal.NewFromInt(2)
+
+// flatAnomalyChange is the fraction of the mean a month must move by to be
+// flagged when the months before it never varied
+var flatAnomalyChange = decimal.NewFromFloat(0.5)
+
+// TrendQuery selects the months and grouping of a trends request
+type TrendQuery struct {
+	End       time.Time             // last month covered, defaults to the current month
+	Months    int                   // number of months covered
+	GroupBy   entities.CategoryKind // main or sub-categories, defaults to main
+	Window    int                   // months in each rolling average
+	Threshold decimal.Decimal       // z-score beyond which a month is anomalous
+}
+
+// GetTrends gets monthly spending series per category in the base currency,
+// with changes, rolling averages, anomalies and a forecast for the current month
+func (s *expenseService) GetTrends(ctx context.Context, userID string, query *TrendQuery) (*entities.SpendingTrends, error) {
+	if query == nil {
+		query = &TrendQuery{}
+	}
+	if err := normalizeTrendQuery(query); err != nil {
+		return nil, err
+	}
+
+	first := query.End.AddDate(0, 1-query.Months, 0)
+	expenses, err := s.GetExpensesByPeriod(ctx, userID, first.AddDate(0, -trendLookback, 0), query.End.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+	base, err := s.currencyService.BaseCurrency(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]*entities.Expense, 0, len(expenses))
+	for _, expense := range expenses {
+		inBase, err := convertExpense(ctx, s.currencyService, userID, expense, base)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, inBase)
+	}
+
+	trends := buildTrends(converted, query, time.Now())
+	trends.Currency = base
+	return trends, nil
+}
+
+// normalizeTrendQuery checks a trends query and fills in its defaults
+func normalizeTrendQuery(query *TrendQuery) error {
+	if query.End.IsZero() {
+		query.End = time.Now()
+	}
+	query.End = startOfMonth(query.End)
+
+	if query.Months < 0 || query.Months > MaxTrendMonths {
+		return fmt.Errorf("%w: months must be between 1 and %d", ErrInvalidInput, MaxTrendMonths)
+	}
+	if query.Months == 0 {
+		query.Months = DefaultTrendMonths
+	}
+	if query.Window < 0 || query.Window > trendLookback {
+		return fmt.Errorf("%w: window must be between 1 and %d months", ErrInvalidInput, trendLookback)
+	}
+	if query.Window == 0 {
+		query.Window = DefaultTrendWindow
+	}
+	if query.GroupBy == "" {
+		query.GroupBy = entities.MainCategoryKind
+	}
+	if !query.GroupBy.IsValid() {
+		return fmt.Errorf("%w: group_by must be %q or %q", ErrInvalidInput, entities.MainCategoryKind, entities.SubCategoryKind)
+	}
+	if query.Threshold.IsNegative() {
+		return fmt.Errorf("%w: threshold must not be negative", ErrInvalidInput)
+	}
+	if query.Threshold.IsZero() {
+		query.Threshold = DefaultAnomalyThreshold
+	}
+	return nil
+}
+
+// trendHistory describes the months loaded for a trends request, from the
+// start of the lookback to the end of the range. Months before the user's
+// first expense don't count as history, so a new user isn't compared against
+// months of zeros.
+type trendHistory struct {
+	start       time.Time // first month loaded, trendLookback months before the range
+	length      int
+	firstActive int // index of the first month with any spending
+	partial     int // index of the current month, or -1 when it is outside the range
+	query       *TrendQuery
+}
+
+// buildTrends builds the trends for a normalized query from expenses already
+// converted to one currency. now decides which month is still in progress.
+func buildTrends(expenses []*entities.Expense, query *TrendQuery, now time.Time) *entities.SpendingTrends {
+	h := &trendHistory{
+		start:   query.End.AddDate(0, 1-query.Months-trendLookback, 0),
+		length:  query.Months + trendLookback,
+		partial: -1,
+		query:   query,
+	}
+	h.firstActive = h.length
+	if current := h.index(now); current >= trendLookback && current < h.length {
+		h.partial = current
+	}
+
+	totals := make([]decimal.Decimal, h.length)
+	categories := make(map[string][]decimal.Decimal)
+	for _, expense := range expenses {
+		i := h.index(expense.Date)
+		if i < 0 || i >= h.length {
+			continue
+		}
+		amounts := map[string]decimal.Decimal{string(expense.MainCategory): expense.Amount}
+		if query.GroupBy == entities.SubCategoryKind {
+			amounts = make(map[string]decimal.Decimal)
+			for category, amount := range subCategoryAmounts(expense) {
+				amounts[string(category)] = amount
+			}
+		}
+		for category, amount := range amounts {
+			if categories[category] == nil {
+				categories[category] = make([]decimal.Decimal, h.length)
+			}
+			categories[category][i] = categories[category][i].Add(amount)
+		}
+
+		totals[i] = totals[i].Add(expense.Amount)
+		if i < h.firstActive {
+			h.firstActive = i
+		}
+	}
+
+	trends := &entities.SpendingTrends{
+		GroupBy:    query.GroupBy,
+		Months:     make([]time.Time, 0, query.Months),
+		Categories: []*entities.TrendSeries{},
+		Anomalies:  []*entities.TrendAnomaly{},
+	}
+	for i := trendLookback; i < h.length; i++ {
+		trends.Months = append(trends.Months, h.month(i))
+	}
+
+	var anomalies []*entities.TrendAnomaly
+	trends.Total, anomalies = h.series("", totals)
+	trends.Anomalies = append(trends.Anomalies, anomalies...)
+	for category, amounts := range categories {
+		series, anomalies := h.series(category, amounts)
+		if series.Total.IsZero() {
+			continue
+		}
+		trends.Categories = append(trends.Categories, series)
+		trends.Anomalies = append(trends.Anomalies, anomalies...)
+	}
+	sort.Slice(trends.Categories, func(i, j int) bool {
+		a, b := trends.Categories[i], trends.Categories[j]
+		if !a.Total.Equal(b.Total) {
+			return a.Total.GreaterThan(b.Total)
+		}
+		return a.Category < b.Category
+	})
+	sort.SliceStable(trends.Anomalies, func(i, j int) bool {
+		a, b := trends.Anomalies[i], trends.Anomalies[j]
+		if !a.Month.Equal(b.Month) {
+			return a.Month.Before(b.Month)
+		}
+		return a.Category < b.Category
+	})
+
+	if h.partial >= 0 {
+		trends.Forecast = h.forecast(totals, now)
+	}
+	return trends
+}
+
+// index returns the position of t's month in the history
+func (h *trendHistory) index(t time.Time) int {
+	return (t.Year()-h.start.Year())*12 + int(t.Month()-h.start.Month())
+}
+
+// month returns the month at position i in the history
+func (h *trendHistory) month(i int) time.Time {
+	return h.start.AddDate(0, i, 0)
+}
+
+// series builds the points for one series over the requested range, along
+// with the anomalies found in it
+func (h *trendHistory) series(category string, amounts []decimal.Decimal) (*entities.TrendSeries, []*entities.TrendAnomaly) {
+	series := &entities.TrendSeries{
+		Category: category,
+		Points:   make([]*entities.TrendPoint, 0, h.length-trendLookback),
+	}
+	var anomalies []*entities.TrendAnomaly
+
+	active := 0
+	for i := trendLookback; i < h.length; i++ {
+		point := &entities.TrendPoint{
+			Month:          h.month(i),
+			Amount:         amounts[i],
+			MonthOverMonth: h.change(amounts, i, 1),
+			YearOverYear:   h.change(amounts, i, 12),
+			RollingAverage: h.average(amounts, i-h.query.Window+1, i+1),
+			Partial:        i == h.partial,
+		}
+		if anomaly := h.anomaly(amounts, i); anomaly != nil {
+			anomaly.Category = category
+			point.Anomaly = true
+			anomalies = append(anomalies, anomaly)
+		}
+		series.Points = append(series.Points, point)
+
+		series.Total = series.Total.Add(amounts[i])
+		if i >= h.firstActive {
+			active++
+		}
+	}
+	if active > 0 {
+		series.Average = series.Total.Div(decimal.NewFromInt(int64(active))).Round(averagePrecision)
+	}
+	return series, anomalies
+}
+
+// change returns the percentage change from lag months before month i, or
+// nil if that month has no history or nothing was spent in it
+func (h *trendHistory) change(amounts []decimal.Decimal, i, lag int) *decimal.Decimal {
+	j := i - lag
+	if j < h.firstActive || amounts[j].IsZero() {
+		return nil
+	}
+	change := percentOf(amounts[i].Sub(amounts[j]), amounts[j])
+	return &change
+}
+
+// average returns the mean of amounts[from:to], ignoring months before the first active one
+func (h *trendHistory) average(amounts []decimal.Decimal, from, to int) decimal.Decimal {
+	if from < h.firstActive {
+		from = h.firstActive
+	}
+	if from >= to {
+		return decimal.Zero
+	}
+	sum := decimal.Zero
+	for _, amount := range amounts[from:to] {
+		sum = sum.Add(amount)
+	}
+	return sum.Div(decimal.NewFromInt(int64(to - from))).Round(averagePrecision)
+}
+
+// anomaly runs a z-score test of month i against up to a year of earlier
+// months. A month still in progress is only flagged for spending too much,
+// since it is bound to look low until it ends.
+func (h *trendHistory) anomaly(amounts []decimal.Decimal, i int) *entities.TrendAnomaly {
+	from := i - trendLookback
+	if from < h.firstActive {
+		from = h.firstActive
+	}
+	if i-from < minAnomalyHistory {
+		return nil
+	}
+
+	mean, stdDev := meanAndStdDev(amounts[from:i])
+	if amounts[i].Equal(mean) {
+		return nil
+	}
+	anomaly := &entities.TrendAnomaly{
+		Month:     h.month(i),
+		Amount:    amounts[i],
+		Mean:      mean.Round(averagePrecision),
+		StdDev:    stdDev.Round(averagePrecision),
+		Direction: entities.AnomalyHigh,
+	}
+	if amounts[i].LessThan(mean) {
+		anomaly.Direction = entities.AnomalyLow
+	}
+	if i == h.partial && anomaly.Direction == entities.AnomalyLow {
+		return nil
+	}
+
+	// A history that never varied has no spread to measure against, so only
+	// a large change relative to it counts. Spending after months of none
+	// isn't flagged, since a first expense in a category isn't unusual.
+	if stdDev.IsZero() {
+		if mean.IsZero() || amounts[i].Sub(mean).Abs().LessThan(mean.Mul(flatAnomalyChange)) {
+			return nil
+		}
+		return anomaly
+	}
+	z := amounts[i].Sub(mean).Div(stdDev).Round(averagePrecision)
+	if z.Abs().LessThan(h.query.Threshold) {
+		return nil
+	}
+	anomaly.ZScore = &z
+	return anomaly
+}
+
+// forecast estimates the current month's total: what has been spent so far
+// plus the usual spending of the previous months for the days still to come
+func (h *trendHistory) forecast(totals []decimal.Decimal, now time.Time) *entities.SpendForecast {
+	month := h.month(h.partial)
+	forecast := &entities.SpendForecast{
+		Month:       month,
+		SpentSoFar:  totals[h.partial],
+		DaysElapsed: now.Day(),
+		DaysInMonth: month.AddDate(0, 1, -1).Day(),
+		Baseline:    h.average(totals, h.partial-h.query.Window, h.partial),
+	}
+
+	elapsed := decimal.NewFromInt(int64(forecast.DaysElapsed))
+	days := decimal.NewFromInt(int64(forecast.DaysInMonth))
+	forecast.RunRate = forecast.SpentSoFar.Div(elapsed).Mul(days).Round(averagePrecision)
+	if h.partial <= h.firstActive {
+		// No earlier month to go on, so the run rate is the best guess
+		forecast.Projected = forecast.RunRate
+		return forecast
+	}
+	remaining := days.Sub(elapsed)
+	forecast.Projected = forecast.SpentSoFar.Add(forecast.Baseline.Mul(remaining).Div(days)).Round(averagePrecision)
+	return forecast
+}
+
+// meanAndStdDev returns the mean and sample standard deviation of amounts.
+// Only the square root is taken in floating point, so identical amounts
+// always have a standard deviation of exactly zero.
+func meanAndStdDev(amounts []decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	n := decimal.NewFromInt(int64(len(amounts)))
+	mean := decimal.Sum(decimal.Zero, amounts...).Div(n)
+	if len(amounts) < 2 {
+		return mean, decimal.Zero
+	}
+
+	squares := decimal.Zero
+	for _, amount := range amounts {
+		deviation := amount.Sub(mean)
+		squares = squares.Add(deviation.Mul(deviation))
+	}
+	if squares.IsZero() {
+		return mean, decimal.Zero
+	}
+	variance := squares.Div(n.Sub(decimal.NewFromInt(1)))
+	return mean, decimal.NewFromFloat(math.Sqrt(variance.InexactFloat64()))
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+func TestBuildTrends(t *testing.T) {
+	var expenses []*entities.Expense
+	for m := month(2023, 6); m.Before(month(2024, 6)); m = m.AddDate(0, 1, 0) {
+		expenses = append(expenses, newTestExpense("u1", "100", m, entities.Self, entities.Food))
+	}
+	expenses = append(expenses,
+		newTestExpense("u1", "1000", date(2024, 3, 5), entities.ChennaiHouse, entities.Travel),
+		newTestExpense("u1", "400", date(2024, 6, 2), entities.Self, entities.Food),
+	)
+
+	query := &TrendQuery{End: month(2024, 6), Months: 6}
+	if err := normalizeTrendQuery(query); err != nil {
+		t.Fatalf("normalizeTrendQuery() error = %v", err)
+	}
+	trends := buildTrends(expenses, query, date(2024, 6, 15))
+
+	if len(trends.Months) != 6 || !trends.Months[0].Equal(month(2024, 1)) {
+		t.Fatalf("months = %v, want January to June 2024", trends.Months)
+	}
+	if len(trends.Categories) != 2 || trends.Categories[0].Category != string(entities.ChennaiHouse) {
+		t.Fatalf("categories = %d, want Chennai House first by total", len(trends.Categories))
+	}
+
+	self := trends.Categories[1]
+	june := self.Points[5]
+	if !june.Partial || june.MonthOverMonth == nil || june.MonthOverMonth.String() != "300" {
+		t.Errorf("June = %+v, want a partial month up 300%% on May", june)
+	}
+	if june.YearOverYear == nil || june.YearOverYear.String() != "300" {
+		t.Errorf("June year over year = %v, want 300", june.YearOverYear)
+	}
+	if got := trends.Total.Points[3].RollingAverage.String(); got != "433.33" {
+		t.Errorf("April rolling average = %s, want 433.33", got)
+	}
+	if trends.Categories[0].Points[0].MonthOverMonth != nil {
+		t.Error("month over month should be nil after a month with no spending")
+	}
+
+	wantAnomalies := []struct {
+		month    time.Time
+		category string
+	}{
+		// Chennai House's first spending after months of none isn't flagged
+		{month(2024, 3), ""},
+		{month(2024, 6), string(entities.Self)},
+	}
+	if len(trends.Anomalies) != len(wantAnomalies) {
+		t.Fatalf("got %d anomalies, want %d: %+v", len(trends.Anomalies), len(wantAnomalies), trends.Anomalies)
+	}
+	for i, want := range wantAnomalies {
+		got := trends.Anomalies[i]
+		if !got.Month.Equal(want.month) || got.Category != want.category || got.Direction != entities.AnomalyHigh {
+			t.Errorf("anomaly %d = %s %q %s, want %s %q high", i, got.Month.Format("2006-01"), got.Category, got.Direction, want.month.Format("2006-01"), want.category)
+		}
+	}
+
+	forecast := trends.Forecast
+	if forecast == nil {
+		t.Fatal("expected a forecast for the current month")
+	}
+	if forecast.Baseline.String() != "433.33" || forecast.RunRate.String() != "800" || forecast.Projected.String() != "616.67" {
+		t.Errorf("forecast = baseline %s, run rate %s, projected %s, want 433.33, 800 and 616.67", forecast.Baseline, forecast.RunRate, forecast.Projected)
+	}
+}
+
+func TestBuildTrendsFlatHistory(t *testing.T) {
+	var expenses []*entities.Expense
+	for m := month(2024, 1); m.Before(month(2024, 5)); m = m.AddDate(0, 1, 0) {
+		expenses = append(expenses,
+			newTestExpense("u1", "100", m, entities.Self, entities.Food),
+			newTestExpense("u1", "100", m, entities.Self, entities.Travel),
+		)
+	}
+	expenses = append(expenses,
+		newTestExpense("u1", "101", month(2024, 5), entities.Self, entities.Food),
+		newTestExpense("u1", "200", month(2024, 5), entities.Self, entities.Travel),
+		newTestExpense("u1", "50", month(2024, 5), entities.Self, entities.Misc),
+	)
+
+	query := &TrendQuery{End: month(2024, 5), Months: 5, GroupBy: entities.SubCategoryKind}
+	if err := normalizeTrendQuery(query); err != nil {
+		t.Fatalf("normalizeTrendQuery() error = %v", err)
+	}
+	trends := buildTrends(expenses, query, date(2024, 7, 1))
+
+	var flagged []string
+	for _, anomaly := range trends.Anomalies {
+		flagged = append(flagged, anomaly.Category)
+	}
+	if len(flagged) != 2 || flagged[0] != "" || flagged[1] != string(entities.Travel) {
+		t.Errorf("anomalies = %q, want only the total and Travel doubling", flagged)
+	}
+}
+
+func TestBuildTrendsIgnoresMonthsBeforeFirstExpense(t *testing.T) {
+	expenses := []*entities.Expense{
+		newTestExpense("u1", "100", month(2024, 4), entities.Self, entities.Food),
+		newTestExpense("u1", "120", month(2024, 5), entities.Self, entities.Food),
+	}
+	query := &TrendQuery{End: month(2024, 5), Months: 12}
+	if err := normalizeTrendQuery(query); err != nil {
+		t.Fatalf("normalizeTrendQuery() error = %v", err)
+	}
+	trends := buildTrends(expenses, query, date(2024, 7, 1))
+
+	if len(trends.Anomalies) != 0 {
+		t.Errorf("got %d anomalies, want none without enough history", len(trends.Anomalies))
+	}
+	if trends.Forecast != nil {
+		t.Error("a range ending before the current month should have no forecast")
+	}
+	if got := trends.Total.Average.String(); got != "110" {
+		t.Errorf("average = %s, want 110 over the two months with spending", got)
+	}
+	if got := trends.Total.Points[11].RollingAverage.String(); got != "110" {
+		t.Errorf("May rolling average = %s, want 110", got)
+	}
+}
+
+func TestGetTrendsValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestServices(t)
+
+	cases := map[string]*TrendQuery{
+		"too many months":    {Months: MaxTrendMonths + 1},
+		"negative window":    {Window: -1},
+		"unknown grouping":   {GroupBy: "tags"},
+		"negative threshold": {Threshold: decimal.NewFromInt(-1)},
+	}
+	for name, query := range cases {
+		if _, err := svc.expenses.GetTrends(ctx, "u1", query); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: GetTrends() error = %v, want ErrInvalidInput", name, err)
+		}
+	}
+
+	if _, err := svc.expenses.AddExpense(ctx, newTestExpense("u1", "250", time.Now(), entities.Self, entities.Food)); err != nil {
+		t.Fatalf("AddExpense() error = %v", err)
+	}
+	trends, err := svc.expenses.GetTrends(ctx, "u1", &TrendQuery{GroupBy: entities.SubCategoryKind})
+	if err != nil {
+		t.Fatalf("GetTrends() error = %v", err)
+	}
+	if trends.Currency == "" || len(trends.Months) != DefaultTrendMonths || trends.Forecast == nil {
+		t.Errorf("trends = %+v, want %d months in the base currency with a forecast", trends, DefaultTrendMonths)
+	}
+	if len(trends.Categories) != 1 || trends.Categories[0].Category != string(entities.Food) {
+		t.Errorf("categories = %+v, want Food only", trends.Categories)
+	}
+}
@@ -0,0 +1,139 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AccountType is the kind of place money is held or paid from
+type AccountType string
+
+const (
+	BankAccount AccountType = "bank"
+	CreditCard  AccountType = "credit_card"
+	UPIAccount  AccountType = "upi"
+	CashWallet  AccountType = "cash"
+)
+
+// IsValid reports whether the account type is known
+func (t AccountType) IsValid() bool {
+	return t == BankAccount || t == CreditCard || t == UPIAccount || t == CashWallet
+}
+
+// Account is a bank account, credit card, UPI handle or cash wallet that
+// expenses are paid from and incomes paid into. Its balance starts at
+// OpeningBalance on OpeningDate; a credit card's balance goes negative as
+// it is spent on.
+type Account struct {
+	ID             string          `json:"id"`
+	UserID         string          `json:"user_id"`
+	Name           string          `json:"name"`
+	Type           AccountType     `json:"type"`
+	Currency       string          `json:"currency"`
+	Last4          string          `json:"last4,omitempty"` // last digits of the account or card number, as printed on statements
+	OpeningBalance decimal.Decimal `json:"opening_balance"`
+	OpeningDate    time.Time       `json:"opening_date"`
+	Archived       bool            `json:"archived"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// AccountSummary is an account with its current balance
+type AccountSummary struct {
+	Account *Account        `json:"account"`
+	Balance decimal.Decimal `json:"balance"`
+}
+
+// Transfer moves money between two of the user's accounts, such as paying
+// a credit card bill from a bank account. Transfers change balances but are
+// never counted as spending.
+type Transfer struct {
+	ID            string          `json:"id"`
+	UserID        string          `json:"user_id"`
+	FromAccountID string          `json:"from_account_id"`
+	ToAccountID   string          `json:"to_account_id"`
+	Amount        decimal.Decimal `json:"amount"`    // in the source account's currency
+	ToAmount      decimal.Decimal `json:"to_amount"` // in the destination account's currency
+	Date          time.Time       `json:"date"`
+	Note          string          `json:"note"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// AccountEntryKind says what moved money in or out of an account
+type AccountEntryKind string
+
+const (
+	EntryExpense     AccountEntryKind = "expense"
+	EntryIncome      AccountEntryKind = "income"
+	EntryTransferIn  AccountEntryKind = "transfer_in"
+	EntryTransferOut AccountEntryKind = "transfer_out"
+)
+
+// AccountEntry is one line of an account's ledger. Amount is signed, in the
+// account's currency: negative for money going out.
+type AccountEntry struct {
+	Kind        AccountEntryKind `json:"kind"`
+	ID          string           `json:"id"` // expense, income or transfer ID
+	Date        time.Time        `json:"date"`
+	Description string           `json:"description"`
+	Amount      decimal.Decimal  `json:"amount"`
+	Balance     decimal.Decimal  `json:"balance"` // running balance after this entry
+}
+
+// AccountLedger lists the entries of an account in [From, To), oldest first
+type AccountLedger struct {
+	Account        *Account        `json:"account"`
+	From           time.Time       `json:"from"`
+	To             *time.Time      `json:"to,omitempty"`    // nil runs to the latest entry
+	OpeningBalance decimal.Decimal `json:"opening_balance"` // balance at From
+	Entries        []*AccountEntry `json:"entries"`
+	ClosingBalance decimal.Decimal `json:"closing_balance"`
+}
+
+// StatementLineType says whether a statement line took money out or put it in
+type StatementLineType string
+
+const (
+	StatementDebit  StatementLineType = "debit"
+	StatementCredit StatementLineType = "credit"
+)
+
+// StatementLine is one transaction on a bank or card statement
+type StatementLine struct {
+	Date        time.Time         `json:"date"`
+	Description string            `json:"description"`
+	Amount      decimal.Decimal   `json:"amount"` // always positive, Type gives the direction
+	Type        StatementLineType `json:"type"`
+}
+
+// Statement is an imported account statement to reconcile against what was
+// recorded. The period defaults to the span of its lines.
+type Statement struct {
+	AccountLast4   string           `json:"account_last4,omitempty"`
+	StartDate      time.Time        `json:"start_date"`
+	EndDate        time.Time        `json:"end_date"` // inclusive
+	ClosingBalance *decimal.Decimal `json:"closing_balance,omitempty"`
+	Lines          []*StatementLine `json:"lines"`
+}
+
+// ReconciledLine pairs a statement line with the recorded entry it matched
+type ReconciledLine struct {
+	Line  *StatementLine `json:"line"`
+	Entry *AccountEntry  `json:"entry"`
+}
+
+// Reconciliation reports how a statement compares with an account's records.
+// Unrecorded lines are on the statement but were never entered; unmatched
+// entries were recorded in the period but aren't on the statement.
+type Reconciliation struct {
+	AccountID        string            `json:"account_id"`
+	StartDate        time.Time         `json:"start_date"`
+	EndDate          time.Time         `json:"end_date"`
+	Matched          []*ReconciledLine `json:"matched"`
+	Unrecorded       []*StatementLine  `json:"unrecorded"`
+	Unmatched        []*AccountEntry   `json:"unmatched"`
+	RecordedBalance  decimal.Decimal   `json:"recorded_balance"` // at the end of the period
+	StatementBalance *decimal.Decimal  `json:"statement_balance,omitempty"`
+	Difference       *decimal.Decimal  `json:"difference,omitempty"` // statement minus recorded balance
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BudgetState summarizes how much of a budget has been used
+type BudgetState string
+
+const (
+	BudgetStateOnTrack  BudgetState = "on_track"
+	BudgetStateWarning  BudgetState = "warning"  // at least BudgetWarningPercent used
+	BudgetStateExceeded BudgetState = "exceeded" // at least BudgetExceededPercent used
+)
+
+// Percentages of a budget at which warnings are raised
+var (
+	BudgetWarningPercent  = decimal.NewFromInt(80)
+	BudgetExceededPercent = decimal.NewFromInt(100)
+)
+
+// Budget caps spending in a main or sub category for a month.
+// A budget covers its category and every category nested under it.
+type Budget struct {
+	ID        string          `json:"id"`
+	UserID    string          `json:"user_id"`
+	Kind      CategoryKind    `json:"kind"`
+	Category  string          `json:"category"`
+	Month     time.Time       `json:"month"`
+	Amount    decimal.Decimal `json:"amount"`
+	Currency  string          `json:"currency"`
+	Rollover  bool            `json:"rollover"` // add the previous month's unspent amount
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// BudgetStatus compares a budget with the actual spending for its month.
+// Amounts are in Currency, the user's base currency.
+type BudgetStatus struct {
+	Budget      *Budget         `json:"budget"`
+	Currency    string          `json:"currency"`
+	Budgeted    decimal.Decimal `json:"budgeted"`
+	RolledOver  decimal.Decimal `json:"rolled_over"`
+	Available   decimal.Decimal `json:"available"` // budgeted plus rolled over
+	Spent       decimal.Decimal `json:"spent"`
+	Remaining   decimal.Decimal `json:"remaining"` // negative once over budget
+	PercentUsed decimal.Decimal `json:"percent_used"`
+	State       BudgetState     `json:"state"`
+}
+
+// BudgetReport lists budget-vs-actual for every budget in a month
+type BudgetReport struct {
+	Month    time.Time       `json:"month"`
+	Currency string          `json:"currency"`
+	Budgets  []*BudgetStatus `json:"budgets"`
+}
+
+// BudgetWarning is raised when an expense pushes a budget past a threshold
+type BudgetWarning struct {
+	BudgetID    string          `json:"budget_id"`
+	Kind        CategoryKind    `json:"kind"`
+	Category    string          `json:"category"`
+	Threshold   decimal.Decimal `json:"threshold"`
+	PercentUsed decimal.Decimal `json:"percent_used"`
+	Spent       decimal.Decimal `json:"spent"`
+	Available   decimal.Decimal `json:"available"`
+	Currency    string          `json:"currency"`
+	Message     string          `json:"message"`
+}
@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RuleMatchType is how a categorization rule's pattern is compared with a description
+type RuleMatchType string
+
+const (
+	KeywordMatch RuleMatchType = "keyword" // case-insensitive substring
+	RegexMatch   RuleMatchType = "regex"   // case-insensitive regular expression
+)
+
+// TransactionType is the direction money moved in a bank transaction
+type TransactionType string
+
+const (
+	DebitTransaction  TransactionType = "debit"
+	CreditTransaction TransactionType = "credit"
+)
+
+// CategorizationRule files transactions matching all of its conditions under
+// a main and sub-category. Empty conditions match anything, but a rule needs
+// at least one. When several rules match, the highest priority wins, then
+// the oldest rule.
+type CategorizationRule struct {
+	ID              string           `json:"id"`
+	UserID          string           `json:"user_id"`
+	Name            string           `json:"name"`
+	MatchType       RuleMatchType    `json:"match_type,omitempty"`
+	Pattern         string           `json:"pattern,omitempty"`          // matched against the description
+	MinAmount       *decimal.Decimal `json:"min_amount,omitempty"`       // inclusive
+	MaxAmount       *decimal.Decimal `json:"max_amount,omitempty"`       // inclusive
+	TransactionType TransactionType  `json:"transaction_type,omitempty"` // empty matches both
+	AccountID       string           `json:"account_id,omitempty"`
+	MainCategory    MainCategory     `json:"main_category"`
+	SubCategory     SubCategory      `json:"sub_category"`
+	Priority        int              `json:"priority"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+// RulePreview shows what a rule would do to the expenses already recorded
+type RulePreview struct {
+	Matched       int        `json:"matched"`       // expenses the rule matches
+	Unchanged     int        `json:"unchanged"`     // matches already filed under the rule's categories
+	Recategorized int        `json:"recategorized"` // matches the rule would file differently
+	Expenses      []*Expense `json:"expenses"`      // the most recent matches
+}
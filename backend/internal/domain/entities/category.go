@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"time"
+)
+
+// CategoryKind distinguishes the two independent category trees an expense is filed under
+type CategoryKind string
+
+const (
+	MainCategoryKind CategoryKind = "main"
+	SubCategoryKind  CategoryKind = "sub"
+)
+
+// CategoryRole marks a default category the app relies on, so it can still
+// be found after the user renames it
+type CategoryRole string
+
+const (
+	// SavingsRole is the main category whose expenses can fund savings goals
+	SavingsRole CategoryRole = "savings"
+)
+
+// IsValid reports whether k is a known category kind
+func (k CategoryKind) IsValid() bool {
+	return k == MainCategoryKind || k == SubCategoryKind
+}
+
+// Category represents a user-defined expense category.
+// Categories of the same kind form a tree through ParentID.
+type Category struct {
+	ID        string       `json:"id"`
+	UserID    string       `json:"user_id"`
+	Name      string       `json:"name"`
+	Kind      CategoryKind `json:"kind"`
+	ParentID  string       `json:"parent_id,omitempty"`
+	Color     string       `json:"color,omitempty"`
+	Icon      string       `json:"icon,omitempty"`
+	Archived  bool         `json:"archived"`
+	Role      CategoryRole `json:"role,omitempty"`
+	Children  []*Category  `json:"children,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// DefaultCategories returns the taxonomy every user starts with
+func DefaultCategories() []*Category {
+	return []*Category{
+		{Name: string(ChennaiHouse), Kind: MainCategoryKind, Color: "#E57373", Icon: "home"},
+		{Name: string(BangaloreHouse), Kind: MainCategoryKind, Color: "#64B5F6", Icon: "home"},
+		{Name: string(Self), Kind: MainCategoryKind, Color: "#81C784", Icon: "person"},
+		{Name: string(Savings), Kind: MainCategoryKind, Color: "#FFD54F", Icon: "savings", Role: SavingsRole},
+		{Name: string(Food), Kind: SubCategoryKind, Color: "#FF8A65", Icon: "restaurant"},
+		{Name: string(Entertainment), Kind: SubCategoryKind, Color: "#BA68C8", Icon: "movie"},
+		{Name: string(Education), Kind: SubCategoryKind, Color: "#4DB6AC", Icon: "school"},
+		{Name: string(Travel), Kind: SubCategoryKind, Color: "#7986CB", Icon: "flight"},
+		{Name: string(Misc), Kind: SubCategoryKind, Color: "#90A4AE", Icon: "category"},
+	}
+}
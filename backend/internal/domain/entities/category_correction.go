@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CategoryCorrection records the categories a user settled on for a
+// statement transaction during review. Corrections train the categorizer
+// alongside the user's recorded expenses.
+type CategoryCorrection struct {
+	ID              string          `json:"id"`
+	UserID          string          `json:"user_id"`
+	Description     string          `json:"description"`
+	Amount          decimal.Decimal `json:"amount"`
+	TransactionType TransactionType `json:"transaction_type"`
+	MainCategory    MainCategory    `json:"main_category"`
+	SubCategory     SubCategory     `json:"sub_category"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCurrency is the base currency of users who haven't chosen one
+const DefaultCurrency = "INR"
+
+// ExchangeRate records the value of one unit of FromCurrency in ToCurrency on a given date
+type ExchangeRate struct {
+	ID           string          `json:"id"`
+	UserID       string          `json:"user_id"`
+	FromCurrency string          `json:"from_currency"`
+	ToCurrency   string          `json:"to_currency"`
+	Date         time.Time       `json:"date"`
+	Rate         decimal.Decimal `json:"rate"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// UserSettings holds a user's preferences
+type UserSettings struct {
+	UserID          string     `json:"user_id"`
+	BaseCurrency    string     `json:"base_currency"`
+	FiscalYearStart time.Month `json:"fiscal_year_start"` // first month of the user's financial year
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
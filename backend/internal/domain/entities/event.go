@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Event groups the expenses of a trip, wedding, house move or similar across
+// categories. Expenses dated within an auto-attaching event join it when
+// they're added; any expense can also be attached by hand.
+type Event struct {
+	ID         string           `json:"id"`
+	UserID     string           `json:"user_id"`
+	Name       string           `json:"name"`
+	StartDate  time.Time        `json:"start_date"`
+	EndDate    time.Time        `json:"end_date"` // inclusive
+	AutoAttach bool             `json:"auto_attach"`
+	Budget     *decimal.Decimal `json:"budget,omitempty"`
+	Currency   string           `json:"currency"` // of the budget and the event's totals
+	Note       string           `json:"note"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// EventSummary totals an event's expenses, converted to the event's currency,
+// and compares them with its budget when it has one
+type EventSummary struct {
+	Event                *Event                                           `json:"event"`
+	Currency             string                                           `json:"currency"`
+	TotalExpenses        decimal.Decimal                                  `json:"total_expenses"`
+	ExpenseCount         int                                              `json:"expense_count"`
+	CategoryBreakdown    map[MainCategory]decimal.Decimal                 `json:"category_breakdown"`
+	SubCategoryBreakdown map[SubCategory]decimal.Decimal                  `json:"sub_category_breakdown"`
+	CategoryCrossTab     map[MainCategory]map[SubCategory]decimal.Decimal `json:"category_cross_tab"`
+	Remaining            *decimal.Decimal                                 `json:"remaining,omitempty"` // negative once over budget
+	PercentUsed          *decimal.Decimal                                 `json:"percent_used,omitempty"`
+	BudgetState          BudgetState                                      `json:"budget_state,omitempty"`
+	Expenses             []*Expense                                       `json:"expenses,omitempty"`
+}
@@ -6,57 +6,132 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// MainCategory represents the main expense category
+// MainCategory is the name of one of the user's main categories
 type MainCategory string
 
+// Default main categories seeded for every user
 const (
-	ChennaiHouse  MainCategory = "Chennai House"
+	ChennaiHouse   MainCategory = "Chennai House"
 	BangaloreHouse MainCategory = "Bangalore House"
-	Self          MainCategory = "Self"
-	Savings       MainCategory = "Savings"
+	Self           MainCategory = "Self"
+	Savings        MainCategory = "Savings"
 )
 
-// SubCategory represents the expense sub-category
+// SubCategory is the name of one of the user's sub-categories
 type SubCategory string
 
+// Default sub-categories seeded for every user
 const (
-	Food         SubCategory = "Food"
+	Food          SubCategory = "Food"
 	Entertainment SubCategory = "Entertainment"
-	Education    SubCategory = "Education"
-	Travel       SubCategory = "Travel"
-	Misc         SubCategory = "Misc"
+	Education     SubCategory = "Education"
+	Travel        SubCategory = "Travel"
+	Misc          SubCategory = "Misc"
 )
 
 // Expense represents an expense entity
 type Expense struct {
-	ID          string
-	UserID      string
-	Amount      decimal.Decimal
-	Description string
-	Date        time.Time
-	MainCategory MainCategory
-	SubCategory  SubCategory
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID             string          `json:"id"`
+	UserID         string          `json:"user_id"`
+	Amount         decimal.Decimal `json:"amount"`
+	Currency       string          `json:"currency"`
+	Description    string          `json:"description"`
+	Date           time.Time       `json:"date"`
+	MainCategory   MainCategory    `json:"main_category"`
+	SubCategory    SubCategory     `json:"sub_category"`
+	AccountID      string          `json:"account_id,omitempty"`      // account the expense was paid from
+	TaxSection     TaxSection      `json:"tax_section,omitempty"`     // deduction section the expense is claimed under
+	Items          []*ExpenseItem  `json:"items,omitempty"`           // line items, adding up to Amount
+	AmortizeMonths int             `json:"amortize_months,omitempty"` // months the expense is spread over in amortized figures
+	Reimbursable   bool            `json:"reimbursable,omitempty"`    // expected to be paid back
+	ExpectedPayer  string          `json:"expected_payer,omitempty"`  // who is expected to pay it back
+	EventID        string          `json:"event_id,omitempty"`        // trip or other event the expense belongs to
+	ImportBatchID  string          `json:"import_batch_id,omitempty"` // CSV import the expense came from
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
 }
 
-// Income represents an income entity
+// ExpenseItem is one line of an itemized expense, filed under its own sub-category.
+// Items share the expense's currency and main category.
+type ExpenseItem struct {
+	ID          string          `json:"id"`
+	Description string          `json:"description"`
+	Amount      decimal.Decimal `json:"amount"`
+	SubCategory SubCategory     `json:"sub_category"`
+}
+
+// Income represents an income entity.
+// A user may record several incomes per month, one per source.
 type Income struct {
-	ID        string
-	UserID    string
-	Amount    decimal.Decimal
-	Month     time.Time
-	Source    string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            string          `json:"id"`
+	UserID        string          `json:"user_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+	Month         time.Time       `json:"month"`
+	Source        string          `json:"source"`
+	IsRecurring   bool            `json:"is_recurring"`
+	AccountID     string          `json:"account_id,omitempty"`      // account the income was paid into
+	CarriedFromID string          `json:"carried_from_id,omitempty"` // previous month's income this was carried forward from
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// IncomeSummary represents the total income for a month across every source,
+// converted to the user's base currency
+type IncomeSummary struct {
+	Month    time.Time                  `json:"month"`
+	Currency string                     `json:"currency"`
+	Total    decimal.Decimal            `json:"total"`
+	BySource map[string]decimal.Decimal `json:"by_source"`
 }
 
-// MonthlyBreakdown represents a monthly expense breakdown
+// Basis selects how expenses are counted in breakdowns and savings
+type Basis string
+
+const (
+	// CashBasis counts every expense in full in the month it was paid
+	CashBasis Basis = "cash"
+
+	// AmortizedBasis spreads amortized expenses evenly over their months
+	AmortizedBasis Basis = "amortized"
+)
+
+// MonthlyBreakdown represents a monthly expense breakdown.
+// Every amount is in Currency, the user's base currency; OriginalAmounts keeps
+// the expense totals in the currencies they were recorded in.
 type MonthlyBreakdown struct {
-	Month          time.Time
-	TotalIncome    decimal.Decimal
-	TotalExpenses  decimal.Decimal
-	Savings        decimal.Decimal
-	CategoryBreakdown map[MainCategory]decimal.Decimal
-	SubCategoryBreakdown map[SubCategory]decimal.Decimal
-}
\ No newline at end of file
+	Month                time.Time                                        `json:"month"`
+	Currency             string                                           `json:"currency"`
+	Basis                Basis                                            `json:"basis"`
+	TotalIncome          decimal.Decimal                                  `json:"total_income"`
+	TotalExpenses        decimal.Decimal                                  `json:"total_expenses"`
+	Savings              decimal.Decimal                                  `json:"savings"`
+	Reimbursed           decimal.Decimal                                  `json:"reimbursed"`   // received back against the month's expenses
+	NetExpenses          decimal.Decimal                                  `json:"net_expenses"` // TotalExpenses minus Reimbursed
+	NetSavings           decimal.Decimal                                  `json:"net_savings"`  // TotalIncome minus NetExpenses
+	CategoryBreakdown    map[MainCategory]decimal.Decimal                 `json:"category_breakdown"`
+	SubCategoryBreakdown map[SubCategory]decimal.Decimal                  `json:"sub_category_breakdown"`
+	CategoryCrossTab     map[MainCategory]map[SubCategory]decimal.Decimal `json:"category_cross_tab"`
+	IncomePercentages    *IncomePercentages                               `json:"income_percentages,omitempty"` // nil when there is no income
+	OriginalAmounts      map[string]decimal.Decimal                       `json:"original_amounts"`
+	Comparison           *BreakdownComparison                             `json:"comparison,omitempty"`
+	Projection           *RecurringProjection                             `json:"projection,omitempty"` // current month only
+}
+
+// IncomePercentages expresses a month's spending as percentages of its income
+type IncomePercentages struct {
+	Expenses      decimal.Decimal                  `json:"expenses"`
+	Savings       decimal.Decimal                  `json:"savings"`
+	Categories    map[MainCategory]decimal.Decimal `json:"categories"`
+	SubCategories map[SubCategory]decimal.Decimal  `json:"sub_categories"`
+}
+
+// BreakdownComparison holds the change from the previous month (current minus previous)
+type BreakdownComparison struct {
+	PreviousMonth time.Time                        `json:"previous_month"`
+	TotalIncome   decimal.Decimal                  `json:"total_income"`
+	TotalExpenses decimal.Decimal                  `json:"total_expenses"`
+	Savings       decimal.Decimal                  `json:"savings"`
+	Categories    map[MainCategory]decimal.Decimal `json:"categories"`
+	SubCategories map[SubCategory]decimal.Decimal  `json:"sub_categories"`
+}
@@ -0,0 +1,143 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AmountSign says which sign a single amount column uses for money spent
+type AmountSign string
+
+const (
+	AmountSignPositive AmountSign = "positive" // spending is positive, negative amounts are credits
+	AmountSignNegative AmountSign = "negative" // spending is negative, as on most bank statements
+)
+
+// IsValid reports whether the sign convention is known
+func (s AmountSign) IsValid() bool {
+	return s == AmountSignPositive || s == AmountSignNegative
+}
+
+// DefaultImportDateFormat is used when a mapping doesn't name a date format
+const DefaultImportDateFormat = "YYYY-MM-DD"
+
+// ImportMapping describes how to read one kind of CSV file into expenses.
+// Columns are matched against the header row by name, ignoring case.
+// Amounts come either from AmountColumn, signed per AmountSign, or from
+// separate DebitColumn and CreditColumn where only debits are expenses.
+type ImportMapping struct {
+	ID                  string     `json:"id"`
+	UserID              string     `json:"user_id"`
+	Name                string     `json:"name"`
+	DateColumn          string     `json:"date_column"`
+	DateFormat          string     `json:"date_format"` // built from YYYY, YY, MMM, MM and DD, e.g. DD/MM/YYYY
+	AmountColumn        string     `json:"amount_column,omitempty"`
+	AmountSign          AmountSign `json:"amount_sign,omitempty"`
+	DebitColumn         string     `json:"debit_column,omitempty"`
+	CreditColumn        string     `json:"credit_column,omitempty"`
+	DescriptionColumn   string     `json:"description_column,omitempty"`
+	CurrencyColumn      string     `json:"currency_column,omitempty"` // the base currency is used when absent
+	MainCategoryColumn  string     `json:"main_category_column,omitempty"`
+	SubCategoryColumn   string     `json:"sub_category_column,omitempty"`
+	DefaultMainCategory string     `json:"default_main_category,omitempty"` // used when the column is absent or the cell is empty
+	DefaultSubCategory  string     `json:"default_sub_category,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// ImportRowStatus is the outcome of parsing one CSV row
+type ImportRowStatus string
+
+const (
+	ImportRowValid   ImportRowStatus = "valid"
+	ImportRowSkipped ImportRowStatus = "skipped" // credits and zero amounts aren't expenses
+	ImportRowError   ImportRowStatus = "error"
+)
+
+// ImportRow is one parsed CSV row along with the expense it would create
+type ImportRow struct {
+	Line    int             `json:"line"`
+	Status  ImportRowStatus `json:"status"`
+	Expense *Expense        `json:"expense,omitempty"`
+	Message string          `json:"message,omitempty"` // why the row was skipped or failed
+}
+
+// ImportPreview is the result of parsing a CSV file without saving it
+type ImportPreview struct {
+	Rows    []*ImportRow               `json:"rows"`
+	Valid   int                        `json:"valid"`
+	Skipped int                        `json:"skipped"`
+	Errors  int                        `json:"errors"`
+	Totals  map[string]decimal.Decimal `json:"totals"` // valid amounts per currency
+}
+
+// ImportSource is the kind of file an import batch was read from
+type ImportSource string
+
+const (
+	ImportFromCSV       ImportSource = "csv"
+	ImportFromStatement ImportSource = "statement" // a PDF bank statement
+)
+
+// ImportStatus is where an import batch is in its life
+type ImportStatus string
+
+const (
+	ImportStaged    ImportStatus = "staged"    // awaiting review, nothing saved as expenses yet
+	ImportCommitted ImportStatus = "committed" // its expenses are saved
+	ImportUndone    ImportStatus = "undone"    // rolled back after committing, or discarded while staged
+)
+
+// ImportBatch records the expenses saved by one import so they can be undone
+// together. Statement imports are staged first so their transactions can be
+// reviewed before any expenses are saved.
+type ImportBatch struct {
+	ID           string       `json:"id"`
+	UserID       string       `json:"user_id"`
+	Source       ImportSource `json:"source"`
+	Status       ImportStatus `json:"status"`
+	MappingID    string       `json:"mapping_id,omitempty"` // CSV imports only
+	FileName     string       `json:"file_name"`
+	BankName     string       `json:"bank_name,omitempty"`  // statement imports only
+	AccountID    string       `json:"account_id,omitempty"` // account the statement's expenses are recorded against
+	ExpenseCount int          `json:"expense_count"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UndoneAt     *time.Time   `json:"undone_at,omitempty"`
+}
+
+// StagedTransaction is one bank statement transaction awaiting review in a
+// staged import. Confirming the import saves every included debit as an
+// expense; credits are staged excluded since they aren't spending.
+type StagedTransaction struct {
+	ID                    string          `json:"id"`
+	BatchID               string          `json:"batch_id"`
+	Line                  int             `json:"line"` // position in the statement, from 1
+	Date                  time.Time       `json:"date"`
+	Description           string          `json:"description"`
+	Amount                decimal.Decimal `json:"amount"` // always positive
+	Type                  TransactionType `json:"type"`
+	MainCategory          MainCategory    `json:"main_category"`
+	SubCategory           SubCategory     `json:"sub_category"`
+	SuggestedMainCategory MainCategory    `json:"suggested_main_category"` // what the categorizer proposed
+	SuggestedSubCategory  SubCategory     `json:"suggested_sub_category"`
+	Confidence            float64         `json:"confidence"`
+	RuleID                string          `json:"rule_id,omitempty"`
+	NeedsReview           bool            `json:"needs_review"`
+	Excluded              bool            `json:"excluded"`
+}
+
+// StagedTransactionUpdate is a review edit to a staged transaction. Nil
+// fields are left as they are.
+type StagedTransactionUpdate struct {
+	Description  *string
+	MainCategory *MainCategory
+	SubCategory  *SubCategory
+	Excluded     *bool
+}
+
+// StagedImport is an import batch along with its staged transactions
+type StagedImport struct {
+	Batch        *ImportBatch         `json:"batch"`
+	Transactions []*StagedTransaction `json:"transactions"`
+}
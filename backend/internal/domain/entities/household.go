@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Household is a group of users who share expenses, such as everyone living
+// in one house. Balances between members are kept in Currency.
+type Household struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	Currency  string             `json:"currency"`
+	CreatedBy string             `json:"created_by"`
+	Members   []*HouseholdMember `json:"members"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// HasMember reports whether userID belongs to the household
+func (h *Household) HasMember(userID string) bool {
+	for _, member := range h.Members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// HouseholdMember is a user in a household
+type HouseholdMember struct {
+	UserID      string    `json:"user_id"`
+	DisplayName string    `json:"display_name"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+// SplitMethod says how a shared expense is divided between members
+type SplitMethod string
+
+const (
+	SplitEqual      SplitMethod = "equal"
+	SplitPercentage SplitMethod = "percentage" // share values are percentages adding up to 100
+	SplitExact      SplitMethod = "exact"      // share values are amounts adding up to the expense amount
+)
+
+// IsValid reports whether the split method is known
+func (m SplitMethod) IsValid() bool {
+	return m == SplitEqual || m == SplitPercentage || m == SplitExact
+}
+
+// ExpenseSplit shares an expense paid by one member between members of a
+// household. The payer is the expense's owner and needn't have a share.
+type ExpenseSplit struct {
+	ExpenseID   string          `json:"expense_id"`
+	HouseholdID string          `json:"household_id"`
+	Method      SplitMethod     `json:"method"`
+	Shares      []*ExpenseShare `json:"shares"`
+}
+
+// ExpenseShare is one member's part of a split expense
+type ExpenseShare struct {
+	UserID string          `json:"user_id"`
+	Value  decimal.Decimal `json:"value"`  // percentage or exact amount, unused for equal splits
+	Amount decimal.Decimal `json:"amount"` // owed, in the household currency
+}
+
+// SharedExpense is a split expense as it appears in a household ledger
+type SharedExpense struct {
+	Expense *Expense        `json:"expense"`
+	PaidBy  string          `json:"paid_by"`
+	Amount  decimal.Decimal `json:"amount"` // in the household currency
+	Split   *ExpenseSplit   `json:"split"`
+}
+
+// Settlement records a payment from one member to another to settle up
+type Settlement struct {
+	ID          string          `json:"id"`
+	HouseholdID string          `json:"household_id"`
+	FromUserID  string          `json:"from_user_id"`
+	ToUserID    string          `json:"to_user_id"`
+	Amount      decimal.Decimal `json:"amount"` // in the household currency
+	Date        time.Time       `json:"date"`
+	Note        string          `json:"note"`
+	CreatedBy   string          `json:"created_by"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// HouseholdLedger lists a household's shared expenses and settlements, oldest first
+type HouseholdLedger struct {
+	HouseholdID string           `json:"household_id"`
+	Currency    string           `json:"currency"`
+	Expenses    []*SharedExpense `json:"expenses"`
+	Settlements []*Settlement    `json:"settlements"`
+}
+
+// MemberBalance sums up where a member stands. A positive Net means the
+// member is owed money, a negative one that they owe it.
+type MemberBalance struct {
+	UserID      string          `json:"user_id"`
+	DisplayName string          `json:"display_name"`
+	Paid        decimal.Decimal `json:"paid"`     // shared expenses paid for
+	Share       decimal.Decimal `json:"share"`    // their part of shared expenses
+	Sent        decimal.Decimal `json:"sent"`     // settlements paid to others
+	Received    decimal.Decimal `json:"received"` // settlements received from others
+	Net         decimal.Decimal `json:"net"`
+}
+
+// SettleUpPayment is one payment in a plan that clears every balance
+type SettleUpPayment struct {
+	FromUserID string          `json:"from_user_id"`
+	ToUserID   string          `json:"to_user_id"`
+	Amount     decimal.Decimal `json:"amount"`
+}
+
+// HouseholdBalances shows who owes whom in a household and how to settle up
+type HouseholdBalances struct {
+	HouseholdID string             `json:"household_id"`
+	Currency    string             `json:"currency"`
+	Members     []*MemberBalance   `json:"members"`
+	SettleUp    []*SettleUpPayment `json:"settle_up"`
+}
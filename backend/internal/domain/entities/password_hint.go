@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// PasswordHint is a reminder of how a bank builds the password of its
+// statement PDFs, such as "date of birth as DDMM". It's stored encrypted
+// and never holds the password itself.
+type PasswordHint struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Bank      string    `json:"bank"`
+	Hint      string    `json:"hint"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RecurringExpense is a template for an expense that repeats, such as rent or
+// a subscription. An expense is generated from it on every date its rule produces.
+type RecurringExpense struct {
+	ID           string          `json:"id"`
+	UserID       string          `json:"user_id"`
+	Amount       decimal.Decimal `json:"amount"`
+	Currency     string          `json:"currency"`
+	Description  string          `json:"description"`
+	MainCategory MainCategory    `json:"main_category"`
+	SubCategory  SubCategory     `json:"sub_category"`
+	Rule         *RecurrenceRule `json:"rule"`
+	StartDate    time.Time       `json:"start_date"`
+	NextDate     *time.Time      `json:"next_date"` // next occurrence still to be generated, nil once the rule has ended
+	Paused       bool            `json:"paused"`    // dates that pass while paused are never generated
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// RecurringOccurrence is one date produced by a recurring expense, with any
+// change made to that date alone applied
+type RecurringOccurrence struct {
+	RecurringID  string          `json:"recurring_id"`
+	Date         time.Time       `json:"date"`
+	Amount       decimal.Decimal `json:"amount"`
+	Currency     string          `json:"currency"`
+	Description  string          `json:"description"`
+	MainCategory MainCategory    `json:"main_category"`
+	SubCategory  SubCategory     `json:"sub_category"`
+	Skipped      bool            `json:"skipped"`
+	Edited       bool            `json:"edited"`               // the amount or description differs from the template
+	ExpenseID    string          `json:"expense_id,omitempty"` // set once the expense has been generated
+}
+
+// OccurrenceChange skips or edits a single upcoming occurrence. Nil fields
+// keep the template's values.
+type OccurrenceChange struct {
+	Skip        bool             `json:"skip"`
+	Amount      *decimal.Decimal `json:"amount,omitempty"`
+	Description *string          `json:"description,omitempty"`
+}
+
+// RecurringProjection adds the recurring expenses still to be generated this
+// month to a monthly breakdown
+type RecurringProjection struct {
+	Upcoming []*RecurringOccurrence `json:"upcoming"`
+	Total    decimal.Decimal        `json:"total"`    // upcoming spend in the breakdown currency
+	Expenses decimal.Decimal        `json:"expenses"` // total expenses once the upcoming ones are generated
+	Savings  decimal.Decimal        `json:"savings"`
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReimbursementKind tells a payer's reimbursement apart from a merchant's refund
+type ReimbursementKind string
+
+const (
+	ReimbursementKindReimbursement ReimbursementKind = "reimbursement"
+	ReimbursementKindRefund        ReimbursementKind = "refund"
+)
+
+// Reimbursement is money received back against an expense
+type Reimbursement struct {
+	ID        string            `json:"id"`
+	UserID    string            `json:"user_id"`
+	ExpenseID string            `json:"expense_id"`
+	Kind      ReimbursementKind `json:"kind"`
+	Amount    decimal.Decimal   `json:"amount"`
+	Currency  string            `json:"currency"`
+	Date      time.Time         `json:"date"`
+	Payer     string            `json:"payer,omitempty"` // who paid it back, defaults to the expense's expected payer
+	Note      string            `json:"note,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// PendingReimbursement is a reimbursable expense that hasn't been fully paid back.
+// Received and Outstanding are in the expense's currency.
+type PendingReimbursement struct {
+	Expense     *Expense        `json:"expense"`
+	Received    decimal.Decimal `json:"received"`
+	Outstanding decimal.Decimal `json:"outstanding"`
+	DaysPending int             `json:"days_pending"`
+}
+
+// PendingReimbursements lists outstanding reimbursements, oldest first, with
+// their total converted to the user's base currency
+type PendingReimbursements struct {
+	Currency string                  `json:"currency"`
+	Total    decimal.Decimal         `json:"total"`
+	Expenses []*PendingReimbursement `json:"expenses"`
+}
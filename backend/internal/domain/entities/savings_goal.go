@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SavingsGoal is something the user is saving towards, such as an emergency
+// fund or a car, with an optional date to reach it by
+type SavingsGoal struct {
+	ID           string          `json:"id"`
+	UserID       string          `json:"user_id"`
+	Name         string          `json:"name"`
+	TargetAmount decimal.Decimal `json:"target_amount"`
+	Currency     string          `json:"currency"`
+	TargetDate   *time.Time      `json:"target_date,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// GoalContribution is money put towards a savings goal, either entered by
+// hand or taken from an expense in the Savings category
+type GoalContribution struct {
+	ID        string          `json:"id"`
+	GoalID    string          `json:"goal_id"`
+	ExpenseID string          `json:"expense_id,omitempty"`
+	Amount    decimal.Decimal `json:"amount"`
+	Currency  string          `json:"currency"`
+	Date      time.Time       `json:"date"`
+	Note      string          `json:"note"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// GoalStatus says where a savings goal stands
+type GoalStatus string
+
+const (
+	GoalAchieved    GoalStatus = "achieved"
+	GoalOnTrack     GoalStatus = "on_track"
+	GoalBehind      GoalStatus = "behind"      // saving, but too slowly to make the target date
+	GoalUnreachable GoalStatus = "unreachable" // recent months show no surplus to save from
+)
+
+// GoalProgress shows how far a savings goal has come. Amounts are in the
+// goal's currency.
+type GoalProgress struct {
+	Goal          *SavingsGoal        `json:"goal"`
+	Saved         decimal.Decimal     `json:"saved"`
+	Remaining     decimal.Decimal     `json:"remaining"`
+	Percent       decimal.Decimal     `json:"percent"`
+	Status        GoalStatus          `json:"status"`
+	Projection    *GoalProjection     `json:"projection,omitempty"` // nil once achieved
+	Contributions []*GoalContribution `json:"contributions,omitempty"`
+}
+
+// GoalProjection estimates when a goal will be reached from the average
+// monthly surplus (income minus expenses) over recent complete months. The
+// surplus is shared by every goal, so each one is judged against all of it.
+type GoalProjection struct {
+	MonthlySurplus  decimal.Decimal  `json:"monthly_surplus"`
+	SurplusMonths   int              `json:"surplus_months"`
+	MonthsLeft      int              `json:"months_left,omitempty"`      // until the target date
+	RequiredMonthly *decimal.Decimal `json:"required_monthly,omitempty"` // nil without a target date
+	ProjectedDate   *time.Time       `json:"projected_date,omitempty"`   // nil without a surplus
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SubscriptionCadence is how often a detected subscription charges
+type SubscriptionCadence string
+
+const (
+	WeeklyCadence    SubscriptionCadence = "weekly"
+	MonthlyCadence   SubscriptionCadence = "monthly"
+	QuarterlyCadence SubscriptionCadence = "quarterly"
+	YearlyCadence    SubscriptionCadence = "yearly"
+)
+
+// Subscription is a merchant found charging at a regular cadence with similar
+// amounts. Amounts are in Currency, the currency of the latest charge.
+type Subscription struct {
+	Key            string              `json:"key"` // the normalized merchant name, used to convert it to a recurring expense
+	Merchant       string              `json:"merchant"`
+	Cadence        SubscriptionCadence `json:"cadence"`
+	Currency       string              `json:"currency"`
+	Amount         decimal.Decimal     `json:"amount"` // latest charge
+	AnnualizedCost decimal.Decimal     `json:"annualized_cost"`
+	Charges        int                 `json:"charges"`
+	FirstCharge    time.Time           `json:"first_charge"`
+	LastCharge     time.Time           `json:"last_charge"`
+	NextCharge     time.Time           `json:"next_charge"` // expected
+	Active         bool                `json:"active"`      // the next charge isn't overdue
+	MainCategory   MainCategory        `json:"main_category"`
+	SubCategory    SubCategory         `json:"sub_category"`
+	PriceIncrease  *PriceIncrease      `json:"price_increase,omitempty"`
+	RecurringID    string              `json:"recurring_id,omitempty"` // recurring expense already tracking it
+	ExpenseIDs     []string            `json:"expense_ids"`
+}
+
+// PriceIncrease flags a subscription whose latest charge is more than the one before
+type PriceIncrease struct {
+	PreviousAmount decimal.Decimal `json:"previous_amount"`
+	NewAmount      decimal.Decimal `json:"new_amount"`
+	Percent        decimal.Decimal `json:"percent"`
+	Date           time.Time       `json:"date"`
+}
+
+// SubscriptionReport lists the subscriptions detected in a user's expenses
+type SubscriptionReport struct {
+	Currency        string          `json:"currency"`
+	AnnualizedTotal decimal.Decimal `json:"annualized_total"` // of active subscriptions, in Currency
+	Subscriptions   []*Subscription `json:"subscriptions"`
+}
@@ -48,17 +48,17 @@ type Reminder struct {
 	CreatedAt time.Time
 }
 
-// RecurrenceRule represents a task recurrence pattern
+// RecurrenceRule represents a recurrence pattern shared by tasks and recurring expenses
 type RecurrenceRule struct {
-	Frequency  RecurrenceFrequency
-	Interval   int
-	DaysOfWeek []time.Weekday
-	MonthDay   int
-	EndDate    *time.Time
-	Count      int
+	Frequency  RecurrenceFrequency `json:"frequency"`
+	Interval   int                 `json:"interval"`               // every Interval days, weeks, months or years
+	DaysOfWeek []time.Weekday      `json:"days_of_week,omitempty"` // weekly only, defaults to the start date's weekday
+	MonthDay   int                 `json:"month_day,omitempty"`    // monthly only, defaults to the start date's day
+	EndDate    *time.Time          `json:"end_date,omitempty"`     // last possible date, inclusive
+	Count      int                 `json:"count,omitempty"`        // total number of occurrences, unlimited when zero
 }
 
-// RecurrenceFrequency represents how often a task recurs
+// RecurrenceFrequency represents how often something recurs
 type RecurrenceFrequency string
 
 const (
@@ -66,4 +66,9 @@ const (
 	Weekly  RecurrenceFrequency = "weekly"
 	Monthly RecurrenceFrequency = "monthly"
 	Yearly  RecurrenceFrequency = "yearly"
-)
\ No newline at end of file
+)
+
+// IsValid reports whether the frequency is known
+func (f RecurrenceFrequency) IsValid() bool {
+	return f == Daily || f == Weekly || f == Monthly || f == Yearly
+}
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultFiscalYearStart is the first month of the financial year for users
+// who haven't chosen one, matching the Indian April–March year
+const DefaultFiscalYearStart = time.April
+
+// TaxCurrency is the currency deduction limits are set in
+const TaxCurrency = "INR"
+
+// TaxSection is an income-tax deduction section an expense can be claimed under
+type TaxSection string
+
+// Supported deduction sections
+const (
+	Section80C     TaxSection = "80C"
+	Section80CCD1B TaxSection = "80CCD(1B)"
+	Section80D     TaxSection = "80D"
+	Section80E     TaxSection = "80E"
+	Section80G     TaxSection = "80G"
+	Section80GG    TaxSection = "80GG"
+	Section24B     TaxSection = "24(b)"
+	SectionHRA     TaxSection = "HRA"
+)
+
+// DeductionSection describes a deduction section and its statutory limit
+type DeductionSection struct {
+	Section TaxSection       `json:"section"`
+	Name    string           `json:"name"`
+	Limit   *decimal.Decimal `json:"limit,omitempty"` // in TaxCurrency, nil when there is no fixed cap
+}
+
+// DeductionSections lists every supported section in report order
+var DeductionSections = []DeductionSection{
+	{Section: Section80C, Name: "Life insurance, PPF, ELSS, tuition fees and home loan principal", Limit: deductionLimit(150000)},
+	{Section: Section80CCD1B, Name: "Additional NPS contribution", Limit: deductionLimit(50000)},
+	{Section: Section80D, Name: "Health insurance premiums", Limit: deductionLimit(25000)},
+	{Section: Section80E, Name: "Education loan interest"},
+	{Section: Section80G, Name: "Donations to approved funds and charities"},
+	{Section: Section80GG, Name: "Rent paid without HRA", Limit: deductionLimit(60000)},
+	{Section: Section24B, Name: "Interest on a self-occupied home loan", Limit: deductionLimit(200000)},
+	{Section: SectionHRA, Name: "Rent paid for HRA exemption"},
+}
+
+func deductionLimit(amount int64) *decimal.Decimal {
+	limit := decimal.NewFromInt(amount)
+	return &limit
+}
+
+// FiscalYearSummary totals a financial year's income, expenses and savings,
+// converted to the user's base currency, along with its tax deductions
+type FiscalYearSummary struct {
+	Year                 string                                           `json:"year"` // e.g. "2024-25", or "2024" for calendar years
+	StartDate            time.Time                                        `json:"start_date"`
+	EndDate              time.Time                                        `json:"end_date"` // exclusive
+	Currency             string                                           `json:"currency"`
+	TotalIncome          decimal.Decimal                                  `json:"total_income"`
+	TotalExpenses        decimal.Decimal                                  `json:"total_expenses"`
+	Savings              decimal.Decimal                                  `json:"savings"`
+	CategoryBreakdown    map[MainCategory]decimal.Decimal                 `json:"category_breakdown"`
+	SubCategoryBreakdown map[SubCategory]decimal.Decimal                  `json:"sub_category_breakdown"`
+	CategoryCrossTab     map[MainCategory]map[SubCategory]decimal.Decimal `json:"category_cross_tab"`
+	Months               []*FiscalMonth                                   `json:"months"`
+	DeductionCurrency    string                                           `json:"deduction_currency"`
+	Deductions           []*DeductionTotal                                `json:"deductions"`
+}
+
+// FiscalMonth holds one month's totals within a financial year
+type FiscalMonth struct {
+	Month         time.Time       `json:"month"`
+	TotalIncome   decimal.Decimal `json:"total_income"`
+	TotalExpenses decimal.Decimal `json:"total_expenses"`
+	Savings       decimal.Decimal `json:"savings"`
+}
+
+// DeductionTotal totals the expenses tagged with a section against its limit
+type DeductionTotal struct {
+	Section   TaxSection       `json:"section"`
+	Name      string           `json:"name"`
+	Claimed   decimal.Decimal  `json:"claimed"`
+	Limit     *decimal.Decimal `json:"limit,omitempty"`
+	Eligible  decimal.Decimal  `json:"eligible"`            // claimed, capped at the limit
+	Remaining *decimal.Decimal `json:"remaining,omitempty"` // room left under the limit
+	Expenses  int              `json:"expenses"`
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AnomalyDirection says whether an anomalous amount is unusually high or low
+type AnomalyDirection string
+
+const (
+	AnomalyHigh AnomalyDirection = "high"
+	AnomalyLow  AnomalyDirection = "low"
+)
+
+// SpendingTrends holds monthly spending series for charting, oldest month
+// first, with every amount in the user's base currency
+type SpendingTrends struct {
+	Currency   string          `json:"currency"`
+	GroupBy    CategoryKind    `json:"group_by"`
+	Months     []time.Time     `json:"months"`
+	Total      *TrendSeries    `json:"total"`
+	Categories []*TrendSeries  `json:"categories"`         // largest total first
+	Forecast   *SpendForecast  `json:"forecast,omitempty"` // only when the range includes the current month
+	Anomalies  []*TrendAnomaly `json:"anomalies"`
+}
+
+// TrendSeries is the spending for one category, or for everything, month by month
+type TrendSeries struct {
+	Category string          `json:"category,omitempty"` // empty for the total series
+	Points   []*TrendPoint   `json:"points"`
+	Total    decimal.Decimal `json:"total"`
+	Average  decimal.Decimal `json:"average"` // per month, over the months with history
+}
+
+// TrendPoint is one month of a trend series. Changes are percentages and are
+// nil when there is nothing to compare against.
+type TrendPoint struct {
+	Month          time.Time        `json:"month"`
+	Amount         decimal.Decimal  `json:"amount"`
+	MonthOverMonth *decimal.Decimal `json:"month_over_month"`
+	YearOverYear   *decimal.Decimal `json:"year_over_year"`
+	RollingAverage decimal.Decimal  `json:"rolling_average"`
+	Partial        bool             `json:"partial"` // the month isn't over yet
+	Anomaly        bool             `json:"anomaly"`
+}
+
+// SpendForecast estimates the current month's total spending
+type SpendForecast struct {
+	Month       time.Time       `json:"month"`
+	SpentSoFar  decimal.Decimal `json:"spent_so_far"`
+	DaysElapsed int             `json:"days_elapsed"`
+	DaysInMonth int             `json:"days_in_month"`
+	Baseline    decimal.Decimal `json:"baseline"`  // average of the previous full months
+	RunRate     decimal.Decimal `json:"run_rate"`  // spend so far extrapolated to the whole month
+	Projected   decimal.Decimal `json:"projected"` // spend so far plus the baseline for the days left
+}
+
+// TrendAnomaly flags a month whose spending, overall or in one category, is
+// far from that series' usual level
+type TrendAnomaly struct {
+	Month     time.Time        `json:"month"`
+	Category  string           `json:"category,omitempty"` // empty when the month's total is unusual
+	Amount    decimal.Decimal  `json:"amount"`
+	Mean      decimal.Decimal  `json:"mean"`
+	StdDev    decimal.Decimal  `json:"std_dev"`
+	ZScore    *decimal.Decimal `json:"z_score"` // nil when the history never varied
+	Direction AnomalyDirection `json:"direction"`
+}
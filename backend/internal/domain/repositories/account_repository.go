@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AccountRepository defines the interface for account data access
+type AccountRepository interface {
+	// Create a new account
+	Create(ctx context.Context, account *Account) error
+
+	// Get an account by ID
+	GetByID(ctx context.Context, id string) (*Account, error)
+
+	// Get every account for a user, archived ones last
+	GetByUserID(ctx context.Context, userID string) ([]*Account, error)
+
+	// Update an account
+	Update(ctx context.Context, account *Account) error
+
+	// Delete an account by ID
+	Delete(ctx context.Context, id string) error
+
+	// Count the expenses, incomes, transfers, categorization rules and import batches referencing an account
+	CountEntries(ctx context.Context, id string) (int, error)
+
+	// Create a new transfer
+	CreateTransfer(ctx context.Context, transfer *Transfer) error
+
+	// Get a transfer by ID
+	GetTransferByID(ctx context.Context, id string) (*Transfer, error)
+
+	// Get every transfer into or out of an account, oldest first
+	GetTransfersByAccount(ctx context.Context, accountID string) ([]*Transfer, error)
+
+	// Delete a transfer by ID
+	DeleteTransfer(ctx context.Context, id string) error
+}
+
+// Account represents the repository account model
+type Account struct {
+	ID             string
+	UserID         string
+	Name           string
+	Type           string
+	Currency       string
+	Last4          string
+	OpeningBalance decimal.Decimal
+	OpeningDate    time.Time
+	Archived       bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Transfer represents the repository transfer model
+type Transfer struct {
+	ID            string
+	UserID        string
+	FromAccountID string
+	ToAccountID   string
+	Amount        decimal.Decimal
+	ToAmount      decimal.Decimal
+	Date          time.Time
+	Note          string
+	CreatedAt     time.Time
+}
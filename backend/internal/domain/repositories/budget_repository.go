@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BudgetRepository defines the interface for budget data access
+type BudgetRepository interface {
+	// Create a new budget
+	Create(ctx context.Context, budget *Budget) error
+
+	// Get a budget by ID
+	GetByID(ctx context.Context, id string) (*Budget, error)
+
+	// Get every budget for a user in the given month
+	GetByMonth(ctx context.Context, userID string, month time.Time) ([]*Budget, error)
+
+	// Get the budget for a category in the given month, ignoring case
+	GetByCategory(ctx context.Context, userID, kind, category string, month time.Time) (*Budget, error)
+
+	// Update a budget
+	Update(ctx context.Context, budget *Budget) error
+
+	// Delete a budget by ID
+	Delete(ctx context.Context, id string) error
+}
+
+// Budget represents the repository budget model
+type Budget struct {
+	ID        string
+	UserID    string
+	Kind      string
+	Category  string
+	Month     time.Time
+	Amount    decimal.Decimal
+	Currency  string
+	Rollover  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CategorizationRuleRepository defines the interface for categorization rule data access
+type CategorizationRuleRepository interface {
+	// Create a new rule
+	Create(ctx context.Context, rule *CategorizationRule) error
+
+	// Get a rule by ID
+	GetByID(ctx context.Context, id string) (*CategorizationRule, error)
+
+	// Get every rule for a user in evaluation order: highest priority first, then oldest first
+	GetByUserID(ctx context.Context, userID string) ([]*CategorizationRule, error)
+
+	// Update a rule
+	Update(ctx context.Context, rule *CategorizationRule) error
+
+	// Delete a rule
+	Delete(ctx context.Context, id string) error
+}
+
+// CategorizationRule represents the repository categorization rule model
+type CategorizationRule struct {
+	ID              string
+	UserID          string
+	Name            string
+	MatchType       string
+	Pattern         string
+	MinAmount       *decimal.Decimal
+	MaxAmount       *decimal.Decimal
+	TransactionType string
+	AccountID       string
+	MainCategory    string
+	SubCategory     string
+	Priority        int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
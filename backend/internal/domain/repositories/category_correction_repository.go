@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CategoryCorrectionRepository defines the interface for category correction data access
+type CategoryCorrectionRepository interface {
+	// Create a new correction
+	Create(ctx context.Context, correction *CategoryCorrection) error
+
+	// Get a user's most recent corrections, newest first. A limit of 0 means no limit.
+	GetByUserID(ctx context.Context, userID string, limit int) ([]*CategoryCorrection, error)
+}
+
+// CategoryCorrection represents the repository category correction model
+type CategoryCorrection struct {
+	ID              string
+	UserID          string
+	Description     string
+	Amount          decimal.Decimal
+	TransactionType string
+	MainCategory    string
+	SubCategory     string
+	CreatedAt       time.Time
+}
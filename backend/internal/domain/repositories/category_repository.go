@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// CategoryRepository defines the interface for category data access
+type CategoryRepository interface {
+	// Create categories atomically
+	CreateAll(ctx context.Context, categories []*Category) error
+	
+	// Get a category by ID
+	GetByID(ctx context.Context, id string) (*Category, error)
+	
+	// Get a category by kind and name, ignoring case
+	GetByName(ctx context.Context, userID, kind, name string) (*Category, error)
+	
+	// Get all categories for a user in creation order
+	GetByUserID(ctx context.Context, userID string) ([]*Category, error)
+	
+	// Update a category; a changed name is applied to every expense filed under it
+	Update(ctx context.Context, category *Category) error
+	
+	// Merge moves expenses, children and role from source to target, then deletes source.
+	// Budgets both have in the same month are added together; ErrConflict is returned
+	// when their currencies differ.
+	Merge(ctx context.Context, sourceID, targetID string) error
+}
+
+// Category represents the repository category model
+type Category struct {
+	ID        string
+	UserID    string
+	Name      string
+	Kind      string
+	ParentID  string
+	Color     string
+	Icon      string
+	Archived  bool
+	Role      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "errors"
+
+// ErrNotFound is returned when a requested record does not exist
+var ErrNotFound = errors.New("record not found")
+
+// ErrConflict is returned when a change would clash with records that can't be combined
+var ErrConflict = errors.New("conflicting records")
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// EventRepository defines the interface for event data access
+type EventRepository interface {
+	// Create a new event
+	Create(ctx context.Context, event *Event) error
+
+	// Get an event by ID
+	GetByID(ctx context.Context, id string) (*Event, error)
+
+	// Get every event for a user, most recent first
+	GetByUserID(ctx context.Context, userID string) ([]*Event, error)
+
+	// Get a user's auto-attaching events whose dates include day, latest start first
+	GetAutoAttaching(ctx context.Context, userID string, day time.Time) ([]*Event, error)
+
+	// Update an event
+	Update(ctx context.Context, event *Event) error
+
+	// Delete an event, detaching its expenses
+	Delete(ctx context.Context, id string) error
+
+	// Attach a user's expenses dated in [start, end) that aren't in any event, returning how many were attached
+	AttachUnassigned(ctx context.Context, eventID, userID string, start, end time.Time) (int, error)
+
+	// Attach an expense to an event, or detach it when eventID is empty
+	SetExpenseEvent(ctx context.Context, expenseID, eventID string) error
+}
+
+// Event represents the repository event model
+type Event struct {
+	ID         string
+	UserID     string
+	Name       string
+	StartDate  time.Time
+	EndDate    time.Time
+	AutoAttach bool
+	Budget     *decimal.Decimal
+	Currency   string
+	Note       string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
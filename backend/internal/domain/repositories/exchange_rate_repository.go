@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRateRepository defines the interface for exchange rate data access
+type ExchangeRateRepository interface {
+	// Save rates atomically, replacing any rate for the same currency pair and date
+	Upsert(ctx context.Context, rates []*ExchangeRate) error
+
+	// Get a rate by ID
+	GetByID(ctx context.Context, id string) (*ExchangeRate, error)
+
+	// Get all rates for a user, most recent date first
+	GetByUserID(ctx context.Context, userID string) ([]*ExchangeRate, error)
+
+	// Get the most recent rate for a currency pair dated on or before the given day
+	GetLatest(ctx context.Context, userID, from, to string, on time.Time) (*ExchangeRate, error)
+
+	// Delete a rate by ID
+	Delete(ctx context.Context, id string) error
+}
+
+// ExchangeRate represents the repository exchange rate model
+type ExchangeRate struct {
+	ID           string
+	UserID       string
+	FromCurrency string
+	ToCurrency   string
+	Date         time.Time
+	Rate         decimal.Decimal
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
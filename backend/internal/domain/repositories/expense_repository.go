@@ -9,16 +9,22 @@ import (
 
 // ExpenseRepository defines the interface for expense data access
 type ExpenseRepository interface {
-	// Create a new expense
+	// Create a new expense along with its line items
 	Create(ctx context.Context, expense *Expense) error
 	
 	// Get an expense by ID
 	GetByID(ctx context.Context, id string) (*Expense, error)
 	
-	// Get expenses by user ID and date range
+	// Get expenses by user ID whose date falls in [startDate, endDate)
 	GetByUserIDAndDateRange(ctx context.Context, userID string, startDate, endDate time.Time) ([]*Expense, error)
 	
-	// Update an expense
+	// Get expenses by user ID and filters, most recent first unless filters.OldestFirst is set
+	GetByUserID(ctx context.Context, userID string, filters *ExpenseFilters) ([]*Expense, error)
+	
+	// Count expenses by user ID and filters, ignoring Limit and Offset
+	CountByUserID(ctx context.Context, userID string, filters *ExpenseFilters) (int, error)
+	
+	// Update an expense, replacing its line items
 	Update(ctx context.Context, expense *Expense) error
 	
 	// Delete an expense by ID
@@ -30,10 +36,42 @@ type Expense struct {
 	ID          string
 	UserID      string
 	Amount      decimal.Decimal
+	Currency    string
 	Description string
 	Date        time.Time
 	MainCategory string
 	SubCategory  string
+	AccountID    string
+	TaxSection   string
+	Items        []*ExpenseItem
+	AmortizeMonths int
+	Reimbursable bool
+	ExpectedPayer string
+	EventID      string
+	ImportBatchID string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
-}
\ No newline at end of file
+}
+
+// ExpenseItem represents the repository expense line item model
+type ExpenseItem struct {
+	ID          string
+	Description string
+	Amount      decimal.Decimal
+	SubCategory string
+}
+
+// ExpenseFilters represents filters for expense queries
+type ExpenseFilters struct {
+	StartDate    *time.Time // inclusive
+	EndDate      *time.Time // exclusive
+	MainCategory string
+	SubCategory  string // matches the expense or any of its line items
+	AccountID    string
+	Amortized    bool // only expenses spread over more than one month
+	Reimbursable bool // only expenses expected to be paid back
+	EventID      string
+	Limit        int // 0 means no limit
+	Offset       int
+	OldestFirst  bool // order by date ascending instead of most recent first
+}
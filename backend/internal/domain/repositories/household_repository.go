@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// HouseholdRepository defines the interface for household data access
+type HouseholdRepository interface {
+	// Create a new household along with its members
+	Create(ctx context.Context, household *Household) error
+
+	// Get a household and its members by ID
+	GetByID(ctx context.Context, id string) (*Household, error)
+
+	// Get every household the user is a member of, ordered by name
+	GetByMember(ctx context.Context, userID string) ([]*Household, error)
+
+	// Update a household's name and currency
+	Update(ctx context.Context, household *Household) error
+
+	// Add a member to a household
+	AddMember(ctx context.Context, member *HouseholdMember) error
+
+	// Remove a member from a household
+	RemoveMember(ctx context.Context, householdID, userID string) error
+
+	// Save an expense's split, replacing any earlier one
+	SaveSplit(ctx context.Context, split *ExpenseSplit) error
+
+	// Get the split for an expense
+	GetSplit(ctx context.Context, expenseID string) (*ExpenseSplit, error)
+
+	// Get every split in a household
+	GetSplitsByHousehold(ctx context.Context, householdID string) ([]*ExpenseSplit, error)
+
+	// Delete the split for an expense
+	DeleteSplit(ctx context.Context, expenseID string) error
+
+	// Create a new settlement
+	CreateSettlement(ctx context.Context, settlement *Settlement) error
+
+	// Get a settlement by ID
+	GetSettlementByID(ctx context.Context, id string) (*Settlement, error)
+
+	// Get every settlement in a household, oldest first
+	GetSettlementsByHousehold(ctx context.Context, householdID string) ([]*Settlement, error)
+
+	// Delete a settlement by ID
+	DeleteSettlement(ctx context.Context, id string) error
+}
+
+// Household represents the repository household model
+type Household struct {
+	ID        string
+	Name      string
+	Currency  string
+	CreatedBy string
+	Members   []*HouseholdMember
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// HouseholdMember represents the repository household member model
+type HouseholdMember struct {
+	HouseholdID string
+	UserID      string
+	DisplayName string
+	JoinedAt    time.Time
+}
+
+// ExpenseSplit represents the repository expense split model
+type ExpenseSplit struct {
+	ExpenseID   string
+	HouseholdID string
+	Method      string
+	Shares      []*ExpenseShare
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ExpenseShare represents one member's share of a split
+type ExpenseShare struct {
+	UserID string
+	Value  decimal.Decimal
+}
+
+// Settlement represents the repository settlement model
+type Settlement struct {
+	ID          string
+	HouseholdID string
+	FromUserID  string
+	ToUserID    string
+	Amount      decimal.Decimal
+	Date        time.Time
+	Note        string
+	CreatedBy   string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ImportRepository defines the interface for CSV import mappings and batches
+type ImportRepository interface {
+	// Create a new column mapping
+	CreateMapping(ctx context.Context, mapping *ImportMapping) error
+
+	// Get a column mapping by ID
+	GetMappingByID(ctx context.Context, id string) (*ImportMapping, error)
+
+	// Get every column mapping for a user, ordered by name
+	GetMappingsByUserID(ctx context.Context, userID string) ([]*ImportMapping, error)
+
+	// Update a column mapping
+	UpdateMapping(ctx context.Context, mapping *ImportMapping) error
+
+	// Delete a column mapping by ID
+	DeleteMapping(ctx context.Context, id string) error
+
+	// Save a batch and every one of its expenses in a single transaction
+	CreateBatch(ctx context.Context, batch *ImportBatch, expenses []*Expense) error
+
+	// Get a batch by ID
+	GetBatchByID(ctx context.Context, id string) (*ImportBatch, error)
+
+	// Get every batch for a user, most recent first
+	GetBatchesByUserID(ctx context.Context, userID string) ([]*ImportBatch, error)
+
+	// Delete the batch's expenses and mark it undone in a single transaction
+	UndoBatch(ctx context.Context, id string, undoneAt time.Time) error
+
+	// Save a staged batch and its transactions in a single transaction
+	CreateStagedBatch(ctx context.Context, batch *ImportBatch, transactions []*StagedTransaction) error
+
+	// Get a batch's staged transactions in statement order
+	GetStagedTransactions(ctx context.Context, batchID string) ([]*StagedTransaction, error)
+
+	// Update a staged transaction
+	UpdateStagedTransaction(ctx context.Context, transaction *StagedTransaction) error
+
+	// Save a staged batch's expenses and review corrections and mark it committed in a single transaction
+	ConfirmBatch(ctx context.Context, id string, expenses []*Expense, corrections []*CategoryCorrection) error
+}
+
+// ImportMapping represents the repository column mapping model
+type ImportMapping struct {
+	ID                  string
+	UserID              string
+	Name                string
+	DateColumn          string
+	DateFormat          string
+	AmountColumn        string
+	AmountSign          string
+	DebitColumn         string
+	CreditColumn        string
+	DescriptionColumn   string
+	CurrencyColumn      string
+	MainCategoryColumn  string
+	SubCategoryColumn   string
+	DefaultMainCategory string
+	DefaultSubCategory  string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// ImportBatch represents the repository import batch model
+type ImportBatch struct {
+	ID           string
+	UserID       string
+	Source       string
+	Status       string
+	MappingID    string
+	FileName     string
+	BankName     string
+	AccountID    string
+	ExpenseCount int
+	CreatedAt    time.Time
+	UndoneAt     *time.Time
+}
+
+// StagedTransaction represents the repository staged transaction model
+type StagedTransaction struct {
+	ID                    string
+	BatchID               string
+	Line                  int
+	Date                  time.Time
+	Description           string
+	Amount                decimal.Decimal
+	Type                  string
+	MainCategory          string
+	SubCategory           string
+	SuggestedMainCategory string
+	SuggestedSubCategory  string
+	Confidence            float64
+	RuleID                string
+	NeedsReview           bool
+	Excluded              bool
+}
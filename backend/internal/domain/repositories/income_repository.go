@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// IncomeRepository defines the interface for income data access
+type IncomeRepository interface {
+	// Create a new income
+	Create(ctx context.Context, income *Income) error
+	
+	// Get an income by ID
+	GetByID(ctx context.Context, id string) (*Income, error)
+	
+	// Get all incomes for a user, most recent month first
+	GetByUserID(ctx context.Context, userID string) ([]*Income, error)
+	
+	// Get every income recorded for a user in the given month
+	GetByMonth(ctx context.Context, userID string, month time.Time) ([]*Income, error)
+	
+	// Get recurring incomes before the given month that have not yet been carried forward
+	GetUncarriedRecurring(ctx context.Context, userID string, before time.Time) ([]*Income, error)
+	
+	// Update an income
+	Update(ctx context.Context, income *Income) error
+	
+	// Delete an income by ID
+	Delete(ctx context.Context, id string) error
+}
+
+// Income represents the repository income model
+type Income struct {
+	ID            string
+	UserID        string
+	Amount        decimal.Decimal
+	Currency      string
+	Month         time.Time
+	Source        string
+	IsRecurring   bool
+	AccountID     string
+	CarriedFromID string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
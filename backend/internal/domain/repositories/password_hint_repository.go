@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// PasswordHintRepository defines the interface for statement password hint data access
+type PasswordHintRepository interface {
+	// Upsert creates the user's hint for a bank or replaces the existing one
+	Upsert(ctx context.Context, hint *PasswordHint) error
+
+	// Get a user's hint for a bank
+	GetByBank(ctx context.Context, userID, bank string) (*PasswordHint, error)
+
+	// Get all of a user's hints, ordered by bank
+	GetByUserID(ctx context.Context, userID string) ([]*PasswordHint, error)
+
+	// Delete a user's hint for a bank
+	Delete(ctx context.Context, userID, bank string) error
+}
+
+// PasswordHint represents the repository password hint model. The hint is
+// only ever stored sealed.
+type PasswordHint struct {
+	ID         string
+	UserID     string
+	Bank       string
+	SealedHint string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RecurringExpenseRepository defines the interface for recurring expense data access
+type RecurringExpenseRepository interface {
+	// Create a new recurring expense
+	Create(ctx context.Context, recurring *RecurringExpense) error
+
+	// Get a recurring expense by ID
+	GetByID(ctx context.Context, id string) (*RecurringExpense, error)
+
+	// Get every recurring expense for a user, ordered by next date
+	GetByUserID(ctx context.Context, userID string) ([]*RecurringExpense, error)
+
+	// Get every active recurring expense with an occurrence due on or before asOf
+	GetDue(ctx context.Context, asOf time.Time) ([]*RecurringExpense, error)
+
+	// Update a recurring expense
+	Update(ctx context.Context, recurring *RecurringExpense) error
+
+	// Delete a recurring expense and its occurrences; generated expenses are kept
+	Delete(ctx context.Context, id string) error
+
+	// Save a change to a single occurrence, replacing any earlier one
+	SaveOccurrence(ctx context.Context, occurrence *RecurringOccurrence) error
+
+	// Delete the stored change for a single occurrence
+	DeleteOccurrence(ctx context.Context, recurringID string, date time.Time) error
+
+	// Get the stored occurrences of a recurring expense dated in [from, to)
+	GetOccurrences(ctx context.Context, recurringID string, from, to time.Time) ([]*RecurringOccurrence, error)
+
+	// Record an occurrence as generated and move the recurring expense on to
+	// nextDate in one transaction. expense is nil for skipped occurrences.
+	Generate(ctx context.Context, occurrence *RecurringOccurrence, expense *Expense, nextDate *time.Time) error
+}
+
+// RecurringExpense represents the repository recurring expense model
+type RecurringExpense struct {
+	ID           string
+	UserID       string
+	Amount       decimal.Decimal
+	Currency     string
+	Description  string
+	MainCategory string
+	SubCategory  string
+	Frequency    string
+	Interval     int
+	DaysOfWeek   []int
+	MonthDay     int
+	EndDate      *time.Time
+	Count        int
+	StartDate    time.Time
+	NextDate     *time.Time
+	Paused       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// RecurringOccurrence represents a stored occurrence: a change made to one
+// date, a generated expense, or both
+type RecurringOccurrence struct {
+	RecurringID string
+	Date        time.Time
+	Skipped     bool
+	Amount      *decimal.Decimal // nil keeps the template amount
+	Description *string          // nil keeps the template description
+	ExpenseID   string
+}
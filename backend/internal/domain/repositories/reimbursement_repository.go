@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReimbursementRepository defines the interface for reimbursement data access
+type ReimbursementRepository interface {
+	// Create a new reimbursement
+	Create(ctx context.Context, reimbursement *Reimbursement) error
+
+	// Get a reimbursement by ID
+	GetByID(ctx context.Context, id string) (*Reimbursement, error)
+
+	// Get every reimbursement received against an expense, oldest first
+	GetByExpense(ctx context.Context, expenseID string) ([]*Reimbursement, error)
+
+	// Get every reimbursement received against the user's expenses dated in [startDate, endDate)
+	GetByExpenseDateRange(ctx context.Context, userID string, startDate, endDate time.Time) ([]*Reimbursement, error)
+
+	// Delete a reimbursement by ID
+	Delete(ctx context.Context, id string) error
+}
+
+// Reimbursement represents the repository reimbursement model
+type Reimbursement struct {
+	ID        string
+	UserID    string
+	ExpenseID string
+	Kind      string
+	Amount    decimal.Decimal
+	Currency  string
+	Date      time.Time
+	Payer     string
+	Note      string
+	CreatedAt time.Time
+}
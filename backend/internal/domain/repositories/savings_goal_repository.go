@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SavingsGoalRepository defines the interface for savings goal data access
+type SavingsGoalRepository interface {
+	// Create a new savings goal
+	Create(ctx context.Context, goal *SavingsGoal) error
+
+	// Get a savings goal by ID
+	GetByID(ctx context.Context, id string) (*SavingsGoal, error)
+
+	// Get every savings goal for a user, ordered by target date
+	GetByUserID(ctx context.Context, userID string) ([]*SavingsGoal, error)
+
+	// Update a savings goal
+	Update(ctx context.Context, goal *SavingsGoal) error
+
+	// Delete a savings goal and its contributions
+	Delete(ctx context.Context, id string) error
+
+	// Create a new contribution
+	CreateContribution(ctx context.Context, contribution *GoalContribution) error
+
+	// Get a contribution by ID
+	GetContributionByID(ctx context.Context, id string) (*GoalContribution, error)
+
+	// Get every contribution to a goal, oldest first
+	GetContributions(ctx context.Context, goalID string) ([]*GoalContribution, error)
+
+	// Get every contribution taken from an expense
+	GetContributionsByExpense(ctx context.Context, expenseID string) ([]*GoalContribution, error)
+
+	// Delete a contribution by ID
+	DeleteContribution(ctx context.Context, id string) error
+}
+
+// SavingsGoal represents the repository savings goal model
+type SavingsGoal struct {
+	ID           string
+	UserID       string
+	Name         string
+	TargetAmount decimal.Decimal
+	Currency     string
+	TargetDate   *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// GoalContribution represents the repository goal contribution model
+type GoalContribution struct {
+	ID        string
+	GoalID    string
+	ExpenseID string
+	Amount    decimal.Decimal
+	Currency  string
+	Date      time.Time
+	Note      string
+	CreatedAt time.Time
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// UserSettingsRepository defines the interface for user settings data access
+type UserSettingsRepository interface {
+	// Get a user's settings, or ErrNotFound if none have been saved
+	Get(ctx context.Context, userID string) (*UserSettings, error)
+
+	// Save a user's settings, replacing any previous ones
+	Save(ctx context.Context, settings *UserSettings) error
+}
+
+// UserSettings represents the repository user settings model
+type UserSettings struct {
+	UserID          string
+	BaseCurrency    string
+	FiscalYearStart int
+	UpdatedAt       time.Time
+}
@@ -5,10 +5,11 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
-	Auth     AuthConfig     `json:"auth"`
-	Firebase FirebaseConfig `json:"firebase"`
+	Server     ServerConfig     `json:"server"`
+	Database   DatabaseConfig   `json:"database"`
+	Auth       AuthConfig       `json:"auth"`
+	Firebase   FirebaseConfig   `json:"firebase"`
+	Encryption EncryptionConfig `json:"encryption"`
 }
 
 type ServerConfig struct {
@@ -36,6 +37,10 @@ type FirebaseConfig struct {
 	ClientEmail string `json:"client_email"`
 }
 
+type EncryptionConfig struct {
+	Key string `json:"key"` // passphrase for secrets stored at rest
+}
+
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
@@ -59,6 +64,9 @@ func Load() *Config {
 			PrivateKey:  getEnv("FIREBASE_PRIVATE_KEY", ""),
 			ClientEmail: getEnv("FIREBASE_CLIENT_EMAIL", ""),
 		},
+		Encryption: EncryptionConfig{
+			Key: getEnv("ENCRYPTION_KEY", ""),
+		},
 	}
 }
 
@@ -67,4 +75,4 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
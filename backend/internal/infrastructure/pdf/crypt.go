@@ -0,0 +1,381 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"crypto/rc4"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+)
+
+// passwordPadding pads passwords to 32 bytes for revisions 2 to 4 of the
+// standard security handler
+var passwordPadding = []byte{
+	0x28, 0xbf, 0x4e, 0x5e, 0x4e, 0x75, 0x8a, 0x41, 0x64, 0x00, 0x4e, 0x56, 0xff, 0xfa, 0x01, 0x08,
+	0x2e, 0x2e, 0x00, 0xb6, 0xd0, 0x68, 0x3e, 0x80, 0x2f, 0x0c, 0xa9, 0xfe, 0x64, 0x53, 0x69, 0x7a,
+}
+
+// cryptMethod is how strings or streams are encrypted
+type cryptMethod int
+
+const (
+	cryptNone  cryptMethod = iota // the Identity crypt filter
+	cryptRC4                      // RC4 with a per-object key
+	cryptAESV2                    // AES-128-CBC with a per-object key
+	cryptAESV3                    // AES-256-CBC with the file key
+)
+
+// securityHandler decrypts the strings and streams of a document protected
+// by the standard security handler, revisions 2 to 6
+type securityHandler struct {
+	key             []byte
+	strings         cryptMethod
+	streams         cryptMethod
+	encryptMetadata bool
+}
+
+// newSecurityHandler checks password against the document's /Encrypt
+// dictionary. Either the user or the owner password opens the document.
+func newSecurityHandler(encrypt Dict, id []byte, password string) (*securityHandler, error) {
+	if encrypt["Filter"] != Name("Standard") {
+		return nil, fmt.Errorf("%w: security handler %v", ErrUnsupportedEncryption, encrypt["Filter"])
+	}
+	v, r := intValue(encrypt["V"]), intValue(encrypt["R"])
+	o, _ := encrypt["O"].(String)
+	u, _ := encrypt["U"].(String)
+
+	h := &securityHandler{strings: cryptRC4, streams: cryptRC4, encryptMetadata: true}
+	if meta, ok := encrypt["EncryptMetadata"].(bool); ok {
+		h.encryptMetadata = meta
+	}
+
+	keyLength := 5
+	switch v {
+	case 1:
+	case 2:
+		keyLength = bitsToBytes(encrypt["Length"], 40)
+	case 4, 5:
+		var err error
+		if h.strings, err = cryptFilter(encrypt, "StrF"); err != nil {
+			return nil, err
+		}
+		if h.streams, err = cryptFilter(encrypt, "StmF"); err != nil {
+			return nil, err
+		}
+		keyLength = 16
+	default:
+		return nil, fmt.Errorf("%w: algorithm version %d", ErrUnsupportedEncryption, v)
+	}
+
+	switch {
+	case r >= 2 && r <= 4:
+		if len(o) < 32 || len(u) < 32 || keyLength < 5 || keyLength > 16 {
+			return nil, fmt.Errorf("%w: malformed encryption dictionary", ErrUnsupportedEncryption)
+		}
+		h.key = h.authenticateRC4([]byte(password), encrypt, id, r, keyLength)
+	case r == 5 || r == 6:
+		oe, _ := encrypt["OE"].(String)
+		ue, _ := encrypt["UE"].(String)
+		if len(o) < 48 || len(u) < 48 || len(oe) != 32 || len(ue) != 32 {
+			return nil, fmt.Errorf("%w: malformed encryption dictionary", ErrUnsupportedEncryption)
+		}
+		h.key = authenticateAES256([]byte(password), []byte(o), []byte(u), []byte(oe), []byte(ue), r)
+	default:
+		return nil, fmt.Errorf("%w: security handler revision %d", ErrUnsupportedEncryption, r)
+	}
+
+	if h.key == nil {
+		if password == "" {
+			return nil, ErrPasswordRequired
+		}
+		return nil, ErrWrongPassword
+	}
+	return h, nil
+}
+
+// cryptFilter looks up the method of the crypt filter named by key (StmF or
+// StrF) in a version 4 or 5 /Encrypt dictionary
+func cryptFilter(encrypt Dict, key Name) (cryptMethod, error) {
+	name, _ := encrypt[key].(Name)
+	if name == "" || name == "Identity" {
+		return cryptNone, nil
+	}
+	filters, _ := encrypt["CF"].(Dict)
+	filter, _ := filters[name].(Dict)
+	if filter == nil {
+		return 0, fmt.Errorf("%w: missing crypt filter %s", ErrUnsupportedEncryption, name)
+	}
+	switch filter["CFM"] {
+	case Name("None"):
+		return cryptNone, nil
+	case Name("V2"):
+		return cryptRC4, nil
+	case Name("AESV2"):
+		return cryptAESV2, nil
+	case Name("AESV3"):
+		return cryptAESV3, nil
+	}
+	return 0, fmt.Errorf("%w: crypt filter method %v", ErrUnsupportedEncryption, filter["CFM"])
+}
+
+// authenticateRC4 returns the file key for password under revisions 2 to 4,
+// trying it first as the user password and then as the owner password. It
+// returns nil when it's neither.
+func (h *securityHandler) authenticateRC4(password []byte, encrypt Dict, id []byte, r, keyLength int) []byte {
+	o := []byte(encrypt["O"].(String))[:32]
+	u := []byte(encrypt["U"].(String))[:32]
+	p := uint32(int32(intValue(encrypt["P"])))
+
+	userKey := func(password []byte) []byte {
+		key := rc4FileKey(password, o, p, id, r, keyLength, h.encryptMetadata)
+		if bytes.Equal(userPasswordCheck(key, id, r), checkedU(u, r)) {
+			return key
+		}
+		return nil
+	}
+	if key := userKey(password); key != nil {
+		return key
+	}
+
+	// The owner password decrypts O back into the user password
+	sum := md5.Sum(padPassword(password))
+	if r >= 3 {
+		for i := 0; i < 50; i++ {
+			sum = md5.Sum(sum[:])
+		}
+	}
+	ownerKey := sum[:keyLength]
+	user := append([]byte(nil), o...)
+	if r == 2 {
+		rc4XOR(ownerKey, user)
+	} else {
+		for i := 19; i >= 0; i-- {
+			rc4XOR(xorKey(ownerKey, byte(i)), user)
+		}
+	}
+	return userKey(user)
+}
+
+// rc4FileKey computes the file key from a user password (algorithm 2)
+func rc4FileKey(password, o []byte, p uint32, id []byte, r, keyLength int, encryptMetadata bool) []byte {
+	digest := md5.New()
+	digest.Write(padPassword(password))
+	digest.Write(o)
+	binary.Write(digest, binary.LittleEndian, p)
+	digest.Write(id)
+	if r >= 4 && !encryptMetadata {
+		digest.Write([]byte{0xff, 0xff, 0xff, 0xff})
+	}
+	sum := digest.Sum(nil)
+	if r >= 3 {
+		for i := 0; i < 50; i++ {
+			next := md5.Sum(sum[:keyLength])
+			sum = next[:]
+		}
+	}
+	return sum[:keyLength]
+}
+
+// userPasswordCheck computes the value the /U entry holds for key
+// (algorithms 4 and 5)
+func userPasswordCheck(key, id []byte, r int) []byte {
+	if r == 2 {
+		check := append([]byte(nil), passwordPadding...)
+		rc4XOR(key, check)
+		return check
+	}
+	digest := md5.New()
+	digest.Write(passwordPadding)
+	digest.Write(id)
+	check := digest.Sum(nil)
+	for i := 0; i < 20; i++ {
+		rc4XOR(xorKey(key, byte(i)), check)
+	}
+	return check
+}
+
+// checkedU returns the part of /U that's compared: all of it for revision
+// 2, and only the first 16 bytes after that since the rest is arbitrary
+func checkedU(u []byte, r int) []byte {
+	if r == 2 {
+		return u
+	}
+	return u[:16]
+}
+
+// padPassword truncates or pads a password to 32 bytes
+func padPassword(password []byte) []byte {
+	padded := make([]byte, 32)
+	n := copy(padded, password)
+	copy(padded[n:], passwordPadding)
+	return padded
+}
+
+// xorKey returns a copy of key with every byte XORed with b
+func xorKey(key []byte, b byte) []byte {
+	out := make([]byte, len(key))
+	for i := range key {
+		out[i] = key[i] ^ b
+	}
+	return out
+}
+
+// rc4XOR encrypts or decrypts data in place
+func rc4XOR(key, data []byte) {
+	c, _ := rc4.NewCipher(key)
+	c.XORKeyStream(data, data)
+}
+
+// authenticateAES256 returns the file key for password under revisions 5
+// and 6, trying it as the user and then the owner password. It returns nil
+// when it's neither.
+func authenticateAES256(password, o, u, oe, ue []byte, r int) []byte {
+	if len(password) > 127 {
+		password = password[:127]
+	}
+	if bytes.Equal(hashAES256(password, u[32:40], nil, r), u[:32]) {
+		return unwrapFileKey(hashAES256(password, u[40:48], nil, r), ue)
+	}
+	if bytes.Equal(hashAES256(password, o[32:40], u[:48], r), o[:32]) {
+		return unwrapFileKey(hashAES256(password, o[40:48], u[:48], r), oe)
+	}
+	return nil
+}
+
+// hashAES256 is the password hash of revision 5 (plain SHA-256) and
+// revision 6 (algorithm 2.B)
+func hashAES256(password, salt, udata []byte, r int) []byte {
+	digest := sha256.New()
+	digest.Write(password)
+	digest.Write(salt)
+	digest.Write(udata)
+	k := digest.Sum(nil)
+	if r == 5 {
+		return k
+	}
+
+	var e []byte
+	for round := 0; round < 64 || int(e[len(e)-1]) > round-32; round++ {
+		block := make([]byte, 0, len(password)+len(k)+len(udata))
+		block = append(append(append(block, password...), k...), udata...)
+		k1 := bytes.Repeat(block, 64)
+
+		c, _ := aes.NewCipher(k[:16])
+		e = make([]byte, len(k1))
+		cipher.NewCBCEncrypter(c, k[16:32]).CryptBlocks(e, k1)
+
+		var next hash.Hash
+		switch sumMod3(e[:16]) {
+		case 0:
+			next = sha256.New()
+		case 1:
+			next = sha512.New384()
+		default:
+			next = sha512.New()
+		}
+		next.Write(e)
+		k = next.Sum(nil)
+	}
+	return k[:32]
+}
+
+// sumMod3 is the big-endian number in b modulo 3. Since 256 is 1 modulo 3,
+// that's the sum of its bytes modulo 3.
+func sumMod3(b []byte) int {
+	sum := 0
+	for _, c := range b {
+		sum += int(c)
+	}
+	return sum % 3
+}
+
+// unwrapFileKey decrypts /UE or /OE, AES-256 encrypted without padding under a zero IV
+func unwrapFileKey(key, wrapped []byte) []byte {
+	c, _ := aes.NewCipher(key)
+	fileKey := make([]byte, len(wrapped))
+	cipher.NewCBCDecrypter(c, make([]byte, aes.BlockSize)).CryptBlocks(fileKey, wrapped)
+	return fileKey
+}
+
+// decryptObject decrypts every string and stream in obj, which is indirect
+// object num, replacing them in place. It returns the decrypted object.
+func (h *securityHandler) decryptObject(obj Object, num, gen int) Object {
+	switch v := obj.(type) {
+	case String:
+		return String(h.decrypt([]byte(v), h.strings, num, gen))
+	case Array:
+		for i := range v {
+			v[i] = h.decryptObject(v[i], num, gen)
+		}
+	case Dict:
+		for key, value := range v {
+			v[key] = h.decryptObject(value, num, gen)
+		}
+	case *Stream:
+		// Cross-reference streams are never encrypted, and metadata only when asked
+		if v.Dict["Type"] == Name("XRef") || (v.Dict["Type"] == Name("Metadata") && !h.encryptMetadata) {
+			return v
+		}
+		h.decryptObject(v.Dict, num, gen)
+		v.Data = h.decrypt(v.Data, h.streams, num, gen)
+	}
+	return obj
+}
+
+// decrypt decrypts the data of object num with method. Data that can't be
+// decrypted, such as AES data cut short, comes back empty.
+func (h *securityHandler) decrypt(data []byte, method cryptMethod, num, gen int) []byte {
+	switch method {
+	case cryptRC4:
+		out := append([]byte(nil), data...)
+		rc4XOR(h.objectKey(num, gen, false), out)
+		return out
+	case cryptAESV2:
+		return decryptAES(h.objectKey(num, gen, true), data)
+	case cryptAESV3:
+		return decryptAES(h.key, data)
+	}
+	return data
+}
+
+// objectKey derives the key for one object from the file key (algorithm 1)
+func (h *securityHandler) objectKey(num, gen int, salted bool) []byte {
+	digest := md5.New()
+	digest.Write(h.key)
+	digest.Write([]byte{byte(num), byte(num >> 8), byte(num >> 16), byte(gen), byte(gen >> 8)})
+	if salted {
+		digest.Write([]byte("sAlT"))
+	}
+	return digest.Sum(nil)[:min(len(h.key)+5, 16)]
+}
+
+// decryptAES decrypts AES-CBC data that starts with its IV and ends with
+// PKCS#5 padding
+func decryptAES(key, data []byte) []byte {
+	if len(data) < 2*aes.BlockSize || len(data)%aes.BlockSize != 0 {
+		return nil
+	}
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil
+	}
+	out := make([]byte, len(data)-aes.BlockSize)
+	cipher.NewCBCDecrypter(c, data[:aes.BlockSize]).CryptBlocks(out, data[aes.BlockSize:])
+	if pad := int(out[len(out)-1]); pad >= 1 && pad <= aes.BlockSize {
+		out = out[:len(out)-pad]
+	}
+	return out
+}
+
+// bitsToBytes reads a key length given in bits, falling back to fallback bits
+func bitsToBytes(obj Object, fallback int) int {
+	if bits := intValue(obj); bits > 0 {
+		return bits / 8
+	}
+	return fallback / 8
+}
@@ -0,0 +1,145 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var testID = []byte("0123456789abcdef")
+
+// standardRC4 computes the /O and /U entries and the file key of a
+// revision 2 to 4 document (algorithms 3, 2, 4 and 5)
+func standardRC4(user, owner string, p int32, r, keyLength int) (o, u, key []byte) {
+	sum := md5.Sum(padPassword([]byte(owner)))
+	rounds := 1
+	if r >= 3 {
+		for i := 0; i < 50; i++ {
+			sum = md5.Sum(sum[:])
+		}
+		rounds = 20
+	}
+	o = padPassword([]byte(user))
+	for i := 0; i < rounds; i++ {
+		rc4XOR(xorKey(sum[:keyLength], byte(i)), o)
+	}
+
+	key = rc4FileKey([]byte(user), o, uint32(p), testID, r, keyLength, true)
+	u = userPasswordCheck(key, testID, r)
+	if r >= 3 {
+		u = append(u, make([]byte, 16)...)
+	}
+	return o, u, key
+}
+
+// standardAES256 computes the /O, /U, /OE and /UE entries of a revision 6
+// document for a fixed file key
+func standardAES256(user, owner string) (o, u, oe, ue, key []byte) {
+	key = bytes.Repeat([]byte{0x42}, 32)
+	u = append(hashAES256([]byte(user), []byte("uvalidat"), nil, 6), "uvalidatukeysalt"...)
+	ue = wrapFileKey(hashAES256([]byte(user), []byte("ukeysalt"), nil, 6), key)
+	o = append(hashAES256([]byte(owner), []byte("ovalidat"), u, 6), "ovalidatokeysalt"...)
+	oe = wrapFileKey(hashAES256([]byte(owner), []byte("okeysalt"), u, 6), key)
+	return o, u, oe, ue, key
+}
+
+func wrapFileKey(key, fileKey []byte) []byte {
+	c, _ := aes.NewCipher(key)
+	wrapped := make([]byte, len(fileKey))
+	cipher.NewCBCEncrypter(c, make([]byte, aes.BlockSize)).CryptBlocks(wrapped, fileKey)
+	return wrapped
+}
+
+func encryptAES(key, data []byte) []byte {
+	pad := aes.BlockSize - len(data)%aes.BlockSize
+	data = append(append([]byte(nil), data...), bytes.Repeat([]byte{byte(pad)}, pad)...)
+	iv := bytes.Repeat([]byte{7}, aes.BlockSize)
+	c, _ := aes.NewCipher(key)
+	out := make([]byte, len(data))
+	cipher.NewCBCEncrypter(c, iv).CryptBlocks(out, data)
+	return append(iv, out...)
+}
+
+// encryptedPage builds a one-page document whose content stream, object 5,
+// is encrypted with encrypt and whose /Encrypt dictionary is encryptDict
+func encryptedPage(encryptDict string, encrypt func(num int, data []byte) []byte) []byte {
+	content := []byte("BT /F1 10 Tf 50 700 Td (Protected) Tj ET")
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>")
+	b.add(helvetica)
+	b.addStream("", encrypt(5, content))
+	b.add(encryptDict)
+	return b.bytes(fmt.Sprintf("/Root 1 0 R /Encrypt 6 0 R /ID [<%x> <%x>]", testID, testID))
+}
+
+// rc4Page builds an RC4 or AES-128 encrypted page
+func rc4Page(user, owner string, v, r, keyLength int, filters string) []byte {
+	o, u, key := standardRC4(user, owner, -4, r, keyLength)
+	handler := &securityHandler{key: key}
+	dict := fmt.Sprintf("<< /Filter /Standard /V %d /R %d /Length %d /P -4 /O <%x> /U <%x> %s >>", v, r, keyLength*8, o, u, filters)
+	return encryptedPage(dict, func(num int, data []byte) []byte {
+		if v == 4 {
+			return encryptAES(handler.objectKey(num, 0, true), data)
+		}
+		out := append([]byte(nil), data...)
+		rc4XOR(handler.objectKey(num, 0, false), out)
+		return out
+	})
+}
+
+func aes256Page(user, owner string) []byte {
+	o, u, oe, ue, key := standardAES256(user, owner)
+	dict := fmt.Sprintf("<< /Filter /Standard /V 5 /R 6 /Length 256 /P -4 /O <%x> /U <%x> /OE <%x> /UE <%x> "+
+		"/CF << /StdCF << /CFM /AESV3 /Length 32 >> >> /StmF /StdCF /StrF /StdCF >>", o, u, oe, ue)
+	return encryptedPage(dict, func(num int, data []byte) []byte {
+		return encryptAES(key, data)
+	})
+}
+
+func TestOpensEncryptedDocuments(t *testing.T) {
+	aesV2 := "/CF << /StdCF << /CFM /AESV2 /Length 16 >> >> /StmF /StdCF /StrF /StdCF"
+	documents := map[string]func(user, owner string) []byte{
+		"RC4 40-bit":  func(user, owner string) []byte { return rc4Page(user, owner, 1, 2, 5, "") },
+		"RC4 128-bit": func(user, owner string) []byte { return rc4Page(user, owner, 2, 3, 16, "") },
+		"AES-128":     func(user, owner string) []byte { return rc4Page(user, owner, 4, 4, 16, aesV2) },
+		"AES-256":     aes256Page,
+	}
+
+	for name, build := range documents {
+		t.Run(name, func(t *testing.T) {
+			data := build("1234", "owner-secret")
+			for _, password := range []string{"1234", "owner-secret"} {
+				lines := passwordPageLines(t, data, password)
+				if len(lines) != 1 || lines[0] != "Protected" {
+					t.Errorf("password %q: expected Protected, got %q", password, lines)
+				}
+			}
+			if _, err := OpenWithPassword(data, "4321"); !errors.Is(err, ErrWrongPassword) {
+				t.Errorf("expected ErrWrongPassword, got %v", err)
+			}
+			if _, err := Open(data); !errors.Is(err, ErrPasswordRequired) {
+				t.Errorf("expected ErrPasswordRequired, got %v", err)
+			}
+
+			// Documents with an empty user password open without one
+			if lines := pageLines(t, build("", "owner-secret")); len(lines) != 1 || lines[0] != "Protected" {
+				t.Errorf("expected Protected without a password, got %q", lines)
+			}
+		})
+	}
+}
+
+func TestOpenRejectsUnsupportedEncryption(t *testing.T) {
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Filter /Adobe.PubSec /V 4 /R 4 >>")
+	if _, err := Open(b.bytes("/Root 1 0 R /Encrypt 2 0 R")); !errors.Is(err, ErrUnsupportedEncryption) {
+		t.Errorf("expected ErrUnsupportedEncryption, got %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+// Package pdf extracts positioned text from PDF documents. It reads just
+// enough of the format to lay out the text of generated documents such as
+// bank statements; it doesn't render anything.
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	// ErrNotPDF is returned for data that doesn't look like a PDF at all
+	ErrNotPDF = errors.New("pdf: not a PDF document")
+
+	// ErrPasswordRequired is returned for documents that need a password to open
+	ErrPasswordRequired = errors.New("pdf: document is password protected")
+
+	// ErrWrongPassword is returned when the password given doesn't open the document
+	ErrWrongPassword = errors.New("pdf: incorrect password")
+
+	// ErrUnsupportedEncryption is returned for documents encrypted with
+	// anything but the standard password security handler
+	ErrUnsupportedEncryption = errors.New("pdf: unsupported encryption")
+)
+
+// objectHeader matches the "num gen obj" line that starts an indirect object
+var objectHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// Document is a parsed PDF file
+type Document struct {
+	objects map[int]Object
+	trailer Dict
+}
+
+// Open parses a PDF file. Objects are found by scanning the file rather than
+// trusting the cross-reference table, so files with broken offsets still open;
+// objects packed into object streams are unpacked too. Encrypted files open
+// only when their user password is empty.
+func Open(data []byte) (*Document, error) {
+	return OpenWithPassword(data, "")
+}
+
+// OpenWithPassword parses a PDF file like Open, decrypting it with password
+// when it's encrypted. Either the user or the owner password works.
+func OpenWithPassword(data []byte, password string) (*Document, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data[:min(1024, len(data))], "\x00\r\n\t "), []byte("%PDF-")) {
+		return nil, ErrNotPDF
+	}
+
+	d := &Document{objects: make(map[int]Object)}
+	generations := make(map[int]int)
+	for pos := 0; pos < len(data); {
+		loc := objectHeader.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		start := pos + loc[1]
+		num := atoi(data[pos+loc[2] : pos+loc[3]])
+		l := &lexer{data: data, pos: start}
+		obj, err := l.object()
+		if err != nil {
+			pos = start
+			continue
+		}
+		// Later definitions come from incremental updates and replace earlier ones
+		d.objects[num] = obj
+		generations[num] = atoi(data[pos+loc[4] : pos+loc[5]])
+		pos = l.pos
+	}
+	if len(d.objects) == 0 {
+		return nil, fmt.Errorf("%w: no objects found", ErrNotPDF)
+	}
+
+	d.trailer = d.findTrailer(data)
+	if d.trailer == nil {
+		return nil, fmt.Errorf("%w: no trailer found", ErrNotPDF)
+	}
+	if encrypt, ok := d.trailer["Encrypt"]; ok {
+		if err := d.decrypt(encrypt, generations, password); err != nil {
+			return nil, err
+		}
+	}
+	d.unpackObjectStreams()
+	return d, nil
+}
+
+// decrypt opens the document's /Encrypt dictionary with password and
+// decrypts every object stored directly in the file. Objects inside object
+// streams are decrypted along with their stream.
+func (d *Document) decrypt(encrypt Object, generations map[int]int, password string) error {
+	dict := d.resolveDict(encrypt)
+	if dict == nil {
+		return fmt.Errorf("%w: missing encryption dictionary", ErrUnsupportedEncryption)
+	}
+	var id []byte
+	if ids := d.resolveArray(d.trailer["ID"]); len(ids) > 0 {
+		first, _ := ids[0].(String)
+		id = []byte(first)
+	}
+
+	handler, err := newSecurityHandler(dict, id, password)
+	if err != nil {
+		return err
+	}
+	ref, _ := encrypt.(Ref)
+	for num, obj := range d.objects {
+		if num == ref.Num && ref.Num != 0 {
+			continue
+		}
+		d.objects[num] = handler.decryptObject(obj, num, generations[num])
+	}
+	return nil
+}
+
+// findTrailer returns the last trailer dictionary, or the dictionary of the
+// last cross-reference stream for files that have no trailer keyword
+func (d *Document) findTrailer(data []byte) Dict {
+	if i := bytes.LastIndex(data, []byte("trailer")); i >= 0 {
+		l := &lexer{data: data, pos: i + len("trailer")}
+		if obj, err := l.object(); err == nil {
+			if dict, ok := obj.(Dict); ok && dict["Root"] != nil {
+				return dict
+			}
+		}
+	}
+
+	var trailer Dict
+	for _, obj := range d.objects {
+		if s, ok := obj.(*Stream); ok && s.Dict["Type"] == Name("XRef") && s.Dict["Root"] != nil {
+			if trailer == nil || intValue(s.Dict["Size"]) > intValue(trailer["Size"]) {
+				trailer = s.Dict
+			}
+		}
+	}
+	return trailer
+}
+
+// unpackObjectStreams adds the objects stored inside object streams. Objects
+// already defined directly in the file take precedence.
+func (d *Document) unpackObjectStreams() {
+	for _, obj := range d.objects {
+		s, ok := obj.(*Stream)
+		if !ok || s.Dict["Type"] != Name("ObjStm") {
+			continue
+		}
+		data, err := d.decodeStream(s)
+		if err != nil {
+			continue
+		}
+
+		n, first := intValue(s.Dict["N"]), intValue(s.Dict["First"])
+		header := &lexer{data: data}
+		for i := 0; i < n; i++ {
+			numTok, _ := header.token()
+			offTok, _ := header.token()
+			num, ok1 := numTok.(int)
+			off, ok2 := offTok.(int)
+			if !ok1 || !ok2 {
+				break
+			}
+			if _, exists := d.objects[num]; exists || first+off >= len(data) {
+				continue
+			}
+			l := &lexer{data: data, pos: first + off}
+			if value, err := l.object(); err == nil {
+				d.objects[num] = value
+			}
+		}
+	}
+}
+
+// resolve follows indirect references until it reaches a direct object
+func (d *Document) resolve(obj Object) Object {
+	for i := 0; i < 32; i++ {
+		ref, ok := obj.(Ref)
+		if !ok {
+			return obj
+		}
+		obj = d.objects[ref.Num]
+	}
+	return nil
+}
+
+// resolveDict resolves obj, returning it as a dictionary or nil. A stream
+// stands in for its dictionary.
+func (d *Document) resolveDict(obj Object) Dict {
+	switch v := d.resolve(obj).(type) {
+	case Dict:
+		return v
+	case *Stream:
+		return v.Dict
+	}
+	return nil
+}
+
+// resolveArray resolves obj, returning it as an array or nil
+func (d *Document) resolveArray(obj Object) Array {
+	arr, _ := d.resolve(obj).(Array)
+	return arr
+}
+
+// number resolves obj as a number, accepting integers and reals
+func (d *Document) number(obj Object) float64 {
+	switch v := d.resolve(obj).(type) {
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+func intValue(obj Object) int {
+	switch v := obj.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+func atoi(b []byte) int {
+	n := 0
+	for _, c := range b {
+		n = n*10 + int(c-'0')
+	}
+	return n
+}
@@ -0,0 +1,226 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// maxDecodedLength limits how large a single stream may decompress to
+	maxDecodedLength = 64 << 20
+
+	// maxColors and maxBitsPerComponent bound predictor parameters
+	maxColors           = 32
+	maxBitsPerComponent = 16
+)
+
+// decodeStream applies a stream's filters in order, returning the decoded data
+func (d *Document) decodeStream(s *Stream) ([]byte, error) {
+	data := s.Data
+	filters := d.resolveArray(s.Dict["Filter"])
+	if name, ok := d.resolve(s.Dict["Filter"]).(Name); ok {
+		filters = Array{name}
+	}
+	params := d.resolveArray(s.Dict["DecodeParms"])
+	if dict, ok := d.resolve(s.Dict["DecodeParms"]).(Dict); ok {
+		params = Array{dict}
+	}
+
+	for i, f := range filters {
+		var parms Dict
+		if i < len(params) {
+			parms, _ = d.resolve(params[i]).(Dict)
+		}
+		var err error
+		switch name, _ := d.resolve(f).(Name); name {
+		case "FlateDecode", "Fl":
+			data, err = inflate(data)
+			if err == nil {
+				data, err = unpredict(data, parms)
+			}
+		case "ASCIIHexDecode", "AHx":
+			data, err = asciiHexDecode(data)
+		case "ASCII85Decode", "A85":
+			data, err = ascii85Decode(data)
+		default:
+			return nil, fmt.Errorf("unsupported stream filter %s", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// inflate decompresses zlib data, falling back to a raw deflate stream and
+// keeping whatever was decoded from truncated streams
+func inflate(data []byte) ([]byte, error) {
+	if r, err := zlib.NewReader(bytes.NewReader(data)); err == nil {
+		out, err := readDecoded(r)
+		if errors.Is(err, errStreamTooLarge) {
+			return nil, err
+		}
+		if err == nil || len(out) > 0 {
+			return out, nil
+		}
+	}
+	out, err := readDecoded(flate.NewReader(bytes.NewReader(data)))
+	if errors.Is(err, errStreamTooLarge) {
+		return nil, err
+	}
+	if err != nil && len(out) == 0 {
+		return nil, fmt.Errorf("failed to inflate stream: %w", err)
+	}
+	return out, nil
+}
+
+var errStreamTooLarge = fmt.Errorf("stream decodes to more than %d bytes", maxDecodedLength)
+
+// readDecoded reads a decompressing reader, giving up once the output passes
+// maxDecodedLength so a small compressed stream can't exhaust memory
+func readDecoded(r io.Reader) ([]byte, error) {
+	out, err := io.ReadAll(io.LimitReader(r, maxDecodedLength+1))
+	if len(out) > maxDecodedLength {
+		return nil, errStreamTooLarge
+	}
+	return out, err
+}
+
+// unpredict reverses the PNG predictors used by cross-reference streams
+func unpredict(data []byte, parms Dict) ([]byte, error) {
+	predictor, _ := parms["Predictor"].(int)
+	if predictor < 10 {
+		return data, nil
+	}
+	columns, _ := parms["Columns"].(int)
+	if columns <= 0 {
+		columns = 1
+	}
+	colors, _ := parms["Colors"].(int)
+	if colors <= 0 {
+		colors = 1
+	}
+	bits, _ := parms["BitsPerComponent"].(int)
+	if bits <= 0 {
+		bits = 8
+	}
+	// A row can't be wider than the data holds, which also keeps rowLen from overflowing
+	if colors > maxColors || bits > maxBitsPerComponent || columns > len(data)*8 {
+		return nil, fmt.Errorf("invalid predictor parameters: %d columns of %d colors at %d bits", columns, colors, bits)
+	}
+	bpp := (colors*bits + 7) / 8
+	rowLen := (columns*colors*bits + 7) / 8
+
+	out := make([]byte, 0, len(data))
+	prev := make([]byte, rowLen)
+	for len(data) >= rowLen+1 {
+		kind, row := data[0], append([]byte(nil), data[1:rowLen+1]...)
+		data = data[rowLen+1:]
+		for i := range row {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = row[i-bpp], prev[i-bpp]
+			}
+			up := prev[i]
+			switch kind {
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upLeft)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func asciiHexDecode(data []byte) ([]byte, error) {
+	var digits []byte
+	for _, c := range data {
+		if c == '>' {
+			break
+		}
+		if !isWhitespace(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	if _, err := hex.Decode(out, digits); err != nil {
+		return nil, fmt.Errorf("invalid ASCIIHex stream: %w", err)
+	}
+	return out, nil
+}
+
+func ascii85Decode(data []byte) ([]byte, error) {
+	var out []byte
+	var group [5]byte
+	n := 0
+	flush := func(count int) {
+		var v uint32
+		for i := 0; i < 5; i++ {
+			v = v*85 + uint32(group[i])
+		}
+		b := []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+		out = append(out, b[:count]...)
+	}
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case isWhitespace(c):
+			continue
+		case c == '~':
+			i = len(data)
+			continue
+		case c == 'z' && n == 0:
+			out = append(out, 0, 0, 0, 0)
+			continue
+		case c < '!' || c > 'u':
+			return nil, fmt.Errorf("invalid ASCII85 character %q", c)
+		}
+		group[n] = c - '!'
+		n++
+		if n == 5 {
+			flush(4)
+			n = 0
+		}
+	}
+	if n > 0 {
+		for i := n; i < 5; i++ {
+			group[i] = 84
+		}
+		flush(n - 1)
+	}
+	return out, nil
+}
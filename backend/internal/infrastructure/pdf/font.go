@@ -0,0 +1,305 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// defaultGlyphWidth is the width, in thousandths of an em, assumed for glyphs
+// whose font doesn't say
+const defaultGlyphWidth = 500
+
+// glyph is one character code shown by a text operator
+type glyph struct {
+	text  string
+	width float64 // in thousandths of an em
+	space bool    // single-byte code 32, which word spacing applies to
+}
+
+// font maps a font's character codes to text and widths
+type font struct {
+	twoByte      bool
+	toUnicode    map[uint32]string
+	encoding     map[byte]rune
+	firstChar    int
+	widths       []float64
+	cidWidths    map[int]float64
+	defaultWidth float64
+}
+
+// loadFont reads the parts of a font dictionary needed to decode text
+func (d *Document) loadFont(dict Dict) *font {
+	f := &font{defaultWidth: defaultGlyphWidth}
+	if dict == nil {
+		return f
+	}
+
+	if dict["Subtype"] == Name("Type0") {
+		f.twoByte = true
+		f.defaultWidth = 1000
+		if descendants := d.resolveArray(dict["DescendantFonts"]); len(descendants) > 0 {
+			cid := d.resolveDict(descendants[0])
+			if dw, ok := d.resolve(cid["DW"]).(int); ok {
+				f.defaultWidth = float64(dw)
+			}
+			f.cidWidths = d.cidWidths(d.resolveArray(cid["W"]))
+		}
+	} else {
+		f.firstChar = intValue(d.resolve(dict["FirstChar"]))
+		for _, w := range d.resolveArray(dict["Widths"]) {
+			f.widths = append(f.widths, d.number(w))
+		}
+		f.encoding = d.simpleEncoding(dict["Encoding"])
+	}
+
+	if s, ok := d.resolve(dict["ToUnicode"]).(*Stream); ok {
+		if data, err := d.decodeStream(s); err == nil {
+			f.toUnicode, f.twoByte = parseCMap(data, f.twoByte)
+		}
+	}
+	return f
+}
+
+// cidWidths reads a CIDFont's W array, which mixes "first [w1 w2 ...]" and
+// "first last w" entries
+func (d *Document) cidWidths(w Array) map[int]float64 {
+	widths := make(map[int]float64)
+	for i := 0; i+1 < len(w); {
+		first := intValue(d.resolve(w[i]))
+		if list, ok := d.resolve(w[i+1]).(Array); ok {
+			for j, width := range list {
+				widths[first+j] = d.number(width)
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(w) {
+			break
+		}
+		last, width := intValue(d.resolve(w[i+1])), d.number(w[i+2])
+		for c := first; c <= last && c-first < 65536; c++ {
+			widths[c] = width
+		}
+		i += 3
+	}
+	return widths
+}
+
+// simpleEncoding builds the byte-to-rune map of a single-byte font from its
+// base encoding and /Differences
+func (d *Document) simpleEncoding(obj Object) map[byte]rune {
+	encoding := make(map[byte]rune, 256)
+	for i := 0; i < 256; i++ {
+		encoding[byte(i)] = rune(i)
+	}
+	for code, r := range winAnsiExtras {
+		encoding[code] = r
+	}
+
+	dict := d.resolveDict(obj)
+	if dict == nil {
+		return encoding
+	}
+	code := 0
+	for _, entry := range d.resolveArray(dict["Differences"]) {
+		switch v := d.resolve(entry).(type) {
+		case int:
+			code = v
+		case Name:
+			if r, ok := glyphRune(string(v)); ok && code >= 0 && code < 256 {
+				encoding[byte(code)] = r
+			}
+			code++
+		}
+	}
+	return encoding
+}
+
+// decode splits a shown string into glyphs
+func (f *font) decode(s String) []glyph {
+	var glyphs []glyph
+	step := 1
+	if f.twoByte {
+		step = 2
+	}
+	for i := 0; i+step <= len(s); i += step {
+		code := uint32(s[i])
+		if step == 2 {
+			code = code<<8 | uint32(s[i+1])
+		}
+		g := glyph{width: f.width(int(code)), space: step == 1 && code == 32}
+		if text, ok := f.toUnicode[code]; ok {
+			g.text = text
+		} else if step == 1 {
+			if r, ok := f.encoding[byte(code)]; ok {
+				g.text = string(r)
+			} else {
+				g.text = string(rune(code))
+			}
+		}
+		glyphs = append(glyphs, g)
+	}
+	return glyphs
+}
+
+func (f *font) width(code int) float64 {
+	if f.cidWidths != nil {
+		if w, ok := f.cidWidths[code]; ok {
+			return w
+		}
+		return f.defaultWidth
+	}
+	if i := code - f.firstChar; i >= 0 && i < len(f.widths) && f.widths[i] > 0 {
+		return f.widths[i]
+	}
+	return f.defaultWidth
+}
+
+// parseCMap reads the bfchar and bfrange mappings of a ToUnicode CMap. The
+// code space decides whether codes are one or two bytes; twoByte is kept when
+// the CMap doesn't declare one.
+func parseCMap(data []byte, twoByte bool) (map[uint32]string, bool) {
+	mapping := make(map[uint32]string)
+	l := &lexer{data: data}
+	var operands []Object
+	for {
+		tok, err := l.token()
+		if err != nil {
+			continue
+		}
+		if tok == nil {
+			return mapping, twoByte
+		}
+		kw, ok := tok.(Keyword)
+		if !ok {
+			operands = append(operands, tok)
+			continue
+		}
+		switch kw {
+		case "begincodespacerange", "beginbfchar", "beginbfrange":
+			operands = operands[:0]
+		case "endcodespacerange":
+			if len(operands) > 0 {
+				if lo, ok := operands[0].(String); ok {
+					twoByte = len(lo) == 2
+				}
+			}
+			operands = operands[:0]
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].(String)
+				dst, ok2 := operands[i+1].(String)
+				if ok1 && ok2 {
+					mapping[codeOf(src)] = utf16Text(dst)
+				}
+			}
+			operands = operands[:0]
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].(String)
+				hi, ok2 := operands[i+1].(String)
+				if !ok1 || !ok2 {
+					continue
+				}
+				mapBFRange(mapping, codeOf(lo), codeOf(hi), operands[i+2])
+			}
+			operands = operands[:0]
+		case arrayStart:
+			arr, err := l.array()
+			if err == nil {
+				operands = append(operands, arr)
+			}
+		default:
+			operands = operands[:0]
+		}
+	}
+}
+
+// mapBFRange maps lo..hi either to consecutive text starting at a string or
+// to the strings of an array
+func mapBFRange(mapping map[uint32]string, lo, hi uint32, dst Object) {
+	if hi < lo || hi-lo > 65535 {
+		return
+	}
+	switch v := dst.(type) {
+	case String:
+		units := utf16Units(v)
+		if len(units) == 0 {
+			return
+		}
+		for code := lo; code <= hi; code++ {
+			shifted := append([]uint16(nil), units...)
+			shifted[len(shifted)-1] += uint16(code - lo)
+			mapping[code] = string(utf16.Decode(shifted))
+		}
+	case Array:
+		for i, item := range v {
+			if s, ok := item.(String); ok && lo+uint32(i) <= hi {
+				mapping[lo+uint32(i)] = utf16Text(s)
+			}
+		}
+	}
+}
+
+func codeOf(s String) uint32 {
+	var code uint32
+	for i := 0; i < len(s); i++ {
+		code = code<<8 | uint32(s[i])
+	}
+	return code
+}
+
+func utf16Units(s String) []uint16 {
+	units := make([]uint16, 0, len(s)/2)
+	for i := 0; i+1 < len(s); i += 2 {
+		units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+	}
+	if len(s)%2 == 1 {
+		units = append(units, uint16(s[len(s)-1]))
+	}
+	return units
+}
+
+func utf16Text(s String) string {
+	return string(utf16.Decode(utf16Units(s)))
+}
+
+// winAnsiExtras are the WinAnsiEncoding codes that differ from Latin-1
+var winAnsiExtras = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡', 0x89: '‰',
+	0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž', 0x91: '‘', 0x92: '’', 0x93: '“',
+	0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—', 0x99: '™', 0x9A: 'š', 0x9B: '›',
+	0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+// glyphNames maps the glyph names common in /Differences arrays that aren't
+// single letters or uniXXXX names
+var glyphNames = map[string]rune{
+	"space": ' ', "exclam": '!', "quotedbl": '"', "numbersign": '#', "dollar": '$',
+	"percent": '%', "ampersand": '&', "quotesingle": '\'', "quoteright": '’',
+	"parenleft": '(', "parenright": ')', "asterisk": '*', "plus": '+', "comma": ',',
+	"hyphen": '-', "minus": '-', "period": '.', "slash": '/', "zero": '0', "one": '1',
+	"two": '2', "three": '3', "four": '4', "five": '5', "six": '6', "seven": '7',
+	"eight": '8', "nine": '9', "colon": ':', "semicolon": ';', "less": '<',
+	"equal": '=', "greater": '>', "question": '?', "at": '@', "bracketleft": '[',
+	"backslash": '\\', "bracketright": ']', "underscore": '_', "braceleft": '{',
+	"bar": '|', "braceright": '}', "endash": '–', "emdash": '—', "bullet": '•',
+	"rupee": '₹', "Euro": '€', "sterling": '£',
+}
+
+// glyphRune resolves a glyph name to the character it draws
+func glyphRune(name string) (rune, bool) {
+	if r, ok := glyphNames[name]; ok {
+		return r, true
+	}
+	if len(name) == 1 {
+		return rune(name[0]), true
+	}
+	if strings.HasPrefix(name, "uni") && len(name) == 7 {
+		if v, err := strconv.ParseUint(name[3:], 16, 32); err == nil {
+			return rune(v), true
+		}
+	}
+	return 0, false
+}
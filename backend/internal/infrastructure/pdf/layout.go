@@ -0,0 +1,113 @@
+package pdf
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// lineTolerance is how far apart, as a fraction of the font size, two
+	// baselines may be and still belong to the same line
+	lineTolerance = 0.4
+
+	// wordGap is the gap, as a fraction of the font size, above which two
+	// spans are separate words
+	wordGap = 0.15
+
+	// cellGap is the gap, as a fraction of the font size, above which two
+	// spans belong to separate columns
+	cellGap = 1.0
+)
+
+// Cell is text on a line with no column-sized gaps in it, such as one table cell
+type Cell struct {
+	X    float64
+	EndX float64
+	Text string
+}
+
+// Line is the text on one baseline, split into cells from left to right
+type Line struct {
+	Y     float64
+	Cells []Cell
+}
+
+// Text joins a line's cells, separating them by two spaces so columns stay
+// apart from the single spaces between words
+func (l Line) Text() string {
+	texts := make([]string, len(l.Cells))
+	for i, cell := range l.Cells {
+		texts[i] = cell.Text
+	}
+	return strings.Join(texts, "  ")
+}
+
+// Lines lays out a page's spans as lines, top to bottom
+func (p *Page) Lines() []Line {
+	spans := append([]Span(nil), p.Spans...)
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Y > spans[j].Y
+	})
+
+	var groups [][]Span
+	var baseline, size float64
+	for _, span := range spans {
+		n := len(groups)
+		if n > 0 && math.Abs(span.Y-baseline) <= math.Max(size, span.Size)*lineTolerance {
+			groups[n-1] = append(groups[n-1], span)
+			size = math.Max(size, span.Size)
+			continue
+		}
+		groups = append(groups, []Span{span})
+		baseline, size = span.Y, span.Size
+	}
+
+	lines := make([]Line, 0, len(groups))
+	for _, group := range groups {
+		lines = append(lines, layoutLine(group))
+	}
+	return lines
+}
+
+// Text lays out a page's text, one line per row
+func (p *Page) Text() string {
+	lines := p.Lines()
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		texts[i] = line.Text()
+	}
+	return strings.Join(texts, "\n")
+}
+
+// layoutLine orders one line's spans left to right, joining those close
+// together into cells
+func layoutLine(spans []Span) Line {
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].X < spans[j].X
+	})
+
+	line := Line{Y: spans[0].Y}
+	var text strings.Builder
+	cell := Cell{X: spans[0].X}
+	for i, span := range spans {
+		if i > 0 {
+			em := math.Max(span.Size, 1)
+			gap := span.X - cell.EndX
+			switch {
+			case gap > em*cellGap:
+				cell.Text = strings.TrimSpace(text.String())
+				line.Cells = append(line.Cells, cell)
+				text.Reset()
+				cell = Cell{X: span.X}
+			case gap > em*wordGap && !strings.HasSuffix(text.String(), " ") && !strings.HasPrefix(span.Text, " "):
+				text.WriteByte(' ')
+			}
+		}
+		text.WriteString(span.Text)
+		cell.EndX = math.Max(cell.EndX, span.X+span.Width)
+	}
+	cell.Text = strings.TrimSpace(text.String())
+	line.Cells = append(line.Cells, cell)
+	return line
+}
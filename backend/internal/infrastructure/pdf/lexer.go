@@ -0,0 +1,413 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// Object is any PDF object: nil, bool, int, float64, String, Name, Keyword,
+// Array, Dict, Ref or *Stream
+type Object interface{}
+
+// Name is a PDF name such as /Type, without the slash
+type Name string
+
+// String is a literal or hexadecimal PDF string, as raw bytes
+type String string
+
+// Keyword is a bare word such as obj, R or a content stream operator
+type Keyword string
+
+// Array is a PDF array
+type Array []Object
+
+// Dict is a PDF dictionary
+type Dict map[Name]Object
+
+// Ref is an indirect reference to an object
+type Ref struct {
+	Num int
+	Gen int
+}
+
+// Stream is a dictionary followed by a block of (usually compressed) data
+type Stream struct {
+	Dict Dict
+	Data []byte
+}
+
+// delimiter tokens, returned as Keywords by the lexer
+const (
+	arrayStart Keyword = "["
+	arrayEnd   Keyword = "]"
+	dictStart  Keyword = "<<"
+	dictEnd    Keyword = ">>"
+)
+
+// maxNesting limits how deeply arrays and dictionaries may nest, so malformed
+// input can't exhaust the stack
+const maxNesting = 256
+
+// lexer reads PDF tokens and objects from a byte slice
+type lexer struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func isWhitespace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0
+}
+
+func isDelimiter(c byte) bool {
+	return bytes.IndexByte([]byte("()<>[]{}/%"), c) >= 0
+}
+
+// skipSpace skips whitespace and comments
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if isWhitespace(c) {
+			l.pos++
+			continue
+		}
+		if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\r' && l.data[l.pos] != '\n' {
+				l.pos++
+			}
+			continue
+		}
+		return
+	}
+}
+
+// token reads the next token: a number, Name, String, Keyword or delimiter.
+// It returns nil at the end of the data.
+func (l *lexer) token() (Object, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, nil
+	}
+
+	c := l.data[l.pos]
+	switch {
+	case c == '[' || c == ']' || c == '{' || c == '}':
+		l.pos++
+		return Keyword(c), nil
+	case c == '<' && l.peek(1) == '<':
+		l.pos += 2
+		return dictStart, nil
+	case c == '>' && l.peek(1) == '>':
+		l.pos += 2
+		return dictEnd, nil
+	case c == '<':
+		return l.hexString()
+	case c == '(':
+		return l.literalString()
+	case c == '/':
+		return l.name(), nil
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return l.number()
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isWhitespace(l.data[l.pos]) && !isDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	if l.pos == start {
+		l.pos++
+		return nil, fmt.Errorf("unexpected character %q at offset %d", c, start)
+	}
+	return Keyword(l.data[start:l.pos]), nil
+}
+
+func (l *lexer) peek(offset int) byte {
+	if l.pos+offset < len(l.data) {
+		return l.data[l.pos+offset]
+	}
+	return 0
+}
+
+func (l *lexer) number() (Object, error) {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if (c < '0' || c > '9') && c != '.' {
+			break
+		}
+		l.pos++
+	}
+	text := string(l.data[start:l.pos])
+	if bytes.IndexByte(l.data[start:l.pos], '.') < 0 {
+		if n, err := strconv.Atoi(text); err == nil {
+			return n, nil
+		}
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		// Malformed numbers such as "--5" turn up in real files; treat them as zero
+		return 0, nil
+	}
+	return f, nil
+}
+
+func (l *lexer) name() Name {
+	l.pos++
+	var name []byte
+	for l.pos < len(l.data) && !isWhitespace(l.data[l.pos]) && !isDelimiter(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				name = append(name, byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		name = append(name, c)
+		l.pos++
+	}
+	return Name(name)
+}
+
+func (l *lexer) hexString() (Object, error) {
+	l.pos++
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; !isWhitespace(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	if l.pos >= len(l.data) {
+		return nil, fmt.Errorf("unterminated hex string")
+	}
+	l.pos++
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	for i := range out {
+		v, err := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex string: %w", err)
+		}
+		out[i] = byte(v)
+	}
+	return String(out), nil
+}
+
+func (l *lexer) literalString() (Object, error) {
+	l.pos++
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return String(out), nil
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				break
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+			continue
+		}
+		out = append(out, c)
+	}
+	return nil, fmt.Errorf("unterminated string")
+}
+
+// object reads a complete object, combining "n g R" into a Ref and reading
+// the data of a stream that follows a dictionary
+func (l *lexer) object() (Object, error) {
+	tok, err := l.token()
+	if err != nil {
+		return nil, err
+	}
+	return l.complete(tok)
+}
+
+// complete turns an already read token into an object
+func (l *lexer) complete(tok Object) (Object, error) {
+	switch t := tok.(type) {
+	case Keyword:
+		switch t {
+		case arrayStart:
+			return l.array()
+		case dictStart:
+			dict, err := l.dict()
+			if err != nil {
+				return nil, err
+			}
+			return l.maybeStream(dict)
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return t, nil
+	case int:
+		// Look ahead for "gen R"
+		save := l.pos
+		gen, err := l.token()
+		if g, ok := gen.(int); ok && err == nil {
+			if r, err := l.token(); err == nil && r == Keyword("R") {
+				return Ref{Num: t, Gen: g}, nil
+			}
+		}
+		l.pos = save
+		return t, nil
+	}
+	return tok, nil
+}
+
+// errTooDeep is returned for arrays and dictionaries nested past maxNesting
+var errTooDeep = fmt.Errorf("objects nested more than %d deep", maxNesting)
+
+func (l *lexer) array() (Array, error) {
+	l.depth++
+	defer func() { l.depth-- }()
+	if l.depth > maxNesting {
+		return nil, errTooDeep
+	}
+	var arr Array
+	for {
+		tok, err := l.token()
+		if err != nil {
+			return nil, err
+		}
+		if tok == nil {
+			return nil, fmt.Errorf("unterminated array")
+		}
+		if tok == arrayEnd {
+			return arr, nil
+		}
+		obj, err := l.complete(tok)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, obj)
+	}
+}
+
+func (l *lexer) dict() (Dict, error) {
+	l.depth++
+	defer func() { l.depth-- }()
+	if l.depth > maxNesting {
+		return nil, errTooDeep
+	}
+	dict := Dict{}
+	for {
+		tok, err := l.token()
+		if err != nil {
+			return nil, err
+		}
+		if tok == nil {
+			return nil, fmt.Errorf("unterminated dictionary")
+		}
+		if tok == dictEnd {
+			return dict, nil
+		}
+		key, ok := tok.(Name)
+		if !ok {
+			// Skip stray tokens rather than give up on the whole dictionary
+			continue
+		}
+		value, err := l.object()
+		if err != nil {
+			return nil, err
+		}
+		dict[key] = value
+	}
+}
+
+// maybeStream reads the data following a dictionary when the stream keyword
+// comes next. An indirect or wrong /Length falls back to searching for endstream.
+func (l *lexer) maybeStream(dict Dict) (Object, error) {
+	save := l.pos
+	l.skipSpace()
+	if !bytes.HasPrefix(l.data[l.pos:], []byte("stream")) {
+		l.pos = save
+		return dict, nil
+	}
+	l.pos += len("stream")
+	if l.pos < len(l.data) && l.data[l.pos] == '\r' {
+		l.pos++
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+		l.pos++
+	}
+	start := l.pos
+
+	if length, ok := dict["Length"].(int); ok && length >= 0 && start+length <= len(l.data) {
+		end := start + length
+		rest := bytes.TrimLeft(l.data[end:min(end+32, len(l.data))], "\r\n\t ")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			l.pos = end
+			l.skipSpace()
+			l.pos += len("endstream")
+			return &Stream{Dict: dict, Data: l.data[start:end]}, nil
+		}
+	}
+
+	i := bytes.Index(l.data[start:], []byte("endstream"))
+	if i < 0 {
+		return nil, fmt.Errorf("unterminated stream")
+	}
+	end := start + i
+	l.pos = end + len("endstream")
+	// The end-of-line before endstream isn't part of the data
+	if end > start && l.data[end-1] == '\n' {
+		end--
+	}
+	if end > start && l.data[end-1] == '\r' {
+		end--
+	}
+	return &Stream{Dict: dict, Data: l.data[start:end]}, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
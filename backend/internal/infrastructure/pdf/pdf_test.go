@@ -0,0 +1,201 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testPDF builds small PDF files object by object
+type testPDF struct {
+	objects []string
+}
+
+// add appends an object, returning its number
+func (b *testPDF) add(body string) int {
+	b.objects = append(b.objects, body)
+	return len(b.objects)
+}
+
+// addStream appends a stream object with extra dictionary entries
+func (b *testPDF) addStream(dict string, data []byte) int {
+	return b.add(fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data))
+}
+
+// bytes writes the file with a classic cross-reference table and trailer
+func (b *testPDF) bytes(trailer string) []byte {
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(b.objects))
+	for i, body := range b.objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, body)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(b.objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d %s >>\nstartxref\n%d\n%%%%EOF\n", len(b.objects)+1, trailer, xref)
+	return out.Bytes()
+}
+
+func deflate(data string) []byte {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write([]byte(data))
+	w.Close()
+	return buf.Bytes()
+}
+
+// singlePage builds a one-page document drawing content with font F1
+func singlePage(font string, content []byte, filter string) []byte {
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	b.add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>")
+	b.add(font)
+	b.addStream(filter, content)
+	return b.bytes("/Root 1 0 R")
+}
+
+const helvetica = "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>"
+
+func pageLines(t *testing.T, data []byte) []string {
+	t.Helper()
+	return passwordPageLines(t, data, "")
+}
+
+func passwordPageLines(t *testing.T, data []byte, password string) []string {
+	t.Helper()
+	doc, err := OpenWithPassword(data, password)
+	if err != nil {
+		t.Fatalf("OpenWithPassword: %v", err)
+	}
+	pages, err := doc.Pages()
+	if err != nil {
+		t.Fatalf("Pages: %v", err)
+	}
+	if len(pages) != 1 {
+		t.Fatalf("expected 1 page, got %d", len(pages))
+	}
+	var lines []string
+	for _, line := range pages[0].Lines() {
+		lines = append(lines, line.Text())
+	}
+	return lines
+}
+
+func TestExtractsLinesTopToBottom(t *testing.T) {
+	content := `BT /F1 10 Tf 50 700 Td (Date) Tj 100 0 Td (Narration) Tj 200 0 Td (Amount) Tj ET
+BT /F1 10 Tf 50 680 Td (01/04/24) Tj 100 0 Td (UPI\050Swiggy\051) Tj 200 0 Td (450.00) Tj ET
+BT /F1 10 Tf 50 800 Td (Statement) Tj 50 0.5 Td (of account) Tj ET`
+	lines := pageLines(t, singlePage(helvetica, []byte(content), ""))
+
+	want := []string{"Statement of account", "Date  Narration  Amount", "01/04/24  UPI(Swiggy)  450.00"}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected lines:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestExtractsCellPositions(t *testing.T) {
+	content := `BT /F1 10 Tf 1 0 0 1 50 700 Tm [(Opening)-300(Balance)] TJ 1 0 0 1 400 700 Tm (1,000.00) Tj ET`
+	doc, err := Open(singlePage(helvetica, []byte(content), ""))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	pages, _ := doc.Pages()
+	lines := pages[0].Lines()
+	if len(lines) != 1 || len(lines[0].Cells) != 2 {
+		t.Fatalf("expected one line of two cells, got %+v", lines)
+	}
+	cells := lines[0].Cells
+	if cells[0].Text != "Opening Balance" || cells[0].X != 50 {
+		t.Errorf("unexpected first cell %+v", cells[0])
+	}
+	if cells[1].Text != "1,000.00" || cells[1].X != 400 || cells[1].EndX <= 400 {
+		t.Errorf("unexpected second cell %+v", cells[1])
+	}
+}
+
+func TestExtractsCompressedTextWithToUnicode(t *testing.T) {
+	cmap := `/CIDInit /ProcSet findresource begin
+begincmap
+1 begincodespacerange <0000> <FFFF> endcodespacerange
+2 beginbfchar <0001> <0048> <0002> <0069> endbfchar
+1 beginbfrange <0010> <0012> <20B9> endbfrange
+endcmap`
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	b.add("<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>")
+	b.add("<< /Type /Font /Subtype /Type0 /BaseFont /Custom /Encoding /Identity-H /DescendantFonts [7 0 R] /ToUnicode 6 0 R >>")
+	b.addStream("/Filter /FlateDecode", deflate("BT /F1 12 Tf 72 720 Td <00010002> Tj ET"))
+	b.addStream("", []byte(cmap))
+	b.add("<< /Type /Font /Subtype /CIDFontType2 /DW 600 >>")
+	lines := pageLines(t, b.bytes("/Root 1 0 R"))
+
+	if len(lines) != 1 || lines[0] != "Hi" {
+		t.Errorf("expected Hi, got %q", lines)
+	}
+}
+
+func TestReadsObjectStreams(t *testing.T) {
+	// Catalog, pages and font live in an object stream, found through a
+	// cross-reference stream instead of a trailer
+	packed := "<< /Type /Catalog /Pages 2 0 R >> << /Type /Pages /Kids [3 0 R] /Count 1 >> " + helvetica
+	header := fmt.Sprintf("1 0 2 %d 4 %d ", len("<< /Type /Catalog /Pages 2 0 R >> "), len("<< /Type /Catalog /Pages 2 0 R >> << /Type /Pages /Kids [3 0 R] /Count 1 >> "))
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.5\n")
+	fmt.Fprintf(&out, "3 0 obj\n<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>\nendobj\n")
+	content := "BT /F1 10 Tf 50 700 Td (Packed) Tj ET"
+	fmt.Fprintf(&out, "5 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(content), content)
+	stream := deflate(header + packed)
+	fmt.Fprintf(&out, "6 0 obj\n<< /Type /ObjStm /N 3 /First %d /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(header), len(stream), stream)
+	fmt.Fprintf(&out, "7 0 obj\n<< /Type /XRef /Size 8 /Root 1 0 R /W [1 2 1] /Length 0 >>\nstream\n\nendstream\nendobj\n%%%%EOF\n")
+
+	lines := pageLines(t, out.Bytes())
+	if len(lines) != 1 || lines[0] != "Packed" {
+		t.Errorf("expected Packed, got %q", lines)
+	}
+}
+
+func TestOpenRejectsInvalidDocuments(t *testing.T) {
+	if _, err := Open([]byte("date,amount\n2024-04-01,100\n")); !errors.Is(err, ErrNotPDF) {
+		t.Errorf("expected ErrNotPDF, got %v", err)
+	}
+
+	b := &testPDF{}
+	b.add("<< /Type /Catalog /Pages 2 0 R >>")
+	b.add("<< /Filter /Standard /V 2 /R 3 >>")
+	if _, err := Open(b.bytes("/Root 1 0 R /Encrypt 2 0 R")); !errors.Is(err, ErrUnsupportedEncryption) {
+		t.Errorf("expected ErrUnsupportedEncryption, got %v", err)
+	}
+}
+
+func TestRejectsMaliciousInput(t *testing.T) {
+	l := &lexer{data: bytes.Repeat([]byte("["), 1<<20)}
+	if _, err := l.object(); !errors.Is(err, errTooDeep) {
+		t.Errorf("deeply nested arrays: expected errTooDeep, got %v", err)
+	}
+	l = &lexer{data: []byte(strings.Repeat("<< /A [", 100) + strings.Repeat("] >> ", 100))}
+	if _, err := l.object(); err != nil {
+		t.Errorf("expected moderately nested objects to parse, got %v", err)
+	}
+
+	var bomb bytes.Buffer
+	w := zlib.NewWriter(&bomb)
+	w.Write(make([]byte, maxDecodedLength+1))
+	w.Close()
+	if _, err := inflate(bomb.Bytes()); !errors.Is(err, errStreamTooLarge) {
+		t.Errorf("oversized stream: expected errStreamTooLarge, got %v", err)
+	}
+
+	if _, err := unpredict([]byte{0, 1, 2}, Dict{"Predictor": 12, "Columns": 1 << 60}); err == nil {
+		t.Error("expected an error for predictor columns wider than the data")
+	}
+}
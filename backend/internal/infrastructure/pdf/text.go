@@ -0,0 +1,363 @@
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// maxTreeDepth limits how deeply the page tree is followed
+	maxTreeDepth = 32
+
+	// maxFormDepth limits how deeply form XObjects drawing other forms are followed
+	maxFormDepth = 8
+)
+
+// Span is a run of text drawn at one position, in page space with the origin
+// at the bottom left
+type Span struct {
+	X     float64
+	Y     float64
+	Width float64
+	Size  float64
+	Text  string
+}
+
+// Page is the text drawn on one page
+type Page struct {
+	Number int
+	Spans  []Span
+}
+
+// Pages extracts the text of every page, in document order
+func (d *Document) Pages() ([]*Page, error) {
+	catalog := d.resolveDict(d.trailer["Root"])
+	if catalog == nil {
+		return nil, fmt.Errorf("pdf: document has no catalog")
+	}
+	var pages []*Page
+	var walk func(node Dict, resources Dict, depth int) error
+	walk = func(node Dict, resources Dict, depth int) error {
+		// The depth limit guards against page trees that loop back on themselves
+		if node == nil || depth > maxTreeDepth {
+			return nil
+		}
+		if r := d.resolveDict(node["Resources"]); r != nil {
+			resources = r
+		}
+		if node["Type"] == Name("Pages") || node["Kids"] != nil {
+			for _, kid := range d.resolveArray(node["Kids"]) {
+				if err := walk(d.resolveDict(kid), resources, depth+1); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		page := &Page{Number: len(pages) + 1}
+		content, err := d.pageContent(node["Contents"])
+		if err != nil {
+			return fmt.Errorf("pdf: page %d: %w", page.Number, err)
+		}
+		state := &textState{doc: d, page: page, fonts: make(map[Name]*font)}
+		state.run(content, resources, identity, 0)
+		pages = append(pages, page)
+		return nil
+	}
+	if err := walk(d.resolveDict(catalog["Pages"]), nil, 0); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// pageContent concatenates a page's content streams
+func (d *Document) pageContent(obj Object) ([]byte, error) {
+	var streams []*Stream
+	switch v := d.resolve(obj).(type) {
+	case *Stream:
+		streams = append(streams, v)
+	case Array:
+		for _, item := range v {
+			if s, ok := d.resolve(item).(*Stream); ok {
+				streams = append(streams, s)
+			}
+		}
+	}
+
+	var content []byte
+	for _, s := range streams {
+		data, err := d.decodeStream(s)
+		if err != nil {
+			return nil, err
+		}
+		content = append(content, data...)
+		content = append(content, '\n')
+	}
+	return content, nil
+}
+
+// matrix is a PDF transformation matrix [a b c d e f]
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// multiply returns m × n, applying m first
+func (m matrix) multiply(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+func translate(x, y float64) matrix {
+	return matrix{1, 0, 0, 1, x, y}
+}
+
+// graphicsState holds the parts of the graphics and text state that affect
+// where text lands
+type graphicsState struct {
+	ctm         matrix
+	font        *font
+	fontSize    float64
+	charSpacing float64
+	wordSpacing float64
+	scale       float64
+	leading     float64
+	rise        float64
+}
+
+// textState interprets content streams, recording the text they show
+type textState struct {
+	doc   *Document
+	page  *Page
+	fonts map[Name]*font
+	gs    graphicsState
+	stack []graphicsState
+	tm    matrix
+	tlm   matrix
+}
+
+// run interprets a content stream drawn with resources under ctm
+func (t *textState) run(content []byte, resources Dict, ctm matrix, depth int) {
+	t.gs = graphicsState{ctm: ctm, scale: 1, font: &font{defaultWidth: defaultGlyphWidth}}
+	fontDicts := t.doc.resolveDict(resources["Font"])
+	xobjects := t.doc.resolveDict(resources["XObject"])
+
+	l := &lexer{data: content}
+	var operands []Object
+	for {
+		tok, err := l.token()
+		if err != nil {
+			operands = operands[:0]
+			continue
+		}
+		if tok == nil {
+			return
+		}
+		op, ok := tok.(Keyword)
+		if !ok || op == arrayStart || op == dictStart || op == "true" || op == "false" || op == "null" {
+			if obj, err := l.complete(tok); err == nil {
+				operands = append(operands, obj)
+			}
+			continue
+		}
+
+		switch op {
+		case "q":
+			t.stack = append(t.stack, t.gs)
+		case "Q":
+			if n := len(t.stack); n > 0 {
+				t.gs, t.stack = t.stack[n-1], t.stack[:n-1]
+			}
+		case "cm":
+			if m, ok := t.matrixOperand(operands); ok {
+				t.gs.ctm = m.multiply(t.gs.ctm)
+			}
+		case "BT":
+			t.tm, t.tlm = identity, identity
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[0].(Name); ok {
+					t.gs.font = t.font(fontDicts, name)
+				}
+				t.gs.fontSize = t.doc.number(operands[1])
+			}
+		case "Tc":
+			t.gs.charSpacing = t.lastNumber(operands)
+		case "Tw":
+			t.gs.wordSpacing = t.lastNumber(operands)
+		case "Tz":
+			t.gs.scale = t.lastNumber(operands) / 100
+		case "TL":
+			t.gs.leading = t.lastNumber(operands)
+		case "Ts":
+			t.gs.rise = t.lastNumber(operands)
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				tx, ty := t.doc.number(operands[0]), t.doc.number(operands[1])
+				if op == "TD" {
+					t.gs.leading = -ty
+				}
+				t.tlm = translate(tx, ty).multiply(t.tlm)
+				t.tm = t.tlm
+			}
+		case "Tm":
+			if m, ok := t.matrixOperand(operands); ok {
+				t.tm, t.tlm = m, m
+			}
+		case "T*":
+			t.nextLine()
+		case "Tj":
+			if len(operands) > 0 {
+				t.show(operands[len(operands)-1])
+			}
+		case "'":
+			t.nextLine()
+			if len(operands) > 0 {
+				t.show(operands[len(operands)-1])
+			}
+		case "\"":
+			if len(operands) >= 3 {
+				t.gs.wordSpacing = t.doc.number(operands[0])
+				t.gs.charSpacing = t.doc.number(operands[1])
+				t.nextLine()
+				t.show(operands[2])
+			}
+		case "TJ":
+			if len(operands) > 0 {
+				for _, item := range t.doc.resolveArray(operands[len(operands)-1]) {
+					if _, ok := item.(String); ok {
+						t.show(item)
+						continue
+					}
+					t.advance(-t.doc.number(item) / 1000 * t.gs.fontSize * t.gs.scale)
+				}
+			}
+		case "Do":
+			if len(operands) > 0 && depth < maxFormDepth {
+				if name, ok := operands[0].(Name); ok {
+					t.drawForm(xobjects[name], depth)
+				}
+			}
+		case "BI":
+			l.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+}
+
+// drawForm interprets a form XObject with its own resources and matrix
+func (t *textState) drawForm(obj Object, depth int) {
+	s, ok := t.doc.resolve(obj).(*Stream)
+	if !ok || s.Dict["Subtype"] != Name("Form") {
+		return
+	}
+	content, err := t.doc.decodeStream(s)
+	if err != nil {
+		return
+	}
+	ctm := t.gs.ctm
+	if m, ok := t.matrixOperand(t.doc.resolveArray(s.Dict["Matrix"])); ok {
+		ctm = m.multiply(ctm)
+	}
+	nested := &textState{doc: t.doc, page: t.page, fonts: make(map[Name]*font)}
+	nested.run(content, t.doc.resolveDict(s.Dict["Resources"]), ctm, depth+1)
+}
+
+// skipInlineImage moves past an inline image's data, which follows ID and
+// ends at a whitespace-delimited EI
+func (l *lexer) skipInlineImage() {
+	for l.pos < len(l.data) {
+		tok, err := l.token()
+		if err != nil || tok == nil {
+			return
+		}
+		if tok == Keyword("ID") {
+			break
+		}
+	}
+	for l.pos+2 < len(l.data) {
+		if isWhitespace(l.data[l.pos]) && l.data[l.pos+1] == 'E' && l.data[l.pos+2] == 'I' &&
+			(l.pos+3 == len(l.data) || isWhitespace(l.data[l.pos+3])) {
+			l.pos += 3
+			return
+		}
+		l.pos++
+	}
+	l.pos = len(l.data)
+}
+
+func (t *textState) font(fontDicts Dict, name Name) *font {
+	if f, ok := t.fonts[name]; ok {
+		return f
+	}
+	f := t.doc.loadFont(t.doc.resolveDict(fontDicts[name]))
+	t.fonts[name] = f
+	return f
+}
+
+func (t *textState) matrixOperand(operands []Object) (matrix, bool) {
+	if len(operands) < 6 {
+		return matrix{}, false
+	}
+	var m matrix
+	for i := range m {
+		m[i] = t.doc.number(operands[len(operands)-6+i])
+	}
+	return m, true
+}
+
+func (t *textState) lastNumber(operands []Object) float64 {
+	if len(operands) == 0 {
+		return 0
+	}
+	return t.doc.number(operands[len(operands)-1])
+}
+
+func (t *textState) nextLine() {
+	t.tlm = translate(0, -t.gs.leading).multiply(t.tlm)
+	t.tm = t.tlm
+}
+
+// advance moves the text matrix along the baseline by tx text-space units
+func (t *textState) advance(tx float64) {
+	t.tm = translate(tx, 0).multiply(t.tm)
+}
+
+// show records a shown string as a span and advances past it
+func (t *textState) show(obj Object) {
+	s, ok := t.doc.resolve(obj).(String)
+	if !ok {
+		return
+	}
+	trm := matrix{t.gs.fontSize * t.gs.scale, 0, 0, t.gs.fontSize, 0, t.gs.rise}.multiply(t.tm).multiply(t.gs.ctm)
+	startX, y := trm[4], trm[5]
+	size := math.Hypot(trm[2], trm[3])
+
+	var text strings.Builder
+	for _, g := range t.gs.font.decode(s) {
+		text.WriteString(g.text)
+		tx := g.width/1000*t.gs.fontSize + t.gs.charSpacing
+		if g.space {
+			tx += t.gs.wordSpacing
+		}
+		t.advance(tx * t.gs.scale)
+	}
+	end := matrix{1, 0, 0, 1, 0, t.gs.rise}.multiply(t.tm).multiply(t.gs.ctm)
+
+	if strings.TrimSpace(text.String()) == "" {
+		return
+	}
+	t.page.Spans = append(t.page.Spans, Span{
+		X:     startX,
+		Y:     y,
+		Width: math.Abs(end[4] - startX),
+		Size:  size,
+		Text:  text.String(),
+	})
+}
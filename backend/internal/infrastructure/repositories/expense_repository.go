@@ -17,7 +17,7 @@ type ExpenseRepository interface {
 type IncomeRepository interface {
 	Create(income *entities.Income) error
 	GetByUserID(userID string) ([]*entities.Income, error)
-	GetByMonth(userID string, month time.Time) (*entities.Income, error)
+	GetByMonth(userID string, month time.Time) ([]*entities.Income, error)
 	Update(income *entities.Income) error
 	Delete(id string) error
 }
\ No newline at end of file
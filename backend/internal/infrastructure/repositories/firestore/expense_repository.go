@@ -35,6 +35,18 @@ func (r *ExpenseRepository) GetByUserIDAndDateRange(ctx context.Context, userID
 	return nil, errors.New("not implemented")
 }
 
+// GetByUserID gets expenses by user ID and filters
+func (r *ExpenseRepository) GetByUserID(ctx context.Context, userID string, filters *repositories.ExpenseFilters) ([]*repositories.Expense, error) {
+	// Implementation will be added in a future task
+	return nil, errors.New("not implemented")
+}
+
+// CountByUserID counts expenses by user ID and filters
+func (r *ExpenseRepository) CountByUserID(ctx context.Context, userID string, filters *repositories.ExpenseFilters) (int, error) {
+	// Implementation will be added in a future task
+	return 0, errors.New("not implemented")
+}
+
 // Update updates an expense
 func (r *ExpenseRepository) Update(ctx context.Context, expense *repositories.Expense) error {
 	// Implementation will be added in a future task
@@ -0,0 +1,303 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// AccountRepository implements the repositories.AccountRepository interface
+type AccountRepository struct {
+	db *sql.DB
+}
+
+// NewAccountRepository creates a new SQLite account repository
+func NewAccountRepository(db *sql.DB) repositories.AccountRepository {
+	return &AccountRepository{
+		db: db,
+	}
+}
+
+const accountColumns = `id, user_id, name, type, currency, last4, opening_balance, opening_date, archived, created_at, updated_at`
+
+const transferColumns = `id, user_id, from_account_id, to_account_id, amount, to_amount, date, note, created_at`
+
+// Create creates a new account
+func (r *AccountRepository) Create(ctx context.Context, account *repositories.Account) error {
+	if account.ID == "" {
+		account.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if account.CreatedAt.IsZero() {
+		account.CreatedAt = now
+	}
+	if account.UpdatedAt.IsZero() {
+		account.UpdatedAt = now
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO accounts (`+accountColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		account.ID,
+		account.UserID,
+		account.Name,
+		account.Type,
+		account.Currency,
+		account.Last4,
+		account.OpeningBalance.String(),
+		formatTime(account.OpeningDate),
+		account.Archived,
+		formatTime(account.CreatedAt),
+		formatTime(account.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create account: %w", err)
+	}
+	return nil
+}
+
+// GetByID gets an account by ID
+func (r *AccountRepository) GetByID(ctx context.Context, id string) (*repositories.Account, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+accountColumns+` FROM accounts WHERE id = ?`, id)
+
+	account, err := scanAccount(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("account %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	return account, nil
+}
+
+// GetByUserID gets every account for a user, archived ones last
+func (r *AccountRepository) GetByUserID(ctx context.Context, userID string) ([]*repositories.Account, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+accountColumns+` FROM accounts WHERE user_id = ? ORDER BY archived, name`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*repositories.Account
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// Update updates an account
+func (r *AccountRepository) Update(ctx context.Context, account *repositories.Account) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE accounts
+		SET name = ?, type = ?, currency = ?, last4 = ?, opening_balance = ?, opening_date = ?, archived = ?, updated_at = ?
+		WHERE id = ?`,
+		account.Name,
+		account.Type,
+		account.Currency,
+		account.Last4,
+		account.OpeningBalance.String(),
+		formatTime(account.OpeningDate),
+		account.Archived,
+		formatTime(account.UpdatedAt),
+		account.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+	return requireAffected(result, "account", account.ID)
+}
+
+// Delete deletes an account by ID
+func (r *AccountRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM accounts WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+	return requireAffected(result, "account", id)
+}
+
+// CountEntries counts the expenses, incomes, transfers, categorization rules
+// and import batches referencing an account
+func (r *AccountRepository) CountEntries(ctx context.Context, id string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT
+			(SELECT COUNT(*) FROM expenses WHERE account_id = ?) +
+			(SELECT COUNT(*) FROM incomes WHERE account_id = ?) +
+			(SELECT COUNT(*) FROM transfers WHERE from_account_id = ? OR to_account_id = ?) +
+			(SELECT COUNT(*) FROM categorization_rules WHERE account_id = ?) +
+			(SELECT COUNT(*) FROM import_batches WHERE account_id = ?)`,
+		id, id, id, id, id, id,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count account entries: %w", err)
+	}
+	return count, nil
+}
+
+// CreateTransfer creates a new transfer
+func (r *AccountRepository) CreateTransfer(ctx context.Context, transfer *repositories.Transfer) error {
+	if transfer.ID == "" {
+		transfer.ID = uuid.NewString()
+	}
+	if transfer.CreatedAt.IsZero() {
+		transfer.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO transfers (`+transferColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		transfer.ID,
+		transfer.UserID,
+		transfer.FromAccountID,
+		transfer.ToAccountID,
+		transfer.Amount.String(),
+		transfer.ToAmount.String(),
+		formatTime(transfer.Date),
+		transfer.Note,
+		formatTime(transfer.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+	return nil
+}
+
+// GetTransferByID gets a transfer by ID
+func (r *AccountRepository) GetTransferByID(ctx context.Context, id string) (*repositories.Transfer, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+transferColumns+` FROM transfers WHERE id = ?`, id)
+
+	transfer, err := scanTransfer(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("transfer %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+	return transfer, nil
+}
+
+// GetTransfersByAccount gets every transfer into or out of an account, oldest first
+func (r *AccountRepository) GetTransfersByAccount(ctx context.Context, accountID string) ([]*repositories.Transfer, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+transferColumns+` FROM transfers
+		WHERE from_account_id = ? OR to_account_id = ? ORDER BY date, created_at`,
+		accountID, accountID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*repositories.Transfer
+	for rows.Next() {
+		transfer, err := scanTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transfer: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query transfers: %w", err)
+	}
+	return transfers, nil
+}
+
+// DeleteTransfer deletes a transfer by ID
+func (r *AccountRepository) DeleteTransfer(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM transfers WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete transfer: %w", err)
+	}
+	return requireAffected(result, "transfer", id)
+}
+
+// scanAccount reads a single row selected with accountColumns
+func scanAccount(row rowScanner) (*repositories.Account, error) {
+	var (
+		account                repositories.Account
+		openingBalance, opened string
+		createdAt, updatedAt   string
+	)
+	err := row.Scan(
+		&account.ID,
+		&account.UserID,
+		&account.Name,
+		&account.Type,
+		&account.Currency,
+		&account.Last4,
+		&openingBalance,
+		&opened,
+		&account.Archived,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.OpeningBalance, err = decimal.NewFromString(openingBalance); err != nil {
+		return nil, fmt.Errorf("invalid stored opening balance %q: %w", openingBalance, err)
+	}
+	if account.OpeningDate, err = parseTime(opened); err != nil {
+		return nil, err
+	}
+	if account.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if account.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// scanTransfer reads a single row selected with transferColumns
+func scanTransfer(row rowScanner) (*repositories.Transfer, error) {
+	var (
+		transfer         repositories.Transfer
+		amount, toAmount string
+		date, createdAt  string
+	)
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.UserID,
+		&transfer.FromAccountID,
+		&transfer.ToAccountID,
+		&amount,
+		&toAmount,
+		&date,
+		&transfer.Note,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Amount, err = decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", amount, err)
+	}
+	if transfer.ToAmount, err = decimal.NewFromString(toAmount); err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", toAmount, err)
+	}
+	if transfer.Date, err = parseTime(date); err != nil {
+		return nil, err
+	}
+	if transfer.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
@@ -0,0 +1,179 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// BudgetRepository implements the repositories.BudgetRepository interface
+type BudgetRepository struct {
+	db *sql.DB
+}
+
+// NewBudgetRepository creates a new SQLite budget repository
+func NewBudgetRepository(db *sql.DB) repositories.BudgetRepository {
+	return &BudgetRepository{
+		db: db,
+	}
+}
+
+const budgetColumns = `id, user_id, kind, category, month, amount, currency, rollover, created_at, updated_at`
+
+// Create creates a new budget
+func (r *BudgetRepository) Create(ctx context.Context, budget *repositories.Budget) error {
+	if budget.ID == "" {
+		budget.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if budget.CreatedAt.IsZero() {
+		budget.CreatedAt = now
+	}
+	if budget.UpdatedAt.IsZero() {
+		budget.UpdatedAt = now
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO budgets (`+budgetColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		budget.ID,
+		budget.UserID,
+		budget.Kind,
+		budget.Category,
+		formatTime(budget.Month),
+		budget.Amount.String(),
+		budget.Currency,
+		budget.Rollover,
+		formatTime(budget.CreatedAt),
+		formatTime(budget.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create budget: %w", err)
+	}
+	return nil
+}
+
+// GetByID gets a budget by ID
+func (r *BudgetRepository) GetByID(ctx context.Context, id string) (*repositories.Budget, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+budgetColumns+` FROM budgets WHERE id = ?`, id)
+	return r.scanOne(row, id)
+}
+
+// GetByMonth gets every budget for a user in the given month
+func (r *BudgetRepository) GetByMonth(ctx context.Context, userID string, month time.Time) ([]*repositories.Budget, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+budgetColumns+` FROM budgets WHERE user_id = ? AND month = ? ORDER BY kind, category`,
+		userID, formatTime(month),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query budgets: %w", err)
+	}
+	defer rows.Close()
+
+	var budgets []*repositories.Budget
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read budget: %w", err)
+		}
+		budgets = append(budgets, budget)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query budgets: %w", err)
+	}
+	return budgets, nil
+}
+
+// GetByCategory gets the budget for a category in the given month
+func (r *BudgetRepository) GetByCategory(ctx context.Context, userID, kind, category string, month time.Time) (*repositories.Budget, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+budgetColumns+` FROM budgets WHERE user_id = ? AND kind = ? AND category = ? AND month = ?`,
+		userID, kind, category, formatTime(month),
+	)
+	return r.scanOne(row, category)
+}
+
+// Update updates a budget
+func (r *BudgetRepository) Update(ctx context.Context, budget *repositories.Budget) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE budgets
+		SET kind = ?, category = ?, month = ?, amount = ?, currency = ?, rollover = ?, updated_at = ?
+		WHERE id = ?`,
+		budget.Kind,
+		budget.Category,
+		formatTime(budget.Month),
+		budget.Amount.String(),
+		budget.Currency,
+		budget.Rollover,
+		formatTime(budget.UpdatedAt),
+		budget.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	}
+	return requireAffected(result, "budget", budget.ID)
+}
+
+// Delete deletes a budget by ID
+func (r *BudgetRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM budgets WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+	return requireAffected(result, "budget", id)
+}
+
+// scanOne reads a single budget, mapping a missing row to ErrNotFound
+func (r *BudgetRepository) scanOne(row *sql.Row, key string) (*repositories.Budget, error) {
+	budget, err := scanBudget(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("budget %s: %w", key, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+	return budget, nil
+}
+
+// scanBudget reads a single budget row selected with budgetColumns
+func scanBudget(row rowScanner) (*repositories.Budget, error) {
+	var (
+		budget               repositories.Budget
+		month, amount        string
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&budget.ID,
+		&budget.UserID,
+		&budget.Kind,
+		&budget.Category,
+		&month,
+		&amount,
+		&budget.Currency,
+		&budget.Rollover,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if budget.Amount, err = decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", amount, err)
+	}
+	if budget.Month, err = parseTime(month); err != nil {
+		return nil, err
+	}
+	if budget.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if budget.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
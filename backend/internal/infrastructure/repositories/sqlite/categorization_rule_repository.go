@@ -0,0 +1,182 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CategorizationRuleRepository implements the repositories.CategorizationRuleRepository interface
+type CategorizationRuleRepository struct {
+	db *sql.DB
+}
+
+// NewCategorizationRuleRepository creates a new SQLite categorization rule repository
+func NewCategorizationRuleRepository(db *sql.DB) repositories.CategorizationRuleRepository {
+	return &CategorizationRuleRepository{
+		db: db,
+	}
+}
+
+const categorizationRuleColumns = `id, user_id, name, match_type, pattern, min_amount, max_amount, transaction_type,
+	account_id, main_category, sub_category, priority, created_at, updated_at`
+
+// Create creates a new categorization rule
+func (r *CategorizationRuleRepository) Create(ctx context.Context, rule *repositories.CategorizationRule) error {
+	if rule.ID == "" {
+		rule.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = now
+	}
+	if rule.UpdatedAt.IsZero() {
+		rule.UpdatedAt = now
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO categorization_rules (`+categorizationRuleColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rule.ID,
+		rule.UserID,
+		rule.Name,
+		rule.MatchType,
+		rule.Pattern,
+		nullDecimal(rule.MinAmount),
+		nullDecimal(rule.MaxAmount),
+		rule.TransactionType,
+		nullString(rule.AccountID),
+		rule.MainCategory,
+		rule.SubCategory,
+		rule.Priority,
+		formatTime(rule.CreatedAt),
+		formatTime(rule.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create categorization rule: %w", err)
+	}
+	return nil
+}
+
+// GetByID gets a categorization rule by ID
+func (r *CategorizationRuleRepository) GetByID(ctx context.Context, id string) (*repositories.CategorizationRule, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+categorizationRuleColumns+` FROM categorization_rules WHERE id = ?`, id)
+
+	rule, err := scanCategorizationRule(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("categorization rule %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categorization rule: %w", err)
+	}
+	return rule, nil
+}
+
+// GetByUserID gets every rule for a user, highest priority first, then oldest first
+func (r *CategorizationRuleRepository) GetByUserID(ctx context.Context, userID string) ([]*repositories.CategorizationRule, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+categorizationRuleColumns+` FROM categorization_rules
+		WHERE user_id = ? ORDER BY priority DESC, created_at, id`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categorization rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*repositories.CategorizationRule
+	for rows.Next() {
+		rule, err := scanCategorizationRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read categorization rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query categorization rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Update updates a categorization rule
+func (r *CategorizationRuleRepository) Update(ctx context.Context, rule *repositories.CategorizationRule) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE categorization_rules
+		SET name = ?, match_type = ?, pattern = ?, min_amount = ?, max_amount = ?, transaction_type = ?,
+			account_id = ?, main_category = ?, sub_category = ?, priority = ?, updated_at = ?
+		WHERE id = ?`,
+		rule.Name,
+		rule.MatchType,
+		rule.Pattern,
+		nullDecimal(rule.MinAmount),
+		nullDecimal(rule.MaxAmount),
+		rule.TransactionType,
+		nullString(rule.AccountID),
+		rule.MainCategory,
+		rule.SubCategory,
+		rule.Priority,
+		formatTime(rule.UpdatedAt),
+		rule.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update categorization rule: %w", err)
+	}
+	return requireAffected(result, "categorization rule", rule.ID)
+}
+
+// Delete deletes a categorization rule
+func (r *CategorizationRuleRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM categorization_rules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete categorization rule: %w", err)
+	}
+	return requireAffected(result, "categorization rule", id)
+}
+
+// scanCategorizationRule reads a single row selected with categorizationRuleColumns
+func scanCategorizationRule(row rowScanner) (*repositories.CategorizationRule, error) {
+	var (
+		rule                 repositories.CategorizationRule
+		minAmount, maxAmount sql.NullString
+		accountID            sql.NullString
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.MatchType,
+		&rule.Pattern,
+		&minAmount,
+		&maxAmount,
+		&rule.TransactionType,
+		&accountID,
+		&rule.MainCategory,
+		&rule.SubCategory,
+		&rule.Priority,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.AccountID = accountID.String
+	if rule.MinAmount, err = parseNullDecimal(minAmount); err != nil {
+		return nil, err
+	}
+	if rule.MaxAmount, err = parseNullDecimal(maxAmount); err != nil {
+		return nil, err
+	}
+	if rule.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if rule.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
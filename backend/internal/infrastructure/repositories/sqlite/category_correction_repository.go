@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// CategoryCorrectionRepository implements the repositories.CategoryCorrectionRepository interface
+type CategoryCorrectionRepository struct {
+	db *sql.DB
+}
+
+// NewCategoryCorrectionRepository creates a new SQLite category correction repository
+func NewCategoryCorrectionRepository(db *sql.DB) repositories.CategoryCorrectionRepository {
+	return &CategoryCorrectionRepository{
+		db: db,
+	}
+}
+
+const categoryCorrectionColumns = `id, user_id, description, amount, transaction_type, main_category, sub_category, created_at`
+
+// Create creates a new category correction
+func (r *CategoryCorrectionRepository) Create(ctx context.Context, correction *repositories.CategoryCorrection) error {
+	return insertCategoryCorrection(ctx, r.db, correction)
+}
+
+// insertCategoryCorrection inserts a correction row, filling in its ID and
+// creation time when they're unset
+func insertCategoryCorrection(ctx context.Context, db execer, correction *repositories.CategoryCorrection) error {
+	if correction.ID == "" {
+		correction.ID = uuid.NewString()
+	}
+	if correction.CreatedAt.IsZero() {
+		correction.CreatedAt = time.Now()
+	}
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO category_corrections (`+categoryCorrectionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		correction.ID,
+		correction.UserID,
+		correction.Description,
+		correction.Amount.String(),
+		correction.TransactionType,
+		correction.MainCategory,
+		correction.SubCategory,
+		formatTime(correction.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create category correction: %w", err)
+	}
+	return nil
+}
+
+// GetByUserID gets a user's most recent corrections, newest first
+func (r *CategoryCorrectionRepository) GetByUserID(ctx context.Context, userID string, limit int) ([]*repositories.CategoryCorrection, error) {
+	query := `SELECT ` + categoryCorrectionColumns + ` FROM category_corrections
+		WHERE user_id = ? ORDER BY created_at DESC, id`
+	args := []interface{}{userID}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category corrections: %w", err)
+	}
+	defer rows.Close()
+
+	var corrections []*repositories.CategoryCorrection
+	for rows.Next() {
+		correction, err := scanCategoryCorrection(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read category correction: %w", err)
+		}
+		corrections = append(corrections, correction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query category corrections: %w", err)
+	}
+	return corrections, nil
+}
+
+// scanCategoryCorrection reads a single row selected with categoryCorrectionColumns
+func scanCategoryCorrection(row rowScanner) (*repositories.CategoryCorrection, error) {
+	var (
+		correction        repositories.CategoryCorrection
+		amount, createdAt string
+	)
+	err := row.Scan(
+		&correction.ID,
+		&correction.UserID,
+		&correction.Description,
+		&amount,
+		&correction.TransactionType,
+		&correction.MainCategory,
+		&correction.SubCategory,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if correction.Amount, err = decimal.NewFromString(amount); err != nil {
+		return nil, err
+	}
+	if correction.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	return &correction, nil
+}
@@ -0,0 +1,395 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// categoryReference is a column that stores category names of a given kind
+type categoryReference struct {
+	table  string
+	column string
+
+	// kindColumn is set for tables holding names of both kinds in a single column
+	kindColumn string
+
+	// unique marks columns covered by a unique index. When re-pointing would
+	// collide with a row already filed under the target, the target's row is kept.
+	unique bool
+
+	// combine, when set, folds the source's colliding rows into the target's
+	// before they're dropped
+	combine func(ctx context.Context, tx *sql.Tx, userID, kind, from, to string) error
+
+	// userScope selects the user's rows, with one placeholder for the user
+	// ID, for tables without a user_id column. It defaults to user_id = ?.
+	userScope string
+}
+
+// stagedTransactionScope selects staged transactions through their batch's owner
+const stagedTransactionScope = `batch_id IN (SELECT id FROM import_batches WHERE user_id = ?)`
+
+// categoryReferences lists every column holding category names, per kind.
+// Renames and merges rewrite all of them, so new referencing tables must be added here.
+var categoryReferences = map[string][]categoryReference{
+	"main": {
+		{table: "expenses", column: "main_category"},
+		{table: "budgets", column: "category", kindColumn: "kind", unique: true, combine: combineBudgets},
+		{table: "recurring_expenses", column: "main_category"},
+		{table: "import_mappings", column: "default_main_category"},
+		{table: "categorization_rules", column: "main_category"},
+		{table: "category_corrections", column: "main_category"},
+		{table: "staged_transactions", column: "main_category", userScope: stagedTransactionScope},
+		{table: "staged_transactions", column: "suggested_main_category", userScope: stagedTransactionScope},
+	},
+	"sub": {
+		{table: "expenses", column: "sub_category"},
+		{table: "expense_items", column: "sub_category"},
+		{table: "budgets", column: "category", kindColumn: "kind", unique: true, combine: combineBudgets},
+		{table: "recurring_expenses", column: "sub_category"},
+		{table: "import_mappings", column: "default_sub_category"},
+		{table: "categorization_rules", column: "sub_category"},
+		{table: "category_corrections", column: "sub_category"},
+		{table: "staged_transactions", column: "sub_category", userScope: stagedTransactionScope},
+		{table: "staged_transactions", column: "suggested_sub_category", userScope: stagedTransactionScope},
+	},
+}
+
+// CategoryRepository implements the repositories.CategoryRepository interface
+type CategoryRepository struct {
+	db *sql.DB
+}
+
+// NewCategoryRepository creates a new SQLite category repository
+func NewCategoryRepository(db *sql.DB) repositories.CategoryRepository {
+	return &CategoryRepository{
+		db: db,
+	}
+}
+
+const categoryColumns = `id, user_id, kind, name, parent_id, color, icon, archived, role, created_at, updated_at`
+
+// CreateAll creates categories in a single transaction
+func (r *CategoryRepository) CreateAll(ctx context.Context, categories []*repositories.Category) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create categories: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, category := range categories {
+		if category.ID == "" {
+			category.ID = uuid.NewString()
+		}
+		if category.CreatedAt.IsZero() {
+			category.CreatedAt = now
+		}
+		if category.UpdatedAt.IsZero() {
+			category.UpdatedAt = now
+		}
+
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO categories (`+categoryColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			category.ID,
+			category.UserID,
+			category.Kind,
+			category.Name,
+			nullString(category.ParentID),
+			category.Color,
+			category.Icon,
+			category.Archived,
+			category.Role,
+			formatTime(category.CreatedAt),
+			formatTime(category.UpdatedAt),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create category %q: %w", category.Name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID gets a category by ID
+func (r *CategoryRepository) GetByID(ctx context.Context, id string) (*repositories.Category, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+categoryColumns+` FROM categories WHERE id = ?`, id)
+	return r.scanOne(row, id)
+}
+
+// GetByName gets a category by kind and case-insensitive name
+func (r *CategoryRepository) GetByName(ctx context.Context, userID, kind, name string) (*repositories.Category, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+categoryColumns+` FROM categories WHERE user_id = ? AND kind = ? AND name = ?`,
+		userID, kind, name,
+	)
+	return r.scanOne(row, name)
+}
+
+// GetByUserID gets all categories for a user
+func (r *CategoryRepository) GetByUserID(ctx context.Context, userID string) ([]*repositories.Category, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+categoryColumns+` FROM categories WHERE user_id = ? ORDER BY rowid`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*repositories.Category
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query categories: %w", err)
+	}
+	return categories, nil
+}
+
+// Update updates a category and renames it across referencing records
+func (r *CategoryRepository) Update(ctx context.Context, category *repositories.Category) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+	defer tx.Rollback()
+
+	var oldName string
+	err = tx.QueryRowContext(ctx, `SELECT name FROM categories WHERE id = ?`, category.ID).Scan(&oldName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("category %s: %w", category.ID, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE categories
+		SET name = ?, parent_id = ?, color = ?, icon = ?, archived = ?, updated_at = ?
+		WHERE id = ?`,
+		category.Name,
+		nullString(category.ParentID),
+		category.Color,
+		category.Icon,
+		category.Archived,
+		formatTime(category.UpdatedAt),
+		category.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+
+	if oldName != category.Name {
+		if err := repointCategory(ctx, tx, category.UserID, category.Kind, oldName, category.Name); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Merge moves every record filed under source to target and removes source
+func (r *CategoryRepository) Merge(ctx context.Context, sourceID, targetID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to merge categories: %w", err)
+	}
+	defer tx.Rollback()
+
+	source, err := r.scanOne(tx.QueryRowContext(ctx, `SELECT `+categoryColumns+` FROM categories WHERE id = ?`, sourceID), sourceID)
+	if err != nil {
+		return err
+	}
+	target, err := r.scanOne(tx.QueryRowContext(ctx, `SELECT `+categoryColumns+` FROM categories WHERE id = ?`, targetID), targetID)
+	if err != nil {
+		return err
+	}
+
+	if err := repointCategory(ctx, tx, source.UserID, source.Kind, source.Name, target.Name); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE categories SET parent_id = ? WHERE parent_id = ?`, target.ID, source.ID); err != nil {
+		return fmt.Errorf("failed to move child categories: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM categories WHERE id = ?`, source.ID); err != nil {
+		return fmt.Errorf("failed to delete merged category: %w", err)
+	}
+	// The target takes over the source's role unless it has one of its own
+	if source.Role != "" && target.Role == "" {
+		if _, err := tx.ExecContext(ctx, `UPDATE categories SET role = ? WHERE id = ?`, source.Role, target.ID); err != nil {
+			return fmt.Errorf("failed to move category role: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// scanOne reads a single category, mapping a missing row to ErrNotFound
+func (r *CategoryRepository) scanOne(row *sql.Row, key string) (*repositories.Category, error) {
+	category, err := scanCategory(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("category %s: %w", key, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return category, nil
+}
+
+// repointCategory rewrites every reference to a category name within a transaction
+func repointCategory(ctx context.Context, tx *sql.Tx, userID, kind, from, to string) error {
+	for _, ref := range categoryReferences[kind] {
+		scope := ref.userScope
+		if scope == "" {
+			scope = `user_id = ?`
+		}
+		where := scope + ` AND ` + ref.column + ` = ?`
+		args := []interface{}{userID, from}
+		if ref.kindColumn != "" {
+			where += ` AND ` + ref.kindColumn + ` = ?`
+			args = append(args, kind)
+		}
+
+		if ref.combine != nil && !strings.EqualFold(from, to) {
+			if err := ref.combine(ctx, tx, userID, kind, from, to); err != nil {
+				return err
+			}
+		}
+
+		update := `UPDATE `
+		if ref.unique {
+			update = `UPDATE OR IGNORE `
+		}
+		_, err := tx.ExecContext(ctx,
+			update+ref.table+` SET `+ref.column+` = ? WHERE `+where,
+			append([]interface{}{to}, args...)...,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update %s.%s: %w", ref.table, ref.column, err)
+		}
+
+		// Rows left behind collided with the target's and are dropped
+		if ref.unique && !strings.EqualFold(from, to) {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM `+ref.table+` WHERE `+where, args...); err != nil {
+				return fmt.Errorf("failed to clean up %s.%s: %w", ref.table, ref.column, err)
+			}
+		}
+	}
+	return nil
+}
+
+// combineBudgets adds the source's budgets to the target's in months where
+// both have one, so the merged category keeps both limits. Budgets in
+// different currencies can't be added, so the merge fails naming the months.
+func combineBudgets(ctx context.Context, tx *sql.Tx, userID, kind, from, to string) error {
+	type collision struct {
+		month, targetID                string
+		source, target                 decimal.Decimal
+		sourceCurrency, targetCurrency string
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT s.month, s.amount, s.currency, t.id, t.amount, t.currency
+		FROM budgets s JOIN budgets t ON t.user_id = s.user_id AND t.kind = s.kind AND t.month = s.month
+		WHERE s.user_id = ? AND s.kind = ? AND s.category = ? AND t.category = ?
+		ORDER BY s.month`,
+		userID, kind, from, to,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to find colliding budgets: %w", err)
+	}
+	var collisions []collision
+	for rows.Next() {
+		var c collision
+		var source, target string
+		if err := rows.Scan(&c.month, &source, &c.sourceCurrency, &c.targetID, &target, &c.targetCurrency); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read colliding budget: %w", err)
+		}
+		if c.source, err = decimal.NewFromString(source); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read colliding budget: %w", err)
+		}
+		if c.target, err = decimal.NewFromString(target); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read colliding budget: %w", err)
+		}
+		collisions = append(collisions, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to find colliding budgets: %w", err)
+	}
+
+	var mismatched []string
+	for _, c := range collisions {
+		if !strings.EqualFold(c.sourceCurrency, c.targetCurrency) {
+			month, err := parseTime(c.month)
+			if err != nil {
+				return fmt.Errorf("failed to read colliding budget: %w", err)
+			}
+			mismatched = append(mismatched, month.Format("2006-01"))
+		}
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("budgets for %s and %s are in different currencies in %s: %w",
+			from, to, strings.Join(mismatched, ", "), repositories.ErrConflict)
+	}
+
+	now := formatTime(time.Now())
+	for _, c := range collisions {
+		_, err := tx.ExecContext(ctx, `UPDATE budgets SET amount = ?, updated_at = ? WHERE id = ?`,
+			c.source.Add(c.target).String(), now, c.targetID)
+		if err != nil {
+			return fmt.Errorf("failed to combine budgets: %w", err)
+		}
+	}
+	return nil
+}
+
+// scanCategory reads a single category row selected with categoryColumns
+func scanCategory(row rowScanner) (*repositories.Category, error) {
+	var (
+		category             repositories.Category
+		parentID             sql.NullString
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&category.ID,
+		&category.UserID,
+		&category.Kind,
+		&category.Name,
+		&parentID,
+		&category.Color,
+		&category.Icon,
+		&category.Archived,
+		&category.Role,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	category.ParentID = parentID.String
+	if category.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if category.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
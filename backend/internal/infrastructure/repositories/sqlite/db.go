@@ -0,0 +1,99 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/shopspring/decimal"
+)
+
+// timeLayout is a fixed-width UTC layout so stored timestamps sort lexically
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+// Open opens the SQLite database at path and applies pending migrations.
+// Use ":memory:" for a throwaway database.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	if path != ":memory:" {
+		if dir := filepath.Dir(path); dir != "." {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return nil, fmt.Errorf("failed to create database directory: %w", err)
+			}
+		}
+	}
+
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000", path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite allows a single writer; one connection avoids "database is locked"
+	// errors and keeps an in-memory database shared between queries.
+	db.SetMaxOpenConns(1)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := Migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return db, nil
+}
+
+// formatTime converts a time to its stored representation
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// parseTime converts a stored timestamp back to a time
+func parseTime(s string) (time.Time, error) {
+	return time.Parse(timeLayout, s)
+}
+
+// nullTime converts an optional time to its stored representation
+func nullTime(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatTime(*t), Valid: true}
+}
+
+// parseNullTime converts an optional stored timestamp back to a time
+func parseNullTime(s sql.NullString) (*time.Time, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	t, err := parseTime(s.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// nullDecimal converts an optional amount to its stored representation
+func nullDecimal(d *decimal.Decimal) sql.NullString {
+	if d == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: d.String(), Valid: true}
+}
+
+// parseNullDecimal converts an optional stored amount back to a decimal
+func parseNullDecimal(s sql.NullString) (*decimal.Decimal, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	d, err := decimal.NewFromString(s.String)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", s.String, err)
+	}
+	return &d, nil
+}
@@ -0,0 +1,214 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventRepository implements the repositories.EventRepository interface
+type EventRepository struct {
+	db *sql.DB
+}
+
+// NewEventRepository creates a new SQLite event repository
+func NewEventRepository(db *sql.DB) repositories.EventRepository {
+	return &EventRepository{
+		db: db,
+	}
+}
+
+const eventColumns = `id, user_id, name, start_date, end_date, auto_attach, budget, currency, note, created_at, updated_at`
+
+// Create creates a new event
+func (r *EventRepository) Create(ctx context.Context, event *repositories.Event) error {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = now
+	}
+	if event.UpdatedAt.IsZero() {
+		event.UpdatedAt = now
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO events (`+eventColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.ID,
+		event.UserID,
+		event.Name,
+		formatTime(event.StartDate),
+		formatTime(event.EndDate),
+		event.AutoAttach,
+		nullDecimal(event.Budget),
+		event.Currency,
+		event.Note,
+		formatTime(event.CreatedAt),
+		formatTime(event.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+	return nil
+}
+
+// GetByID gets an event by ID
+func (r *EventRepository) GetByID(ctx context.Context, id string) (*repositories.Event, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+eventColumns+` FROM events WHERE id = ?`, id)
+
+	event, err := scanEvent(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("event %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return event, nil
+}
+
+// GetByUserID gets every event for a user, most recent first
+func (r *EventRepository) GetByUserID(ctx context.Context, userID string) ([]*repositories.Event, error) {
+	return r.query(ctx,
+		`SELECT `+eventColumns+` FROM events WHERE user_id = ? ORDER BY start_date DESC, name`,
+		userID,
+	)
+}
+
+// GetAutoAttaching gets a user's auto-attaching events whose dates include day, latest start first
+func (r *EventRepository) GetAutoAttaching(ctx context.Context, userID string, day time.Time) ([]*repositories.Event, error) {
+	return r.query(ctx,
+		`SELECT `+eventColumns+` FROM events
+		WHERE user_id = ? AND auto_attach = 1 AND start_date <= ? AND end_date >= ?
+		ORDER BY start_date DESC, created_at DESC`,
+		userID, formatTime(day), formatTime(day),
+	)
+}
+
+// Update updates an event
+func (r *EventRepository) Update(ctx context.Context, event *repositories.Event) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE events
+		SET name = ?, start_date = ?, end_date = ?, auto_attach = ?, budget = ?, currency = ?, note = ?, updated_at = ?
+		WHERE id = ?`,
+		event.Name,
+		formatTime(event.StartDate),
+		formatTime(event.EndDate),
+		event.AutoAttach,
+		nullDecimal(event.Budget),
+		event.Currency,
+		event.Note,
+		formatTime(event.UpdatedAt),
+		event.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update event: %w", err)
+	}
+	return requireAffected(result, "event", event.ID)
+}
+
+// Delete deletes an event; its expenses are kept but detached
+func (r *EventRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM events WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+	return requireAffected(result, "event", id)
+}
+
+// AttachUnassigned attaches a user's expenses dated in [start, end) that aren't
+// in any event, returning how many were attached
+func (r *EventRepository) AttachUnassigned(ctx context.Context, eventID, userID string, start, end time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE expenses SET event_id = ?
+		WHERE user_id = ? AND event_id IS NULL AND date >= ? AND date < ?`,
+		eventID, userID, formatTime(start), formatTime(end),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to attach expenses: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+// SetExpenseEvent attaches an expense to an event, or detaches it when eventID is empty
+func (r *EventRepository) SetExpenseEvent(ctx context.Context, expenseID, eventID string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE expenses SET event_id = ? WHERE id = ?`, nullString(eventID), expenseID)
+	if err != nil {
+		return fmt.Errorf("failed to set expense event: %w", err)
+	}
+	return requireAffected(result, "expense", expenseID)
+}
+
+// query runs a SELECT over events and scans every row
+func (r *EventRepository) query(ctx context.Context, query string, args ...interface{}) ([]*repositories.Event, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*repositories.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	return events, nil
+}
+
+// scanEvent reads a single row selected with eventColumns
+func scanEvent(row rowScanner) (*repositories.Event, error) {
+	var (
+		event                repositories.Event
+		startDate, endDate   string
+		budget               sql.NullString
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&event.ID,
+		&event.UserID,
+		&event.Name,
+		&startDate,
+		&endDate,
+		&event.AutoAttach,
+		&budget,
+		&event.Currency,
+		&event.Note,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.StartDate, err = parseTime(startDate); err != nil {
+		return nil, err
+	}
+	if event.EndDate, err = parseTime(endDate); err != nil {
+		return nil, err
+	}
+	if event.Budget, err = parseNullDecimal(budget); err != nil {
+		return nil, err
+	}
+	if event.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if event.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
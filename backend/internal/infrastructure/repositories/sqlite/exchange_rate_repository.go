@@ -0,0 +1,176 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRateRepository implements the repositories.ExchangeRateRepository interface
+type ExchangeRateRepository struct {
+	db *sql.DB
+}
+
+// NewExchangeRateRepository creates a new SQLite exchange rate repository
+func NewExchangeRateRepository(db *sql.DB) repositories.ExchangeRateRepository {
+	return &ExchangeRateRepository{
+		db: db,
+	}
+}
+
+const exchangeRateColumns = `id, user_id, from_currency, to_currency, date, rate, created_at, updated_at`
+
+// Upsert saves rates in a single transaction.
+// A rate for an existing pair and date replaces the stored rate but keeps its ID.
+func (r *ExchangeRateRepository) Upsert(ctx context.Context, rates []*repositories.ExchangeRate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to save exchange rates: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, rate := range rates {
+		if rate.ID == "" {
+			rate.ID = uuid.NewString()
+		}
+		if rate.CreatedAt.IsZero() {
+			rate.CreatedAt = now
+		}
+		if rate.UpdatedAt.IsZero() {
+			rate.UpdatedAt = now
+		}
+
+		err := tx.QueryRowContext(ctx,
+			`INSERT INTO exchange_rates (`+exchangeRateColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (user_id, from_currency, to_currency, date)
+			DO UPDATE SET rate = excluded.rate, updated_at = excluded.updated_at
+			RETURNING id`,
+			rate.ID,
+			rate.UserID,
+			rate.FromCurrency,
+			rate.ToCurrency,
+			formatTime(rate.Date),
+			rate.Rate.String(),
+			formatTime(rate.CreatedAt),
+			formatTime(rate.UpdatedAt),
+		).Scan(&rate.ID)
+		if err != nil {
+			return fmt.Errorf("failed to save exchange rate %s/%s: %w", rate.FromCurrency, rate.ToCurrency, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID gets a rate by ID
+func (r *ExchangeRateRepository) GetByID(ctx context.Context, id string) (*repositories.ExchangeRate, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+exchangeRateColumns+` FROM exchange_rates WHERE id = ?`, id)
+
+	rate, err := scanExchangeRate(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("exchange rate %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return rate, nil
+}
+
+// GetByUserID gets all rates for a user
+func (r *ExchangeRateRepository) GetByUserID(ctx context.Context, userID string) ([]*repositories.ExchangeRate, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+exchangeRateColumns+` FROM exchange_rates
+		WHERE user_id = ?
+		ORDER BY date DESC, from_currency, to_currency`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []*repositories.ExchangeRate
+	for rows.Next() {
+		rate, err := scanExchangeRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read exchange rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query exchange rates: %w", err)
+	}
+	return rates, nil
+}
+
+// GetLatest gets the most recent rate for a pair dated on or before the given day
+func (r *ExchangeRateRepository) GetLatest(ctx context.Context, userID, from, to string, on time.Time) (*repositories.ExchangeRate, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+exchangeRateColumns+` FROM exchange_rates
+		WHERE user_id = ? AND from_currency = ? AND to_currency = ? AND date <= ?
+		ORDER BY date DESC
+		LIMIT 1`,
+		userID, from, to, formatTime(on),
+	)
+
+	rate, err := scanExchangeRate(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("exchange rate %s/%s: %w", from, to, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return rate, nil
+}
+
+// Delete deletes a rate by ID
+func (r *ExchangeRateRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM exchange_rates WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete exchange rate: %w", err)
+	}
+	return requireAffected(result, "exchange rate", id)
+}
+
+// scanExchangeRate reads a single rate row selected with exchangeRateColumns
+func scanExchangeRate(row rowScanner) (*repositories.ExchangeRate, error) {
+	var (
+		rate                 repositories.ExchangeRate
+		date, value          string
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&rate.ID,
+		&rate.UserID,
+		&rate.FromCurrency,
+		&rate.ToCurrency,
+		&date,
+		&value,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if rate.Rate, err = decimal.NewFromString(value); err != nil {
+		return nil, fmt.Errorf("invalid stored rate %q: %w", value, err)
+	}
+	if rate.Date, err = parseTime(date); err != nil {
+		return nil, err
+	}
+	if rate.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if rate.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"nestmate-backend/internal/domain/repositories"
+	"strings"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // ExpenseRepository implements the repositories.ExpenseRepository interface
@@ -20,32 +25,412 @@ func NewExpenseRepository(db *sql.DB) repositories.ExpenseRepository {
 	}
 }
 
-// Create creates a new expense
+const expenseColumns = `id, user_id, amount, currency, description, date, main_category, sub_category, account_id, tax_section, amortize_months, reimbursable, expected_payer, event_id, import_batch_id, created_at, updated_at`
+
+// Create creates a new expense along with its line items
 func (r *ExpenseRepository) Create(ctx context.Context, expense *repositories.Expense) error {
-	// Implementation will be added in a future task
-	return errors.New("not implemented")
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertExpense(ctx, tx, expense); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit expense: %w", err)
+	}
+	return nil
 }
 
 // GetByID gets an expense by ID
 func (r *ExpenseRepository) GetByID(ctx context.Context, id string) (*repositories.Expense, error) {
-	// Implementation will be added in a future task
-	return nil, errors.New("not implemented")
+	row := r.db.QueryRowContext(ctx, `SELECT `+expenseColumns+` FROM expenses WHERE id = ?`, id)
+
+	expense, err := scanExpense(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("expense %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense: %w", err)
+	}
+	if err := r.loadItems(ctx, []*repositories.Expense{expense}); err != nil {
+		return nil, err
+	}
+	return expense, nil
 }
 
 // GetByUserIDAndDateRange gets expenses by user ID and date range
 func (r *ExpenseRepository) GetByUserIDAndDateRange(ctx context.Context, userID string, startDate, endDate time.Time) ([]*repositories.Expense, error) {
-	// Implementation will be added in a future task
-	return nil, errors.New("not implemented")
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+expenseColumns+` FROM expenses
+		WHERE user_id = ? AND date >= ? AND date < ?
+		ORDER BY date, created_at`,
+		userID, formatTime(startDate), formatTime(endDate),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expenses: %w", err)
+	}
+	defer rows.Close()
+
+	var expenses []*repositories.Expense
+	for rows.Next() {
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read expense: %w", err)
+		}
+		expenses = append(expenses, expense)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query expenses: %w", err)
+	}
+	if err := r.loadItems(ctx, expenses); err != nil {
+		return nil, err
+	}
+	return expenses, nil
 }
 
-// Update updates an expense
+// GetByUserID gets expenses by user ID and filters, most recent first unless
+// filters.OldestFirst is set
+func (r *ExpenseRepository) GetByUserID(ctx context.Context, userID string, filters *repositories.ExpenseFilters) ([]*repositories.Expense, error) {
+	where, args := expenseFilterClause(userID, filters)
+	order := ` ORDER BY date DESC, created_at DESC, id DESC`
+	if filters != nil && filters.OldestFirst {
+		order = ` ORDER BY date, created_at, id`
+	}
+	query := `SELECT ` + expenseColumns + ` FROM expenses WHERE ` + where + order
+	if filters != nil && filters.Limit > 0 {
+		query += ` LIMIT ? OFFSET ?`
+		args = append(args, filters.Limit, filters.Offset)
+	} else if filters != nil && filters.Offset > 0 {
+		query += ` LIMIT -1 OFFSET ?`
+		args = append(args, filters.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expenses: %w", err)
+	}
+	defer rows.Close()
+
+	var expenses []*repositories.Expense
+	for rows.Next() {
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read expense: %w", err)
+		}
+		expenses = append(expenses, expense)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query expenses: %w", err)
+	}
+	if err := r.loadItems(ctx, expenses); err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+// CountByUserID counts expenses by user ID and filters
+func (r *ExpenseRepository) CountByUserID(ctx context.Context, userID string, filters *repositories.ExpenseFilters) (int, error) {
+	where, args := expenseFilterClause(userID, filters)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM expenses WHERE `+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count expenses: %w", err)
+	}
+	return count, nil
+}
+
+// Update updates an expense, replacing its line items
 func (r *ExpenseRepository) Update(ctx context.Context, expense *repositories.Expense) error {
-	// Implementation will be added in a future task
-	return errors.New("not implemented")
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE expenses
+		SET user_id = ?, amount = ?, currency = ?, description = ?, date = ?, main_category = ?, sub_category = ?, account_id = ?, tax_section = ?, amortize_months = ?, reimbursable = ?, expected_payer = ?, event_id = ?, updated_at = ?
+		WHERE id = ?`,
+		expense.UserID,
+		expense.Amount.String(),
+		expense.Currency,
+		expense.Description,
+		formatTime(expense.Date),
+		expense.MainCategory,
+		expense.SubCategory,
+		nullString(expense.AccountID),
+		nullString(expense.TaxSection),
+		expense.AmortizeMonths,
+		expense.Reimbursable,
+		expense.ExpectedPayer,
+		nullString(expense.EventID),
+		formatTime(expense.UpdatedAt),
+		expense.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update expense: %w", err)
+	}
+	if err := requireAffected(result, "expense", expense.ID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM expense_items WHERE expense_id = ?`, expense.ID); err != nil {
+		return fmt.Errorf("failed to replace expense items: %w", err)
+	}
+	if err := insertExpenseItems(ctx, tx, expense); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit expense: %w", err)
+	}
+	return nil
 }
 
 // Delete deletes an expense by ID
 func (r *ExpenseRepository) Delete(ctx context.Context, id string) error {
-	// Implementation will be added in a future task
-	return errors.New("not implemented")
-}
\ No newline at end of file
+	result, err := r.db.ExecContext(ctx, `DELETE FROM expenses WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete expense: %w", err)
+	}
+	return requireAffected(result, "expense", id)
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertExpense inserts an expense and its line items, filling in IDs and
+// timestamps when unset
+func insertExpense(ctx context.Context, db execer, expense *repositories.Expense) error {
+	if expense.ID == "" {
+		expense.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if expense.CreatedAt.IsZero() {
+		expense.CreatedAt = now
+	}
+	if expense.UpdatedAt.IsZero() {
+		expense.UpdatedAt = now
+	}
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO expenses (`+expenseColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		expense.ID,
+		expense.UserID,
+		expense.Amount.String(),
+		expense.Currency,
+		expense.Description,
+		formatTime(expense.Date),
+		expense.MainCategory,
+		expense.SubCategory,
+		nullString(expense.AccountID),
+		nullString(expense.TaxSection),
+		expense.AmortizeMonths,
+		expense.Reimbursable,
+		expense.ExpectedPayer,
+		nullString(expense.EventID),
+		nullString(expense.ImportBatchID),
+		formatTime(expense.CreatedAt),
+		formatTime(expense.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create expense: %w", err)
+	}
+	return insertExpenseItems(ctx, db, expense)
+}
+
+// insertExpenseItems inserts an expense's line items in order, filling in their IDs when unset
+func insertExpenseItems(ctx context.Context, db execer, expense *repositories.Expense) error {
+	for i, item := range expense.Items {
+		if item.ID == "" {
+			item.ID = uuid.NewString()
+		}
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO expense_items (`+expenseItemColumns+`, expense_id, user_id, position) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			item.ID,
+			item.Description,
+			item.Amount.String(),
+			item.SubCategory,
+			expense.ID,
+			expense.UserID,
+			i,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create expense item: %w", err)
+		}
+	}
+	return nil
+}
+
+// expenseItemColumns are the line item columns read back into repositories.ExpenseItem
+const expenseItemColumns = `id, description, amount, sub_category`
+
+// itemBatchSize caps the number of expense IDs bound into a single line item query
+const itemBatchSize = 500
+
+// loadItems fills in the line items of the given expenses
+func (r *ExpenseRepository) loadItems(ctx context.Context, expenses []*repositories.Expense) error {
+	byID := make(map[string]*repositories.Expense, len(expenses))
+	for _, expense := range expenses {
+		byID[expense.ID] = expense
+	}
+
+	for start := 0; start < len(expenses); start += itemBatchSize {
+		end := start + itemBatchSize
+		if end > len(expenses) {
+			end = len(expenses)
+		}
+		batch := expenses[start:end]
+		args := make([]interface{}, len(batch))
+		for i, expense := range batch {
+			args[i] = expense.ID
+		}
+
+		rows, err := r.db.QueryContext(ctx,
+			`SELECT expense_id, `+expenseItemColumns+` FROM expense_items
+			WHERE expense_id IN (?`+strings.Repeat(", ?", len(batch)-1)+`)
+			ORDER BY expense_id, position`,
+			args...,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to query expense items: %w", err)
+		}
+		for rows.Next() {
+			var (
+				expenseID, amount string
+				item              repositories.ExpenseItem
+			)
+			if err := rows.Scan(&expenseID, &item.ID, &item.Description, &amount, &item.SubCategory); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to read expense item: %w", err)
+			}
+			if item.Amount, err = decimal.NewFromString(amount); err != nil {
+				rows.Close()
+				return fmt.Errorf("invalid stored amount %q: %w", amount, err)
+			}
+			expense := byID[expenseID]
+			expense.Items = append(expense.Items, &item)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return fmt.Errorf("failed to query expense items: %w", err)
+		}
+	}
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanExpense reads a single expense row selected with expenseColumns
+func scanExpense(row rowScanner) (*repositories.Expense, error) {
+	var (
+		expense              repositories.Expense
+		amount, date         string
+		accountID            sql.NullString
+		taxSection           sql.NullString
+		eventID              sql.NullString
+		importBatchID        sql.NullString
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&expense.ID,
+		&expense.UserID,
+		&amount,
+		&expense.Currency,
+		&expense.Description,
+		&date,
+		&expense.MainCategory,
+		&expense.SubCategory,
+		&accountID,
+		&taxSection,
+		&expense.AmortizeMonths,
+		&expense.Reimbursable,
+		&expense.ExpectedPayer,
+		&eventID,
+		&importBatchID,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	expense.AccountID = accountID.String
+	expense.TaxSection = taxSection.String
+	expense.EventID = eventID.String
+	expense.ImportBatchID = importBatchID.String
+	if expense.Amount, err = decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", amount, err)
+	}
+	if expense.Date, err = parseTime(date); err != nil {
+		return nil, err
+	}
+	if expense.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if expense.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+// expenseFilterClause builds the WHERE clause shared by filtered expense queries
+func expenseFilterClause(userID string, filters *repositories.ExpenseFilters) (string, []interface{}) {
+	conditions := []string{"user_id = ?"}
+	args := []interface{}{userID}
+	if filters == nil {
+		return conditions[0], args
+	}
+
+	if filters.StartDate != nil {
+		conditions = append(conditions, "date >= ?")
+		args = append(args, formatTime(*filters.StartDate))
+	}
+	if filters.EndDate != nil {
+		conditions = append(conditions, "date < ?")
+		args = append(args, formatTime(*filters.EndDate))
+	}
+	if filters.MainCategory != "" {
+		conditions = append(conditions, "main_category = ?")
+		args = append(args, filters.MainCategory)
+	}
+	if filters.SubCategory != "" {
+		conditions = append(conditions, "(sub_category = ? OR id IN (SELECT expense_id FROM expense_items WHERE sub_category = ?))")
+		args = append(args, filters.SubCategory, filters.SubCategory)
+	}
+	if filters.AccountID != "" {
+		conditions = append(conditions, "account_id = ?")
+		args = append(args, filters.AccountID)
+	}
+	if filters.Amortized {
+		conditions = append(conditions, "amortize_months > 1")
+	}
+	if filters.Reimbursable {
+		conditions = append(conditions, "reimbursable = 1")
+	}
+	if filters.EventID != "" {
+		conditions = append(conditions, "event_id = ?")
+		args = append(args, filters.EventID)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// requireAffected turns an update or delete that matched no rows into ErrNotFound
+func requireAffected(result sql.Result, kind, id string) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("%s %s: %w", kind, id, repositories.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/repositories"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Open(context.Background(), ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestExpenseRepositoryCRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := NewExpenseRepository(newTestDB(t))
+
+	expense := &repositories.Expense{
+		UserID:       "user-1",
+		Amount:       decimal.RequireFromString("1234.56"),
+		Description:  "Groceries",
+		Date:         time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC),
+		MainCategory: "Chennai House",
+		SubCategory:  "Food",
+	}
+	if err := repo.Create(ctx, expense); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if expense.ID == "" {
+		t.Fatal("Create should assign an ID")
+	}
+
+	got, err := repo.GetByID(ctx, expense.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if !got.Amount.Equal(expense.Amount) {
+		t.Errorf("amount did not round-trip: expected %s, got %s", expense.Amount, got.Amount)
+	}
+	if !got.Date.Equal(expense.Date) || got.MainCategory != "Chennai House" || got.SubCategory != "Food" {
+		t.Errorf("unexpected expense: %+v", got)
+	}
+
+	got.Amount = decimal.RequireFromString("0.1").Add(decimal.RequireFromString("0.2"))
+	got.UpdatedAt = time.Now()
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	updated, _ := repo.GetByID(ctx, expense.ID)
+	if updated.Amount.String() != "0.3" {
+		t.Errorf("expected exact amount 0.3, got %s", updated.Amount)
+	}
+
+	if err := repo.Delete(ctx, expense.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, expense.ID); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, expense.ID); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestExpenseRepositoryDateRange(t *testing.T) {
+	ctx := context.Background()
+	repo := NewExpenseRepository(newTestDB(t))
+
+	dates := []time.Time{
+		time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 18, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, d := range dates {
+		err := repo.Create(ctx, &repositories.Expense{
+			UserID: "user-1", Amount: decimal.NewFromInt(10), Date: d,
+			MainCategory: "Self", SubCategory: "Misc",
+		})
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	// Another user's expense in the same range must not leak
+	_ = repo.Create(ctx, &repositories.Expense{
+		UserID: "user-2", Amount: decimal.NewFromInt(10), Date: dates[1],
+		MainCategory: "Self", SubCategory: "Misc",
+	})
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	got, err := repo.GetByUserIDAndDateRange(ctx, "user-1", start, start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("GetByUserIDAndDateRange failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 expenses in March, got %d", len(got))
+	}
+	if !got[0].Date.Equal(dates[1]) || !got[1].Date.Equal(dates[2]) {
+		t.Errorf("unexpected expenses returned: %v, %v", got[0].Date, got[1].Date)
+	}
+}
+
+func TestExpenseRepositoryItems(t *testing.T) {
+	ctx := context.Background()
+	repo := NewExpenseRepository(newTestDB(t))
+
+	expense := &repositories.Expense{
+		UserID:       "user-1",
+		Amount:       decimal.RequireFromString("1500"),
+		Description:  "Supermarket",
+		Date:         time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		MainCategory: "Self",
+		SubCategory:  "Food",
+		Items: []*repositories.ExpenseItem{
+			{Description: "Vegetables", Amount: decimal.RequireFromString("1000"), SubCategory: "Food"},
+			{Description: "Notebooks", Amount: decimal.RequireFromString("500"), SubCategory: "Education"},
+		},
+	}
+	if err := repo.Create(ctx, expense); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	plain := &repositories.Expense{UserID: "user-1", Amount: decimal.NewFromInt(10), Date: expense.Date, MainCategory: "Self", SubCategory: "Misc"}
+	if err := repo.Create(ctx, plain); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, expense.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if len(got.Items) != 2 || got.Items[0].Description != "Vegetables" || got.Items[1].SubCategory != "Education" || got.Items[0].ID == "" {
+		t.Fatalf("items did not round-trip in order: %+v", got.Items)
+	}
+
+	filtered, err := repo.GetByUserID(ctx, "user-1", &repositories.ExpenseFilters{SubCategory: "Education"})
+	if err != nil {
+		t.Fatalf("GetByUserID failed: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].ID != expense.ID || len(filtered[0].Items) != 2 {
+		t.Errorf("sub-category filter should match line items, got %+v", filtered)
+	}
+
+	got.Items = got.Items[:1]
+	got.Items[0].Amount = got.Amount
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	updated, _ := repo.GetByID(ctx, expense.ID)
+	if len(updated.Items) != 1 || !updated.Items[0].Amount.Equal(decimal.NewFromInt(1500)) {
+		t.Errorf("Update should replace the items, got %+v", updated.Items)
+	}
+
+	if err := repo.Delete(ctx, expense.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	all, err := repo.GetByUserIDAndDateRange(ctx, "user-1", expense.Date, expense.Date.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("GetByUserIDAndDateRange failed: %v", err)
+	}
+	if len(all) != 1 || len(all[0].Items) != 0 {
+		t.Errorf("expected only the plain expense to remain, got %+v", all)
+	}
+}
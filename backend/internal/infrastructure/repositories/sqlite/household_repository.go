@@ -0,0 +1,465 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// HouseholdRepository implements the repositories.HouseholdRepository interface
+type HouseholdRepository struct {
+	db *sql.DB
+}
+
+// NewHouseholdRepository creates a new SQLite household repository
+func NewHouseholdRepository(db *sql.DB) repositories.HouseholdRepository {
+	return &HouseholdRepository{
+		db: db,
+	}
+}
+
+const householdColumns = `id, name, currency, created_by, created_at, updated_at`
+
+const settlementColumns = `id, household_id, from_user_id, to_user_id, amount, date, note, created_by, created_at`
+
+// Create creates a new household and its members in one transaction
+func (r *HouseholdRepository) Create(ctx context.Context, household *repositories.Household) error {
+	if household.ID == "" {
+		household.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if household.CreatedAt.IsZero() {
+		household.CreatedAt = now
+	}
+	if household.UpdatedAt.IsZero() {
+		household.UpdatedAt = now
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO households (`+householdColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+		household.ID,
+		household.Name,
+		household.Currency,
+		household.CreatedBy,
+		formatTime(household.CreatedAt),
+		formatTime(household.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create household: %w", err)
+	}
+	for _, member := range household.Members {
+		member.HouseholdID = household.ID
+		if err := insertHouseholdMember(ctx, tx, member); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit household: %w", err)
+	}
+	return nil
+}
+
+// GetByID gets a household and its members by ID
+func (r *HouseholdRepository) GetByID(ctx context.Context, id string) (*repositories.Household, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+householdColumns+` FROM households WHERE id = ?`, id)
+
+	household, err := scanHousehold(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("household %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get household: %w", err)
+	}
+	if household.Members, err = r.getMembers(ctx, id); err != nil {
+		return nil, err
+	}
+	return household, nil
+}
+
+// GetByMember gets every household the user is a member of, ordered by name
+func (r *HouseholdRepository) GetByMember(ctx context.Context, userID string) ([]*repositories.Household, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+householdColumns+` FROM households
+		WHERE id IN (SELECT household_id FROM household_members WHERE user_id = ?)
+		ORDER BY name`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query households: %w", err)
+	}
+	defer rows.Close()
+
+	var households []*repositories.Household
+	for rows.Next() {
+		household, err := scanHousehold(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read household: %w", err)
+		}
+		households = append(households, household)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query households: %w", err)
+	}
+	rows.Close()
+
+	for _, household := range households {
+		if household.Members, err = r.getMembers(ctx, household.ID); err != nil {
+			return nil, err
+		}
+	}
+	return households, nil
+}
+
+// Update updates a household's name and currency
+func (r *HouseholdRepository) Update(ctx context.Context, household *repositories.Household) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE households SET name = ?, currency = ?, updated_at = ? WHERE id = ?`,
+		household.Name,
+		household.Currency,
+		formatTime(household.UpdatedAt),
+		household.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update household: %w", err)
+	}
+	return requireAffected(result, "household", household.ID)
+}
+
+// AddMember adds a member to a household
+func (r *HouseholdRepository) AddMember(ctx context.Context, member *repositories.HouseholdMember) error {
+	return insertHouseholdMember(ctx, r.db, member)
+}
+
+// RemoveMember removes a member from a household
+func (r *HouseholdRepository) RemoveMember(ctx context.Context, householdID, userID string) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM household_members WHERE household_id = ? AND user_id = ?`,
+		householdID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove household member: %w", err)
+	}
+	return requireAffected(result, "household member", userID)
+}
+
+// SaveSplit saves an expense's split and shares, replacing any earlier ones
+func (r *HouseholdRepository) SaveSplit(ctx context.Context, split *repositories.ExpenseSplit) error {
+	now := time.Now()
+	if split.CreatedAt.IsZero() {
+		split.CreatedAt = now
+	}
+	if split.UpdatedAt.IsZero() {
+		split.UpdatedAt = now
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO expense_splits (expense_id, household_id, method, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(expense_id) DO UPDATE SET household_id = excluded.household_id, method = excluded.method, updated_at = excluded.updated_at`,
+		split.ExpenseID,
+		split.HouseholdID,
+		split.Method,
+		formatTime(split.CreatedAt),
+		formatTime(split.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save expense split: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM expense_shares WHERE expense_id = ?`, split.ExpenseID); err != nil {
+		return fmt.Errorf("failed to save expense split: %w", err)
+	}
+	for _, share := range split.Shares {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO expense_shares (expense_id, user_id, value) VALUES (?, ?, ?)`,
+			split.ExpenseID, share.UserID, share.Value.String(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save expense share: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit expense split: %w", err)
+	}
+	return nil
+}
+
+// GetSplit gets the split for an expense
+func (r *HouseholdRepository) GetSplit(ctx context.Context, expenseID string) (*repositories.ExpenseSplit, error) {
+	splits, err := r.querySplits(ctx, `s.expense_id = ?`, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if len(splits) == 0 {
+		return nil, fmt.Errorf("expense split %s: %w", expenseID, repositories.ErrNotFound)
+	}
+	return splits[0], nil
+}
+
+// GetSplitsByHousehold gets every split in a household
+func (r *HouseholdRepository) GetSplitsByHousehold(ctx context.Context, householdID string) ([]*repositories.ExpenseSplit, error) {
+	return r.querySplits(ctx, `s.household_id = ?`, householdID)
+}
+
+// DeleteSplit deletes the split for an expense along with its shares
+func (r *HouseholdRepository) DeleteSplit(ctx context.Context, expenseID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM expense_splits WHERE expense_id = ?`, expenseID)
+	if err != nil {
+		return fmt.Errorf("failed to delete expense split: %w", err)
+	}
+	return requireAffected(result, "expense split", expenseID)
+}
+
+// CreateSettlement creates a new settlement
+func (r *HouseholdRepository) CreateSettlement(ctx context.Context, settlement *repositories.Settlement) error {
+	if settlement.ID == "" {
+		settlement.ID = uuid.NewString()
+	}
+	if settlement.CreatedAt.IsZero() {
+		settlement.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO settlements (`+settlementColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		settlement.ID,
+		settlement.HouseholdID,
+		settlement.FromUserID,
+		settlement.ToUserID,
+		settlement.Amount.String(),
+		formatTime(settlement.Date),
+		settlement.Note,
+		settlement.CreatedBy,
+		formatTime(settlement.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create settlement: %w", err)
+	}
+	return nil
+}
+
+// GetSettlementByID gets a settlement by ID
+func (r *HouseholdRepository) GetSettlementByID(ctx context.Context, id string) (*repositories.Settlement, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+settlementColumns+` FROM settlements WHERE id = ?`, id)
+
+	settlement, err := scanSettlement(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("settlement %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlement: %w", err)
+	}
+	return settlement, nil
+}
+
+// GetSettlementsByHousehold gets every settlement in a household, oldest first
+func (r *HouseholdRepository) GetSettlementsByHousehold(ctx context.Context, householdID string) ([]*repositories.Settlement, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+settlementColumns+` FROM settlements WHERE household_id = ? ORDER BY date, created_at`,
+		householdID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settlements: %w", err)
+	}
+	defer rows.Close()
+
+	var settlements []*repositories.Settlement
+	for rows.Next() {
+		settlement, err := scanSettlement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read settlement: %w", err)
+		}
+		settlements = append(settlements, settlement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query settlements: %w", err)
+	}
+	return settlements, nil
+}
+
+// DeleteSettlement deletes a settlement by ID
+func (r *HouseholdRepository) DeleteSettlement(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM settlements WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete settlement: %w", err)
+	}
+	return requireAffected(result, "settlement", id)
+}
+
+// getMembers gets a household's members in the order they joined
+func (r *HouseholdRepository) getMembers(ctx context.Context, householdID string) ([]*repositories.HouseholdMember, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT household_id, user_id, display_name, joined_at FROM household_members
+		WHERE household_id = ? ORDER BY joined_at, user_id`,
+		householdID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query household members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*repositories.HouseholdMember
+	for rows.Next() {
+		var (
+			member   repositories.HouseholdMember
+			joinedAt string
+		)
+		if err := rows.Scan(&member.HouseholdID, &member.UserID, &member.DisplayName, &joinedAt); err != nil {
+			return nil, fmt.Errorf("failed to read household member: %w", err)
+		}
+		if member.JoinedAt, err = parseTime(joinedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, &member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query household members: %w", err)
+	}
+	return members, nil
+}
+
+// querySplits gets the splits matching where, each with its shares
+func (r *HouseholdRepository) querySplits(ctx context.Context, where string, args ...interface{}) ([]*repositories.ExpenseSplit, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT s.expense_id, s.household_id, s.method, s.created_at, s.updated_at, sh.user_id, sh.value
+		FROM expense_splits s JOIN expense_shares sh ON sh.expense_id = s.expense_id
+		WHERE `+where+`
+		ORDER BY s.created_at, s.expense_id, sh.user_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expense splits: %w", err)
+	}
+	defer rows.Close()
+
+	var splits []*repositories.ExpenseSplit
+	for rows.Next() {
+		var (
+			split                repositories.ExpenseSplit
+			share                repositories.ExpenseShare
+			createdAt, updatedAt string
+			value                string
+		)
+		err := rows.Scan(&split.ExpenseID, &split.HouseholdID, &split.Method, &createdAt, &updatedAt, &share.UserID, &value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read expense split: %w", err)
+		}
+		if share.Value, err = decimal.NewFromString(value); err != nil {
+			return nil, fmt.Errorf("invalid stored share %q: %w", value, err)
+		}
+
+		if n := len(splits); n > 0 && splits[n-1].ExpenseID == split.ExpenseID {
+			splits[n-1].Shares = append(splits[n-1].Shares, &share)
+			continue
+		}
+		if split.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, err
+		}
+		if split.UpdatedAt, err = parseTime(updatedAt); err != nil {
+			return nil, err
+		}
+		split.Shares = []*repositories.ExpenseShare{&share}
+		splits = append(splits, &split)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query expense splits: %w", err)
+	}
+	return splits, nil
+}
+
+// insertHouseholdMember inserts a member, defaulting its join time to now
+func insertHouseholdMember(ctx context.Context, db execer, member *repositories.HouseholdMember) error {
+	if member.JoinedAt.IsZero() {
+		member.JoinedAt = time.Now()
+	}
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO household_members (household_id, user_id, display_name, joined_at) VALUES (?, ?, ?, ?)`,
+		member.HouseholdID,
+		member.UserID,
+		member.DisplayName,
+		formatTime(member.JoinedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add household member: %w", err)
+	}
+	return nil
+}
+
+// scanHousehold reads a single household row selected with householdColumns
+func scanHousehold(row rowScanner) (*repositories.Household, error) {
+	var (
+		household            repositories.Household
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&household.ID,
+		&household.Name,
+		&household.Currency,
+		&household.CreatedBy,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if household.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if household.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &household, nil
+}
+
+// scanSettlement reads a single settlement row selected with settlementColumns
+func scanSettlement(row rowScanner) (*repositories.Settlement, error) {
+	var (
+		settlement   repositories.Settlement
+		amount, date string
+		createdAt    string
+	)
+	err := row.Scan(
+		&settlement.ID,
+		&settlement.HouseholdID,
+		&settlement.FromUserID,
+		&settlement.ToUserID,
+		&amount,
+		&date,
+		&settlement.Note,
+		&settlement.CreatedBy,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if settlement.Amount, err = decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", amount, err)
+	}
+	if settlement.Date, err = parseTime(date); err != nil {
+		return nil, err
+	}
+	if settlement.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	return &settlement, nil
+}
@@ -0,0 +1,531 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ImportRepository implements the repositories.ImportRepository interface
+type ImportRepository struct {
+	db *sql.DB
+}
+
+// NewImportRepository creates a new SQLite import repository
+func NewImportRepository(db *sql.DB) repositories.ImportRepository {
+	return &ImportRepository{
+		db: db,
+	}
+}
+
+const importMappingColumns = `id, user_id, name, date_column, date_format, amount_column, amount_sign, debit_column, credit_column,
+	description_column, currency_column, main_category_column, sub_category_column, default_main_category, default_sub_category,
+	created_at, updated_at`
+
+const importBatchColumns = `id, user_id, source, status, mapping_id, file_name, bank_name, account_id, expense_count, created_at, undone_at`
+
+const stagedTransactionColumns = `id, batch_id, line, date, description, amount, type, main_category, sub_category,
+	suggested_main_category, suggested_sub_category, confidence, rule_id, needs_review, excluded`
+
+// CreateMapping creates a new column mapping
+func (r *ImportRepository) CreateMapping(ctx context.Context, mapping *repositories.ImportMapping) error {
+	if mapping.ID == "" {
+		mapping.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if mapping.CreatedAt.IsZero() {
+		mapping.CreatedAt = now
+	}
+	if mapping.UpdatedAt.IsZero() {
+		mapping.UpdatedAt = now
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO import_mappings (`+importMappingColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		mapping.ID,
+		mapping.UserID,
+		mapping.Name,
+		mapping.DateColumn,
+		mapping.DateFormat,
+		mapping.AmountColumn,
+		mapping.AmountSign,
+		mapping.DebitColumn,
+		mapping.CreditColumn,
+		mapping.DescriptionColumn,
+		mapping.CurrencyColumn,
+		mapping.MainCategoryColumn,
+		mapping.SubCategoryColumn,
+		mapping.DefaultMainCategory,
+		mapping.DefaultSubCategory,
+		formatTime(mapping.CreatedAt),
+		formatTime(mapping.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create import mapping: %w", err)
+	}
+	return nil
+}
+
+// GetMappingByID gets a column mapping by ID
+func (r *ImportRepository) GetMappingByID(ctx context.Context, id string) (*repositories.ImportMapping, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+importMappingColumns+` FROM import_mappings WHERE id = ?`, id)
+
+	mapping, err := scanImportMapping(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("import mapping %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import mapping: %w", err)
+	}
+	return mapping, nil
+}
+
+// GetMappingsByUserID gets every column mapping for a user, ordered by name
+func (r *ImportRepository) GetMappingsByUserID(ctx context.Context, userID string) ([]*repositories.ImportMapping, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+importMappingColumns+` FROM import_mappings WHERE user_id = ? ORDER BY name`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query import mappings: %w", err)
+	}
+	defer rows.Close()
+
+	var mappings []*repositories.ImportMapping
+	for rows.Next() {
+		mapping, err := scanImportMapping(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read import mapping: %w", err)
+		}
+		mappings = append(mappings, mapping)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query import mappings: %w", err)
+	}
+	return mappings, nil
+}
+
+// UpdateMapping updates a column mapping
+func (r *ImportRepository) UpdateMapping(ctx context.Context, mapping *repositories.ImportMapping) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE import_mappings
+		SET name = ?, date_column = ?, date_format = ?, amount_column = ?, amount_sign = ?, debit_column = ?, credit_column = ?,
+			description_column = ?, currency_column = ?, main_category_column = ?, sub_category_column = ?,
+			default_main_category = ?, default_sub_category = ?, updated_at = ?
+		WHERE id = ?`,
+		mapping.Name,
+		mapping.DateColumn,
+		mapping.DateFormat,
+		mapping.AmountColumn,
+		mapping.AmountSign,
+		mapping.DebitColumn,
+		mapping.CreditColumn,
+		mapping.DescriptionColumn,
+		mapping.CurrencyColumn,
+		mapping.MainCategoryColumn,
+		mapping.SubCategoryColumn,
+		mapping.DefaultMainCategory,
+		mapping.DefaultSubCategory,
+		formatTime(mapping.UpdatedAt),
+		mapping.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update import mapping: %w", err)
+	}
+	return requireAffected(result, "import mapping", mapping.ID)
+}
+
+// DeleteMapping deletes a column mapping by ID
+func (r *ImportRepository) DeleteMapping(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM import_mappings WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete import mapping: %w", err)
+	}
+	return requireAffected(result, "import mapping", id)
+}
+
+// CreateBatch saves a batch and its expenses in one transaction, so a
+// failure part way through leaves nothing behind
+func (r *ImportRepository) CreateBatch(ctx context.Context, batch *repositories.ImportBatch, expenses []*repositories.Expense) error {
+	if batch.Status == "" {
+		batch.Status = "committed"
+	}
+	batch.ExpenseCount = len(expenses)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertImportBatch(ctx, tx, batch); err != nil {
+		return err
+	}
+	for _, expense := range expenses {
+		expense.ImportBatchID = batch.ID
+		if err := insertExpense(ctx, tx, expense); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import batch: %w", err)
+	}
+	return nil
+}
+
+// GetBatchByID gets an import batch by ID
+func (r *ImportRepository) GetBatchByID(ctx context.Context, id string) (*repositories.ImportBatch, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+importBatchColumns+` FROM import_batches WHERE id = ?`, id)
+
+	batch, err := scanImportBatch(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("import batch %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import batch: %w", err)
+	}
+	return batch, nil
+}
+
+// GetBatchesByUserID gets every import batch for a user, most recent first
+func (r *ImportRepository) GetBatchesByUserID(ctx context.Context, userID string) ([]*repositories.ImportBatch, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+importBatchColumns+` FROM import_batches WHERE user_id = ? ORDER BY created_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query import batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []*repositories.ImportBatch
+	for rows.Next() {
+		batch, err := scanImportBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read import batch: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query import batches: %w", err)
+	}
+	return batches, nil
+}
+
+// UndoBatch deletes every expense still attached to the batch and marks it
+// undone in one transaction
+func (r *ImportRepository) UndoBatch(ctx context.Context, id string, undoneAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE import_batches SET status = 'undone', undone_at = ? WHERE id = ? AND undone_at IS NULL`,
+		formatTime(undoneAt), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to undo import batch: %w", err)
+	}
+	if err := requireAffected(result, "import batch", id); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM expenses WHERE import_batch_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete imported expenses: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import undo: %w", err)
+	}
+	return nil
+}
+
+// CreateStagedBatch saves a staged batch and its transactions in one
+// transaction. The batch has no expenses until it's confirmed.
+func (r *ImportRepository) CreateStagedBatch(ctx context.Context, batch *repositories.ImportBatch, transactions []*repositories.StagedTransaction) error {
+	if batch.Status == "" {
+		batch.Status = "staged"
+	}
+	batch.ExpenseCount = 0
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertImportBatch(ctx, tx, batch); err != nil {
+		return err
+	}
+	for _, transaction := range transactions {
+		if transaction.ID == "" {
+			transaction.ID = uuid.NewString()
+		}
+		transaction.BatchID = batch.ID
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO staged_transactions (`+stagedTransactionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			transaction.ID,
+			transaction.BatchID,
+			transaction.Line,
+			formatTime(transaction.Date),
+			transaction.Description,
+			transaction.Amount.String(),
+			transaction.Type,
+			transaction.MainCategory,
+			transaction.SubCategory,
+			transaction.SuggestedMainCategory,
+			transaction.SuggestedSubCategory,
+			transaction.Confidence,
+			transaction.RuleID,
+			transaction.NeedsReview,
+			transaction.Excluded,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create staged transaction: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit staged import: %w", err)
+	}
+	return nil
+}
+
+// GetStagedTransactions gets a batch's staged transactions in statement order
+func (r *ImportRepository) GetStagedTransactions(ctx context.Context, batchID string) ([]*repositories.StagedTransaction, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+stagedTransactionColumns+` FROM staged_transactions WHERE batch_id = ? ORDER BY line`,
+		batchID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query staged transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*repositories.StagedTransaction
+	for rows.Next() {
+		transaction, err := scanStagedTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read staged transaction: %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query staged transactions: %w", err)
+	}
+	return transactions, nil
+}
+
+// UpdateStagedTransaction updates the reviewable fields of a staged transaction
+func (r *ImportRepository) UpdateStagedTransaction(ctx context.Context, transaction *repositories.StagedTransaction) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE staged_transactions
+		SET description = ?, main_category = ?, sub_category = ?, needs_review = ?, excluded = ?
+		WHERE id = ?`,
+		transaction.Description,
+		transaction.MainCategory,
+		transaction.SubCategory,
+		transaction.NeedsReview,
+		transaction.Excluded,
+		transaction.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update staged transaction: %w", err)
+	}
+	return requireAffected(result, "staged transaction", transaction.ID)
+}
+
+// ConfirmBatch saves a staged batch's expenses and the category corrections
+// made while reviewing it, and marks it committed in one transaction. A batch
+// that's no longer staged is reported as not found, so confirming twice can't
+// save the expenses twice.
+func (r *ImportRepository) ConfirmBatch(ctx context.Context, id string, expenses []*repositories.Expense, corrections []*repositories.CategoryCorrection) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE import_batches SET status = 'committed', expense_count = ? WHERE id = ? AND status = 'staged'`,
+		len(expenses), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to confirm import batch: %w", err)
+	}
+	if err := requireAffected(result, "staged import batch", id); err != nil {
+		return err
+	}
+
+	for _, expense := range expenses {
+		expense.ImportBatchID = id
+		if err := insertExpense(ctx, tx, expense); err != nil {
+			return err
+		}
+	}
+	for _, correction := range corrections {
+		if err := insertCategoryCorrection(ctx, tx, correction); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import batch: %w", err)
+	}
+	return nil
+}
+
+// insertImportBatch inserts a batch row, filling in its ID, source and
+// creation time when they're unset
+func insertImportBatch(ctx context.Context, db execer, batch *repositories.ImportBatch) error {
+	if batch.ID == "" {
+		batch.ID = uuid.NewString()
+	}
+	if batch.Source == "" {
+		batch.Source = "csv"
+	}
+	if batch.CreatedAt.IsZero() {
+		batch.CreatedAt = time.Now()
+	}
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO import_batches (`+importBatchColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULL)`,
+		batch.ID,
+		batch.UserID,
+		batch.Source,
+		batch.Status,
+		batch.MappingID,
+		batch.FileName,
+		batch.BankName,
+		nullString(batch.AccountID),
+		batch.ExpenseCount,
+		formatTime(batch.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create import batch: %w", err)
+	}
+	return nil
+}
+
+// scanImportMapping reads a single mapping row selected with importMappingColumns
+func scanImportMapping(row rowScanner) (*repositories.ImportMapping, error) {
+	var (
+		mapping              repositories.ImportMapping
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&mapping.ID,
+		&mapping.UserID,
+		&mapping.Name,
+		&mapping.DateColumn,
+		&mapping.DateFormat,
+		&mapping.AmountColumn,
+		&mapping.AmountSign,
+		&mapping.DebitColumn,
+		&mapping.CreditColumn,
+		&mapping.DescriptionColumn,
+		&mapping.CurrencyColumn,
+		&mapping.MainCategoryColumn,
+		&mapping.SubCategoryColumn,
+		&mapping.DefaultMainCategory,
+		&mapping.DefaultSubCategory,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if mapping.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &mapping, nil
+}
+
+// scanImportBatch reads a single batch row selected with importBatchColumns
+func scanImportBatch(row rowScanner) (*repositories.ImportBatch, error) {
+	var (
+		batch     repositories.ImportBatch
+		accountID sql.NullString
+		createdAt string
+		undoneAt  sql.NullString
+	)
+	err := row.Scan(
+		&batch.ID,
+		&batch.UserID,
+		&batch.Source,
+		&batch.Status,
+		&batch.MappingID,
+		&batch.FileName,
+		&batch.BankName,
+		&accountID,
+		&batch.ExpenseCount,
+		&createdAt,
+		&undoneAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	batch.AccountID = accountID.String
+
+	if batch.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if undoneAt.Valid {
+		t, err := parseTime(undoneAt.String)
+		if err != nil {
+			return nil, err
+		}
+		batch.UndoneAt = &t
+	}
+	return &batch, nil
+}
+
+// scanStagedTransaction reads a single row selected with stagedTransactionColumns
+func scanStagedTransaction(row rowScanner) (*repositories.StagedTransaction, error) {
+	var (
+		transaction  repositories.StagedTransaction
+		date, amount string
+	)
+	err := row.Scan(
+		&transaction.ID,
+		&transaction.BatchID,
+		&transaction.Line,
+		&date,
+		&transaction.Description,
+		&amount,
+		&transaction.Type,
+		&transaction.MainCategory,
+		&transaction.SubCategory,
+		&transaction.SuggestedMainCategory,
+		&transaction.SuggestedSubCategory,
+		&transaction.Confidence,
+		&transaction.RuleID,
+		&transaction.NeedsReview,
+		&transaction.Excluded,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Date, err = parseTime(date); err != nil {
+		return nil, err
+	}
+	if transaction.Amount, err = decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", amount, err)
+	}
+	return &transaction, nil
+}
@@ -0,0 +1,203 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// IncomeRepository implements the repositories.IncomeRepository interface
+type IncomeRepository struct {
+	db *sql.DB
+}
+
+// NewIncomeRepository creates a new SQLite income repository
+func NewIncomeRepository(db *sql.DB) repositories.IncomeRepository {
+	return &IncomeRepository{
+		db: db,
+	}
+}
+
+const incomeColumns = `id, user_id, amount, currency, month, source, is_recurring, account_id, carried_from_id, created_at, updated_at`
+
+// Create creates a new income
+func (r *IncomeRepository) Create(ctx context.Context, income *repositories.Income) error {
+	if income.ID == "" {
+		income.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if income.CreatedAt.IsZero() {
+		income.CreatedAt = now
+	}
+	if income.UpdatedAt.IsZero() {
+		income.UpdatedAt = now
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO incomes (`+incomeColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		income.ID,
+		income.UserID,
+		income.Amount.String(),
+		income.Currency,
+		formatTime(income.Month),
+		income.Source,
+		income.IsRecurring,
+		nullString(income.AccountID),
+		nullString(income.CarriedFromID),
+		formatTime(income.CreatedAt),
+		formatTime(income.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create income: %w", err)
+	}
+	return nil
+}
+
+// GetByID gets an income by ID
+func (r *IncomeRepository) GetByID(ctx context.Context, id string) (*repositories.Income, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+incomeColumns+` FROM incomes WHERE id = ?`, id)
+
+	income, err := scanIncome(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("income %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get income: %w", err)
+	}
+	return income, nil
+}
+
+// GetByUserID gets all incomes for a user
+func (r *IncomeRepository) GetByUserID(ctx context.Context, userID string) ([]*repositories.Income, error) {
+	return r.query(ctx,
+		`SELECT `+incomeColumns+` FROM incomes WHERE user_id = ? ORDER BY month DESC, created_at`,
+		userID,
+	)
+}
+
+// GetByMonth gets every income for a user in the given month
+func (r *IncomeRepository) GetByMonth(ctx context.Context, userID string, month time.Time) ([]*repositories.Income, error) {
+	return r.query(ctx,
+		`SELECT `+incomeColumns+` FROM incomes WHERE user_id = ? AND month = ? ORDER BY created_at`,
+		userID, formatTime(month),
+	)
+}
+
+// GetUncarriedRecurring gets recurring incomes before the given month with no carried-forward successor
+func (r *IncomeRepository) GetUncarriedRecurring(ctx context.Context, userID string, before time.Time) ([]*repositories.Income, error) {
+	return r.query(ctx,
+		`SELECT `+incomeColumns+` FROM incomes i
+		WHERE i.user_id = ? AND i.is_recurring = 1 AND i.month < ?
+		AND NOT EXISTS (SELECT 1 FROM incomes n WHERE n.carried_from_id = i.id)
+		ORDER BY i.month`,
+		userID, formatTime(before),
+	)
+}
+
+// Update updates an income
+func (r *IncomeRepository) Update(ctx context.Context, income *repositories.Income) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE incomes
+		SET user_id = ?, amount = ?, currency = ?, month = ?, source = ?, is_recurring = ?, account_id = ?, carried_from_id = ?, updated_at = ?
+		WHERE id = ?`,
+		income.UserID,
+		income.Amount.String(),
+		income.Currency,
+		formatTime(income.Month),
+		income.Source,
+		income.IsRecurring,
+		nullString(income.AccountID),
+		nullString(income.CarriedFromID),
+		formatTime(income.UpdatedAt),
+		income.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update income: %w", err)
+	}
+	return requireAffected(result, "income", income.ID)
+}
+
+// Delete deletes an income by ID
+func (r *IncomeRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM incomes WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete income: %w", err)
+	}
+	return requireAffected(result, "income", id)
+}
+
+// query runs a select over incomeColumns and collects the results
+func (r *IncomeRepository) query(ctx context.Context, query string, args ...interface{}) ([]*repositories.Income, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incomes: %w", err)
+	}
+	defer rows.Close()
+
+	var incomes []*repositories.Income
+	for rows.Next() {
+		income, err := scanIncome(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read income: %w", err)
+		}
+		incomes = append(incomes, income)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query incomes: %w", err)
+	}
+	return incomes, nil
+}
+
+// scanIncome reads a single income row selected with incomeColumns
+func scanIncome(row rowScanner) (*repositories.Income, error) {
+	var (
+		income               repositories.Income
+		amount, month        string
+		accountID            sql.NullString
+		carriedFromID        sql.NullString
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&income.ID,
+		&income.UserID,
+		&amount,
+		&income.Currency,
+		&month,
+		&income.Source,
+		&income.IsRecurring,
+		&accountID,
+		&carriedFromID,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	income.AccountID = accountID.String
+	income.CarriedFromID = carriedFromID.String
+	if income.Amount, err = decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", amount, err)
+	}
+	if income.Month, err = parseTime(month); err != nil {
+		return nil, err
+	}
+	if income.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if income.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &income, nil
+}
+
+// nullString stores empty strings as NULL so optional references stay unset
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
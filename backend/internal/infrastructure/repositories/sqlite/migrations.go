@@ -0,0 +1,582 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Migration represents a single forward-only schema change.
+// Versions must be unique and increasing; a released migration must never be edited,
+// add a new one instead.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, tx *sql.Tx) error
+}
+
+// migrations is the ordered list of schema changes applied on startup.
+// Append new migrations to the end of this list.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create_expenses",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS expenses (
+				id            TEXT PRIMARY KEY,
+				user_id       TEXT NOT NULL,
+				amount        TEXT NOT NULL,
+				description   TEXT NOT NULL DEFAULT '',
+				date          TEXT NOT NULL,
+				main_category TEXT NOT NULL,
+				sub_category  TEXT NOT NULL,
+				created_at    TEXT NOT NULL,
+				updated_at    TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_expenses_user_date ON expenses(user_id, date)`,
+		),
+	},
+	{
+		Version: 2,
+		Name:    "create_incomes",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS incomes (
+				id              TEXT PRIMARY KEY,
+				user_id         TEXT NOT NULL,
+				amount          TEXT NOT NULL,
+				month           TEXT NOT NULL,
+				source          TEXT NOT NULL,
+				is_recurring    INTEGER NOT NULL DEFAULT 0,
+				carried_from_id TEXT REFERENCES incomes(id) ON DELETE SET NULL,
+				created_at      TEXT NOT NULL,
+				updated_at      TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_incomes_user_month ON incomes(user_id, month)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_incomes_carried_from ON incomes(carried_from_id) WHERE carried_from_id IS NOT NULL`,
+		),
+	},
+	{
+		Version: 3,
+		Name:    "create_categories",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS categories (
+				id         TEXT PRIMARY KEY,
+				user_id    TEXT NOT NULL,
+				kind       TEXT NOT NULL,
+				name       TEXT NOT NULL COLLATE NOCASE,
+				parent_id  TEXT REFERENCES categories(id) ON DELETE SET NULL,
+				color      TEXT NOT NULL DEFAULT '',
+				icon       TEXT NOT NULL DEFAULT '',
+				archived   INTEGER NOT NULL DEFAULT 0,
+				created_at TEXT NOT NULL,
+				updated_at TEXT NOT NULL
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_user_kind_name ON categories(user_id, kind, name)`,
+		),
+	},
+	{
+		Version: 4,
+		Name:    "add_currencies",
+		Up: execStatements(
+			// Everything recorded before currencies existed was in rupees
+			`ALTER TABLE expenses ADD COLUMN currency TEXT NOT NULL DEFAULT 'INR'`,
+			`ALTER TABLE incomes ADD COLUMN currency TEXT NOT NULL DEFAULT 'INR'`,
+			`CREATE TABLE IF NOT EXISTS exchange_rates (
+				id            TEXT PRIMARY KEY,
+				user_id       TEXT NOT NULL,
+				from_currency TEXT NOT NULL,
+				to_currency   TEXT NOT NULL,
+				date          TEXT NOT NULL,
+				rate          TEXT NOT NULL,
+				created_at    TEXT NOT NULL,
+				updated_at    TEXT NOT NULL
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_exchange_rates_pair_date ON exchange_rates(user_id, from_currency, to_currency, date)`,
+			`CREATE TABLE IF NOT EXISTS user_settings (
+				user_id       TEXT PRIMARY KEY,
+				base_currency TEXT NOT NULL,
+				updated_at    TEXT NOT NULL
+			)`,
+		),
+	},
+	{
+		Version: 5,
+		Name:    "create_budgets",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS budgets (
+				id         TEXT PRIMARY KEY,
+				user_id    TEXT NOT NULL,
+				kind       TEXT NOT NULL,
+				category   TEXT NOT NULL COLLATE NOCASE,
+				month      TEXT NOT NULL,
+				amount     TEXT NOT NULL,
+				currency   TEXT NOT NULL,
+				rollover   INTEGER NOT NULL DEFAULT 0,
+				created_at TEXT NOT NULL,
+				updated_at TEXT NOT NULL
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_budgets_user_category_month ON budgets(user_id, kind, category, month)`,
+		),
+	},
+	{
+		Version: 6,
+		Name:    "create_imports",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS import_mappings (
+				id                    TEXT PRIMARY KEY,
+				user_id               TEXT NOT NULL,
+				name                  TEXT NOT NULL COLLATE NOCASE,
+				date_column           TEXT NOT NULL,
+				date_format           TEXT NOT NULL,
+				amount_column         TEXT NOT NULL DEFAULT '',
+				amount_sign           TEXT NOT NULL DEFAULT '',
+				debit_column          TEXT NOT NULL DEFAULT '',
+				credit_column         TEXT NOT NULL DEFAULT '',
+				description_column    TEXT NOT NULL DEFAULT '',
+				currency_column       TEXT NOT NULL DEFAULT '',
+				main_category_column  TEXT NOT NULL DEFAULT '',
+				sub_category_column   TEXT NOT NULL DEFAULT '',
+				default_main_category TEXT NOT NULL DEFAULT '',
+				default_sub_category  TEXT NOT NULL DEFAULT '',
+				created_at            TEXT NOT NULL,
+				updated_at            TEXT NOT NULL
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_import_mappings_user_name ON import_mappings(user_id, name)`,
+			`CREATE TABLE IF NOT EXISTS import_batches (
+				id            TEXT PRIMARY KEY,
+				user_id       TEXT NOT NULL,
+				mapping_id    TEXT NOT NULL,
+				file_name     TEXT NOT NULL DEFAULT '',
+				expense_count INTEGER NOT NULL,
+				created_at    TEXT NOT NULL,
+				undone_at     TEXT
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_import_batches_user ON import_batches(user_id, created_at)`,
+			`ALTER TABLE expenses ADD COLUMN import_batch_id TEXT`,
+			`CREATE INDEX IF NOT EXISTS idx_expenses_import_batch ON expenses(import_batch_id)`,
+		),
+	},
+	{
+		Version: 7,
+		Name:    "create_households",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS households (
+				id         TEXT PRIMARY KEY,
+				name       TEXT NOT NULL,
+				currency   TEXT NOT NULL,
+				created_by TEXT NOT NULL,
+				created_at TEXT NOT NULL,
+				updated_at TEXT NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS household_members (
+				household_id TEXT NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+				user_id      TEXT NOT NULL,
+				display_name TEXT NOT NULL,
+				joined_at    TEXT NOT NULL,
+				PRIMARY KEY (household_id, user_id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_household_members_user ON household_members(user_id)`,
+			`CREATE TABLE IF NOT EXISTS expense_splits (
+				expense_id   TEXT PRIMARY KEY REFERENCES expenses(id) ON DELETE CASCADE,
+				household_id TEXT NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+				method       TEXT NOT NULL,
+				created_at   TEXT NOT NULL,
+				updated_at   TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_expense_splits_household ON expense_splits(household_id)`,
+			`CREATE TABLE IF NOT EXISTS expense_shares (
+				expense_id TEXT NOT NULL REFERENCES expense_splits(expense_id) ON DELETE CASCADE,
+				user_id    TEXT NOT NULL,
+				value      TEXT NOT NULL,
+				PRIMARY KEY (expense_id, user_id)
+			)`,
+			`CREATE TABLE IF NOT EXISTS settlements (
+				id           TEXT PRIMARY KEY,
+				household_id TEXT NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+				from_user_id TEXT NOT NULL,
+				to_user_id   TEXT NOT NULL,
+				amount       TEXT NOT NULL,
+				date         TEXT NOT NULL,
+				note         TEXT NOT NULL DEFAULT '',
+				created_by   TEXT NOT NULL,
+				created_at   TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_settlements_household ON settlements(household_id, date)`,
+		),
+	},
+	{
+		Version: 8,
+		Name:    "create_recurring_expenses",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS recurring_expenses (
+				id            TEXT PRIMARY KEY,
+				user_id       TEXT NOT NULL,
+				amount        TEXT NOT NULL,
+				currency      TEXT NOT NULL,
+				description   TEXT NOT NULL DEFAULT '',
+				main_category TEXT NOT NULL,
+				sub_category  TEXT NOT NULL,
+				frequency     TEXT NOT NULL,
+				interval      INTEGER NOT NULL DEFAULT 1,
+				days_of_week  TEXT NOT NULL DEFAULT '',
+				month_day     INTEGER NOT NULL DEFAULT 0,
+				end_date      TEXT,
+				max_count     INTEGER NOT NULL DEFAULT 0,
+				start_date    TEXT NOT NULL,
+				next_date     TEXT,
+				paused        INTEGER NOT NULL DEFAULT 0,
+				created_at    TEXT NOT NULL,
+				updated_at    TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_recurring_expenses_user ON recurring_expenses(user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_recurring_expenses_next_date ON recurring_expenses(next_date)`,
+			`CREATE TABLE IF NOT EXISTS recurring_occurrences (
+				recurring_id TEXT NOT NULL REFERENCES recurring_expenses(id) ON DELETE CASCADE,
+				date         TEXT NOT NULL,
+				skipped      INTEGER NOT NULL DEFAULT 0,
+				amount       TEXT,
+				description  TEXT,
+				expense_id   TEXT REFERENCES expenses(id) ON DELETE SET NULL,
+				PRIMARY KEY (recurring_id, date)
+			)`,
+		),
+	},
+	{
+		Version: 9,
+		Name:    "create_savings_goals",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS savings_goals (
+				id            TEXT PRIMARY KEY,
+				user_id       TEXT NOT NULL,
+				name          TEXT NOT NULL,
+				target_amount TEXT NOT NULL,
+				currency      TEXT NOT NULL,
+				target_date   TEXT,
+				created_at    TEXT NOT NULL,
+				updated_at    TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_savings_goals_user ON savings_goals(user_id)`,
+			`CREATE TABLE IF NOT EXISTS goal_contributions (
+				id         TEXT PRIMARY KEY,
+				goal_id    TEXT NOT NULL REFERENCES savings_goals(id) ON DELETE CASCADE,
+				expense_id TEXT REFERENCES expenses(id) ON DELETE CASCADE,
+				amount     TEXT NOT NULL,
+				currency   TEXT NOT NULL,
+				date       TEXT NOT NULL,
+				note       TEXT NOT NULL DEFAULT '',
+				created_at TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_goal_contributions_goal ON goal_contributions(goal_id, date)`,
+			`CREATE INDEX IF NOT EXISTS idx_goal_contributions_expense ON goal_contributions(expense_id)`,
+		),
+	},
+	{
+		Version: 10,
+		Name:    "create_accounts",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS accounts (
+				id              TEXT PRIMARY KEY,
+				user_id         TEXT NOT NULL,
+				name            TEXT NOT NULL COLLATE NOCASE,
+				type            TEXT NOT NULL,
+				currency        TEXT NOT NULL,
+				last4           TEXT NOT NULL DEFAULT '',
+				opening_balance TEXT NOT NULL,
+				opening_date    TEXT NOT NULL,
+				archived        INTEGER NOT NULL DEFAULT 0,
+				created_at      TEXT NOT NULL,
+				updated_at      TEXT NOT NULL
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_user_name ON accounts(user_id, name)`,
+			`CREATE TABLE IF NOT EXISTS transfers (
+				id              TEXT PRIMARY KEY,
+				user_id         TEXT NOT NULL,
+				from_account_id TEXT NOT NULL REFERENCES accounts(id),
+				to_account_id   TEXT NOT NULL REFERENCES accounts(id),
+				amount          TEXT NOT NULL,
+				to_amount       TEXT NOT NULL,
+				date            TEXT NOT NULL,
+				note            TEXT NOT NULL DEFAULT '',
+				created_at      TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_transfers_from ON transfers(from_account_id, date)`,
+			`CREATE INDEX IF NOT EXISTS idx_transfers_to ON transfers(to_account_id, date)`,
+			`ALTER TABLE expenses ADD COLUMN account_id TEXT REFERENCES accounts(id)`,
+			`CREATE INDEX IF NOT EXISTS idx_expenses_account ON expenses(account_id, date)`,
+			`ALTER TABLE incomes ADD COLUMN account_id TEXT REFERENCES accounts(id)`,
+			`CREATE INDEX IF NOT EXISTS idx_incomes_account ON incomes(account_id)`,
+		),
+	},
+	{
+		Version: 11,
+		Name:    "add_fiscal_year_and_tax_sections",
+		Up: execStatements(
+			`ALTER TABLE user_settings ADD COLUMN fiscal_year_start INTEGER NOT NULL DEFAULT 4`,
+			`ALTER TABLE expenses ADD COLUMN tax_section TEXT`,
+		),
+	},
+	{
+		Version: 12,
+		Name:    "create_expense_items",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS expense_items (
+				id           TEXT PRIMARY KEY,
+				expense_id   TEXT NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+				user_id      TEXT NOT NULL,
+				position     INTEGER NOT NULL,
+				description  TEXT NOT NULL DEFAULT '',
+				amount       TEXT NOT NULL,
+				sub_category TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_expense_items_expense ON expense_items(expense_id, position)`,
+			`CREATE INDEX IF NOT EXISTS idx_expense_items_sub_category ON expense_items(user_id, sub_category)`,
+		),
+	},
+	{
+		Version: 13,
+		Name:    "add_expense_amortization",
+		Up: execStatements(
+			`ALTER TABLE expenses ADD COLUMN amortize_months INTEGER NOT NULL DEFAULT 0`,
+		),
+	},
+	{
+		Version: 14,
+		Name:    "create_reimbursements",
+		Up: execStatements(
+			`ALTER TABLE expenses ADD COLUMN reimbursable INTEGER NOT NULL DEFAULT 0`,
+			`ALTER TABLE expenses ADD COLUMN expected_payer TEXT NOT NULL DEFAULT ''`,
+			`CREATE TABLE IF NOT EXISTS reimbursements (
+				id         TEXT PRIMARY KEY,
+				user_id    TEXT NOT NULL,
+				expense_id TEXT NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+				kind       TEXT NOT NULL,
+				amount     TEXT NOT NULL,
+				currency   TEXT NOT NULL,
+				date       TEXT NOT NULL,
+				payer      TEXT NOT NULL DEFAULT '',
+				note       TEXT NOT NULL DEFAULT '',
+				created_at TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_reimbursements_expense ON reimbursements(expense_id, date)`,
+		),
+	},
+	{
+		Version: 15,
+		Name:    "create_events",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS events (
+				id          TEXT PRIMARY KEY,
+				user_id     TEXT NOT NULL,
+				name        TEXT NOT NULL,
+				start_date  TEXT NOT NULL,
+				end_date    TEXT NOT NULL,
+				auto_attach INTEGER NOT NULL DEFAULT 1,
+				budget      TEXT,
+				currency    TEXT NOT NULL,
+				note        TEXT NOT NULL DEFAULT '',
+				created_at  TEXT NOT NULL,
+				updated_at  TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_events_user ON events(user_id, start_date)`,
+			`ALTER TABLE expenses ADD COLUMN event_id TEXT REFERENCES events(id) ON DELETE SET NULL`,
+			`CREATE INDEX IF NOT EXISTS idx_expenses_event ON expenses(event_id)`,
+		),
+	},
+	{
+		Version: 16,
+		Name:    "create_categorization_rules",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS categorization_rules (
+				id               TEXT PRIMARY KEY,
+				user_id          TEXT NOT NULL,
+				name             TEXT NOT NULL,
+				match_type       TEXT NOT NULL DEFAULT '',
+				pattern          TEXT NOT NULL DEFAULT '',
+				min_amount       TEXT,
+				max_amount       TEXT,
+				transaction_type TEXT NOT NULL DEFAULT '',
+				account_id       TEXT REFERENCES accounts(id),
+				main_category    TEXT NOT NULL,
+				sub_category     TEXT NOT NULL,
+				priority         INTEGER NOT NULL DEFAULT 0,
+				created_at       TEXT NOT NULL,
+				updated_at       TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_categorization_rules_user ON categorization_rules(user_id, priority)`,
+		),
+	},
+	{
+		Version: 17,
+		Name:    "create_category_corrections",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS category_corrections (
+				id               TEXT PRIMARY KEY,
+				user_id          TEXT NOT NULL,
+				description      TEXT NOT NULL,
+				amount           TEXT NOT NULL,
+				transaction_type TEXT NOT NULL,
+				main_category    TEXT NOT NULL,
+				sub_category     TEXT NOT NULL,
+				created_at       TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_category_corrections_user ON category_corrections(user_id, created_at)`,
+		),
+	},
+	{
+		Version: 18,
+		Name:    "create_staged_transactions",
+		Up: execStatements(
+			`ALTER TABLE import_batches ADD COLUMN source TEXT NOT NULL DEFAULT 'csv'`,
+			`ALTER TABLE import_batches ADD COLUMN status TEXT NOT NULL DEFAULT 'committed'`,
+			`ALTER TABLE import_batches ADD COLUMN bank_name TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE import_batches ADD COLUMN account_id TEXT REFERENCES accounts(id)`,
+			`UPDATE import_batches SET status = 'undone' WHERE undone_at IS NOT NULL`,
+			`CREATE TABLE IF NOT EXISTS staged_transactions (
+				id                      TEXT PRIMARY KEY,
+				batch_id                TEXT NOT NULL REFERENCES import_batches(id) ON DELETE CASCADE,
+				line                    INTEGER NOT NULL,
+				date                    TEXT NOT NULL,
+				description             TEXT NOT NULL,
+				amount                  TEXT NOT NULL,
+				type                    TEXT NOT NULL,
+				main_category           TEXT NOT NULL DEFAULT '',
+				sub_category            TEXT NOT NULL DEFAULT '',
+				suggested_main_category TEXT NOT NULL DEFAULT '',
+				suggested_sub_category  TEXT NOT NULL DEFAULT '',
+				confidence              REAL NOT NULL DEFAULT 0,
+				rule_id                 TEXT NOT NULL DEFAULT '',
+				needs_review            INTEGER NOT NULL DEFAULT 0,
+				excluded                INTEGER NOT NULL DEFAULT 0
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_staged_transactions_batch ON staged_transactions(batch_id, line)`,
+		),
+	},
+	{
+		Version: 19,
+		Name:    "create_statement_password_hints",
+		Up: execStatements(
+			`CREATE TABLE IF NOT EXISTS statement_password_hints (
+				id          TEXT PRIMARY KEY,
+				user_id     TEXT NOT NULL,
+				bank        TEXT NOT NULL,
+				sealed_hint TEXT NOT NULL,
+				created_at  TEXT NOT NULL,
+				updated_at  TEXT NOT NULL,
+				UNIQUE (user_id, bank)
+			)`,
+		),
+	},
+	{
+		Version: 20,
+		Name:    "add_category_roles",
+		Up: execStatements(
+			`ALTER TABLE categories ADD COLUMN role TEXT NOT NULL DEFAULT ''`,
+			`UPDATE categories SET role = 'savings' WHERE kind = 'main' AND name = 'Savings'`,
+		),
+	},
+}
+
+// execStatements returns a migration step that executes the given statements in order
+func execStatements(statements ...string) func(ctx context.Context, tx *sql.Tx) error {
+	return func(ctx context.Context, tx *sql.Tx) error {
+		for _, stmt := range statements {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Migrate applies all pending migrations to the database.
+// It is safe to call on every startup; already applied versions are skipped.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	return runMigrations(ctx, db, migrations)
+}
+
+// runMigrations applies the pending subset of list, each in its own transaction
+func runMigrations(ctx context.Context, db *sql.DB, list []Migration) error {
+	for i := 1; i < len(list); i++ {
+		if list[i].Version <= list[i-1].Version {
+			return fmt.Errorf("migration %d (%s) is out of order", list[i].Version, list[i].Name)
+		}
+	}
+
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	latest := 0
+	if len(list) > 0 {
+		latest = list[len(list)-1].Version
+	}
+	for version := range applied {
+		if version > latest {
+			return fmt.Errorf("database schema version %d is newer than the latest known migration %d", version, latest)
+		}
+	}
+
+	for _, m := range list {
+		if applied[m.Version] {
+			continue
+		}
+		if err := applyMigration(ctx, db, m); err != nil {
+			return fmt.Errorf("failed to apply migration %d (%s): %w", m.Version, m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// appliedVersions returns the set of migration versions already recorded in the database
+func appliedVersions(ctx context.Context, db *sql.DB) (map[int]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration runs a migration and records it atomically
+func applyMigration(ctx context.Context, db *sql.DB, m Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := m.Up(ctx, tx); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+		m.Version, m.Name, formatTime(time.Now()),
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatalf("second Migrate failed: %v", err)
+	}
+
+	var count int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations`).Scan(&count); err != nil {
+		t.Fatalf("failed to count migrations: %v", err)
+	}
+	if count != len(migrations) {
+		t.Errorf("expected %d recorded migrations, got %d", len(migrations), count)
+	}
+}
+
+func TestRunMigrationsAppliesOnlyPending(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	calls := 0
+	list := append([]Migration{}, migrations...)
+	list = append(list, Migration{
+		Version: list[len(list)-1].Version + 1,
+		Name:    "add_test_table",
+		Up: func(ctx context.Context, tx *sql.Tx) error {
+			calls++
+			_, err := tx.ExecContext(ctx, `CREATE TABLE test_upgrade (id TEXT PRIMARY KEY)`)
+			return err
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := runMigrations(ctx, db, list); err != nil {
+			t.Fatalf("runMigrations failed: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected new migration to run once, ran %d times", calls)
+	}
+
+	// A binary that doesn't know about the newer version must refuse to run
+	if err := runMigrations(ctx, db, migrations); err == nil {
+		t.Error("expected error when database is newer than known migrations")
+	}
+}
+
+func TestRunMigrationsRejectsOutOfOrder(t *testing.T) {
+	list := []Migration{
+		{Version: 2, Name: "b", Up: execStatements()},
+		{Version: 1, Name: "a", Up: execStatements()},
+	}
+	if err := runMigrations(context.Background(), newTestDB(t), list); err == nil {
+		t.Error("expected error for out-of-order migrations")
+	}
+}
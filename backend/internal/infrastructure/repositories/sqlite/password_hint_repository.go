@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordHintRepository implements the repositories.PasswordHintRepository interface
+type PasswordHintRepository struct {
+	db *sql.DB
+}
+
+// NewPasswordHintRepository creates a new SQLite password hint repository
+func NewPasswordHintRepository(db *sql.DB) repositories.PasswordHintRepository {
+	return &PasswordHintRepository{
+		db: db,
+	}
+}
+
+const passwordHintColumns = `id, user_id, bank, sealed_hint, created_at, updated_at`
+
+// Upsert creates the user's hint for a bank or replaces the existing one,
+// keeping its ID and creation time
+func (r *PasswordHintRepository) Upsert(ctx context.Context, hint *repositories.PasswordHint) error {
+	if hint.ID == "" {
+		hint.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if hint.CreatedAt.IsZero() {
+		hint.CreatedAt = now
+	}
+	hint.UpdatedAt = now
+
+	var createdAt string
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO statement_password_hints (`+passwordHintColumns+`) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id, bank) DO UPDATE SET sealed_hint = excluded.sealed_hint, updated_at = excluded.updated_at
+		RETURNING id, created_at`,
+		hint.ID,
+		hint.UserID,
+		hint.Bank,
+		hint.SealedHint,
+		formatTime(hint.CreatedAt),
+		formatTime(hint.UpdatedAt),
+	).Scan(&hint.ID, &createdAt)
+	if err != nil {
+		return fmt.Errorf("failed to save password hint: %w", err)
+	}
+	if hint.CreatedAt, err = parseTime(createdAt); err != nil {
+		return fmt.Errorf("failed to save password hint: %w", err)
+	}
+	return nil
+}
+
+// GetByBank gets a user's hint for a bank
+func (r *PasswordHintRepository) GetByBank(ctx context.Context, userID, bank string) (*repositories.PasswordHint, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+passwordHintColumns+` FROM statement_password_hints WHERE user_id = ? AND bank = ?`, userID, bank)
+
+	hint, err := scanPasswordHint(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("password hint %s: %w", bank, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password hint: %w", err)
+	}
+	return hint, nil
+}
+
+// GetByUserID gets all of a user's hints, ordered by bank
+func (r *PasswordHintRepository) GetByUserID(ctx context.Context, userID string) ([]*repositories.PasswordHint, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+passwordHintColumns+` FROM statement_password_hints WHERE user_id = ? ORDER BY bank`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query password hints: %w", err)
+	}
+	defer rows.Close()
+
+	var hints []*repositories.PasswordHint
+	for rows.Next() {
+		hint, err := scanPasswordHint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read password hint: %w", err)
+		}
+		hints = append(hints, hint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query password hints: %w", err)
+	}
+	return hints, nil
+}
+
+// Delete deletes a user's hint for a bank
+func (r *PasswordHintRepository) Delete(ctx context.Context, userID, bank string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM statement_password_hints WHERE user_id = ? AND bank = ?`, userID, bank)
+	if err != nil {
+		return fmt.Errorf("failed to delete password hint: %w", err)
+	}
+	return requireAffected(result, "password hint", bank)
+}
+
+// scanPasswordHint reads a single row selected with passwordHintColumns
+func scanPasswordHint(row rowScanner) (*repositories.PasswordHint, error) {
+	var (
+		hint                 repositories.PasswordHint
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&hint.ID,
+		&hint.UserID,
+		&hint.Bank,
+		&hint.SealedHint,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if hint.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if hint.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &hint, nil
+}
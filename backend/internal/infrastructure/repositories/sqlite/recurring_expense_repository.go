@@ -0,0 +1,396 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// RecurringExpenseRepository implements the repositories.RecurringExpenseRepository interface
+type RecurringExpenseRepository struct {
+	db *sql.DB
+}
+
+// NewRecurringExpenseRepository creates a new SQLite recurring expense repository
+func NewRecurringExpenseRepository(db *sql.DB) repositories.RecurringExpenseRepository {
+	return &RecurringExpenseRepository{
+		db: db,
+	}
+}
+
+const recurringExpenseColumns = `id, user_id, amount, currency, description, main_category, sub_category,
+	frequency, interval, days_of_week, month_day, end_date, max_count, start_date, next_date, paused,
+	created_at, updated_at`
+
+const recurringOccurrenceColumns = `recurring_id, date, skipped, amount, description, expense_id`
+
+// Create creates a new recurring expense
+func (r *RecurringExpenseRepository) Create(ctx context.Context, recurring *repositories.RecurringExpense) error {
+	if recurring.ID == "" {
+		recurring.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if recurring.CreatedAt.IsZero() {
+		recurring.CreatedAt = now
+	}
+	if recurring.UpdatedAt.IsZero() {
+		recurring.UpdatedAt = now
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO recurring_expenses (`+recurringExpenseColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		recurring.ID,
+		recurring.UserID,
+		recurring.Amount.String(),
+		recurring.Currency,
+		recurring.Description,
+		recurring.MainCategory,
+		recurring.SubCategory,
+		recurring.Frequency,
+		recurring.Interval,
+		formatWeekdays(recurring.DaysOfWeek),
+		recurring.MonthDay,
+		nullTime(recurring.EndDate),
+		recurring.Count,
+		formatTime(recurring.StartDate),
+		nullTime(recurring.NextDate),
+		recurring.Paused,
+		formatTime(recurring.CreatedAt),
+		formatTime(recurring.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recurring expense: %w", err)
+	}
+	return nil
+}
+
+// GetByID gets a recurring expense by ID
+func (r *RecurringExpenseRepository) GetByID(ctx context.Context, id string) (*repositories.RecurringExpense, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+recurringExpenseColumns+` FROM recurring_expenses WHERE id = ?`, id)
+
+	recurring, err := scanRecurringExpense(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("recurring expense %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring expense: %w", err)
+	}
+	return recurring, nil
+}
+
+// GetByUserID gets every recurring expense for a user, ended ones last
+func (r *RecurringExpenseRepository) GetByUserID(ctx context.Context, userID string) ([]*repositories.RecurringExpense, error) {
+	return r.query(ctx,
+		`SELECT `+recurringExpenseColumns+` FROM recurring_expenses
+		WHERE user_id = ? ORDER BY next_date IS NULL, next_date, description`,
+		userID,
+	)
+}
+
+// GetDue gets every active recurring expense with an occurrence due on or before asOf
+func (r *RecurringExpenseRepository) GetDue(ctx context.Context, asOf time.Time) ([]*repositories.RecurringExpense, error) {
+	return r.query(ctx,
+		`SELECT `+recurringExpenseColumns+` FROM recurring_expenses
+		WHERE paused = 0 AND next_date IS NOT NULL AND next_date <= ? ORDER BY next_date`,
+		formatTime(asOf),
+	)
+}
+
+// Update updates a recurring expense
+func (r *RecurringExpenseRepository) Update(ctx context.Context, recurring *repositories.RecurringExpense) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE recurring_expenses
+		SET amount = ?, currency = ?, description = ?, main_category = ?, sub_category = ?,
+			frequency = ?, interval = ?, days_of_week = ?, month_day = ?, end_date = ?, max_count = ?,
+			start_date = ?, next_date = ?, paused = ?, updated_at = ?
+		WHERE id = ?`,
+		recurring.Amount.String(),
+		recurring.Currency,
+		recurring.Description,
+		recurring.MainCategory,
+		recurring.SubCategory,
+		recurring.Frequency,
+		recurring.Interval,
+		formatWeekdays(recurring.DaysOfWeek),
+		recurring.MonthDay,
+		nullTime(recurring.EndDate),
+		recurring.Count,
+		formatTime(recurring.StartDate),
+		nullTime(recurring.NextDate),
+		recurring.Paused,
+		formatTime(recurring.UpdatedAt),
+		recurring.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update recurring expense: %w", err)
+	}
+	return requireAffected(result, "recurring expense", recurring.ID)
+}
+
+// Delete deletes a recurring expense; its occurrences go with it
+func (r *RecurringExpenseRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM recurring_expenses WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurring expense: %w", err)
+	}
+	return requireAffected(result, "recurring expense", id)
+}
+
+// SaveOccurrence saves a change to a single occurrence, replacing any earlier one
+func (r *RecurringExpenseRepository) SaveOccurrence(ctx context.Context, occurrence *repositories.RecurringOccurrence) error {
+	return upsertOccurrence(ctx, r.db, occurrence)
+}
+
+// DeleteOccurrence deletes the stored change for a single occurrence
+func (r *RecurringExpenseRepository) DeleteOccurrence(ctx context.Context, recurringID string, date time.Time) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM recurring_occurrences WHERE recurring_id = ? AND date = ?`,
+		recurringID, formatTime(date),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurring occurrence: %w", err)
+	}
+	return requireAffected(result, "recurring occurrence", date.Format("2006-01-02"))
+}
+
+// GetOccurrences gets the stored occurrences of a recurring expense dated in [from, to)
+func (r *RecurringExpenseRepository) GetOccurrences(ctx context.Context, recurringID string, from, to time.Time) ([]*repositories.RecurringOccurrence, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+recurringOccurrenceColumns+` FROM recurring_occurrences
+		WHERE recurring_id = ? AND date >= ? AND date < ? ORDER BY date`,
+		recurringID, formatTime(from), formatTime(to),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurring occurrences: %w", err)
+	}
+	defer rows.Close()
+
+	var occurrences []*repositories.RecurringOccurrence
+	for rows.Next() {
+		occurrence, err := scanRecurringOccurrence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recurring occurrence: %w", err)
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query recurring occurrences: %w", err)
+	}
+	return occurrences, nil
+}
+
+// Generate saves the expense for an occurrence, records the occurrence and
+// moves the recurring expense on to nextDate in one transaction. It fails with
+// ErrNotFound if the occurrence is no longer the next one due, so concurrent
+// runs never generate the same occurrence twice.
+func (r *RecurringExpenseRepository) Generate(ctx context.Context, occurrence *repositories.RecurringOccurrence, expense *repositories.Expense, nextDate *time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE recurring_expenses SET next_date = ? WHERE id = ? AND next_date = ?`,
+		nullTime(nextDate), occurrence.RecurringID, formatTime(occurrence.Date),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to advance recurring expense: %w", err)
+	}
+	if err := requireAffected(result, "recurring expense occurrence", occurrence.RecurringID+" "+occurrence.Date.Format("2006-01-02")); err != nil {
+		return err
+	}
+
+	if expense != nil {
+		if err := insertExpense(ctx, tx, expense); err != nil {
+			return err
+		}
+		occurrence.ExpenseID = expense.ID
+	}
+	if err := upsertOccurrence(ctx, tx, occurrence); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit recurring occurrence: %w", err)
+	}
+	return nil
+}
+
+// query runs a SELECT over recurring_expenses and scans every row
+func (r *RecurringExpenseRepository) query(ctx context.Context, query string, args ...interface{}) ([]*repositories.RecurringExpense, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurring expenses: %w", err)
+	}
+	defer rows.Close()
+
+	var list []*repositories.RecurringExpense
+	for rows.Next() {
+		recurring, err := scanRecurringExpense(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recurring expense: %w", err)
+		}
+		list = append(list, recurring)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query recurring expenses: %w", err)
+	}
+	return list, nil
+}
+
+// upsertOccurrence inserts an occurrence or replaces the stored one for the same date
+func upsertOccurrence(ctx context.Context, db execer, occurrence *repositories.RecurringOccurrence) error {
+	var amount, description sql.NullString
+	if occurrence.Amount != nil {
+		amount = sql.NullString{String: occurrence.Amount.String(), Valid: true}
+	}
+	if occurrence.Description != nil {
+		description = sql.NullString{String: *occurrence.Description, Valid: true}
+	}
+
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO recurring_occurrences (`+recurringOccurrenceColumns+`) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (recurring_id, date) DO UPDATE SET
+			skipped = excluded.skipped, amount = excluded.amount,
+			description = excluded.description, expense_id = excluded.expense_id`,
+		occurrence.RecurringID,
+		formatTime(occurrence.Date),
+		occurrence.Skipped,
+		amount,
+		description,
+		nullString(occurrence.ExpenseID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save recurring occurrence: %w", err)
+	}
+	return nil
+}
+
+// scanRecurringExpense reads a single row selected with recurringExpenseColumns
+func scanRecurringExpense(row rowScanner) (*repositories.RecurringExpense, error) {
+	var (
+		recurring            repositories.RecurringExpense
+		amount, daysOfWeek   string
+		startDate            string
+		endDate, nextDate    sql.NullString
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&recurring.ID,
+		&recurring.UserID,
+		&amount,
+		&recurring.Currency,
+		&recurring.Description,
+		&recurring.MainCategory,
+		&recurring.SubCategory,
+		&recurring.Frequency,
+		&recurring.Interval,
+		&daysOfWeek,
+		&recurring.MonthDay,
+		&endDate,
+		&recurring.Count,
+		&startDate,
+		&nextDate,
+		&recurring.Paused,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if recurring.Amount, err = decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", amount, err)
+	}
+	if recurring.DaysOfWeek, err = parseWeekdays(daysOfWeek); err != nil {
+		return nil, err
+	}
+	if recurring.StartDate, err = parseTime(startDate); err != nil {
+		return nil, err
+	}
+	if recurring.EndDate, err = parseNullTime(endDate); err != nil {
+		return nil, err
+	}
+	if recurring.NextDate, err = parseNullTime(nextDate); err != nil {
+		return nil, err
+	}
+	if recurring.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if recurring.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &recurring, nil
+}
+
+// scanRecurringOccurrence reads a single row selected with recurringOccurrenceColumns
+func scanRecurringOccurrence(row rowScanner) (*repositories.RecurringOccurrence, error) {
+	var (
+		occurrence                     repositories.RecurringOccurrence
+		date                           string
+		amount, description, expenseID sql.NullString
+	)
+	err := row.Scan(
+		&occurrence.RecurringID,
+		&date,
+		&occurrence.Skipped,
+		&amount,
+		&description,
+		&expenseID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if occurrence.Date, err = parseTime(date); err != nil {
+		return nil, err
+	}
+	if amount.Valid {
+		value, err := decimal.NewFromString(amount.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stored amount %q: %w", amount.String, err)
+		}
+		occurrence.Amount = &value
+	}
+	if description.Valid {
+		occurrence.Description = &description.String
+	}
+	occurrence.ExpenseID = expenseID.String
+	return &occurrence, nil
+}
+
+// formatWeekdays stores weekdays as a comma separated list
+func formatWeekdays(days []int) string {
+	parts := make([]string, len(days))
+	for i, day := range days {
+		parts[i] = strconv.Itoa(day)
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseWeekdays reads weekdays stored by formatWeekdays
+func parseWeekdays(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	days := make([]int, len(parts))
+	for i, part := range parts {
+		day, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stored weekday %q: %w", part, err)
+		}
+		days[i] = day
+	}
+	return days, nil
+}
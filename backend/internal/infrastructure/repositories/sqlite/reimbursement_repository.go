@@ -0,0 +1,153 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ReimbursementRepository implements the repositories.ReimbursementRepository interface
+type ReimbursementRepository struct {
+	db *sql.DB
+}
+
+// NewReimbursementRepository creates a new SQLite reimbursement repository
+func NewReimbursementRepository(db *sql.DB) repositories.ReimbursementRepository {
+	return &ReimbursementRepository{
+		db: db,
+	}
+}
+
+const reimbursementColumns = `id, user_id, expense_id, kind, amount, currency, date, payer, note, created_at`
+
+// Create creates a new reimbursement
+func (r *ReimbursementRepository) Create(ctx context.Context, reimbursement *repositories.Reimbursement) error {
+	if reimbursement.ID == "" {
+		reimbursement.ID = uuid.NewString()
+	}
+	if reimbursement.CreatedAt.IsZero() {
+		reimbursement.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO reimbursements (`+reimbursementColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		reimbursement.ID,
+		reimbursement.UserID,
+		reimbursement.ExpenseID,
+		reimbursement.Kind,
+		reimbursement.Amount.String(),
+		reimbursement.Currency,
+		formatTime(reimbursement.Date),
+		reimbursement.Payer,
+		reimbursement.Note,
+		formatTime(reimbursement.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create reimbursement: %w", err)
+	}
+	return nil
+}
+
+// GetByID gets a reimbursement by ID
+func (r *ReimbursementRepository) GetByID(ctx context.Context, id string) (*repositories.Reimbursement, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+reimbursementColumns+` FROM reimbursements WHERE id = ?`, id)
+
+	reimbursement, err := scanReimbursement(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("reimbursement %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reimbursement: %w", err)
+	}
+	return reimbursement, nil
+}
+
+// GetByExpense gets every reimbursement received against an expense, oldest first
+func (r *ReimbursementRepository) GetByExpense(ctx context.Context, expenseID string) ([]*repositories.Reimbursement, error) {
+	return r.query(ctx,
+		`SELECT `+reimbursementColumns+` FROM reimbursements WHERE expense_id = ? ORDER BY date, created_at`,
+		expenseID,
+	)
+}
+
+// GetByExpenseDateRange gets every reimbursement received against the user's
+// expenses dated in [startDate, endDate)
+func (r *ReimbursementRepository) GetByExpenseDateRange(ctx context.Context, userID string, startDate, endDate time.Time) ([]*repositories.Reimbursement, error) {
+	return r.query(ctx,
+		`SELECT `+reimbursementColumns+` FROM reimbursements
+		WHERE expense_id IN (SELECT id FROM expenses WHERE user_id = ? AND date >= ? AND date < ?)
+		ORDER BY date, created_at`,
+		userID, formatTime(startDate), formatTime(endDate),
+	)
+}
+
+// Delete deletes a reimbursement by ID
+func (r *ReimbursementRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM reimbursements WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete reimbursement: %w", err)
+	}
+	return requireAffected(result, "reimbursement", id)
+}
+
+// query runs a SELECT over reimbursements and scans every row
+func (r *ReimbursementRepository) query(ctx context.Context, query string, args ...interface{}) ([]*repositories.Reimbursement, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reimbursements: %w", err)
+	}
+	defer rows.Close()
+
+	var reimbursements []*repositories.Reimbursement
+	for rows.Next() {
+		reimbursement, err := scanReimbursement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read reimbursement: %w", err)
+		}
+		reimbursements = append(reimbursements, reimbursement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query reimbursements: %w", err)
+	}
+	return reimbursements, nil
+}
+
+// scanReimbursement reads a single row selected with reimbursementColumns
+func scanReimbursement(row rowScanner) (*repositories.Reimbursement, error) {
+	var (
+		reimbursement           repositories.Reimbursement
+		amount, date, createdAt string
+	)
+	err := row.Scan(
+		&reimbursement.ID,
+		&reimbursement.UserID,
+		&reimbursement.ExpenseID,
+		&reimbursement.Kind,
+		&amount,
+		&reimbursement.Currency,
+		&date,
+		&reimbursement.Payer,
+		&reimbursement.Note,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if reimbursement.Amount, err = decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", amount, err)
+	}
+	if reimbursement.Date, err = parseTime(date); err != nil {
+		return nil, err
+	}
+	if reimbursement.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	return &reimbursement, nil
+}
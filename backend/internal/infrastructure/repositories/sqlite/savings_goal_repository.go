@@ -0,0 +1,285 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// SavingsGoalRepository implements the repositories.SavingsGoalRepository interface
+type SavingsGoalRepository struct {
+	db *sql.DB
+}
+
+// NewSavingsGoalRepository creates a new SQLite savings goal repository
+func NewSavingsGoalRepository(db *sql.DB) repositories.SavingsGoalRepository {
+	return &SavingsGoalRepository{
+		db: db,
+	}
+}
+
+const savingsGoalColumns = `id, user_id, name, target_amount, currency, target_date, created_at, updated_at`
+
+const goalContributionColumns = `id, goal_id, expense_id, amount, currency, date, note, created_at`
+
+// Create creates a new savings goal
+func (r *SavingsGoalRepository) Create(ctx context.Context, goal *repositories.SavingsGoal) error {
+	if goal.ID == "" {
+		goal.ID = uuid.NewString()
+	}
+	now := time.Now()
+	if goal.CreatedAt.IsZero() {
+		goal.CreatedAt = now
+	}
+	if goal.UpdatedAt.IsZero() {
+		goal.UpdatedAt = now
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO savings_goals (`+savingsGoalColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		goal.ID,
+		goal.UserID,
+		goal.Name,
+		goal.TargetAmount.String(),
+		goal.Currency,
+		nullTime(goal.TargetDate),
+		formatTime(goal.CreatedAt),
+		formatTime(goal.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create savings goal: %w", err)
+	}
+	return nil
+}
+
+// GetByID gets a savings goal by ID
+func (r *SavingsGoalRepository) GetByID(ctx context.Context, id string) (*repositories.SavingsGoal, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+savingsGoalColumns+` FROM savings_goals WHERE id = ?`, id)
+
+	goal, err := scanSavingsGoal(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("savings goal %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get savings goal: %w", err)
+	}
+	return goal, nil
+}
+
+// GetByUserID gets every savings goal for a user, those without a target date last
+func (r *SavingsGoalRepository) GetByUserID(ctx context.Context, userID string) ([]*repositories.SavingsGoal, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+savingsGoalColumns+` FROM savings_goals
+		WHERE user_id = ? ORDER BY target_date IS NULL, target_date, name`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query savings goals: %w", err)
+	}
+	defer rows.Close()
+
+	var goals []*repositories.SavingsGoal
+	for rows.Next() {
+		goal, err := scanSavingsGoal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read savings goal: %w", err)
+		}
+		goals = append(goals, goal)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query savings goals: %w", err)
+	}
+	return goals, nil
+}
+
+// Update updates a savings goal
+func (r *SavingsGoalRepository) Update(ctx context.Context, goal *repositories.SavingsGoal) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE savings_goals
+		SET name = ?, target_amount = ?, currency = ?, target_date = ?, updated_at = ?
+		WHERE id = ?`,
+		goal.Name,
+		goal.TargetAmount.String(),
+		goal.Currency,
+		nullTime(goal.TargetDate),
+		formatTime(goal.UpdatedAt),
+		goal.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update savings goal: %w", err)
+	}
+	return requireAffected(result, "savings goal", goal.ID)
+}
+
+// Delete deletes a savings goal; its contributions go with it
+func (r *SavingsGoalRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM savings_goals WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete savings goal: %w", err)
+	}
+	return requireAffected(result, "savings goal", id)
+}
+
+// CreateContribution creates a new contribution
+func (r *SavingsGoalRepository) CreateContribution(ctx context.Context, contribution *repositories.GoalContribution) error {
+	if contribution.ID == "" {
+		contribution.ID = uuid.NewString()
+	}
+	if contribution.CreatedAt.IsZero() {
+		contribution.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO goal_contributions (`+goalContributionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		contribution.ID,
+		contribution.GoalID,
+		nullString(contribution.ExpenseID),
+		contribution.Amount.String(),
+		contribution.Currency,
+		formatTime(contribution.Date),
+		contribution.Note,
+		formatTime(contribution.CreatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create goal contribution: %w", err)
+	}
+	return nil
+}
+
+// GetContributionByID gets a contribution by ID
+func (r *SavingsGoalRepository) GetContributionByID(ctx context.Context, id string) (*repositories.GoalContribution, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+goalContributionColumns+` FROM goal_contributions WHERE id = ?`, id)
+
+	contribution, err := scanGoalContribution(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("goal contribution %s: %w", id, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal contribution: %w", err)
+	}
+	return contribution, nil
+}
+
+// GetContributions gets every contribution to a goal, oldest first
+func (r *SavingsGoalRepository) GetContributions(ctx context.Context, goalID string) ([]*repositories.GoalContribution, error) {
+	return r.queryContributions(ctx,
+		`SELECT `+goalContributionColumns+` FROM goal_contributions WHERE goal_id = ? ORDER BY date, created_at`,
+		goalID,
+	)
+}
+
+// GetContributionsByExpense gets every contribution taken from an expense
+func (r *SavingsGoalRepository) GetContributionsByExpense(ctx context.Context, expenseID string) ([]*repositories.GoalContribution, error) {
+	return r.queryContributions(ctx,
+		`SELECT `+goalContributionColumns+` FROM goal_contributions WHERE expense_id = ? ORDER BY created_at`,
+		expenseID,
+	)
+}
+
+// DeleteContribution deletes a contribution by ID
+func (r *SavingsGoalRepository) DeleteContribution(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM goal_contributions WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete goal contribution: %w", err)
+	}
+	return requireAffected(result, "goal contribution", id)
+}
+
+// queryContributions runs a SELECT over goal_contributions and scans every row
+func (r *SavingsGoalRepository) queryContributions(ctx context.Context, query string, args ...interface{}) ([]*repositories.GoalContribution, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goal contributions: %w", err)
+	}
+	defer rows.Close()
+
+	var contributions []*repositories.GoalContribution
+	for rows.Next() {
+		contribution, err := scanGoalContribution(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read goal contribution: %w", err)
+		}
+		contributions = append(contributions, contribution)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query goal contributions: %w", err)
+	}
+	return contributions, nil
+}
+
+// scanSavingsGoal reads a single row selected with savingsGoalColumns
+func scanSavingsGoal(row rowScanner) (*repositories.SavingsGoal, error) {
+	var (
+		goal                 repositories.SavingsGoal
+		targetAmount         string
+		targetDate           sql.NullString
+		createdAt, updatedAt string
+	)
+	err := row.Scan(
+		&goal.ID,
+		&goal.UserID,
+		&goal.Name,
+		&targetAmount,
+		&goal.Currency,
+		&targetDate,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if goal.TargetAmount, err = decimal.NewFromString(targetAmount); err != nil {
+		return nil, fmt.Errorf("invalid stored target amount %q: %w", targetAmount, err)
+	}
+	if goal.TargetDate, err = parseNullTime(targetDate); err != nil {
+		return nil, err
+	}
+	if goal.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	if goal.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+// scanGoalContribution reads a single row selected with goalContributionColumns
+func scanGoalContribution(row rowScanner) (*repositories.GoalContribution, error) {
+	var (
+		contribution            repositories.GoalContribution
+		expenseID               sql.NullString
+		amount, date, createdAt string
+	)
+	err := row.Scan(
+		&contribution.ID,
+		&contribution.GoalID,
+		&expenseID,
+		&amount,
+		&contribution.Currency,
+		&date,
+		&contribution.Note,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	contribution.ExpenseID = expenseID.String
+	if contribution.Amount, err = decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid stored amount %q: %w", amount, err)
+	}
+	if contribution.Date, err = parseTime(date); err != nil {
+		return nil, err
+	}
+	if contribution.CreatedAt, err = parseTime(createdAt); err != nil {
+		return nil, err
+	}
+	return &contribution, nil
+}
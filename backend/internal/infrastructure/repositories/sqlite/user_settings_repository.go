@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"nestmate-backend/internal/domain/repositories"
+)
+
+// UserSettingsRepository implements the repositories.UserSettingsRepository interface
+type UserSettingsRepository struct {
+	db *sql.DB
+}
+
+// NewUserSettingsRepository creates a new SQLite user settings repository
+func NewUserSettingsRepository(db *sql.DB) repositories.UserSettingsRepository {
+	return &UserSettingsRepository{
+		db: db,
+	}
+}
+
+// Get gets a user's settings
+func (r *UserSettingsRepository) Get(ctx context.Context, userID string) (*repositories.UserSettings, error) {
+	var (
+		settings  repositories.UserSettings
+		updatedAt string
+	)
+	err := r.db.QueryRowContext(ctx,
+		`SELECT user_id, base_currency, fiscal_year_start, updated_at FROM user_settings WHERE user_id = ?`,
+		userID,
+	).Scan(&settings.UserID, &settings.BaseCurrency, &settings.FiscalYearStart, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("settings for user %s: %w", userID, repositories.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+
+	if settings.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// Save creates or replaces a user's settings
+func (r *UserSettingsRepository) Save(ctx context.Context, settings *repositories.UserSettings) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO user_settings (user_id, base_currency, fiscal_year_start, updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET base_currency = excluded.base_currency,
+			fiscal_year_start = excluded.fiscal_year_start, updated_at = excluded.updated_at`,
+		settings.UserID,
+		settings.BaseCurrency,
+		settings.FiscalYearStart,
+		formatTime(settings.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save user settings: %w", err)
+	}
+	return nil
+}
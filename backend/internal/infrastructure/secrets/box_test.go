@@ -0,0 +1,44 @@
+package secrets
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSealAndOpen(t *testing.T) {
+	box, err := NewBox("test-key")
+	if err != nil {
+		t.Fatalf("NewBox: %v", err)
+	}
+
+	sealed, err := box.Seal("date of birth, DDMM", "u1/hdfc")
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if strings.Contains(sealed, "birth") {
+		t.Errorf("expected the sealed value to hide the plaintext, got %q", sealed)
+	}
+	if again, _ := box.Seal("date of birth, DDMM", "u1/hdfc"); again == sealed {
+		t.Error("expected sealing twice to use different nonces")
+	}
+
+	opened, err := box.Open(sealed, "u1/hdfc")
+	if err != nil || opened != "date of birth, DDMM" {
+		t.Errorf("expected the hint back, got %q, %v", opened, err)
+	}
+
+	if _, err := box.Open(sealed, "u2/hdfc"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("expected another context to fail, got %v", err)
+	}
+	other, _ := NewBox("other-key")
+	if _, err := other.Open(sealed, "u1/hdfc"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("expected another key to fail, got %v", err)
+	}
+	if _, err := box.Open("not base64!", "u1/hdfc"); !errors.Is(err, ErrDecrypt) {
+		t.Errorf("expected garbage to fail, got %v", err)
+	}
+	if _, err := NewBox(""); err == nil {
+		t.Error("expected an empty passphrase to be rejected")
+	}
+}
@@ -0,0 +1,345 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// accountRequest is the request body for creating or updating an account
+type accountRequest struct {
+	Name           string               `json:"name" binding:"required"`
+	Type           entities.AccountType `json:"type" binding:"required"`
+	Currency       string               `json:"currency"` // defaults to the user's base currency
+	Last4          string               `json:"last4"`
+	OpeningBalance decimal.Decimal      `json:"opening_balance"`
+	OpeningDate    string               `json:"opening_date"` // defaults to today
+	Archived       bool                 `json:"archived"`
+}
+
+// toEntity converts the request into an account owned by userID
+func (r *accountRequest) toEntity(userID string) (*entities.Account, error) {
+	account := &entities.Account{
+		UserID:         userID,
+		Name:           r.Name,
+		Type:           r.Type,
+		Currency:       r.Currency,
+		Last4:          r.Last4,
+		OpeningBalance: r.OpeningBalance,
+		Archived:       r.Archived,
+	}
+	if r.OpeningDate != "" {
+		date, err := parseDate(r.OpeningDate)
+		if err != nil {
+			return nil, err
+		}
+		account.OpeningDate = date
+	}
+	return account, nil
+}
+
+// transferRequest is the request body for moving money between accounts
+type transferRequest struct {
+	FromAccountID string          `json:"from_account_id" binding:"required"`
+	ToAccountID   string          `json:"to_account_id" binding:"required"`
+	Amount        decimal.Decimal `json:"amount"`
+	ToAmount      decimal.Decimal `json:"to_amount"` // defaults to amount converted into the destination currency
+	Date          string          `json:"date"`      // defaults to now
+	Note          string          `json:"note"`
+}
+
+// statementRequest is the request body for reconciling an account against a statement
+type statementRequest struct {
+	AccountLast4   string           `json:"account_last4"`
+	StartDate      string           `json:"start_date"` // defaults to the first line's date
+	EndDate        string           `json:"end_date"`   // inclusive, defaults to the last line's date
+	ClosingBalance *decimal.Decimal `json:"closing_balance"`
+	Lines          []struct {
+		Date        string          `json:"date" binding:"required"`
+		Description string          `json:"description"`
+		Amount      decimal.Decimal `json:"amount"`
+		Type        string          `json:"type" binding:"required"` // debit or credit
+	} `json:"lines" binding:"required,dive"`
+}
+
+// toEntity converts the request into a statement, returning the name of the
+// field that failed to parse along with the error
+func (r *statementRequest) toEntity() (*entities.Statement, string, error) {
+	statement := &entities.Statement{
+		AccountLast4:   strings.TrimSpace(r.AccountLast4),
+		ClosingBalance: r.ClosingBalance,
+	}
+	var err error
+	if r.StartDate != "" {
+		if statement.StartDate, err = parseDate(r.StartDate); err != nil {
+			return nil, "start_date", err
+		}
+	}
+	if r.EndDate != "" {
+		if statement.EndDate, err = parseDate(r.EndDate); err != nil {
+			return nil, "end_date", err
+		}
+	}
+	for _, line := range r.Lines {
+		date, err := parseDate(line.Date)
+		if err != nil {
+			return nil, "lines.date", err
+		}
+		statement.Lines = append(statement.Lines, &entities.StatementLine{
+			Date:        date,
+			Description: line.Description,
+			Amount:      line.Amount,
+			Type:        entities.StatementLineType(strings.ToLower(line.Type)),
+		})
+	}
+	return statement, "", nil
+}
+
+func (s *Server) handleCreateAccount(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req accountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	account, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, "opening_date", err)
+		return
+	}
+	ctx := context.Background()
+	if err := s.accountService.CreateAccount(ctx, account); err != nil {
+		respondServiceError(c, "Failed to create account", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"account": account,
+	})
+}
+
+func (s *Server) handleGetAccounts(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	accounts, err := s.accountService.GetAccounts(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get accounts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"accounts": accounts,
+	})
+}
+
+func (s *Server) handleGetAccount(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	account, err := s.accountService.GetAccount(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get account", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"account": account,
+	})
+}
+
+func (s *Server) handleUpdateAccount(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req accountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	account, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, "opening_date", err)
+		return
+	}
+	ctx := context.Background()
+	if err := s.accountService.UpdateAccount(ctx, c.Param("id"), account); err != nil {
+		respondServiceError(c, "Failed to update account", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"account": account,
+	})
+}
+
+func (s *Server) handleDeleteAccount(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.accountService.DeleteAccount(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete account", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Account deleted",
+	})
+}
+
+func (s *Server) handleGetAccountLedger(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var from, to time.Time
+	var err error
+	if v := c.Query("from"); v != "" {
+		if from, err = parseDate(v); err != nil {
+			respondInvalidParam(c, "from", err)
+			return
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = parseDate(v); err != nil {
+			respondInvalidParam(c, "to", err)
+			return
+		}
+	}
+
+	ctx := context.Background()
+	ledger, err := s.accountService.GetLedger(ctx, userID, c.Param("id"), from, to)
+	if err != nil {
+		respondServiceError(c, "Failed to get account ledger", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ledger": ledger,
+	})
+}
+
+func (s *Server) handleReconcileAccount(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req statementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	statement, field, err := req.toEntity()
+	if err != nil {
+		respondInvalidParam(c, field, err)
+		return
+	}
+	ctx := context.Background()
+	reconciliation, err := s.accountService.Reconcile(ctx, userID, c.Param("id"), statement)
+	if err != nil {
+		respondServiceError(c, "Failed to reconcile account", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reconciliation": reconciliation,
+	})
+}
+
+func (s *Server) handleCreateTransfer(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req transferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	transfer := &entities.Transfer{
+		UserID:        userID,
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		ToAmount:      req.ToAmount,
+		Note:          req.Note,
+	}
+	if req.Date != "" {
+		date, err := parseDate(req.Date)
+		if err != nil {
+			respondInvalidParam(c, "date", err)
+			return
+		}
+		transfer.Date = date
+	}
+
+	ctx := context.Background()
+	if err := s.accountService.CreateTransfer(ctx, transfer); err != nil {
+		respondServiceError(c, "Failed to create transfer", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"transfer": transfer,
+	})
+}
+
+func (s *Server) handleDeleteTransfer(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.accountService.DeleteTransfer(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete transfer", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Transfer deleted",
+	})
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// budgetRequest is the request body for creating or updating a budget
+type budgetRequest struct {
+	Kind     entities.CategoryKind `json:"kind" binding:"required"`
+	Category string                `json:"category" binding:"required"`
+	Month    string                `json:"month" binding:"required"`
+	Amount   decimal.Decimal       `json:"amount"`
+	Currency string                `json:"currency"` // defaults to the user's base currency
+	Rollover bool                  `json:"rollover"`
+}
+
+// toEntity converts the request into a budget owned by userID
+func (r *budgetRequest) toEntity(userID string) (*entities.Budget, error) {
+	month, err := parseMonth(r.Month)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.Budget{
+		UserID:   userID,
+		Kind:     r.Kind,
+		Category: r.Category,
+		Month:    month,
+		Amount:   r.Amount,
+		Currency: r.Currency,
+		Rollover: r.Rollover,
+	}, nil
+}
+
+func (s *Server) handleCreateBudget(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req budgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	budget, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, "month", err)
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.budgetService.CreateBudget(ctx, budget); err != nil {
+		respondServiceError(c, "Failed to create budget", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"budget": budget,
+	})
+}
+
+func (s *Server) handleGetBudgets(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	month, err := parseMonth(c.Query("month"))
+	if err != nil {
+		respondInvalidParam(c, "month", err)
+		return
+	}
+
+	ctx := context.Background()
+	budgets, err := s.budgetService.GetBudgets(ctx, userID, month)
+	if err != nil {
+		respondServiceError(c, "Failed to get budgets", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"budgets": budgets,
+	})
+}
+
+func (s *Server) handleGetBudgetReport(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	month, err := parseMonth(c.Query("month"))
+	if err != nil {
+		respondInvalidParam(c, "month", err)
+		return
+	}
+
+	ctx := context.Background()
+	report, err := s.budgetService.GetBudgetReport(ctx, userID, month)
+	if err != nil {
+		respondServiceError(c, "Failed to get budget report", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report": report,
+	})
+}
+
+func (s *Server) handleGetBudget(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	budget, err := s.budgetService.GetBudget(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get budget", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"budget": budget,
+	})
+}
+
+func (s *Server) handleUpdateBudget(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req budgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	budget, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, "month", err)
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.budgetService.UpdateBudget(ctx, c.Param("id"), budget); err != nil {
+		respondServiceError(c, "Failed to update budget", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"budget": budget,
+	})
+}
+
+func (s *Server) handleDeleteBudget(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.budgetService.DeleteBudget(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete budget", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Budget deleted",
+	})
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// categorizationRuleRequest is the request body for creating, updating or previewing a categorization rule
+type categorizationRuleRequest struct {
+	Name            string           `json:"name"`             // defaults to the pattern
+	MatchType       string           `json:"match_type"`       // keyword (default) or regex
+	Pattern         string           `json:"pattern"`
+	MinAmount       *decimal.Decimal `json:"min_amount"`
+	MaxAmount       *decimal.Decimal `json:"max_amount"`
+	TransactionType string           `json:"transaction_type"` // debit, credit or empty for both
+	AccountID       string           `json:"account_id"`
+	MainCategory    string           `json:"main_category" binding:"required"`
+	SubCategory     string           `json:"sub_category" binding:"required"`
+	Priority        int              `json:"priority"`
+}
+
+// toEntity converts the request into a categorization rule owned by userID
+func (r *categorizationRuleRequest) toEntity(userID string) *entities.CategorizationRule {
+	return &entities.CategorizationRule{
+		UserID:          userID,
+		Name:            r.Name,
+		MatchType:       entities.RuleMatchType(r.MatchType),
+		Pattern:         r.Pattern,
+		MinAmount:       r.MinAmount,
+		MaxAmount:       r.MaxAmount,
+		TransactionType: entities.TransactionType(r.TransactionType),
+		AccountID:       r.AccountID,
+		MainCategory:    entities.MainCategory(r.MainCategory),
+		SubCategory:     entities.SubCategory(r.SubCategory),
+		Priority:        r.Priority,
+	}
+}
+
+func (s *Server) handleCreateCategorizationRule(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req categorizationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+	rule := req.toEntity(userID)
+	ctx := context.Background()
+	if err := s.ruleService.CreateRule(ctx, rule); err != nil {
+		respondServiceError(c, "Failed to create categorization rule", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"rule": rule,
+	})
+}
+
+func (s *Server) handleGetCategorizationRules(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	rules, err := s.ruleService.GetRules(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get categorization rules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+	})
+}
+
+func (s *Server) handleGetCategorizationRule(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	rule, err := s.ruleService.GetRule(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get categorization rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rule": rule,
+	})
+}
+
+func (s *Server) handleUpdateCategorizationRule(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req categorizationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+	rule := req.toEntity(userID)
+	ctx := context.Background()
+	if err := s.ruleService.UpdateRule(ctx, c.Param("id"), rule); err != nil {
+		respondServiceError(c, "Failed to update categorization rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rule": rule,
+	})
+}
+
+func (s *Server) handleDeleteCategorizationRule(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.ruleService.DeleteRule(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete categorization rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Categorization rule deleted",
+	})
+}
+
+// handlePreviewCategorizationRule tests a rule against the user's recorded
+// expenses without saving it
+func (s *Server) handlePreviewCategorizationRule(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req categorizationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+	ctx := context.Background()
+	preview, err := s.ruleService.PreviewRule(ctx, req.toEntity(userID))
+	if err != nil {
+		respondServiceError(c, "Failed to preview categorization rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preview": preview,
+	})
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// categoryRequest is the request body for creating or updating a category.
+// Kind is ignored on update since a category can't move between trees.
+type categoryRequest struct {
+	Name     string                `json:"name" binding:"required"`
+	Kind     entities.CategoryKind `json:"kind"`
+	ParentID string                `json:"parent_id"`
+	Color    string                `json:"color"`
+	Icon     string                `json:"icon"`
+}
+
+// toEntity converts the request into a category owned by userID
+func (r *categoryRequest) toEntity(userID string) *entities.Category {
+	return &entities.Category{
+		UserID:   userID,
+		Name:     r.Name,
+		Kind:     r.Kind,
+		ParentID: r.ParentID,
+		Color:    r.Color,
+		Icon:     r.Icon,
+	}
+}
+
+// mergeCategoryRequest is the request body for merging a category into another
+type mergeCategoryRequest struct {
+	TargetID string `json:"target_id" binding:"required"`
+}
+
+func (s *Server) handleGetCategories(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	includeArchived := false
+	if v := c.Query("include_archived"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			respondInvalidParam(c, "include_archived", err)
+			return
+		}
+		includeArchived = parsed
+	}
+
+	ctx := context.Background()
+	categories, err := s.categoryService.GetCategoryTree(ctx, userID, includeArchived)
+	if err != nil {
+		respondServiceError(c, "Failed to get categories", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"categories": categories,
+	})
+}
+
+func (s *Server) handleCreateCategory(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req categoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	category := req.toEntity(userID)
+	ctx := context.Background()
+	if err := s.categoryService.CreateCategory(ctx, category); err != nil {
+		respondServiceError(c, "Failed to create category", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"category": category,
+	})
+}
+
+func (s *Server) handleGetCategory(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	category, err := s.categoryService.GetCategory(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get category", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"category": category,
+	})
+}
+
+func (s *Server) handleUpdateCategory(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req categoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	category := req.toEntity(userID)
+	ctx := context.Background()
+	if err := s.categoryService.UpdateCategory(ctx, c.Param("id"), category); err != nil {
+		respondServiceError(c, "Failed to update category", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"category": category,
+	})
+}
+
+func (s *Server) handleArchiveCategory(c *gin.Context) {
+	s.setCategoryArchived(c, true)
+}
+
+func (s *Server) handleUnarchiveCategory(c *gin.Context) {
+	s.setCategoryArchived(c, false)
+}
+
+// setCategoryArchived archives or restores the category named in the path
+func (s *Server) setCategoryArchived(c *gin.Context, archived bool) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.categoryService.ArchiveCategory(ctx, userID, c.Param("id"), archived); err != nil {
+		respondServiceError(c, "Failed to archive category", err)
+		return
+	}
+
+	category, err := s.categoryService.GetCategory(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get category", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"category": category,
+	})
+}
+
+func (s *Server) handleMergeCategory(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req mergeCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.categoryService.MergeCategories(ctx, userID, c.Param("id"), req.TargetID); err != nil {
+		respondServiceError(c, "Failed to merge categories", err)
+		return
+	}
+
+	target, err := s.categoryService.GetCategory(ctx, userID, req.TargetID)
+	if err != nil {
+		respondServiceError(c, "Failed to get category", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"category": target,
+	})
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"nestmate-backend/internal/application/services"
+)
+
+// respondServiceError maps a service error to the matching HTTP status and error code
+func respondServiceError(c *gin.Context, message string, err error) {
+	status, code := serviceErrorStatus(err)
+	c.JSON(status, gin.H{
+		"error":   message,
+		"code":    code,
+		"details": err.Error(),
+	})
+}
+
+// serviceErrorStatus returns the HTTP status and error code for a service error
+func serviceErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound, "NOT_FOUND"
+	case errors.Is(err, services.ErrInvalidInput):
+		return http.StatusBadRequest, "VALIDATION_FAILED"
+	case errors.Is(err, services.ErrPasswordRequired):
+		return http.StatusBadRequest, "PASSWORD_REQUIRED"
+	case errors.Is(err, services.ErrWrongPassword):
+		return http.StatusBadRequest, "WRONG_PASSWORD"
+	}
+	return http.StatusInternalServerError, "INTERNAL_ERROR"
+}
+
+// respondInvalidParam reports a malformed request parameter
+func respondInvalidParam(c *gin.Context, param string, err error) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":   "Invalid " + param,
+		"code":    "INVALID_REQUEST",
+		"details": err.Error(),
+	})
+}
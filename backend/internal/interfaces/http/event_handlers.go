@@ -0,0 +1,193 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// eventRequest is the request body for creating or updating an event
+type eventRequest struct {
+	Name       string           `json:"name" binding:"required"`
+	StartDate  string           `json:"start_date" binding:"required"`
+	EndDate    string           `json:"end_date" binding:"required"` // inclusive
+	AutoAttach *bool            `json:"auto_attach"`                 // defaults to true
+	Budget     *decimal.Decimal `json:"budget"`                      // optional
+	Currency   string           `json:"currency"`                    // defaults to the user's base currency
+	Note       string           `json:"note"`
+}
+
+// toEntity converts the request into an event owned by userID, naming the
+// offending field when a date is malformed
+func (r *eventRequest) toEntity(userID string) (*entities.Event, string, error) {
+	event := &entities.Event{
+		UserID:     userID,
+		Name:       r.Name,
+		AutoAttach: r.AutoAttach == nil || *r.AutoAttach,
+		Budget:     r.Budget,
+		Currency:   r.Currency,
+		Note:       r.Note,
+	}
+	var err error
+	if event.StartDate, err = parseDate(r.StartDate); err != nil {
+		return nil, "start_date", err
+	}
+	if event.EndDate, err = parseDate(r.EndDate); err != nil {
+		return nil, "end_date", err
+	}
+	return event, "", nil
+}
+
+func (s *Server) handleCreateEvent(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req eventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	event, field, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, field, err)
+		return
+	}
+	ctx := context.Background()
+	if err := s.eventService.CreateEvent(ctx, event); err != nil {
+		respondServiceError(c, "Failed to create event", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"event": event,
+	})
+}
+
+func (s *Server) handleGetEvents(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	events, err := s.eventService.GetEvents(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get events", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+	})
+}
+
+func (s *Server) handleGetEvent(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	summary, err := s.eventService.GetEvent(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get event", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"event": summary,
+	})
+}
+
+func (s *Server) handleUpdateEvent(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req eventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	event, field, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, field, err)
+		return
+	}
+	ctx := context.Background()
+	if err := s.eventService.UpdateEvent(ctx, c.Param("id"), event); err != nil {
+		respondServiceError(c, "Failed to update event", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"event": event,
+	})
+}
+
+func (s *Server) handleDeleteEvent(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.eventService.DeleteEvent(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete event", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Event deleted",
+	})
+}
+
+func (s *Server) handleAttachEventExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.eventService.AttachExpense(ctx, userID, c.Param("id"), c.Param("expenseId")); err != nil {
+		respondServiceError(c, "Failed to attach expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Expense attached",
+	})
+}
+
+func (s *Server) handleDetachEventExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.eventService.DetachExpense(ctx, userID, c.Param("id"), c.Param("expenseId")); err != nil {
+		respondServiceError(c, "Failed to detach expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Expense detached",
+	})
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// exchangeRateRequest is the request body for recording an exchange rate
+type exchangeRateRequest struct {
+	FromCurrency string          `json:"from_currency" binding:"required"`
+	ToCurrency   string          `json:"to_currency" binding:"required"`
+	Date         string          `json:"date" binding:"required"`
+	Rate         decimal.Decimal `json:"rate"`
+}
+
+func (s *Server) handleGetExchangeRates(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	rates, err := s.currencyService.GetRates(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get exchange rates", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"exchange_rates": rates,
+	})
+}
+
+func (s *Server) handleSetExchangeRate(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req exchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	date, err := parseDate(req.Date)
+	if err != nil {
+		respondInvalidParam(c, "date", err)
+		return
+	}
+
+	rate := &entities.ExchangeRate{
+		UserID:       userID,
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+		Date:         date,
+		Rate:         req.Rate,
+	}
+	ctx := context.Background()
+	if err := s.currencyService.SetRate(ctx, rate); err != nil {
+		respondServiceError(c, "Failed to save exchange rate", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"exchange_rate": rate,
+	})
+}
+
+// handleImportExchangeRates imports rates from a CSV uploaded as the "file" form field
+func (s *Server) handleImportExchangeRates(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		respondInvalidParam(c, "file", err)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		respondInvalidParam(c, "file", err)
+		return
+	}
+	defer file.Close()
+
+	ctx := context.Background()
+	rates, err := s.currencyService.ImportRates(ctx, userID, file)
+	if err != nil {
+		respondServiceError(c, "Failed to import exchange rates", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"exchange_rates": rates,
+		"imported":       len(rates),
+	})
+}
+
+func (s *Server) handleDeleteExchangeRate(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.currencyService.DeleteRate(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete exchange rate", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Exchange rate deleted",
+	})
+}
@@ -0,0 +1,395 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/application/services"
+	"nestmate-backend/internal/domain/entities"
+	"nestmate-backend/internal/interfaces/http/middleware"
+)
+
+// expenseRequest is the request body for creating or updating an expense
+type expenseRequest struct {
+	Amount         decimal.Decimal      `json:"amount"`   // defaults to the items' total when items are given
+	Currency       string               `json:"currency"` // defaults to the user's base currency
+	Description    string               `json:"description"`
+	Date           string               `json:"date" binding:"required"`
+	MainCategory   string               `json:"main_category" binding:"required"`
+	SubCategory    string               `json:"sub_category"` // defaults to the largest item's when items are given
+	AccountID      string               `json:"account_id"`
+	TaxSection     string               `json:"tax_section"` // deduction section such as 80C, see GET /reports/tax-sections
+	Items          []expenseItemRequest `json:"items"`
+	AmortizeMonths int                  `json:"amortize_months"` // spread over this many months in amortized figures
+	Reimbursable   bool                 `json:"reimbursable"`
+	ExpectedPayer  string               `json:"expected_payer"` // requires reimbursable
+	EventID        string               `json:"event_id"`       // defaults to an auto-attaching event covering the date
+}
+
+// expenseItemRequest is one line item of an itemized expense
+type expenseItemRequest struct {
+	Description string          `json:"description"`
+	Amount      decimal.Decimal `json:"amount"`
+	SubCategory string          `json:"sub_category"`
+}
+
+// toEntity converts the request into an expense owned by userID
+func (r *expenseRequest) toEntity(userID string) (*entities.Expense, error) {
+	date, err := parseDate(r.Date)
+	if err != nil {
+		return nil, err
+	}
+	expense := &entities.Expense{
+		UserID:         userID,
+		Amount:         r.Amount,
+		Currency:       r.Currency,
+		Description:    r.Description,
+		Date:           date,
+		MainCategory:   entities.MainCategory(r.MainCategory),
+		SubCategory:    entities.SubCategory(r.SubCategory),
+		AccountID:      r.AccountID,
+		TaxSection:     entities.TaxSection(r.TaxSection),
+		AmortizeMonths: r.AmortizeMonths,
+		Reimbursable:   r.Reimbursable,
+		ExpectedPayer:  r.ExpectedPayer,
+		EventID:        r.EventID,
+	}
+	for _, item := range r.Items {
+		expense.Items = append(expense.Items, &entities.ExpenseItem{
+			Description: item.Description,
+			Amount:      item.Amount,
+			SubCategory: entities.SubCategory(item.SubCategory),
+		})
+	}
+	return expense, nil
+}
+
+func (s *Server) handleCreateExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req expenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	expense, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, "date", err)
+		return
+	}
+
+	ctx := context.Background()
+	warnings, err := s.expenseService.AddExpense(ctx, expense)
+	if err != nil {
+		respondServiceError(c, "Failed to create expense", err)
+		return
+	}
+
+	response := gin.H{
+		"expense": expense,
+	}
+	if len(warnings) > 0 {
+		response["budget_warnings"] = warnings
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (s *Server) handleGetExpenses(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	filter, ok := parseExpenseFilter(c)
+	if !ok {
+		return
+	}
+
+	var err error
+	if filter.Limit, err = queryInt(c, "limit"); err != nil {
+		respondInvalidParam(c, "limit", err)
+		return
+	}
+	if filter.Offset, err = queryInt(c, "offset"); err != nil {
+		respondInvalidParam(c, "offset", err)
+		return
+	}
+
+	ctx := context.Background()
+	expenses, total, err := s.expenseService.ListExpenses(ctx, userID, filter)
+	if err != nil {
+		respondServiceError(c, "Failed to get expenses", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"expenses": expenses,
+		"total":    total,
+		"limit":    filter.Limit,
+		"offset":   filter.Offset,
+	})
+}
+
+func (s *Server) handleGetExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	expense, err := s.expenseService.GetExpense(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"expense": expense,
+	})
+}
+
+func (s *Server) handleUpdateExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req expenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	expense, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, "date", err)
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.expenseService.UpdateExpense(ctx, c.Param("id"), expense); err != nil {
+		respondServiceError(c, "Failed to update expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"expense": expense,
+	})
+}
+
+func (s *Server) handleDeleteExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.expenseService.DeleteExpense(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Expense deleted",
+	})
+}
+
+// handleGetMonthlyBreakdown returns the breakdown for "month" (YYYY-MM), on a cash
+// basis unless basis=amortized spreads amortized expenses over their months
+func (s *Server) handleGetMonthlyBreakdown(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	month, err := parseMonth(c.Query("month"))
+	if err != nil {
+		respondInvalidParam(c, "month", err)
+		return
+	}
+
+	ctx := context.Background()
+	breakdown, err := s.expenseService.GetMonthlyBreakdown(ctx, userID, month, entities.Basis(c.Query("basis")))
+	if err != nil {
+		respondServiceError(c, "Failed to get monthly breakdown", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"breakdown": breakdown,
+	})
+}
+
+// handleGetTrends returns monthly spending series ending at "end" (YYYY-MM,
+// defaults to the current month), grouped by main or sub-category
+func (s *Server) handleGetTrends(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	query := &services.TrendQuery{GroupBy: entities.CategoryKind(c.Query("group_by"))}
+	var err error
+	if query.End, err = parseMonth(c.Query("end")); err != nil {
+		respondInvalidParam(c, "end", err)
+		return
+	}
+	if query.Months, err = queryInt(c, "months"); err != nil {
+		respondInvalidParam(c, "months", err)
+		return
+	}
+	if query.Window, err = queryInt(c, "window"); err != nil {
+		respondInvalidParam(c, "window", err)
+		return
+	}
+	if v := c.Query("threshold"); v != "" {
+		if query.Threshold, err = decimal.NewFromString(v); err != nil {
+			respondInvalidParam(c, "threshold", err)
+			return
+		}
+	}
+
+	ctx := context.Background()
+	trends, err := s.expenseService.GetTrends(ctx, userID, query)
+	if err != nil {
+		respondServiceError(c, "Failed to get spending trends", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"trends": trends,
+	})
+}
+
+// exportContentTypes maps each export format to the Content-Type it is served with
+var exportContentTypes = map[services.ExportFormat]string{
+	services.ExportCSV:  "text/csv; charset=utf-8",
+	services.ExportJSON: "application/json; charset=utf-8",
+	services.ExportOFX:  "application/x-ofx",
+}
+
+func (s *Server) handleExportExpenses(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	filter, ok := parseExpenseFilter(c)
+	if !ok {
+		return
+	}
+	options := &services.ExportOptions{
+		Format: services.ExportFormat(strings.ToLower(c.DefaultQuery("format", string(services.ExportCSV)))),
+		Filter: filter,
+	}
+	if v := c.Query("columns"); v != "" {
+		options.Columns = strings.Split(v, ",")
+	}
+
+	// Headers only reach the client with the first byte, so they can still be
+	// dropped if the service rejects the options before writing anything
+	if contentType, known := exportContentTypes[options.Format]; known {
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="expenses.%s"`, options.Format))
+	}
+
+	ctx := context.Background()
+	if err := s.expenseService.ExportData(ctx, userID, c.Writer, options); err != nil {
+		if c.Writer.Written() {
+			log.Printf("Failed to finish expense export for user %s: %v", userID, err)
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		respondServiceError(c, "Failed to export expenses", err)
+	}
+}
+
+// parseExpenseFilter reads the category, account, event and date range filters shared by the
+// list and export endpoints, responding with 400 if a date is malformed
+func parseExpenseFilter(c *gin.Context) (*services.ExpenseFilter, bool) {
+	filter := &services.ExpenseFilter{
+		MainCategory: entities.MainCategory(c.Query("main_category")),
+		SubCategory:  entities.SubCategory(c.Query("sub_category")),
+		AccountID:    c.Query("account_id"),
+		EventID:      c.Query("event_id"),
+	}
+
+	if v := c.Query("start_date"); v != "" {
+		start, err := parseDate(v)
+		if err != nil {
+			respondInvalidParam(c, "start_date", err)
+			return nil, false
+		}
+		filter.StartDate = &start
+	}
+	if v := c.Query("end_date"); v != "" {
+		end, err := parseDate(v)
+		if err != nil {
+			respondInvalidParam(c, "end_date", err)
+			return nil, false
+		}
+		// end_date is inclusive for clients, the service expects an exclusive bound
+		end = end.AddDate(0, 0, 1)
+		filter.EndDate = &end
+	}
+	return filter, true
+}
+
+// parseDate accepts either a calendar date (2006-01-02) or an RFC 3339 timestamp
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseMonth parses a YYYY-MM month, defaulting to the current month when empty
+func parseMonth(value string) (time.Time, error) {
+	if value == "" {
+		now := time.Now().UTC()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Parse("2006-01", value)
+}
+
+// queryInt reads an optional integer query parameter
+func queryInt(c *gin.Context, key string) (int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// requireUserID reads the authenticated user ID, responding with 401 if it is missing
+func requireUserID(c *gin.Context) (string, bool) {
+	userID, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User not authenticated",
+			"code":  "USER_NOT_AUTHENTICATED",
+		})
+		return "", false
+	}
+	return userID, true
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	// Keep NewServer from creating a database file next to the tests
+	os.Setenv("DB_NAME", ":memory:")
+	os.Setenv("ENCRYPTION_KEY", "test-key")
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
+
+// newTestRouter exposes handlers behind a stub that authenticates as the X-Test-User header
+func newTestRouter(register func(api *gin.RouterGroup)) *gin.Engine {
+	router := gin.New()
+	api := router.Group("/api/v1")
+	api.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-Test-User"); userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	})
+	register(api)
+	return router
+}
+
+// performRequest sends a JSON request as userID and decodes the JSON response
+func performRequest(t *testing.T, router http.Handler, method, path, userID string, body interface{}) (*httptest.ResponseRecorder, map[string]interface{}) {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to encode request: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, _ := http.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if userID != "" {
+		req.Header.Set("X-Test-User", userID)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var decoded map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &decoded)
+	return w, decoded
+}
+
+func newExpenseTestRouter() *gin.Engine {
+	server := NewServer()
+	return newTestRouter(func(api *gin.RouterGroup) {
+		api.POST("/expenses", server.handleCreateExpense)
+		api.GET("/expenses", server.handleGetExpenses)
+		api.GET("/expenses/export", server.handleExportExpenses)
+		api.GET("/expenses/:id", server.handleGetExpense)
+		api.PUT("/expenses/:id", server.handleUpdateExpense)
+		api.DELETE("/expenses/:id", server.handleDeleteExpense)
+		api.GET("/expenses/breakdown", server.handleGetMonthlyBreakdown)
+	})
+}
+
+func TestExpenseEndpoints(t *testing.T) {
+	router := newExpenseTestRouter()
+
+	w, resp := performRequest(t, router, "POST", "/api/v1/expenses", "alice", gin.H{
+		"amount":        "1499.99",
+		"description":   "Weekly groceries",
+		"date":          "2024-03-15",
+		"main_category": "Chennai House",
+		"sub_category":  "Food",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	expense := resp["expense"].(map[string]interface{})
+	id := expense["id"].(string)
+	if expense["amount"] != "1499.99" {
+		t.Errorf("amount should keep exact decimal formatting, got %v", expense["amount"])
+	}
+
+	w, _ = performRequest(t, router, "GET", "/api/v1/expenses/"+id, "bob", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("other users must not see the expense: expected %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w, resp = performRequest(t, router, "GET", "/api/v1/expenses?start_date=2024-03-01&end_date=2024-03-15&sub_category=Food", "alice", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("list: expected %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if resp["total"].(float64) != 1 {
+		t.Errorf("expected end_date to be inclusive, got total %v", resp["total"])
+	}
+
+	w, resp = performRequest(t, router, "GET", "/api/v1/expenses/breakdown?month=2024-03", "alice", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("breakdown: expected %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	breakdown := resp["breakdown"].(map[string]interface{})
+	if breakdown["total_expenses"] != "1499.99" {
+		t.Errorf("unexpected breakdown total %v", breakdown["total_expenses"])
+	}
+
+	w, _ = performRequest(t, router, "GET", "/api/v1/expenses/export?format=csv&columns=date,amount&end_date=2024-03-15", "alice", nil)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Fatalf("export: expected a CSV download, got %d %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+	if w.Body.String() != "date,amount\n2024-03-15,1499.99\n" {
+		t.Errorf("unexpected CSV export %q", w.Body.String())
+	}
+
+	w, resp = performRequest(t, router, "GET", "/api/v1/expenses/export?format=xls", "alice", nil)
+	if w.Code != http.StatusBadRequest || resp["code"] != "VALIDATION_FAILED" || w.Header().Get("Content-Disposition") != "" {
+		t.Errorf("export: expected an unknown format to be rejected, got %d %v", w.Code, resp)
+	}
+
+	w, _ = performRequest(t, router, "DELETE", "/api/v1/expenses/"+id, "alice", nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("delete: expected %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestCreateExpenseRejectsInvalidInput(t *testing.T) {
+	router := newExpenseTestRouter()
+
+	bodies := []gin.H{
+		{"amount": "-10", "date": "2024-03-15", "main_category": "Self", "sub_category": "Food"},
+		{"amount": "10", "date": "2024-03-15", "main_category": "Pune House", "sub_category": "Food"},
+		{"amount": "10", "date": "2024-03-15", "main_category": "Self", "sub_category": "Rent"},
+		{"amount": "10", "date": "15/03/2024", "main_category": "Self", "sub_category": "Food"},
+	}
+	for _, body := range bodies {
+		w, _ := performRequest(t, router, "POST", "/api/v1/expenses", "alice", body)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected %d for %v, got %d", http.StatusBadRequest, body, w.Code)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// handleGetFiscalYearSummary returns the financial year starting in "year"
+// (defaults to the current one) as JSON, or as CSV when format=csv
+func (s *Server) handleGetFiscalYearSummary(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	year, err := queryInt(c, "year")
+	if err != nil {
+		respondInvalidParam(c, "year", err)
+		return
+	}
+
+	ctx := context.Background()
+	switch strings.ToLower(c.DefaultQuery("format", "json")) {
+	case "json":
+		summary, err := s.fiscalYearService.GetSummary(ctx, userID, year)
+		if err != nil {
+			respondServiceError(c, "Failed to get financial year summary", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"summary": summary,
+		})
+	case "csv":
+		// Headers only reach the client with the first byte, so they can still be
+		// dropped if the service fails before writing anything
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="financial-year.csv"`)
+		if err := s.fiscalYearService.ExportSummary(ctx, userID, year, c.Writer); err != nil {
+			if c.Writer.Written() {
+				log.Printf("Failed to finish financial year export for user %s: %v", userID, err)
+				return
+			}
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			respondServiceError(c, "Failed to export financial year summary", err)
+		}
+	default:
+		respondInvalidParam(c, "format", fmt.Errorf("unsupported format %q, expected json or csv", c.Query("format")))
+	}
+}
+
+// handleGetTaxSections lists the deduction sections expenses can be tagged with
+func (s *Server) handleGetTaxSections(c *gin.Context) {
+	if _, ok := requireUserID(c); !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sections": entities.DeductionSections,
+		"currency": entities.TaxCurrency,
+	})
+}
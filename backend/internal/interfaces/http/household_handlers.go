@@ -0,0 +1,350 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// householdRequest is the request body for creating or updating a household
+type householdRequest struct {
+	Name     string                   `json:"name" binding:"required"`
+	Currency string                   `json:"currency"` // defaults to the creator's base currency
+	Members  []householdMemberRequest `json:"members"`  // other members, only read on create
+}
+
+// toEntity converts the request into a household created by userID
+func (r *householdRequest) toEntity(userID string) *entities.Household {
+	household := &entities.Household{
+		Name:      r.Name,
+		Currency:  r.Currency,
+		CreatedBy: userID,
+	}
+	for _, member := range r.Members {
+		household.Members = append(household.Members, member.toEntity())
+	}
+	return household
+}
+
+// householdMemberRequest is the request body for adding a household member
+type householdMemberRequest struct {
+	UserID      string `json:"user_id" binding:"required"`
+	DisplayName string `json:"display_name"` // defaults to the user ID
+}
+
+// toEntity converts the request into a household member
+func (r *householdMemberRequest) toEntity() *entities.HouseholdMember {
+	return &entities.HouseholdMember{
+		UserID:      r.UserID,
+		DisplayName: r.DisplayName,
+	}
+}
+
+// expenseSplitRequest is the request body for splitting an expense
+type expenseSplitRequest struct {
+	Method entities.SplitMethod `json:"method" binding:"required"`
+	Shares []struct {
+		UserID string          `json:"user_id" binding:"required"`
+		Value  decimal.Decimal `json:"value"` // percentage or exact amount, omitted for equal splits
+	} `json:"shares" binding:"required"`
+}
+
+// settlementRequest is the request body for recording a settlement
+type settlementRequest struct {
+	FromUserID string          `json:"from_user_id" binding:"required"`
+	ToUserID   string          `json:"to_user_id" binding:"required"`
+	Amount     decimal.Decimal `json:"amount"`
+	Date       string          `json:"date"` // defaults to now
+	Note       string          `json:"note"`
+}
+
+func (s *Server) handleCreateHousehold(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req householdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	household := req.toEntity(userID)
+	ctx := context.Background()
+	if err := s.householdService.CreateHousehold(ctx, household); err != nil {
+		respondServiceError(c, "Failed to create household", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"household": household,
+	})
+}
+
+func (s *Server) handleGetHouseholds(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	households, err := s.householdService.GetHouseholds(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get households", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"households": households,
+	})
+}
+
+func (s *Server) handleGetHousehold(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	household, err := s.householdService.GetHousehold(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get household", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"household": household,
+	})
+}
+
+func (s *Server) handleUpdateHousehold(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req householdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	household := req.toEntity(userID)
+	ctx := context.Background()
+	if err := s.householdService.UpdateHousehold(ctx, userID, c.Param("id"), household); err != nil {
+		respondServiceError(c, "Failed to update household", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"household": household,
+	})
+}
+
+func (s *Server) handleAddHouseholdMember(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req householdMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	member := req.toEntity()
+	ctx := context.Background()
+	if err := s.householdService.AddMember(ctx, userID, c.Param("id"), member); err != nil {
+		respondServiceError(c, "Failed to add household member", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"member": member,
+	})
+}
+
+func (s *Server) handleRemoveHouseholdMember(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.householdService.RemoveMember(ctx, userID, c.Param("id"), c.Param("userId")); err != nil {
+		respondServiceError(c, "Failed to remove household member", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Household member removed",
+	})
+}
+
+func (s *Server) handleSplitExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req expenseSplitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	split := &entities.ExpenseSplit{
+		ExpenseID:   c.Param("expenseId"),
+		HouseholdID: c.Param("id"),
+		Method:      req.Method,
+	}
+	for _, share := range req.Shares {
+		split.Shares = append(split.Shares, &entities.ExpenseShare{UserID: share.UserID, Value: share.Value})
+	}
+
+	ctx := context.Background()
+	if err := s.householdService.SplitExpense(ctx, userID, split); err != nil {
+		respondServiceError(c, "Failed to split expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"split": split,
+	})
+}
+
+func (s *Server) handleRemoveExpenseSplit(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.householdService.RemoveSplit(ctx, userID, c.Param("id"), c.Param("expenseId")); err != nil {
+		respondServiceError(c, "Failed to remove expense split", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Expense split removed",
+	})
+}
+
+func (s *Server) handleGetHouseholdLedger(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	ledger, err := s.householdService.GetLedger(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get household ledger", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ledger": ledger,
+	})
+}
+
+func (s *Server) handleGetHouseholdBalances(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	balances, err := s.householdService.GetBalances(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get household balances", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"balances": balances,
+	})
+}
+
+func (s *Server) handleRecordSettlement(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req settlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	var date time.Time
+	if req.Date != "" {
+		var err error
+		if date, err = parseDate(req.Date); err != nil {
+			respondInvalidParam(c, "date", err)
+			return
+		}
+	}
+
+	settlement := &entities.Settlement{
+		HouseholdID: c.Param("id"),
+		FromUserID:  req.FromUserID,
+		ToUserID:    req.ToUserID,
+		Amount:      req.Amount,
+		Date:        date,
+		Note:        req.Note,
+	}
+	ctx := context.Background()
+	if err := s.householdService.RecordSettlement(ctx, userID, settlement); err != nil {
+		respondServiceError(c, "Failed to record settlement", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"settlement": settlement,
+	})
+}
+
+func (s *Server) handleDeleteSettlement(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.householdService.DeleteSettlement(ctx, userID, c.Param("id"), c.Param("settlementId")); err != nil {
+		respondServiceError(c, "Failed to delete settlement", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Settlement deleted",
+	})
+}
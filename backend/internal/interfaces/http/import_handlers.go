@@ -0,0 +1,267 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"nestmate-backend/internal/application/services"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// importMappingRequest is the request body for creating or updating a CSV column mapping
+type importMappingRequest struct {
+	Name                string              `json:"name" binding:"required"`
+	DateColumn          string              `json:"date_column" binding:"required"`
+	DateFormat          string              `json:"date_format"` // defaults to YYYY-MM-DD
+	AmountColumn        string              `json:"amount_column"`
+	AmountSign          entities.AmountSign `json:"amount_sign"` // defaults to positive
+	DebitColumn         string              `json:"debit_column"`
+	CreditColumn        string              `json:"credit_column"`
+	DescriptionColumn   string              `json:"description_column"`
+	CurrencyColumn      string              `json:"currency_column"`
+	MainCategoryColumn  string              `json:"main_category_column"`
+	SubCategoryColumn   string              `json:"sub_category_column"`
+	DefaultMainCategory string              `json:"default_main_category"`
+	DefaultSubCategory  string              `json:"default_sub_category"`
+}
+
+// toEntity converts the request into a column mapping owned by userID
+func (r *importMappingRequest) toEntity(userID string) *entities.ImportMapping {
+	return &entities.ImportMapping{
+		UserID:              userID,
+		Name:                r.Name,
+		DateColumn:          r.DateColumn,
+		DateFormat:          r.DateFormat,
+		AmountColumn:        r.AmountColumn,
+		AmountSign:          r.AmountSign,
+		DebitColumn:         r.DebitColumn,
+		CreditColumn:        r.CreditColumn,
+		DescriptionColumn:   r.DescriptionColumn,
+		CurrencyColumn:      r.CurrencyColumn,
+		MainCategoryColumn:  r.MainCategoryColumn,
+		SubCategoryColumn:   r.SubCategoryColumn,
+		DefaultMainCategory: r.DefaultMainCategory,
+		DefaultSubCategory:  r.DefaultSubCategory,
+	}
+}
+
+func (s *Server) handleCreateImportMapping(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req importMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	mapping := req.toEntity(userID)
+	ctx := context.Background()
+	if err := s.importService.CreateMapping(ctx, mapping); err != nil {
+		respondServiceError(c, "Failed to create import mapping", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"mapping": mapping,
+	})
+}
+
+func (s *Server) handleGetImportMappings(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	mappings, err := s.importService.GetMappings(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get import mappings", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mappings": mappings,
+	})
+}
+
+func (s *Server) handleGetImportMapping(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	mapping, err := s.importService.GetMapping(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get import mapping", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mapping": mapping,
+	})
+}
+
+func (s *Server) handleUpdateImportMapping(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req importMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	mapping := req.toEntity(userID)
+	ctx := context.Background()
+	if err := s.importService.UpdateMapping(ctx, c.Param("id"), mapping); err != nil {
+		respondServiceError(c, "Failed to update import mapping", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mapping": mapping,
+	})
+}
+
+func (s *Server) handleDeleteImportMapping(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.importService.DeleteMapping(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete import mapping", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Import mapping deleted",
+	})
+}
+
+// handlePreviewImport parses an uploaded CSV ("file") with a saved mapping
+// ("mapping_id") and reports what would be imported without saving anything
+func (s *Server) handlePreviewImport(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		respondInvalidParam(c, "file", err)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		respondInvalidParam(c, "file", err)
+		return
+	}
+	defer file.Close()
+
+	ctx := context.Background()
+	preview, err := s.importService.PreviewImport(ctx, userID, c.PostForm("mapping_id"), file)
+	if err != nil {
+		respondServiceError(c, "Failed to preview import", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preview": preview,
+	})
+}
+
+// handleCommitImport saves an uploaded CSV as one batch. A file with bad rows
+// is rejected along with its preview so the rows can be fixed.
+func (s *Server) handleCommitImport(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		respondInvalidParam(c, "file", err)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		respondInvalidParam(c, "file", err)
+		return
+	}
+	defer file.Close()
+
+	ctx := context.Background()
+	batch, preview, err := s.importService.CommitImport(ctx, userID, c.PostForm("mapping_id"), header.Filename, file)
+	if err != nil {
+		if preview != nil && errors.Is(err, services.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Failed to import expenses",
+				"code":    "VALIDATION_FAILED",
+				"details": err.Error(),
+				"preview": preview,
+			})
+			return
+		}
+		respondServiceError(c, "Failed to import expenses", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"batch":   batch,
+		"preview": preview,
+	})
+}
+
+func (s *Server) handleGetImportBatches(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	batches, err := s.importService.GetBatches(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get imports", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"batches": batches,
+	})
+}
+
+func (s *Server) handleUndoImportBatch(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	batch, err := s.importService.UndoBatch(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to undo import", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"batch": batch,
+	})
+}
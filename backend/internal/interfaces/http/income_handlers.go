@@ -0,0 +1,193 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// incomeRequest is the request body for creating or updating an income
+type incomeRequest struct {
+	Amount      decimal.Decimal `json:"amount"`
+	Currency    string          `json:"currency"` // defaults to the user's base currency
+	Month       string          `json:"month" binding:"required"`
+	Source      string          `json:"source" binding:"required"`
+	IsRecurring bool            `json:"is_recurring"`
+	AccountID   string          `json:"account_id"`
+}
+
+// toEntity converts the request into an income owned by userID
+func (r *incomeRequest) toEntity(userID string) (*entities.Income, error) {
+	month, err := parseMonth(r.Month)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.Income{
+		UserID:      userID,
+		Amount:      r.Amount,
+		Currency:    r.Currency,
+		Month:       month,
+		Source:      r.Source,
+		IsRecurring: r.IsRecurring,
+		AccountID:   r.AccountID,
+	}, nil
+}
+
+func (s *Server) handleCreateIncome(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req incomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	income, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, "month", err)
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.incomeService.AddIncome(ctx, income); err != nil {
+		respondServiceError(c, "Failed to create income", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"income": income,
+	})
+}
+
+func (s *Server) handleGetIncomes(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	var (
+		incomes []*entities.Income
+		err     error
+	)
+	if v := c.Query("month"); v != "" {
+		month, parseErr := parseMonth(v)
+		if parseErr != nil {
+			respondInvalidParam(c, "month", parseErr)
+			return
+		}
+		incomes, err = s.incomeService.GetIncomesByMonth(ctx, userID, month)
+	} else {
+		incomes, err = s.incomeService.GetIncomes(ctx, userID)
+	}
+	if err != nil {
+		respondServiceError(c, "Failed to get incomes", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"incomes": incomes,
+	})
+}
+
+func (s *Server) handleGetIncomeSummary(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	month, err := parseMonth(c.Query("month"))
+	if err != nil {
+		respondInvalidParam(c, "month", err)
+		return
+	}
+
+	ctx := context.Background()
+	summary, err := s.incomeService.GetMonthlySummary(ctx, userID, month)
+	if err != nil {
+		respondServiceError(c, "Failed to get income summary", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"summary": summary,
+	})
+}
+
+func (s *Server) handleGetIncome(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	income, err := s.incomeService.GetIncome(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get income", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"income": income,
+	})
+}
+
+func (s *Server) handleUpdateIncome(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req incomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	income, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, "month", err)
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.incomeService.UpdateIncome(ctx, c.Param("id"), income); err != nil {
+		respondServiceError(c, "Failed to update income", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"income": income,
+	})
+}
+
+func (s *Server) handleDeleteIncome(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.incomeService.DeleteIncome(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete income", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Income deleted",
+	})
+}
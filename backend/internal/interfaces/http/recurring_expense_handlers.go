@@ -0,0 +1,304 @@
+package http
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// recurringExpenseInterval is how often due recurring expenses are generated
+const recurringExpenseInterval = time.Hour
+
+// recurringExpenseRequest is the request body for creating or updating a recurring expense
+type recurringExpenseRequest struct {
+	Amount       decimal.Decimal       `json:"amount"`
+	Currency     string                `json:"currency"` // defaults to the user's base currency
+	Description  string                `json:"description"`
+	MainCategory string                `json:"main_category" binding:"required"`
+	SubCategory  string                `json:"sub_category" binding:"required"`
+	StartDate    string                `json:"start_date" binding:"required"`
+	Rule         recurrenceRuleRequest `json:"rule" binding:"required"`
+	Paused       bool                  `json:"paused"`
+}
+
+// recurrenceRuleRequest is the recurrence rule part of a recurring expense request
+type recurrenceRuleRequest struct {
+	Frequency  entities.RecurrenceFrequency `json:"frequency" binding:"required"`
+	Interval   int                          `json:"interval"`     // defaults to 1
+	DaysOfWeek []time.Weekday               `json:"days_of_week"` // 0 (Sunday) to 6 (Saturday)
+	MonthDay   int                          `json:"month_day"`
+	EndDate    string                       `json:"end_date"`
+	Count      int                          `json:"count"`
+}
+
+// toEntity converts the request into a recurring expense owned by userID,
+// returning the name of the field that failed to parse along with the error
+func (r *recurringExpenseRequest) toEntity(userID string) (*entities.RecurringExpense, string, error) {
+	start, err := parseDate(r.StartDate)
+	if err != nil {
+		return nil, "start_date", err
+	}
+	rule := &entities.RecurrenceRule{
+		Frequency:  r.Rule.Frequency,
+		Interval:   r.Rule.Interval,
+		DaysOfWeek: r.Rule.DaysOfWeek,
+		MonthDay:   r.Rule.MonthDay,
+		Count:      r.Rule.Count,
+	}
+	if r.Rule.EndDate != "" {
+		end, err := parseDate(r.Rule.EndDate)
+		if err != nil {
+			return nil, "rule.end_date", err
+		}
+		rule.EndDate = &end
+	}
+	return &entities.RecurringExpense{
+		UserID:       userID,
+		Amount:       r.Amount,
+		Currency:     r.Currency,
+		Description:  r.Description,
+		MainCategory: entities.MainCategory(r.MainCategory),
+		SubCategory:  entities.SubCategory(r.SubCategory),
+		Rule:         rule,
+		StartDate:    start,
+		Paused:       r.Paused,
+	}, "", nil
+}
+
+func (s *Server) handleCreateRecurringExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req recurringExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	recurring, field, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, field, err)
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.recurringService.CreateRecurringExpense(ctx, recurring); err != nil {
+		respondServiceError(c, "Failed to create recurring expense", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"recurring_expense": recurring,
+	})
+}
+
+func (s *Server) handleGetRecurringExpenses(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	list, err := s.recurringService.GetRecurringExpenses(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get recurring expenses", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recurring_expenses": list,
+	})
+}
+
+func (s *Server) handleGetRecurringExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	recurring, err := s.recurringService.GetRecurringExpense(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get recurring expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recurring_expense": recurring,
+	})
+}
+
+func (s *Server) handleUpdateRecurringExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req recurringExpenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	recurring, field, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, field, err)
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.recurringService.UpdateRecurringExpense(ctx, c.Param("id"), recurring); err != nil {
+		respondServiceError(c, "Failed to update recurring expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recurring_expense": recurring,
+	})
+}
+
+func (s *Server) handleDeleteRecurringExpense(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.recurringService.DeleteRecurringExpense(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete recurring expense", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Recurring expense deleted",
+	})
+}
+
+// handleGetRecurringOccurrences lists occurrences between "from" and "to"
+// (exclusive), defaulting to the current month and the two after it
+func (s *Server) handleGetRecurringOccurrences(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	from, err := parseMonth("")
+	if err != nil {
+		respondInvalidParam(c, "from", err)
+		return
+	}
+	if value := c.Query("from"); value != "" {
+		if from, err = parseDate(value); err != nil {
+			respondInvalidParam(c, "from", err)
+			return
+		}
+	}
+	to := from.AddDate(0, 3, 0)
+	if value := c.Query("to"); value != "" {
+		if to, err = parseDate(value); err != nil {
+			respondInvalidParam(c, "to", err)
+			return
+		}
+	}
+
+	ctx := context.Background()
+	occurrences, err := s.recurringService.GetOccurrences(ctx, userID, c.Param("id"), from, to)
+	if err != nil {
+		respondServiceError(c, "Failed to get occurrences", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"occurrences": occurrences,
+	})
+}
+
+// handleUpdateRecurringOccurrence skips or edits the occurrence on :date (YYYY-MM-DD)
+func (s *Server) handleUpdateRecurringOccurrence(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	date, err := parseDate(c.Param("date"))
+	if err != nil {
+		respondInvalidParam(c, "date", err)
+		return
+	}
+
+	var change entities.OccurrenceChange
+	if err := c.ShouldBindJSON(&change); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx := context.Background()
+	occurrence, err := s.recurringService.UpdateOccurrence(ctx, userID, c.Param("id"), date, &change)
+	if err != nil {
+		respondServiceError(c, "Failed to update occurrence", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"occurrence": occurrence,
+	})
+}
+
+func (s *Server) handleResetRecurringOccurrence(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	date, err := parseDate(c.Param("date"))
+	if err != nil {
+		respondInvalidParam(c, "date", err)
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.recurringService.ResetOccurrence(ctx, userID, c.Param("id"), date); err != nil {
+		respondServiceError(c, "Failed to reset occurrence", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Occurrence reset",
+	})
+}
+
+// generateRecurringExpenses generates every due recurring expense straight
+// away and then once per interval, for as long as the server runs
+func (s *Server) generateRecurringExpenses(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		generated, err := s.recurringService.GenerateDue(context.Background(), time.Now())
+		if err != nil {
+			log.Printf("Warning: failed to generate recurring expenses: %v", err)
+		} else if generated > 0 {
+			log.Printf("Generated %d recurring expenses", generated)
+		}
+		<-ticker.C
+	}
+}
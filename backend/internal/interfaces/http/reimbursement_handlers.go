@@ -0,0 +1,120 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// reimbursementRequest is the request body for recording money received back against an expense
+type reimbursementRequest struct {
+	Kind     string          `json:"kind"` // reimbursement or refund, defaults by whether the expense is reimbursable
+	Amount   decimal.Decimal `json:"amount"`
+	Currency string          `json:"currency"` // defaults to the expense's currency
+	Date     string          `json:"date"`     // defaults to now
+	Payer    string          `json:"payer"`    // defaults to the expense's expected payer
+	Note     string          `json:"note"`
+}
+
+func (s *Server) handleAddReimbursement(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req reimbursementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	reimbursement := &entities.Reimbursement{
+		UserID:    userID,
+		ExpenseID: c.Param("id"),
+		Kind:      entities.ReimbursementKind(req.Kind),
+		Amount:    req.Amount,
+		Currency:  req.Currency,
+		Payer:     req.Payer,
+		Note:      req.Note,
+	}
+	if req.Date != "" {
+		date, err := parseDate(req.Date)
+		if err != nil {
+			respondInvalidParam(c, "date", err)
+			return
+		}
+		reimbursement.Date = date
+	}
+
+	ctx := context.Background()
+	if err := s.reimbursementService.AddReimbursement(ctx, reimbursement); err != nil {
+		respondServiceError(c, "Failed to add reimbursement", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"reimbursement": reimbursement,
+	})
+}
+
+func (s *Server) handleGetReimbursements(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	reimbursements, err := s.reimbursementService.GetReimbursements(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get reimbursements", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reimbursements": reimbursements,
+	})
+}
+
+func (s *Server) handleDeleteReimbursement(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.reimbursementService.DeleteReimbursement(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete reimbursement", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reimbursement deleted",
+	})
+}
+
+// handleGetPendingReimbursements lists reimbursable expenses not yet fully paid
+// back, optionally only those expected from "payer"
+func (s *Server) handleGetPendingReimbursements(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	pending, err := s.reimbursementService.GetPending(ctx, userID, c.Query("payer"))
+	if err != nil {
+		respondServiceError(c, "Failed to get pending reimbursements", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pending": pending,
+	})
+}
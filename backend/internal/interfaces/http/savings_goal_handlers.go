@@ -0,0 +1,222 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// savingsGoalRequest is the request body for creating or updating a savings goal
+type savingsGoalRequest struct {
+	Name         string          `json:"name" binding:"required"`
+	TargetAmount decimal.Decimal `json:"target_amount"`
+	Currency     string          `json:"currency"`    // defaults to the user's base currency
+	TargetDate   string          `json:"target_date"` // optional
+}
+
+// toEntity converts the request into a savings goal owned by userID
+func (r *savingsGoalRequest) toEntity(userID string) (*entities.SavingsGoal, error) {
+	goal := &entities.SavingsGoal{
+		UserID:       userID,
+		Name:         r.Name,
+		TargetAmount: r.TargetAmount,
+		Currency:     r.Currency,
+	}
+	if r.TargetDate != "" {
+		date, err := parseDate(r.TargetDate)
+		if err != nil {
+			return nil, err
+		}
+		goal.TargetDate = &date
+	}
+	return goal, nil
+}
+
+// goalContributionRequest is the request body for contributing to a savings goal
+type goalContributionRequest struct {
+	ExpenseID string          `json:"expense_id"` // a Savings expense to take the money from
+	Amount    decimal.Decimal `json:"amount"`     // defaults to the rest of the expense when linked
+	Currency  string          `json:"currency"`   // defaults to the goal's currency
+	Date      string          `json:"date"`       // defaults to now
+	Note      string          `json:"note"`
+}
+
+func (s *Server) handleCreateSavingsGoal(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req savingsGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	goal, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, "target_date", err)
+		return
+	}
+	ctx := context.Background()
+	if err := s.savingsGoalService.CreateGoal(ctx, goal); err != nil {
+		respondServiceError(c, "Failed to create savings goal", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"goal": goal,
+	})
+}
+
+func (s *Server) handleGetSavingsGoals(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	goals, err := s.savingsGoalService.GetGoals(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get savings goals", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"goals": goals,
+	})
+}
+
+func (s *Server) handleGetSavingsGoal(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	progress, err := s.savingsGoalService.GetGoal(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get savings goal", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"goal": progress,
+	})
+}
+
+func (s *Server) handleUpdateSavingsGoal(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req savingsGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	goal, err := req.toEntity(userID)
+	if err != nil {
+		respondInvalidParam(c, "target_date", err)
+		return
+	}
+	ctx := context.Background()
+	if err := s.savingsGoalService.UpdateGoal(ctx, c.Param("id"), goal); err != nil {
+		respondServiceError(c, "Failed to update savings goal", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"goal": goal,
+	})
+}
+
+func (s *Server) handleDeleteSavingsGoal(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.savingsGoalService.DeleteGoal(ctx, userID, c.Param("id")); err != nil {
+		respondServiceError(c, "Failed to delete savings goal", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Savings goal deleted",
+	})
+}
+
+func (s *Server) handleAddGoalContribution(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req goalContributionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	contribution := &entities.GoalContribution{
+		GoalID:    c.Param("id"),
+		ExpenseID: req.ExpenseID,
+		Amount:    req.Amount,
+		Currency:  req.Currency,
+		Note:      req.Note,
+	}
+	if req.Date != "" {
+		date, err := parseDate(req.Date)
+		if err != nil {
+			respondInvalidParam(c, "date", err)
+			return
+		}
+		contribution.Date = date
+	}
+
+	ctx := context.Background()
+	if err := s.savingsGoalService.AddContribution(ctx, userID, contribution); err != nil {
+		respondServiceError(c, "Failed to add goal contribution", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"contribution": contribution,
+	})
+}
+
+func (s *Server) handleDeleteGoalContribution(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.savingsGoalService.DeleteContribution(ctx, userID, c.Param("id"), c.Param("contributionId")); err != nil {
+		respondServiceError(c, "Failed to delete goal contribution", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Goal contribution deleted",
+	})
+}
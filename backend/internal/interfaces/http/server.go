@@ -11,6 +11,8 @@ import (
 	"nestmate-backend/internal/infrastructure/auth"
 	"nestmate-backend/internal/infrastructure/config"
 	"nestmate-backend/internal/infrastructure/repositories/memory"
+	"nestmate-backend/internal/infrastructure/repositories/sqlite"
+	"nestmate-backend/internal/infrastructure/secrets"
 	"nestmate-backend/internal/interfaces/http/middleware"
 )
 
@@ -18,6 +20,25 @@ type Server struct {
 	router      *gin.Engine
 	config      *config.Config
 	authService *services.AuthService
+	expenseService services.ExpenseService
+	incomeService  services.IncomeService
+	categoryService services.CategoryService
+	settingsService services.SettingsService
+	currencyService services.CurrencyService
+	budgetService   services.BudgetService
+	importService   services.ImportService
+	householdService services.HouseholdService
+	recurringService services.RecurringExpenseService
+	savingsGoalService services.SavingsGoalService
+	accountService   services.AccountService
+	fiscalYearService services.FiscalYearService
+	reimbursementService services.ReimbursementService
+	eventService     services.EventService
+	subscriptionService services.SubscriptionService
+	pdfParserService services.PDFParserService
+	ruleService      services.CategorizationRuleService
+	statementImportService services.StatementImportService
+	passwordHintService services.PasswordHintService
 	authMiddleware *middleware.AuthMiddleware
 }
 
@@ -33,11 +54,66 @@ func NewServer() *Server {
 		firebaseAuth = nil // Explicitly set to nil for safety
 	}
 	
+	// Open the database and apply pending migrations
+	if cfg.Database.Driver != "sqlite" {
+		log.Printf("Warning: database driver %q is not supported yet, using sqlite", cfg.Database.Driver)
+	}
+	db, err := sqlite.Open(context.Background(), cfg.Database.Name)
+	if err != nil {
+		log.Fatalf("Failed to open database %s: %v", cfg.Database.Name, err)
+	}
+	
 	// Initialize repositories
 	userRepo := memory.NewInMemoryUserRepository()
+	expenseRepo := sqlite.NewExpenseRepository(db)
+	incomeRepo := sqlite.NewIncomeRepository(db)
+	categoryRepo := sqlite.NewCategoryRepository(db)
+	settingsRepo := sqlite.NewUserSettingsRepository(db)
+	exchangeRateRepo := sqlite.NewExchangeRateRepository(db)
+	budgetRepo := sqlite.NewBudgetRepository(db)
+	importRepo := sqlite.NewImportRepository(db)
+	householdRepo := sqlite.NewHouseholdRepository(db)
+	recurringRepo := sqlite.NewRecurringExpenseRepository(db)
+	savingsGoalRepo := sqlite.NewSavingsGoalRepository(db)
+	accountRepo := sqlite.NewAccountRepository(db)
+	reimbursementRepo := sqlite.NewReimbursementRepository(db)
+	eventRepo := sqlite.NewEventRepository(db)
+	ruleRepo := sqlite.NewCategorizationRuleRepository(db)
+	correctionRepo := sqlite.NewCategoryCorrectionRepository(db)
+	passwordHintRepo := sqlite.NewPasswordHintRepository(db)
+	
+	// Initialize the box that encrypts secrets stored at rest. There's no
+	// default key, since secrets sealed with a well-known one aren't secret.
+	if cfg.Encryption.Key == "" {
+		log.Fatal("ENCRYPTION_KEY is not set. Set it to a long random passphrase; it encrypts secrets stored at rest, such as statement password hints.")
+	}
+	secretBox, err := secrets.NewBox(cfg.Encryption.Key)
+	if err != nil {
+		log.Fatalf("Failed to initialize encryption: %v", err)
+	}
 	
 	// Initialize services
 	authService := services.NewAuthService(firebaseAuth, userRepo)
+	settingsService := services.NewSettingsService(settingsRepo)
+	currencyService := services.NewCurrencyService(exchangeRateRepo, settingsService)
+	incomeService := services.NewIncomeService(incomeRepo, currencyService, accountRepo)
+	categoryService := services.NewCategoryService(categoryRepo)
+	budgetService := services.NewBudgetService(budgetRepo, expenseRepo, categoryService, currencyService)
+	expenseService := services.NewExpenseService(expenseRepo, incomeService, categoryService, currencyService, budgetService, recurringRepo, accountRepo, reimbursementRepo, eventRepo, savingsGoalRepo)
+	importService := services.NewImportService(importRepo, eventRepo, categoryService, currencyService)
+	householdService := services.NewHouseholdService(householdRepo, expenseRepo, currencyService)
+	recurringService := services.NewRecurringExpenseService(recurringRepo, eventRepo, categoryService, currencyService)
+	savingsGoalService := services.NewSavingsGoalService(savingsGoalRepo, expenseService, categoryService, currencyService)
+	accountService := services.NewAccountService(accountRepo, expenseRepo, incomeService, currencyService)
+	fiscalYearService := services.NewFiscalYearService(expenseService, incomeService, settingsService, currencyService)
+	reimbursementService := services.NewReimbursementService(reimbursementRepo, expenseRepo, currencyService)
+	eventService := services.NewEventService(eventRepo, expenseRepo, currencyService)
+	subscriptionService := services.NewSubscriptionService(expenseService, recurringRepo, recurringService, currencyService)
+	bankParsers := services.DefaultBankParsers()
+	pdfParserService := services.NewPDFParserService(bankParsers, ruleRepo, expenseRepo, correctionRepo, categoryService)
+	ruleService := services.NewCategorizationRuleService(ruleRepo, expenseRepo, accountRepo, categoryService)
+	statementImportService := services.NewStatementImportService(importRepo, accountRepo, eventRepo, pdfParserService, categoryService, currencyService)
+	passwordHintService := services.NewPasswordHintService(passwordHintRepo, bankParsers, secretBox)
 	
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(authService)
@@ -46,6 +122,25 @@ func NewServer() *Server {
 		router:         router,
 		config:         cfg,
 		authService:    authService,
+		expenseService: expenseService,
+		incomeService:  incomeService,
+		categoryService: categoryService,
+		settingsService: settingsService,
+		currencyService: currencyService,
+		budgetService:   budgetService,
+		importService:   importService,
+		householdService: householdService,
+		recurringService: recurringService,
+		savingsGoalService: savingsGoalService,
+		accountService:   accountService,
+		fiscalYearService: fiscalYearService,
+		reimbursementService: reimbursementService,
+		eventService:     eventService,
+		subscriptionService: subscriptionService,
+		pdfParserService: pdfParserService,
+		ruleService:      ruleService,
+		statementImportService: statementImportService,
+		passwordHintService: passwordHintService,
 		authMiddleware: authMiddleware,
 	}
 	
@@ -82,10 +177,194 @@ func (s *Server) setupRoutes() {
 			{
 				expenses.POST("", s.handleCreateExpense)
 				expenses.GET("", s.handleGetExpenses)
+				expenses.GET("/export", s.handleExportExpenses)
 				expenses.GET("/:id", s.handleGetExpense)
 				expenses.PUT("/:id", s.handleUpdateExpense)
 				expenses.DELETE("/:id", s.handleDeleteExpense)
 				expenses.GET("/breakdown", s.handleGetMonthlyBreakdown)
+				expenses.GET("/trends", s.handleGetTrends)
+				expenses.POST("/:id/reimbursements", s.handleAddReimbursement)
+				expenses.GET("/:id/reimbursements", s.handleGetReimbursements)
+			}
+			
+			// Income routes
+			incomes := protected.Group("/incomes")
+			{
+				incomes.POST("", s.handleCreateIncome)
+				incomes.GET("", s.handleGetIncomes)
+				incomes.GET("/summary", s.handleGetIncomeSummary)
+				incomes.GET("/:id", s.handleGetIncome)
+				incomes.PUT("/:id", s.handleUpdateIncome)
+				incomes.DELETE("/:id", s.handleDeleteIncome)
+			}
+			
+			// Category routes
+			categories := protected.Group("/categories")
+			{
+				categories.POST("", s.handleCreateCategory)
+				categories.GET("", s.handleGetCategories)
+				categories.GET("/:id", s.handleGetCategory)
+				categories.PUT("/:id", s.handleUpdateCategory)
+				categories.POST("/:id/archive", s.handleArchiveCategory)
+				categories.POST("/:id/unarchive", s.handleUnarchiveCategory)
+				categories.POST("/:id/merge", s.handleMergeCategory)
+			}
+			
+			// Budget routes
+			budgets := protected.Group("/budgets")
+			{
+				budgets.POST("", s.handleCreateBudget)
+				budgets.GET("", s.handleGetBudgets)
+				budgets.GET("/report", s.handleGetBudgetReport)
+				budgets.GET("/:id", s.handleGetBudget)
+				budgets.PUT("/:id", s.handleUpdateBudget)
+				budgets.DELETE("/:id", s.handleDeleteBudget)
+			}
+			
+			// CSV and bank statement import routes
+			imports := protected.Group("/imports")
+			{
+				imports.POST("/mappings", s.handleCreateImportMapping)
+				imports.GET("/mappings", s.handleGetImportMappings)
+				imports.GET("/mappings/:id", s.handleGetImportMapping)
+				imports.PUT("/mappings/:id", s.handleUpdateImportMapping)
+				imports.DELETE("/mappings/:id", s.handleDeleteImportMapping)
+				imports.POST("/preview", s.handlePreviewImport)
+				imports.POST("/statements/preview", s.handlePreviewStatement)
+				imports.POST("/statements/categorize", s.handleCategorizeStatement)
+				imports.POST("/statements/corrections", s.handleRecordCategoryCorrection)
+				imports.GET("/statements/hints", s.handleGetPasswordHints)
+				imports.GET("/statements/hints/:bank", s.handleGetPasswordHint)
+				imports.PUT("/statements/hints/:bank", s.handleSetPasswordHint)
+				imports.DELETE("/statements/hints/:bank", s.handleDeletePasswordHint)
+				imports.POST("/statements", s.handleStageStatement)
+				imports.POST("", s.handleCommitImport)
+				imports.GET("", s.handleGetImportBatches)
+				imports.GET("/:id/transactions", s.handleGetStagedImport)
+				imports.PATCH("/:id/transactions/:transactionId", s.handleUpdateStagedTransaction)
+				imports.POST("/:id/confirm", s.handleConfirmImport)
+				imports.POST("/:id/undo", s.handleUndoImportBatch)
+			}
+
+			// Shared household routes
+			households := protected.Group("/households")
+			{
+				households.POST("", s.handleCreateHousehold)
+				households.GET("", s.handleGetHouseholds)
+				households.GET("/:id", s.handleGetHousehold)
+				households.PUT("/:id", s.handleUpdateHousehold)
+				households.POST("/:id/members", s.handleAddHouseholdMember)
+				households.DELETE("/:id/members/:userId", s.handleRemoveHouseholdMember)
+				households.PUT("/:id/splits/:expenseId", s.handleSplitExpense)
+				households.DELETE("/:id/splits/:expenseId", s.handleRemoveExpenseSplit)
+				households.GET("/:id/ledger", s.handleGetHouseholdLedger)
+				households.GET("/:id/balances", s.handleGetHouseholdBalances)
+				households.POST("/:id/settlements", s.handleRecordSettlement)
+				households.DELETE("/:id/settlements/:settlementId", s.handleDeleteSettlement)
+			}
+
+			// Recurring expense routes
+			recurring := protected.Group("/recurring-expenses")
+			{
+				recurring.POST("", s.handleCreateRecurringExpense)
+				recurring.GET("", s.handleGetRecurringExpenses)
+				recurring.GET("/:id", s.handleGetRecurringExpense)
+				recurring.PUT("/:id", s.handleUpdateRecurringExpense)
+				recurring.DELETE("/:id", s.handleDeleteRecurringExpense)
+				recurring.GET("/:id/occurrences", s.handleGetRecurringOccurrences)
+				recurring.PUT("/:id/occurrences/:date", s.handleUpdateRecurringOccurrence)
+				recurring.DELETE("/:id/occurrences/:date", s.handleResetRecurringOccurrence)
+			}
+
+			// Savings goal routes
+			goals := protected.Group("/savings-goals")
+			{
+				goals.POST("", s.handleCreateSavingsGoal)
+				goals.GET("", s.handleGetSavingsGoals)
+				goals.GET("/:id", s.handleGetSavingsGoal)
+				goals.PUT("/:id", s.handleUpdateSavingsGoal)
+				goals.DELETE("/:id", s.handleDeleteSavingsGoal)
+				goals.POST("/:id/contributions", s.handleAddGoalContribution)
+				goals.DELETE("/:id/contributions/:contributionId", s.handleDeleteGoalContribution)
+			}
+
+			// Account routes
+			accounts := protected.Group("/accounts")
+			{
+				accounts.POST("", s.handleCreateAccount)
+				accounts.GET("", s.handleGetAccounts)
+				accounts.GET("/:id", s.handleGetAccount)
+				accounts.PUT("/:id", s.handleUpdateAccount)
+				accounts.DELETE("/:id", s.handleDeleteAccount)
+				accounts.GET("/:id/ledger", s.handleGetAccountLedger)
+				accounts.POST("/:id/reconcile", s.handleReconcileAccount)
+			}
+
+			// Transfer routes
+			transfers := protected.Group("/transfers")
+			{
+				transfers.POST("", s.handleCreateTransfer)
+				transfers.DELETE("/:id", s.handleDeleteTransfer)
+			}
+
+			// Reimbursement routes
+			reimbursements := protected.Group("/reimbursements")
+			{
+				reimbursements.GET("/pending", s.handleGetPendingReimbursements)
+				reimbursements.DELETE("/:id", s.handleDeleteReimbursement)
+			}
+
+			// Event routes
+			events := protected.Group("/events")
+			{
+				events.POST("", s.handleCreateEvent)
+				events.GET("", s.handleGetEvents)
+				events.GET("/:id", s.handleGetEvent)
+				events.PUT("/:id", s.handleUpdateEvent)
+				events.DELETE("/:id", s.handleDeleteEvent)
+				events.PUT("/:id/expenses/:expenseId", s.handleAttachEventExpense)
+				events.DELETE("/:id/expenses/:expenseId", s.handleDetachEventExpense)
+			}
+
+			// Categorization rule routes
+			rules := protected.Group("/categorization-rules")
+			{
+				rules.POST("", s.handleCreateCategorizationRule)
+				rules.GET("", s.handleGetCategorizationRules)
+				rules.POST("/preview", s.handlePreviewCategorizationRule)
+				rules.GET("/:id", s.handleGetCategorizationRule)
+				rules.PUT("/:id", s.handleUpdateCategorizationRule)
+				rules.DELETE("/:id", s.handleDeleteCategorizationRule)
+			}
+
+			// Subscription routes
+			subscriptions := protected.Group("/subscriptions")
+			{
+				subscriptions.GET("", s.handleGetSubscriptions)
+				subscriptions.POST("/:key/recurring", s.handleConvertSubscription)
+			}
+
+			// Report routes
+			reports := protected.Group("/reports")
+			{
+				reports.GET("/fiscal-year", s.handleGetFiscalYearSummary)
+				reports.GET("/tax-sections", s.handleGetTaxSections)
+			}
+			
+			// Settings routes
+			settings := protected.Group("/settings")
+			{
+				settings.GET("", s.handleGetSettings)
+				settings.PUT("", s.handleUpdateSettings)
+			}
+			
+			// Exchange rate routes
+			rates := protected.Group("/exchange-rates")
+			{
+				rates.POST("", s.handleSetExchangeRate)
+				rates.GET("", s.handleGetExchangeRates)
+				rates.POST("/import", s.handleImportExchangeRates)
+				rates.DELETE("/:id", s.handleDeleteExchangeRate)
 			}
 			
 			// Task routes
@@ -114,6 +393,7 @@ func (s *Server) setupRoutes() {
 }
 
 func (s *Server) Start(addr string) error {
+	go s.generateRecurringExpenses(recurringExpenseInterval)
 	return s.router.Run(addr)
 }
 
@@ -286,12 +566,6 @@ func (s *Server) handleUpdateProfile(c *gin.Context) {
 }
 
 // Placeholder handlers for other modules - will be implemented in later tasks
-func (s *Server) handleCreateExpense(c *gin.Context)    { c.JSON(501, gin.H{"error": "not implemented"}) }
-func (s *Server) handleGetExpenses(c *gin.Context)      { c.JSON(501, gin.H{"error": "not implemented"}) }
-func (s *Server) handleGetExpense(c *gin.Context)       { c.JSON(501, gin.H{"error": "not implemented"}) }
-func (s *Server) handleUpdateExpense(c *gin.Context)    { c.JSON(501, gin.H{"error": "not implemented"}) }
-func (s *Server) handleDeleteExpense(c *gin.Context)    { c.JSON(501, gin.H{"error": "not implemented"}) }
-func (s *Server) handleGetMonthlyBreakdown(c *gin.Context) { c.JSON(501, gin.H{"error": "not implemented"}) }
 func (s *Server) handleCreateTask(c *gin.Context)       { c.JSON(501, gin.H{"error": "not implemented"}) }
 func (s *Server) handleGetTasks(c *gin.Context)         { c.JSON(501, gin.H{"error": "not implemented"}) }
 func (s *Server) handleGetTask(c *gin.Context)          { c.JSON(501, gin.H{"error": "not implemented"}) }
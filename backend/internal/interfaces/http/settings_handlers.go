@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// settingsRequest is the request body for updating a user's settings.
+// Fields left out keep their current values.
+type settingsRequest struct {
+	BaseCurrency    string `json:"base_currency"`
+	FiscalYearStart int    `json:"fiscal_year_start"` // 1 (January) to 12 (December)
+}
+
+func (s *Server) handleGetSettings(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	settings, err := s.settingsService.GetSettings(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get settings", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"settings": settings,
+	})
+}
+
+func (s *Server) handleUpdateSettings(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req settingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx := context.Background()
+	settings, err := s.settingsService.GetSettings(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to update settings", err)
+		return
+	}
+	if req.BaseCurrency != "" {
+		settings.BaseCurrency = req.BaseCurrency
+	}
+	if req.FiscalYearStart != 0 {
+		settings.FiscalYearStart = time.Month(req.FiscalYearStart)
+	}
+	if err := s.settingsService.UpdateSettings(ctx, settings); err != nil {
+		respondServiceError(c, "Failed to update settings", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"settings": settings,
+	})
+}
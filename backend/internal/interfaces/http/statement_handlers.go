@@ -0,0 +1,381 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"nestmate-backend/internal/application/services"
+	"nestmate-backend/internal/domain/entities"
+)
+
+// maxStatementSize limits the size of an uploaded statement request
+const maxStatementSize = 20 << 20
+
+// readStatementFile reads the uploaded statement ("file"), responding with an
+// error and returning false when it's missing or larger than maxStatementSize
+func readStatementFile(c *gin.Context) (*multipart.FileHeader, []byte, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxStatementSize)
+	header, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error":   "Statement is too large",
+			"code":    "FILE_TOO_LARGE",
+			"details": err.Error(),
+		})
+		return nil, nil, false
+	}
+	if err != nil {
+		respondInvalidParam(c, "file", err)
+		return nil, nil, false
+	}
+	file, err := header.Open()
+	if err != nil {
+		respondInvalidParam(c, "file", err)
+		return nil, nil, false
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		respondInvalidParam(c, "file", err)
+		return nil, nil, false
+	}
+	return header, data, true
+}
+
+// handlePreviewStatement parses an uploaded PDF bank statement ("file") and
+// returns its transactions without saving anything. The bank is taken from
+// "bank_type", or detected from the statement when it's left out. Password
+// protected statements are opened with "password".
+func (s *Server) handlePreviewStatement(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	_, data, ok := readStatementFile(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	statement, err := s.pdfParserService.ParseBankStatement(ctx, data, c.PostForm("bank_type"), c.PostForm("password"))
+	if err != nil {
+		s.respondStatementError(ctx, c, userID, c.PostForm("bank_type"), "Failed to parse bank statement", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statement": statement,
+	})
+}
+
+// categorizeStatementRequest is the request body for categorizing statement transactions
+type categorizeStatementRequest struct {
+	Transactions []*services.Transaction `json:"transactions" binding:"required"`
+}
+
+// handleCategorizeStatement suggests categories for parsed statement
+// transactions, flagging the ones the user should review
+func (s *Server) handleCategorizeStatement(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req categorizeStatementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx := context.Background()
+	transactions, err := s.pdfParserService.CategorizeTransactions(ctx, userID, req.Transactions)
+	if err != nil {
+		respondServiceError(c, "Failed to categorize transactions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transactions": transactions,
+	})
+}
+
+// categoryCorrectionRequest is the request body for recording the categories
+// chosen for a transaction during review
+type categoryCorrectionRequest struct {
+	Description     string          `json:"description" binding:"required"`
+	Amount          decimal.Decimal `json:"amount"`
+	TransactionType string          `json:"transaction_type"` // debit (default) or credit
+	MainCategory    string          `json:"main_category" binding:"required"`
+	SubCategory     string          `json:"sub_category" binding:"required"`
+}
+
+func (s *Server) handleRecordCategoryCorrection(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req categoryCorrectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+	correction := &entities.CategoryCorrection{
+		UserID:          userID,
+		Description:     req.Description,
+		Amount:          req.Amount,
+		TransactionType: entities.TransactionType(req.TransactionType),
+		MainCategory:    entities.MainCategory(req.MainCategory),
+		SubCategory:     entities.SubCategory(req.SubCategory),
+	}
+	ctx := context.Background()
+	if err := s.pdfParserService.RecordCorrection(ctx, correction); err != nil {
+		respondServiceError(c, "Failed to record category correction", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"correction": correction,
+	})
+}
+
+// handleStageStatement parses an uploaded PDF bank statement ("file"),
+// opening it with "password" if it's protected, and stages its categorized
+// transactions for review. Nothing is saved as an expense until the import
+// is confirmed.
+func (s *Server) handleStageStatement(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	header, data, ok := readStatementFile(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	staged, err := s.statementImportService.StageStatement(ctx, userID, header.Filename, data, c.PostForm("bank_type"), c.PostForm("password"), c.PostForm("account_id"))
+	if err != nil {
+		s.respondStatementError(ctx, c, userID, c.PostForm("bank_type"), "Failed to stage bank statement", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"batch":        staged.Batch,
+		"transactions": staged.Transactions,
+	})
+}
+
+func (s *Server) handleGetStagedImport(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	staged, err := s.statementImportService.GetStagedImport(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to get import", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"batch":        staged.Batch,
+		"transactions": staged.Transactions,
+	})
+}
+
+// stagedTransactionRequest is the request body for editing a staged
+// transaction; fields left out are unchanged
+type stagedTransactionRequest struct {
+	Description  *string `json:"description"`
+	MainCategory *string `json:"main_category"`
+	SubCategory  *string `json:"sub_category"`
+	Excluded     *bool   `json:"excluded"`
+}
+
+// toEntity converts the request into a staged transaction update
+func (r *stagedTransactionRequest) toEntity() *entities.StagedTransactionUpdate {
+	update := &entities.StagedTransactionUpdate{
+		Description: r.Description,
+		Excluded:    r.Excluded,
+	}
+	if r.MainCategory != nil {
+		main := entities.MainCategory(*r.MainCategory)
+		update.MainCategory = &main
+	}
+	if r.SubCategory != nil {
+		sub := entities.SubCategory(*r.SubCategory)
+		update.SubCategory = &sub
+	}
+	return update
+}
+
+func (s *Server) handleUpdateStagedTransaction(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req stagedTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx := context.Background()
+	transaction, err := s.statementImportService.UpdateStagedTransaction(ctx, userID, c.Param("id"), c.Param("transactionId"), req.toEntity())
+	if err != nil {
+		respondServiceError(c, "Failed to update staged transaction", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transaction": transaction,
+	})
+}
+
+// handleConfirmImport saves a staged import's included transactions as expenses
+func (s *Server) handleConfirmImport(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	batch, err := s.statementImportService.ConfirmImport(ctx, userID, c.Param("id"))
+	if err != nil {
+		respondServiceError(c, "Failed to confirm import", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"batch": batch,
+	})
+}
+
+// respondStatementError reports a failure to read a statement. When the
+// statement needs a password, the user's hint for bankType is included so
+// the client can show it on the password prompt.
+func (s *Server) respondStatementError(ctx context.Context, c *gin.Context, userID, bankType, message string, err error) {
+	status, code := serviceErrorStatus(err)
+	body := gin.H{
+		"error":   message,
+		"code":    code,
+		"details": err.Error(),
+	}
+	if bankType != "" && (errors.Is(err, services.ErrPasswordRequired) || errors.Is(err, services.ErrWrongPassword)) {
+		if hint, hintErr := s.passwordHintService.GetHint(ctx, userID, bankType); hintErr == nil {
+			body["password_hint"] = hint.Hint
+		}
+	}
+	c.JSON(status, body)
+}
+
+// passwordHintRequest is the request body for saving a statement password hint
+type passwordHintRequest struct {
+	Hint string `json:"hint" binding:"required"`
+}
+
+// handleSetPasswordHint saves the user's reminder of how a bank's statement
+// password is built, replacing any earlier one. Hints are stored encrypted.
+func (s *Server) handleSetPasswordHint(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req passwordHintRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	ctx := context.Background()
+	hint, err := s.passwordHintService.SetHint(ctx, userID, c.Param("bank"), req.Hint)
+	if err != nil {
+		respondServiceError(c, "Failed to save password hint", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hint": hint,
+	})
+}
+
+func (s *Server) handleGetPasswordHints(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	hints, err := s.passwordHintService.GetHints(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to get password hints", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hints": hints,
+	})
+}
+
+func (s *Server) handleGetPasswordHint(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	hint, err := s.passwordHintService.GetHint(ctx, userID, c.Param("bank"))
+	if err != nil {
+		respondServiceError(c, "Failed to get password hint", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hint": hint,
+	})
+}
+
+func (s *Server) handleDeletePasswordHint(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	if err := s.passwordHintService.DeleteHint(ctx, userID, c.Param("bank")); err != nil {
+		respondServiceError(c, "Failed to delete password hint", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Password hint deleted successfully",
+	})
+}
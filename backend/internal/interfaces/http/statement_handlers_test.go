@@ -0,0 +1,42 @@
+package http
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReadStatementFileRejectsOversizedUploads(t *testing.T) {
+	router := newTestRouter(func(api *gin.RouterGroup) {
+		api.POST("/upload", func(c *gin.Context) {
+			if _, data, ok := readStatementFile(c); ok {
+				c.JSON(http.StatusOK, gin.H{"size": len(data)})
+			}
+		})
+	})
+
+	upload := func(size int) int {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("file", "statement.pdf")
+		part.Write(make([]byte, size))
+		form.Close()
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/upload", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := upload(1 << 10); code != http.StatusOK {
+		t.Errorf("small upload: expected 200, got %d", code)
+	}
+	if code := upload(maxStatementSize + 1); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized upload: expected 413, got %d", code)
+	}
+}
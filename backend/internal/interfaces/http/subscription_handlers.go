@@ -0,0 +1,45 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) handleGetSubscriptions(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	report, err := s.subscriptionService.DetectSubscriptions(ctx, userID)
+	if err != nil {
+		respondServiceError(c, "Failed to detect subscriptions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"subscriptions": report,
+	})
+}
+
+// handleConvertSubscription turns a detected subscription into a recurring expense
+func (s *Server) handleConvertSubscription(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	recurring, err := s.subscriptionService.ConvertToRecurring(ctx, userID, c.Param("key"))
+	if err != nil {
+		respondServiceError(c, "Failed to convert subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"recurring_expense": recurring,
+	})
+}